@@ -53,9 +53,11 @@ type CachePurgeClient struct {
 	v3Network    string
 	retries      int
 	retryBackoff time.Duration
+	maxBackoff   time.Duration
 	log          blog.Logger
 	purgeLatency prometheus.Histogram
 	purges       *prometheus.CounterVec
+	failedURLs   prometheus.Counter
 	clk          clock.Clock
 }
 
@@ -71,6 +73,18 @@ var (
 	ErrAllRetriesFailed = errors.New("All attempts to submit purge request failed")
 )
 
+// BatchError is returned by CachePurgeClient.Purge when one or more batches
+// could not be purged. FailedURLs contains the URLs from every failed batch so
+// that the caller can resubmit just those.
+type BatchError struct {
+	FailedURLs []string
+	Err        error
+}
+
+func (be *BatchError) Error() string {
+	return fmt.Sprintf("Failed to purge %d URLs: %s", len(be.FailedURLs), be.Err)
+}
+
 // NewCachePurgeClient constructs a new CachePurgeClient
 func NewCachePurgeClient(
 	endpoint,
@@ -80,6 +94,7 @@ func NewCachePurgeClient(
 	v3Network string,
 	retries int,
 	retryBackoff time.Duration,
+	maxBackoff time.Duration,
 	log blog.Logger,
 	stats prometheus.Registerer,
 ) (*CachePurgeClient, error) {
@@ -94,6 +109,11 @@ func NewCachePurgeClient(
 		Help: "A counter of CCU purges labelled by the result",
 	}, []string{"type"})
 	stats.MustRegister(purges)
+	failedURLs := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ccu_purge_failed_urls",
+		Help: "A counter of URLs in CCU purge batches that failed after all retries",
+	})
+	stats.MustRegister(failedURLs)
 
 	if strings.HasSuffix(endpoint, "/") {
 		endpoint = endpoint[:len(endpoint)-1]
@@ -107,6 +127,9 @@ func NewCachePurgeClient(
 		return nil, fmt.Errorf(
 			"Invalid CCU v3 network: %q. Must be \"staging\" or \"production\"", v3Network)
 	}
+	if maxBackoff == 0 {
+		maxBackoff = time.Minute
+	}
 	return &CachePurgeClient{
 		client:       new(http.Client),
 		apiEndpoint:  endpoint,
@@ -118,10 +141,12 @@ func NewCachePurgeClient(
 		v3Network:    v3Network,
 		retries:      retries,
 		retryBackoff: retryBackoff,
+		maxBackoff:   maxBackoff,
 		log:          log,
 		clk:          clock.New(),
 		purgeLatency: purgeLatency,
 		purges:       purges,
+		failedURLs:   failedURLs,
 	}, nil
 }
 
@@ -228,6 +253,13 @@ func (cpc *CachePurgeClient) purge(urls []string) error {
 		return err
 	}
 
+	// Akamai asks that rate limited (429) requests and server errors be
+	// retried, any other unexpected status indicates a problem with the request
+	// itself that retrying won't fix.
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("Unexpected HTTP status code '%d': %s", resp.StatusCode, string(body))
+	}
+
 	// Check purge was successful
 	var purgeInfo purgeResponse
 	err = json.Unmarshal(body, &purgeInfo)
@@ -238,7 +270,7 @@ func (cpc *CachePurgeClient) purge(urls []string) error {
 		if purgeInfo.HTTPStatus == http.StatusForbidden {
 			return errFatal(fmt.Sprintf("Unauthorized to purge URLs %q", urls))
 		}
-		return fmt.Errorf("Unexpected HTTP status code '%d': %s", resp.StatusCode, string(body))
+		return errFatal(fmt.Sprintf("Unexpected HTTP status code '%d': %s", resp.StatusCode, string(body)))
 	}
 
 	cpc.log.Infof("Sent successful purge request purgeID: %s, purge expected in: %ds, for URLs: %s",
@@ -250,7 +282,7 @@ func (cpc *CachePurgeClient) purge(urls []string) error {
 func (cpc *CachePurgeClient) purgeBatch(urls []string) error {
 	successful := false
 	for i := 0; i <= cpc.retries; i++ {
-		cpc.clk.Sleep(core.RetryBackoff(i, cpc.retryBackoff, time.Minute, 1.3))
+		cpc.clk.Sleep(core.RetryBackoff(i, cpc.retryBackoff, cpc.maxBackoff, 1.3))
 
 		err := cpc.purge(urls)
 		if err != nil {
@@ -275,21 +307,61 @@ func (cpc *CachePurgeClient) purgeBatch(urls []string) error {
 	return nil
 }
 
-var akamaiBatchSize = 100
+var (
+	// akamaiBatchSize is the maximum number of URLs sent in a single purge
+	// request.
+	akamaiBatchSize = 100
+	// akamaiBytesPerReq is the maximum size of the JSON body of a single purge
+	// request accepted by the CCU v3 API.
+	akamaiBytesPerReq = 50000
+)
 
-// Purge attempts to send a purge request to the Akamai CCU API cpc.retries number
-//  of times before giving up and returning ErrAllRetriesFailed
-func (cpc *CachePurgeClient) Purge(urls []string) error {
-	for i := 0; i < len(urls); {
-		sliceEnd := i + akamaiBatchSize
-		if sliceEnd > len(urls) {
-			sliceEnd = len(urls)
+// makeBatches splits urls into batches that each fit within the CCU v3 API
+// limits on the number of objects and the size of the request body. Purges are
+// not ordered with respect to one another so URLs may be batched freely.
+func makeBatches(urls []string) [][]string {
+	// The overhead of the enclosing `{"objects":[]}`.
+	overhead := len(`{"objects":[]}`)
+	var batches [][]string
+	var batch []string
+	size := overhead
+	for _, u := range urls {
+		// Each URL is quoted and, other than the first, comma separated. URLs
+		// from GeneratePurgeURLs never need JSON escaping.
+		urlSize := len(u) + 3
+		if len(batch) > 0 && (len(batch) >= akamaiBatchSize || size+urlSize > akamaiBytesPerReq) {
+			batches = append(batches, batch)
+			batch = nil
+			size = overhead
 		}
-		err := cpc.purgeBatch(urls[i:sliceEnd])
+		batch = append(batch, u)
+		size += urlSize
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// Purge splits urls into batches and attempts to send a purge request for each
+// batch to the Akamai CCU API cpc.retries number of times before giving up on
+// that batch. Every batch is attempted; if any fail a *BatchError containing
+// the URLs that weren't purged is returned.
+func (cpc *CachePurgeClient) Purge(urls []string) error {
+	var batchErr *BatchError
+	for _, batch := range makeBatches(urls) {
+		err := cpc.purgeBatch(batch)
 		if err != nil {
-			return err
+			cpc.log.AuditErrf("Akamai cache purge of %d URLs failed: %s", len(batch), err)
+			cpc.failedURLs.Add(float64(len(batch)))
+			if batchErr == nil {
+				batchErr = &BatchError{Err: err}
+			}
+			batchErr.FailedURLs = append(batchErr.FailedURLs, batch...)
 		}
-		i += akamaiBatchSize
+	}
+	if batchErr != nil {
+		return batchErr
 	}
 	return nil
 }
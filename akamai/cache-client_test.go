@@ -27,6 +27,7 @@ func TestConstructAuthHeader(t *testing.T) {
 		"production",
 		0,
 		time.Second,
+		time.Minute,
 		log,
 		stats,
 	)
@@ -136,6 +137,7 @@ func TestV3Purge(t *testing.T) {
 		"production",
 		3,
 		time.Second,
+		time.Minute,
 		blog.NewMock(),
 		metrics.NoopRegisterer,
 	)
@@ -159,6 +161,42 @@ func TestV3Purge(t *testing.T) {
 	test.Assert(t, client.clk.Since(started) < time.Second, "Purge should've failed out immediately")
 }
 
+func TestPurgeRetryableStatus(t *testing.T) {
+	as := newAkamaiServer(http.StatusTooManyRequests)
+	defer as.Close()
+
+	client, err := NewCachePurgeClient(
+		as.URL,
+		"token",
+		"secret",
+		"accessToken",
+		"production",
+		5,
+		time.Second,
+		time.Second,
+		blog.NewMock(),
+		metrics.NoopRegisterer,
+	)
+	test.AssertNotError(t, err, "Failed to create CachePurgeClient")
+	fc := clock.NewFake()
+	client.clk = fc
+
+	// A 429 should be retried with the backoff capped at 1s (plus jitter).
+	started := client.clk.Now()
+	err = client.Purge([]string{"http://test.com"})
+	test.AssertError(t, err, "Purge didn't fail with 429 response")
+	elapsed := client.clk.Since(started)
+	test.Assert(t, elapsed >= 4*time.Second, "Retries should've taken at least 4 seconds")
+	test.Assert(t, elapsed <= 6*time.Second, "Retries shouldn't have taken more than 6 seconds")
+
+	// Any other unexpected status shouldn't be retried.
+	started = client.clk.Now()
+	as.responseCode = http.StatusBadRequest
+	err = client.Purge([]string{"http://test.com"})
+	test.AssertError(t, err, "Purge didn't fail with 400 response")
+	test.Assert(t, client.clk.Since(started) < time.Second, "Purge should've failed out immediately")
+}
+
 func TestNewCachePurgeClient(t *testing.T) {
 	// Creating a new cache purge client with an invalid "network" parameter should error
 	_, err := NewCachePurgeClient(
@@ -169,6 +207,7 @@ func TestNewCachePurgeClient(t *testing.T) {
 		"fake",
 		3,
 		time.Second,
+		time.Minute,
 		blog.NewMock(),
 		metrics.NoopRegisterer,
 	)
@@ -183,6 +222,7 @@ func TestNewCachePurgeClient(t *testing.T) {
 		"staging",
 		3,
 		time.Second,
+		time.Minute,
 		blog.NewMock(),
 		metrics.NoopRegisterer,
 	)
@@ -197,6 +237,7 @@ func TestNewCachePurgeClient(t *testing.T) {
 		"staging",
 		3,
 		time.Second,
+		time.Minute,
 		blog.NewMock(),
 		metrics.NoopRegisterer,
 	)
@@ -222,6 +263,7 @@ func TestBigBatchPurge(t *testing.T) {
 		"production",
 		3,
 		time.Second,
+		time.Minute,
 		log,
 		metrics.NoopRegisterer,
 	)
@@ -236,6 +278,70 @@ func TestBigBatchPurge(t *testing.T) {
 	test.AssertNotError(t, err, "Purge failed with 201 response")
 }
 
+func TestPartialBatchFailure(t *testing.T) {
+	as := newAkamaiServer(http.StatusCreated)
+	defer as.Close()
+
+	client, err := NewCachePurgeClient(
+		as.URL,
+		"token",
+		"secret",
+		"accessToken",
+		"production",
+		3,
+		time.Second,
+		time.Minute,
+		blog.NewMock(),
+		metrics.NoopRegisterer,
+	)
+	test.AssertNotError(t, err, "Failed to create CachePurgeClient")
+	client.clk = clock.NewFake()
+
+	var urls []string
+	for i := 0; i < 250; i++ {
+		urls = append(urls, fmt.Sprintf("http://test.com/%d", i))
+	}
+	// A malformed URL causes the second batch to be rejected but the batches
+	// either side of it should still be purged.
+	urls[150] = "http:/test.com/150"
+
+	err = client.Purge(urls)
+	test.AssertError(t, err, "Purge didn't fail with a malformed URL")
+	batchErr, ok := err.(*BatchError)
+	test.Assert(t, ok, "Purge didn't return a *BatchError")
+	test.AssertDeepEquals(t, batchErr.FailedURLs, urls[100:200])
+	test.AssertEquals(t, test.CountCounter(client.failedURLs), 100)
+}
+
+func TestMakeBatches(t *testing.T) {
+	test.AssertEquals(t, len(makeBatches(nil)), 0)
+
+	var urls []string
+	for i := 0; i < 250; i++ {
+		urls = append(urls, fmt.Sprintf("http://test.com/%d", i))
+	}
+	batches := makeBatches(urls)
+	test.AssertEquals(t, len(batches), 3)
+	test.AssertEquals(t, len(batches[0]), 100)
+	test.AssertEquals(t, len(batches[1]), 100)
+	test.AssertEquals(t, len(batches[2]), 50)
+
+	// Long URLs should be split so that no request body exceeds the size
+	// limit.
+	long := "http://test.com/" + strings.Repeat("a", 1000)
+	urls = nil
+	for i := 0; i < 100; i++ {
+		urls = append(urls, long)
+	}
+	batches = makeBatches(urls)
+	test.AssertEquals(t, len(batches), 3)
+	for _, batch := range batches {
+		body, err := json.Marshal(v3PurgeRequest{Objects: batch})
+		test.AssertNotError(t, err, "Failed to marshal batch")
+		test.Assert(t, len(body) <= akamaiBytesPerReq, "Batch exceeds size limit")
+	}
+}
+
 func TestReverseBytes(t *testing.T) {
 	a := []byte{0, 1, 2, 3}
 	test.AssertDeepEquals(t, reverseBytes(a), []byte{3, 2, 1, 0})
@@ -0,0 +1,148 @@
+package bdns
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxCacheEntries bounds the number of responses held by a dnsCache. Once it
+// is reached new responses aren't cached until expired ones are removed.
+const maxCacheEntries = 10000
+
+type cacheKey struct {
+	name  string
+	qtype uint16
+}
+
+type cacheEntry struct {
+	resp    *dns.Msg
+	expires time.Time
+}
+
+// dnsCache is a small in-memory cache of DNS responses. Each response is kept
+// for the lowest TTL of its answers, or for negative responses (NXDOMAIN and
+// NOERROR without answers) the negative caching TTL from the SOA record in
+// the authority section (RFC 2308 Section 5). No response is kept longer than
+// maxTTL.
+type dnsCache struct {
+	sync.Mutex
+	entries map[cacheKey]cacheEntry
+	maxTTL  time.Duration
+	clk     clock.Clock
+
+	lookups *prometheus.CounterVec
+}
+
+func newDNSCache(maxTTL time.Duration, clk clock.Clock, stats prometheus.Registerer) *dnsCache {
+	lookups := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dns_cache_lookups",
+			Help: "Counter of DNS cache lookups sliced by query type and result (hit or miss)",
+		},
+		[]string{"qtype", "result"},
+	)
+	stats.MustRegister(lookups)
+	return &dnsCache{
+		entries: make(map[cacheKey]cacheEntry),
+		maxTTL:  maxTTL,
+		clk:     clk,
+		lookups: lookups,
+	}
+}
+
+func newCacheKey(hostname string, qtype uint16) cacheKey {
+	return cacheKey{name: dns.Fqdn(strings.ToLower(hostname)), qtype: qtype}
+}
+
+// get returns the cached response for hostname and qtype, or nil if there is
+// no unexpired one.
+func (c *dnsCache) get(hostname string, qtype uint16) *dns.Msg {
+	key := newCacheKey(hostname, qtype)
+	c.Lock()
+	defer c.Unlock()
+	entry, ok := c.entries[key]
+	if ok && !c.clk.Now().Before(entry.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	result := "miss"
+	if ok {
+		result = "hit"
+	}
+	c.lookups.With(prometheus.Labels{
+		"qtype":  dns.TypeToString[qtype],
+		"result": result,
+	}).Inc()
+	if !ok {
+		return nil
+	}
+	return entry.resp
+}
+
+// add caches resp as the response for hostname and qtype, if it is cacheable.
+func (c *dnsCache) add(hostname string, qtype uint16, resp *dns.Msg) {
+	ttl := c.ttl(resp)
+	if ttl <= 0 {
+		return
+	}
+	now := c.clk.Now()
+	c.Lock()
+	defer c.Unlock()
+	if len(c.entries) >= maxCacheEntries {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			return
+		}
+	}
+	c.entries[newCacheKey(hostname, qtype)] = cacheEntry{
+		resp:    resp,
+		expires: now.Add(ttl),
+	}
+}
+
+// ttl returns how long resp may be cached for. Zero means it must not be
+// cached, e.g. for SERVFAIL responses or negative responses without a SOA
+// record.
+func (c *dnsCache) ttl(resp *dns.Msg) time.Duration {
+	var seconds uint32
+	switch {
+	case resp.Rcode == dns.RcodeSuccess && len(resp.Answer) > 0:
+		seconds = resp.Answer[0].Header().Ttl
+		for _, rr := range resp.Answer[1:] {
+			if rr.Header().Ttl < seconds {
+				seconds = rr.Header().Ttl
+			}
+		}
+	case resp.Rcode == dns.RcodeSuccess || resp.Rcode == dns.RcodeNameError:
+		found := false
+		for _, rr := range resp.Ns {
+			if soa, ok := rr.(*dns.SOA); ok {
+				seconds = soa.Hdr.Ttl
+				if soa.Minttl < seconds {
+					seconds = soa.Minttl
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+	default:
+		return 0
+	}
+	ttl := time.Duration(seconds) * time.Second
+	if ttl > c.maxTTL {
+		ttl = c.maxTTL
+	}
+	return ttl
+}
@@ -0,0 +1,139 @@
+package bdns
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/miekg/dns"
+)
+
+// cannedExchanger answers every query with the response built by respond and
+// counts the queries it received.
+type cannedExchanger struct {
+	sync.Mutex
+	queries int
+	respond func(q dns.Question) *dns.Msg
+}
+
+func (ce *cannedExchanger) Exchange(m *dns.Msg, a string) (*dns.Msg, time.Duration, error) {
+	ce.Lock()
+	defer ce.Unlock()
+	ce.queries++
+	resp := ce.respond(m.Question[0])
+	resp.SetReply(m)
+	return resp, time.Millisecond, nil
+}
+
+func soaRecord(ttl, minttl uint32) *dns.SOA {
+	return &dns.SOA{
+		Hdr:    dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: ttl},
+		Ns:     "ns.example.com.",
+		Mbox:   "master.example.com.",
+		Minttl: minttl,
+	}
+}
+
+func TestDNSCache(t *testing.T) {
+	ce := &cannedExchanger{
+		respond: func(q dns.Question) *dns.Msg {
+			m := new(dns.Msg)
+			switch q.Name {
+			case "a.example.com.":
+				m.Answer = []dns.RR{
+					&dns.A{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 300}, A: net.ParseIP("10.0.0.1")},
+					&dns.A{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 20}, A: net.ParseIP("10.0.0.2")},
+				}
+			case "nxdomain.example.com.":
+				m.Rcode = dns.RcodeNameError
+				m.Ns = []dns.RR{soaRecord(3600, 5)}
+			case "nosoa.example.com.":
+				m.Rcode = dns.RcodeNameError
+			case "servfail.example.com.":
+				m.Rcode = dns.RcodeServerFailure
+			}
+			return m
+		},
+	}
+	clk := clock.NewFake()
+	client := NewTestDNSClientImpl(time.Second, []string{dnsLoopbackAddr}, metrics.NoopRegisterer, clk, 1, blog.UseMock())
+	client.dnsClient = ce
+	client.EnableCache(time.Minute, metrics.NoopRegisterer)
+
+	lookup := func(name string) {
+		t.Helper()
+		_, _ = client.LookupTXT(context.Background(), name)
+	}
+	expectQueries := func(expected int) {
+		t.Helper()
+		test.AssertEquals(t, ce.queries, expected)
+		ce.queries = 0
+	}
+
+	// A positive response is cached for its lowest answer TTL, regardless of
+	// the case of the name.
+	lookup("a.example.com")
+	lookup("A.example.com")
+	expectQueries(1)
+	clk.Add(19 * time.Second)
+	lookup("a.example.com")
+	expectQueries(0)
+	clk.Add(time.Second)
+	lookup("a.example.com")
+	expectQueries(1)
+
+	// NXDOMAIN is cached for the lower of the SOA's TTL and minimum TTL.
+	lookup("nxdomain.example.com")
+	lookup("nxdomain.example.com")
+	expectQueries(1)
+	clk.Add(5 * time.Second)
+	lookup("nxdomain.example.com")
+	expectQueries(1)
+
+	// Negative responses without a SOA and server failures aren't cached.
+	lookup("nosoa.example.com")
+	lookup("nosoa.example.com")
+	expectQueries(2)
+	lookup("servfail.example.com")
+	lookup("servfail.example.com")
+	expectQueries(2)
+
+	// Different query types are cached separately.
+	_, _, _ = client.LookupCAA(context.Background(), "a.example.com")
+	expectQueries(1)
+}
+
+func TestDNSCacheMaxTTL(t *testing.T) {
+	ce := &cannedExchanger{
+		respond: func(q dns.Question) *dns.Msg {
+			m := new(dns.Msg)
+			m.Answer = []dns.RR{
+				&dns.TXT{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 86400}, Txt: []string{"a"}},
+			}
+			return m
+		},
+	}
+	clk := clock.NewFake()
+	client := NewTestDNSClientImpl(time.Second, []string{dnsLoopbackAddr}, metrics.NoopRegisterer, clk, 1, blog.UseMock())
+	client.dnsClient = ce
+	client.EnableCache(10*time.Second, metrics.NoopRegisterer)
+
+	txts, err := client.LookupTXT(context.Background(), "example.com")
+	test.AssertNotError(t, err, "LookupTXT failed")
+	test.AssertDeepEquals(t, txts, []string{"a"})
+	txts, err = client.LookupTXT(context.Background(), "example.com")
+	test.AssertNotError(t, err, "LookupTXT failed")
+	test.AssertDeepEquals(t, txts, []string{"a"})
+	test.AssertEquals(t, ce.queries, 1)
+
+	clk.Add(10 * time.Second)
+	_, err = client.LookupTXT(context.Background(), "example.com")
+	test.AssertNotError(t, err, "LookupTXT failed")
+	test.AssertEquals(t, ce.queries, 2)
+}
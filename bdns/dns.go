@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -149,7 +150,8 @@ var (
 type DNSClient interface {
 	LookupTXT(context.Context, string) (txts []string, err error)
 	LookupHost(context.Context, string) ([]net.IP, error)
-	LookupCAA(context.Context, string) ([]*dns.CAA, error)
+	LookupCAA(context.Context, string) ([]*dns.CAA, bool, error)
+	LookupMX(context.Context, string) ([]string, error)
 }
 
 // DNSClientImpl represents a client that talks to an external resolver
@@ -160,6 +162,7 @@ type DNSClientImpl struct {
 	maxTries                 int
 	clk                      clock.Clock
 	log                      blog.Logger
+	cache                    *dnsCache
 
 	queryTime         *prometheus.HistogramVec
 	totalLookupTime   *prometheus.HistogramVec
@@ -250,6 +253,14 @@ func NewTestDNSClientImpl(
 	return resolver
 }
 
+// EnableCache makes the resolver cache responses in memory for up to maxTTL,
+// or less if the responses' TTLs are lower. NXDOMAIN and empty responses are
+// cached for their SOA's negative caching TTL. Responses indicating a server
+// failure and failed queries are never cached.
+func (dnsClient *DNSClientImpl) EnableCache(maxTTL time.Duration, stats prometheus.Registerer) {
+	dnsClient.cache = newDNSCache(maxTTL, dnsClient.clk, stats)
+}
+
 // exchangeOne performs a single DNS exchange with a randomly chosen server
 // out of the server list, returning the response, time, and error (if any).
 // We assume that the upstream resolver requests and validates DNSSEC records
@@ -261,9 +272,10 @@ func (dnsClient *DNSClientImpl) exchangeOne(ctx context.Context, hostname string
 	// Set the AD bit in the query header so that the resolver knows that
 	// we are interested in this bit in the response header. If this isn't
 	// set the AD bit in the response is useless (RFC 6840 Section 5.7).
-	// This has no security implications, it simply allows us to gather
-	// metrics about the percentage of responses that are secured with
-	// DNSSEC.
+	// The AD bit of CAA responses is recorded by the VA, and may be
+	// required, to show that the records were DNSSEC validated. Other
+	// responses' AD bits are only used for metrics about the percentage of
+	// responses that are secured with DNSSEC.
 	m.AuthenticatedData = true
 	// Tell the resolver that we're willing to receive responses up to 4096 bytes.
 	// This happens sometimes when there are a very large number of CAA records
@@ -274,6 +286,17 @@ func (dnsClient *DNSClientImpl) exchangeOne(ctx context.Context, hostname string
 		return nil, fmt.Errorf("Not configured with at least one DNS Server")
 	}
 
+	if dnsClient.cache != nil {
+		if cached := dnsClient.cache.get(hostname, qtype); cached != nil {
+			return cached, nil
+		}
+		defer func() {
+			if err == nil && resp != nil {
+				dnsClient.cache.add(hostname, qtype, resp)
+			}
+		}()
+	}
+
 	// Randomly pick a server
 	chosenServerIndex := rand.Intn(len(dnsClient.servers))
 	chosenServer := dnsClient.servers[chosenServerIndex]
@@ -405,6 +428,39 @@ func (dnsClient *DNSClientImpl) LookupTXT(ctx context.Context, hostname string)
 	return txt, err
 }
 
+// LookupMX sends a DNS query to find all MX records associated with the
+// provided hostname, and returns their exchange hostnames in order of
+// preference. A "null MX" record (RFC 7505), stating that the host doesn't
+// accept mail, is returned as an exchange of ".".
+func (dnsClient *DNSClientImpl) LookupMX(ctx context.Context, hostname string) ([]string, error) {
+	dnsType := dns.TypeMX
+	r, err := dnsClient.exchangeOne(ctx, hostname, dnsType)
+	if err != nil {
+		return nil, &DNSError{dnsType, hostname, err, -1}
+	}
+	if r.Rcode != dns.RcodeSuccess {
+		return nil, &DNSError{dnsType, hostname, nil, r.Rcode}
+	}
+
+	var records []*dns.MX
+	for _, answer := range r.Answer {
+		if answer.Header().Rrtype == dnsType {
+			if mxRec, ok := answer.(*dns.MX); ok {
+				records = append(records, mxRec)
+			}
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Preference < records[j].Preference
+	})
+	mx := make([]string, 0, len(records))
+	for _, record := range records {
+		mx = append(mx, record.Mx)
+	}
+
+	return mx, nil
+}
+
 func isPrivateV4(ip net.IP) bool {
 	for _, net := range privateNetworks {
 		if net.Contains(ip) {
@@ -482,16 +538,20 @@ func (dnsClient *DNSClientImpl) LookupHost(ctx context.Context, hostname string)
 }
 
 // LookupCAA sends a DNS query to find all CAA records associated with
-// the provided hostname.
-func (dnsClient *DNSClientImpl) LookupCAA(ctx context.Context, hostname string) ([]*dns.CAA, error) {
+// the provided hostname. It also returns whether the resolver set the AD bit
+// of its response, i.e. whether it validated the response with DNSSEC. A
+// resolver which doesn't validate, or which is behind something that strips
+// the AD bit, is indistinguishable from an unsigned zone and both are
+// reported as unvalidated rather than as an error.
+func (dnsClient *DNSClientImpl) LookupCAA(ctx context.Context, hostname string) ([]*dns.CAA, bool, error) {
 	dnsType := dns.TypeCAA
 	r, err := dnsClient.exchangeOne(ctx, hostname, dnsType)
 	if err != nil {
-		return nil, &DNSError{dnsType, hostname, err, -1}
+		return nil, false, &DNSError{dnsType, hostname, err, -1}
 	}
 
 	if r.Rcode == dns.RcodeServerFailure {
-		return nil, &DNSError{dnsType, hostname, nil, r.Rcode}
+		return nil, false, &DNSError{dnsType, hostname, nil, r.Rcode}
 	}
 
 	var CAAs []*dns.CAA
@@ -500,7 +560,7 @@ func (dnsClient *DNSClientImpl) LookupCAA(ctx context.Context, hostname string)
 			CAAs = append(CAAs, caaR)
 		}
 	}
-	return CAAs, nil
+	return CAAs, r.AuthenticatedData, nil
 }
 
 // logDNSError logs the provided err result from making a query for hostname to
@@ -125,6 +125,15 @@ func mockDNSQuery(w dns.ResponseWriter, r *dns.Msg) {
 				appendAnswer(record)
 			}
 		case dns.TypeCAA:
+			if q.Name == "dnssec.example.com." {
+				// A response the resolver validated with DNSSEC.
+				m.AuthenticatedData = true
+				record := new(dns.CAA)
+				record.Hdr = dns.RR_Header{Name: q.Name, Rrtype: dns.TypeCAA, Class: dns.ClassINET, Ttl: 0}
+				record.Tag = "issue"
+				record.Value = "letsencrypt.org"
+				appendAnswer(record)
+			}
 			if q.Name == "bracewel.net." || q.Name == "caa.example.com." {
 				record := new(dns.CAA)
 				record.Hdr = dns.RR_Header{Name: q.Name, Rrtype: dns.TypeCAA, Class: dns.ClassINET, Ttl: 0}
@@ -141,6 +150,22 @@ func mockDNSQuery(w dns.ResponseWriter, r *dns.Msg) {
 				record.Flag = 1
 				appendAnswer(record)
 			}
+		case dns.TypeMX:
+			if q.Name == "nxdomain.letsencrypt.org." {
+				m.SetRcode(r, dns.RcodeNameError)
+			}
+			if q.Name == "mx.letsencrypt.org." {
+				for _, mx := range []struct {
+					preference uint16
+					host       string
+				}{{20, "backup.letsencrypt.org."}, {10, "mail.letsencrypt.org."}} {
+					record := new(dns.MX)
+					record.Hdr = dns.RR_Header{Name: q.Name, Rrtype: dns.TypeMX, Class: dns.ClassINET, Ttl: 0}
+					record.Preference = mx.preference
+					record.Mx = mx.host
+					appendAnswer(record)
+				}
+			}
 		case dns.TypeTXT:
 			if q.Name == "split-txt.letsencrypt.org." {
 				record := new(dns.TXT)
@@ -273,7 +298,7 @@ func TestDNSLookupsNoServer(t *testing.T) {
 	_, err = obj.LookupHost(context.Background(), "letsencrypt.org")
 	test.AssertError(t, err, "No servers")
 
-	_, err = obj.LookupCAA(context.Background(), "letsencrypt.org")
+	_, _, err = obj.LookupCAA(context.Background(), "letsencrypt.org")
 	test.AssertError(t, err, "No servers")
 }
 
@@ -287,7 +312,7 @@ func TestDNSServFail(t *testing.T) {
 	_, err = obj.LookupHost(context.Background(), bad)
 	test.AssertError(t, err, "LookupHost didn't return an error")
 
-	emptyCaa, err := obj.LookupCAA(context.Background(), bad)
+	emptyCaa, _, err := obj.LookupCAA(context.Background(), bad)
 	test.Assert(t, len(emptyCaa) == 0, "Query returned non-empty list of CAA records")
 	test.AssertError(t, err, "LookupCAA should have returned an error")
 }
@@ -393,19 +418,44 @@ func TestDNSNXDOMAIN(t *testing.T) {
 func TestDNSLookupCAA(t *testing.T) {
 	obj := NewTestDNSClientImpl(time.Second*10, []string{dnsLoopbackAddr}, metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
 
-	caas, err := obj.LookupCAA(context.Background(), "bracewel.net")
+	caas, authenticated, err := obj.LookupCAA(context.Background(), "bracewel.net")
+	test.AssertNotError(t, err, "CAA lookup failed")
+	test.Assert(t, len(caas) > 0, "Should have CAA records")
+	test.Assert(t, !authenticated, "Response without the AD bit was reported as DNSSEC validated")
+
+	caas, authenticated, err = obj.LookupCAA(context.Background(), "dnssec.example.com")
 	test.AssertNotError(t, err, "CAA lookup failed")
 	test.Assert(t, len(caas) > 0, "Should have CAA records")
+	test.Assert(t, authenticated, "Response with the AD bit wasn't reported as DNSSEC validated")
 
-	caas, err = obj.LookupCAA(context.Background(), "nonexistent.letsencrypt.org")
+	caas, _, err = obj.LookupCAA(context.Background(), "nonexistent.letsencrypt.org")
 	test.AssertNotError(t, err, "CAA lookup failed")
 	test.Assert(t, len(caas) == 0, "Shouldn't have CAA records")
 
-	caas, err = obj.LookupCAA(context.Background(), "cname.example.com")
+	caas, _, err = obj.LookupCAA(context.Background(), "cname.example.com")
 	test.AssertNotError(t, err, "CAA lookup failed")
 	test.Assert(t, len(caas) > 0, "Should follow CNAME to find CAA")
 }
 
+func TestDNSLookupMX(t *testing.T) {
+	obj := NewTestDNSClientImpl(time.Second*10, []string{dnsLoopbackAddr}, metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
+
+	mx, err := obj.LookupMX(context.Background(), "mx.letsencrypt.org")
+	test.AssertNotError(t, err, "MX lookup failed")
+	test.AssertDeepEquals(t, mx, []string{"mail.letsencrypt.org.", "backup.letsencrypt.org."})
+
+	mx, err = obj.LookupMX(context.Background(), "letsencrypt.org")
+	test.AssertNotError(t, err, "MX lookup failed")
+	test.AssertEquals(t, len(mx), 0)
+
+	hostname := "nxdomain.letsencrypt.org"
+	_, err = obj.LookupMX(context.Background(), hostname)
+	expected := DNSError{dns.TypeMX, hostname, nil, dns.RcodeNameError}
+	if err, ok := err.(*DNSError); !ok || *err != expected || !err.NXDomain() {
+		t.Errorf("Looking up %s, got %#v, expected %#v", hostname, err, expected)
+	}
+}
+
 func TestIsPrivateIP(t *testing.T) {
 	test.Assert(t, isPrivateV4(net.ParseIP("127.0.0.1")), "should be private")
 	test.Assert(t, isPrivateV4(net.ParseIP("192.168.254.254")), "should be private")
@@ -69,7 +69,8 @@ func (t timeoutError) Timeout() bool {
 // LookupHost is a mock
 func (mock *MockDNSClient) LookupHost(_ context.Context, hostname string) ([]net.IP, error) {
 	if hostname == "always.invalid" ||
-		hostname == "invalid.invalid" {
+		hostname == "invalid.invalid" ||
+		hostname == "no-mx-or-address.com" {
 		return []net.IP{}, nil
 	}
 	if hostname == "always.timeout" {
@@ -119,6 +120,21 @@ func (mock *MockDNSClient) LookupHost(_ context.Context, hostname string) ([]net
 }
 
 // LookupCAA returns mock records for use in tests.
-func (mock *MockDNSClient) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, error) {
-	return nil, nil
+func (mock *MockDNSClient) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, bool, error) {
+	return nil, false, nil
+}
+
+// LookupMX is a mock
+func (mock *MockDNSClient) LookupMX(_ context.Context, domain string) ([]string, error) {
+	switch domain {
+	case "nxdomain.com":
+		return nil, &DNSError{dns.TypeMX, domain, nil, dns.RcodeNameError}
+	case "servfail.com":
+		return nil, &DNSError{dns.TypeMX, domain, nil, dns.RcodeServerFailure}
+	case "null-mx.com":
+		return []string{"."}, nil
+	case "no-mx.com", "no-mx-or-address.com":
+		return nil, nil
+	}
+	return []string{"mail." + domain + "."}, nil
 }
@@ -49,6 +49,11 @@ func (d DNSError) Error() string {
 		dns.TypeToString[d.recordType], d.hostname, additional)
 }
 
+// NXDomain returns true if the queried name doesn't exist.
+func (d DNSError) NXDomain() bool {
+	return d.underlying == nil && d.rCode == dns.RcodeNameError
+}
+
 // Timeout returns true if the underlying error was a timeout
 func (d DNSError) Timeout() bool {
 	if netErr, ok := d.underlying.(*net.OpError); ok {
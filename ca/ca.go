@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -16,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/jmhodges/clock"
 	"github.com/miekg/pkcs11"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zmap/zlint/v2/lint"
 	"golang.org/x/crypto/ocsp"
 
 	ca_config "github.com/letsencrypt/boulder/ca/config"
@@ -114,8 +117,6 @@ const (
 // CertificateAuthorityImpl represents a CA that signs certificates, CRLs, and
 // OCSP responses.
 type CertificateAuthorityImpl struct {
-	rsaProfile   string
-	ecdsaProfile string
 	// A map from issuer cert common name to an internalIssuer struct
 	issuers map[string]*internalIssuer
 	// A map from issuer ID to internalIssuer
@@ -130,6 +131,8 @@ type CertificateAuthorityImpl struct {
 	prefix             int // Prepended to the serial number
 	validityPeriod     time.Duration
 	backdate           time.Duration
+	defaultProfile     *issuanceProfile
+	profiles           map[string]*issuanceProfile
 	maxNames           int
 	forceCNFromSAN     bool
 	signatureCount     *prometheus.CounterVec
@@ -137,14 +140,367 @@ type CertificateAuthorityImpl struct {
 	orphanCount        *prometheus.CounterVec
 	adoptedOrphanCount *prometheus.CounterVec
 	signErrorCounter   *prometheus.CounterVec
+	lintErrorCount     *prometheus.CounterVec
+	maxNamesRejections *prometheus.CounterVec
+	keyTypeRejections  *prometheus.CounterVec
 	orphanQueue        *goque.Queue
 	ocspLifetime       time.Duration
 }
 
+// defaultProfileName is the name used in audit logs for issuances that don't
+// select one of the configured issuance profiles.
+const defaultProfileName = "default"
+
+// issuanceProfile is a named set of issuance parameters that an issuance
+// request may select.
+type issuanceProfile struct {
+	name string
+	// validity is the maximum validity period of certificates issued with the
+	// profile, measured from the backdated NotBefore.
+	validity time.Duration
+	// backdate is how long before the time of issuance the NotBefore of
+	// certificates issued with the profile is.
+	backdate     time.Duration
+	rsaProfile   string
+	ecdsaProfile string
+	// sigAlgo is the RSA-PSS signature algorithm certificates issued with the
+	// profile are signed with, or x509.UnknownSignatureAlgorithm to use the
+	// issuer's default PKCS #1 v1.5 signatures.
+	sigAlgo x509.SignatureAlgorithm
+	// maxNames is the maximum number of subjectAltNames in certificates
+	// issued with the profile.
+	maxNames int
+	// serialRandomBits is the number of random bits following the CA's
+	// prefix byte in the serials of certificates issued with the profile.
+	serialRandomBits int
+	// keyTypes restricts the public keys of certificates issued with the
+	// profile. If nil, any key allowed by the CA's key policy is accepted.
+	keyTypes *keyTypePolicy
+	// omitSCTs is true if final certificates issued with the profile don't
+	// embed SCTs.
+	omitSCTs bool
+}
+
+const (
+	// defaultSerialRandomBits is the number of random bits in serials issued
+	// with profiles that don't configure SerialRandomBits.
+	defaultSerialRandomBits = 136
+	// minSerialRandomBits is the least entropy the Baseline Requirements
+	// (section 7.1) allow in a serial.
+	minSerialRandomBits = 64
+	// maxSerialOctets is the longest serial, as the contents of its DER INTEGER,
+	// that RFC 5280 (section 4.1.2.2) allows.
+	maxSerialOctets = 20
+)
+
+// checkSerialRandomBits returns an error if serials made of the prefix byte
+// followed by randomBits random bits wouldn't comply with RFC 5280. Because
+// the prefix is non-zero the serials never have leading zero octets, and they
+// are always positive, but a prefix with its high bit set needs a leading
+// zero octet in DER to stay positive, which counts towards the 20 octets.
+func checkSerialRandomBits(prefix int, randomBits int) error {
+	if randomBits%8 != 0 {
+		return fmt.Errorf("SerialRandomBits %d must be a multiple of 8", randomBits)
+	}
+	if randomBits < minSerialRandomBits {
+		return fmt.Errorf("SerialRandomBits %d must be at least %d", randomBits, minSerialRandomBits)
+	}
+	octets := 1 + randomBits/8
+	if prefix >= 0x80 {
+		octets++
+	}
+	if octets > maxSerialOctets {
+		return fmt.Errorf("SerialRandomBits %d with serial prefix %d makes %d octet serials, more than %d",
+			randomBits, prefix, octets, maxSerialOctets)
+	}
+	return nil
+}
+
+// pssSignatureAlgorithms maps the hash names accepted in RSAPSSConfig to the
+// matching RSA-PSS signature algorithm and hash.
+var pssSignatureAlgorithms = map[string]struct {
+	sigAlgo x509.SignatureAlgorithm
+	hash    crypto.Hash
+}{
+	"SHA256": {x509.SHA256WithRSAPSS, crypto.SHA256},
+	"SHA384": {x509.SHA384WithRSAPSS, crypto.SHA384},
+	"SHA512": {x509.SHA512WithRSAPSS, crypto.SHA512},
+}
+
+// pssSignatureAlgorithm validates an RSA-PSS configuration and returns the
+// signature algorithm it selects. The x509 package always uses a salt as long
+// as the hash output, so no other salt length can be honored.
+func pssSignatureAlgorithm(c *ca_config.RSAPSSConfig) (x509.SignatureAlgorithm, error) {
+	alg, ok := pssSignatureAlgorithms[c.Hash]
+	if !ok {
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported RSA-PSS hash %q", c.Hash)
+	}
+	if c.SaltLength != 0 && c.SaltLength != alg.hash.Size() {
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported RSA-PSS salt length %d for %s, only %d is supported",
+			c.SaltLength, c.Hash, alg.hash.Size())
+	}
+	return alg.sigAlgo, nil
+}
+
+// profileECDSACurves are the ECDSA curves issuance profiles may accept, keyed
+// by the names used in KeyTypesConfig. They're the curves goodkey allows.
+var profileECDSACurves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+}
+
+// minProfileRSAModulusSize is the smallest RSA modulus size, in bits, an
+// issuance profile may accept, matching goodkey.
+const minProfileRSAModulusSize = 2048
+
+// keyTypePolicy is the set of public keys an issuance profile accepts.
+type keyTypePolicy struct {
+	// rsaModulusSizes and ecdsaCurves are the accepted RSA modulus sizes, in
+	// bits, and ECDSA curve names, in the order they were configured.
+	rsaModulusSizes []int
+	ecdsaCurves     []string
+}
+
+// makeKeyTypePolicy checks c and returns the keyTypePolicy it describes.
+func makeKeyTypePolicy(c *ca_config.KeyTypesConfig) (*keyTypePolicy, error) {
+	if len(c.RSAModulusSizes) == 0 && len(c.ECDSACurves) == 0 {
+		return nil, errors.New("KeyTypes must accept at least one RSA modulus size or ECDSA curve")
+	}
+	for _, size := range c.RSAModulusSizes {
+		if size < minProfileRSAModulusSize || size%8 != 0 {
+			return nil, fmt.Errorf("RSA modulus size %d must be a multiple of 8 and at least %d", size, minProfileRSAModulusSize)
+		}
+	}
+	for _, name := range c.ECDSACurves {
+		if _, ok := profileECDSACurves[name]; !ok {
+			return nil, fmt.Errorf("unsupported ECDSA curve %q", name)
+		}
+	}
+	return &keyTypePolicy{
+		rsaModulusSizes: c.RSAModulusSizes,
+		ecdsaCurves:     c.ECDSACurves,
+	}, nil
+}
+
+// check returns a BadPublicKey error if the policy doesn't accept key, along
+// with the reason for the rejection, used to label the rejection metric.
+func (ktp *keyTypePolicy) check(profileName string, key crypto.PublicKey) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if len(ktp.rsaModulusSizes) == 0 {
+			return "rsa", berrors.BadPublicKeyError("certificate profile %q doesn't allow RSA keys", profileName)
+		}
+		size := k.N.BitLen()
+		for _, allowed := range ktp.rsaModulusSizes {
+			if size == allowed {
+				return "", nil
+			}
+		}
+		var sizes []string
+		for _, allowed := range ktp.rsaModulusSizes {
+			sizes = append(sizes, strconv.Itoa(allowed))
+		}
+		return "rsa_modulus_size", berrors.BadPublicKeyError("certificate profile %q doesn't allow %d bit RSA keys, only %s",
+			profileName, size, strings.Join(sizes, ", "))
+	case *ecdsa.PublicKey:
+		if len(ktp.ecdsaCurves) == 0 {
+			return "ecdsa", berrors.BadPublicKeyError("certificate profile %q doesn't allow ECDSA keys", profileName)
+		}
+		for _, allowed := range ktp.ecdsaCurves {
+			if k.Curve == profileECDSACurves[allowed] {
+				return "", nil
+			}
+		}
+		return "ecdsa_curve", berrors.BadPublicKeyError("certificate profile %q doesn't allow ECDSA keys on curve %s, only %s",
+			profileName, k.Curve.Params().Name, strings.Join(ktp.ecdsaCurves, ", "))
+	default:
+		return "key_type", berrors.BadPublicKeyError("certificate profile %q doesn't allow %T keys", profileName, key)
+	}
+}
+
+// lintProfileErrLevel is the CFSSL lint error level used for lint profiles:
+// any lint result more severe than a warning, i.e. an error or fatal result,
+// stops the precertificate from being signed.
+const lintProfileErrLevel = lint.Warn
+
+// makeLintRegistries builds the zlint registry for each configured lint
+// profile, keyed by the lint profile's name.
+func makeLintRegistries(configs map[string]ca_config.LintProfileConfig) (map[string]lint.Registry, error) {
+	registries := make(map[string]lint.Registry, len(configs))
+	for name, c := range configs {
+		var sources lint.SourceList
+		for _, sourceName := range c.IgnoredLintSources {
+			var source lint.LintSource
+			source.FromString(sourceName)
+			if source == lint.UnknownLintSource {
+				return nil, fmt.Errorf("lint profile %q: unknown lint source %q", name, sourceName)
+			}
+			sources = append(sources, source)
+		}
+		registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{
+			ExcludeNames:   c.IgnoredLints,
+			ExcludeSources: sources,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("lint profile %q: %s", name, err)
+		}
+		registries[name] = registry
+	}
+	return registries, nil
+}
+
+// lintSigningProfile returns the name of a CFSSL signing profile that is a
+// copy of the named one with pre-issuance linting done using the given lint
+// profile, adding it to the policy if it isn't there yet. This has to happen
+// before the signers are created since CFSSL only generates the key it signs
+// linting certificates with if some profile enables linting.
+func lintSigningProfile(
+	policy *cfsslConfig.Signing,
+	signingProfile string,
+	lintProfile string,
+	registry lint.Registry,
+) (string, error) {
+	name := fmt.Sprintf("%s+lint:%s", signingProfile, lintProfile)
+	if existing, ok := policy.Profiles[name]; ok {
+		if existing.LintRegistry != registry {
+			return "", fmt.Errorf("CFSSL signing profile %q conflicts with lint profile %q", name, lintProfile)
+		}
+		return name, nil
+	}
+	linted := *policy.Profiles[signingProfile]
+	linted.LintErrLevel = lintProfileErrLevel
+	linted.LintRegistry = registry
+	policy.Profiles[name] = &linted
+	return name, nil
+}
+
+// makeIssuanceProfiles validates the configured issuance profiles and returns
+// them keyed by name. Profiles that don't name CFSSL signing profiles inherit
+// the CA-wide ones, and likewise for the backdate period. A profile's validity
+// must be longer than its backdate period, or certificates issued with it would
+// already be expired, and may not exceed the CA-wide validity period. Likewise
+// a profile's maximum number of names must be at least one and may not exceed
+// the CA-wide maximum, and its serial length must be compliant with the serial
+// prefix. Profiles that select a lint profile get their own CFSSL signing
+// profiles which are added to the policy.
+func makeIssuanceProfiles(
+	configs map[string]ca_config.IssuanceProfileConfig,
+	lintProfiles map[string]ca_config.LintProfileConfig,
+	policy *cfsslConfig.Signing,
+	maxValidity time.Duration,
+	backdate time.Duration,
+	maxNames int,
+	serialPrefix int,
+	rsaProfile string,
+	ecdsaProfile string,
+) (map[string]*issuanceProfile, error) {
+	lintRegistries, err := makeLintRegistries(lintProfiles)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make(map[string]*issuanceProfile, len(configs))
+	for name, c := range configs {
+		if name == "" || name == defaultProfileName {
+			return nil, fmt.Errorf("issuance profile name %q is reserved", name)
+		}
+		profileBackdate := backdate
+		if c.Backdate.Duration != 0 {
+			if c.Backdate.Duration < 0 {
+				return nil, fmt.Errorf("issuance profile %q: backdate %s must not be negative", name, c.Backdate.Duration)
+			}
+			profileBackdate = c.Backdate.Duration
+		}
+		validity := c.Validity.Duration
+		if validity <= profileBackdate {
+			return nil, fmt.Errorf("issuance profile %q: validity %s must be longer than the backdate period %s",
+				name, validity, profileBackdate)
+		}
+		if validity > maxValidity {
+			return nil, fmt.Errorf("issuance profile %q: validity %s exceeds the maximum validity period %s",
+				name, validity, maxValidity)
+		}
+		profile := &issuanceProfile{
+			name:             name,
+			validity:         validity,
+			backdate:         profileBackdate,
+			rsaProfile:       rsaProfile,
+			ecdsaProfile:     ecdsaProfile,
+			maxNames:         maxNames,
+			serialRandomBits: defaultSerialRandomBits,
+		}
+		if c.MaxNames != 0 {
+			if c.MaxNames < 1 {
+				return nil, fmt.Errorf("issuance profile %q: MaxNames %d must be at least 1", name, c.MaxNames)
+			}
+			if c.MaxNames > maxNames {
+				return nil, fmt.Errorf("issuance profile %q: MaxNames %d exceeds the CA-wide MaxNames %d",
+					name, c.MaxNames, maxNames)
+			}
+			profile.maxNames = c.MaxNames
+		}
+		if c.SerialRandomBits != 0 {
+			if err := checkSerialRandomBits(serialPrefix, c.SerialRandomBits); err != nil {
+				return nil, fmt.Errorf("issuance profile %q: %s", name, err)
+			}
+			profile.serialRandomBits = c.SerialRandomBits
+		}
+		if c.RSAProfile != "" {
+			profile.rsaProfile = c.RSAProfile
+		}
+		if c.ECDSAProfile != "" {
+			profile.ecdsaProfile = c.ECDSAProfile
+		}
+		for _, signingProfile := range []string{profile.rsaProfile, profile.ecdsaProfile} {
+			if _, ok := policy.Profiles[signingProfile]; !ok {
+				return nil, fmt.Errorf("issuance profile %q: unknown CFSSL signing profile %q",
+					name, signingProfile)
+			}
+			// A CFSSL signing profile with CT logs submits the precertificate
+			// itself and embeds the SCTs it gets back.
+			if c.OmitSCTs && len(policy.Profiles[signingProfile].CTLogServers) > 0 {
+				return nil, fmt.Errorf("issuance profile %q: OmitSCTs is set but CFSSL signing profile %q submits to CT logs",
+					name, signingProfile)
+			}
+		}
+		profile.omitSCTs = c.OmitSCTs
+		if c.LintProfile != "" {
+			registry, ok := lintRegistries[c.LintProfile]
+			if !ok {
+				return nil, fmt.Errorf("issuance profile %q: unknown lint profile %q", name, c.LintProfile)
+			}
+			profile.rsaProfile, err = lintSigningProfile(policy, profile.rsaProfile, c.LintProfile, registry)
+			if err != nil {
+				return nil, fmt.Errorf("issuance profile %q: %s", name, err)
+			}
+			profile.ecdsaProfile, err = lintSigningProfile(policy, profile.ecdsaProfile, c.LintProfile, registry)
+			if err != nil {
+				return nil, fmt.Errorf("issuance profile %q: %s", name, err)
+			}
+		}
+		if c.RSAPSS != nil {
+			profile.sigAlgo, err = pssSignatureAlgorithm(c.RSAPSS)
+			if err != nil {
+				return nil, fmt.Errorf("issuance profile %q: %s", name, err)
+			}
+		}
+		if c.KeyTypes != nil {
+			profile.keyTypes, err = makeKeyTypePolicy(c.KeyTypes)
+			if err != nil {
+				return nil, fmt.Errorf("issuance profile %q: %s", name, err)
+			}
+		}
+		profiles[name] = profile
+	}
+	return profiles, nil
+}
+
 // Issuer represents a single issuer certificate, along with its key.
 type Issuer struct {
 	Signer crypto.Signer
 	Cert   *x509.Certificate
+	// Chain holds the certificates, in order, which follow Cert in the chain
+	// served with the certificates it issues.
+	Chain []*x509.Certificate
 }
 
 // localSigner is an interface describing the functions of a cfssl.local.Signer
@@ -160,12 +516,70 @@ type internalIssuer struct {
 	cert       *x509.Certificate
 	eeSigner   localSigner
 	ocspSigner crypto.Signer
+	// pssSigners holds a signer for each RSA-PSS signature algorithm used by
+	// an issuance profile.
+	pssSigners map[x509.SignatureAlgorithm]localSigner
+	// fingerprint is the hex SHA-256 fingerprint of cert, and
+	// chainFingerprints those of cert and the certificates of its chain.
+	fingerprint       string
+	chainFingerprints []string
+}
+
+// certFingerprint returns the hex encoded SHA-256 fingerprint of cert.
+func certFingerprint(cert *x509.Certificate) string {
+	h := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(h[:])
+}
+
+// signerFor returns the signer that signs certificates with the given
+// signature algorithm, or the PKCS #1 v1.5 eeSigner for
+// x509.UnknownSignatureAlgorithm.
+func (ii *internalIssuer) signerFor(sigAlgo x509.SignatureAlgorithm) (localSigner, error) {
+	if sigAlgo == x509.UnknownSignatureAlgorithm {
+		return ii.eeSigner, nil
+	}
+	s, ok := ii.pssSigners[sigAlgo]
+	if !ok {
+		return nil, fmt.Errorf("no signer for signature algorithm %s", sigAlgo)
+	}
+	return s, nil
+}
+
+// checkPSSSupport returns an error unless key is an RSA key that produces
+// valid RSA-PSS signatures with the salt length used by the x509 package.
+// This catches keys, e.g. in HSMs, that can't sign with PSS before any
+// certificate is issued.
+func checkPSSSupport(key crypto.Signer, sigAlgo x509.SignatureAlgorithm) error {
+	pub, ok := key.Public().(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%s requires an RSA key, not %T", sigAlgo, key.Public())
+	}
+	var hash crypto.Hash
+	for _, alg := range pssSignatureAlgorithms {
+		if alg.sigAlgo == sigAlgo {
+			hash = alg.hash
+		}
+	}
+	h := hash.New()
+	h.Write([]byte("RSA-PSS self test"))
+	digest := h.Sum(nil)
+	opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: hash}
+	sig, err := key.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return fmt.Errorf("signing with %s: %s", sigAlgo, err)
+	}
+	err = rsa.VerifyPSS(pub, hash, digest, sig, opts)
+	if err != nil {
+		return fmt.Errorf("verifying %s signature: %s", sigAlgo, err)
+	}
+	return nil
 }
 
 func makeInternalIssuers(
 	issuers []Issuer,
 	policy *cfsslConfig.Signing,
 	lifespanOCSP time.Duration,
+	pssAlgos []x509.SignatureAlgorithm,
 ) (map[string]*internalIssuer, error) {
 	if len(issuers) == 0 {
 		return nil, errors.New("No issuers specified.")
@@ -181,13 +595,40 @@ func makeInternalIssuers(
 		}
 
 		cn := iss.Cert.Subject.CommonName
+		pssSigners := make(map[x509.SignatureAlgorithm]localSigner, len(pssAlgos))
+		for _, sigAlgo := range pssAlgos {
+			if err := checkPSSSupport(iss.Signer, sigAlgo); err != nil {
+				return nil, fmt.Errorf("issuer %q: %s", cn, err)
+			}
+			pssSigners[sigAlgo], err = local.NewSigner(iss.Signer, iss.Cert, sigAlgo, policy)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Each certificate of the chain must have been signed by the next, so
+		// that the chain logged is the one certificates are served with.
+		chainFingerprints := []string{certFingerprint(iss.Cert)}
+		prev := iss.Cert
+		for _, chainCert := range iss.Chain {
+			if err := prev.CheckSignatureFrom(chainCert); err != nil {
+				return nil, fmt.Errorf("issuer %q: chain certificate %q didn't sign %q: %s",
+					cn, chainCert.Subject.CommonName, prev.Subject.CommonName, err)
+			}
+			chainFingerprints = append(chainFingerprints, certFingerprint(chainCert))
+			prev = chainCert
+		}
+
 		if internalIssuers[cn] != nil {
 			return nil, errors.New("Multiple issuer certs with the same CommonName are not supported")
 		}
 		internalIssuers[cn] = &internalIssuer{
-			cert:       iss.Cert,
-			eeSigner:   eeSigner,
-			ocspSigner: iss.Signer,
+			cert:              iss.Cert,
+			eeSigner:          eeSigner,
+			ocspSigner:        iss.Signer,
+			pssSigners:        pssSigners,
+			fingerprint:       chainFingerprints[0],
+			chainFingerprints: chainFingerprints,
 		}
 	}
 	return internalIssuers, nil
@@ -244,15 +685,6 @@ func NewCertificateAuthorityImpl(
 		}
 	}
 
-	internalIssuers, err := makeInternalIssuers(
-		issuers,
-		cfsslConfigObj.Signing,
-		config.LifespanOCSP.Duration)
-	if err != nil {
-		return nil, err
-	}
-	defaultIssuer := internalIssuers[issuers[0].Cert.Subject.CommonName]
-
 	rsaProfile := config.RSAProfile
 	ecdsaProfile := config.ECDSAProfile
 
@@ -260,6 +692,10 @@ func NewCertificateAuthorityImpl(
 		return nil, errors.New("must specify rsaProfile and ecdsaProfile")
 	}
 
+	if config.MaxNames < 1 {
+		return nil, fmt.Errorf("MaxNames %d must be at least 1", config.MaxNames)
+	}
+
 	csrExtensionCount := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "csr_extensions",
@@ -298,13 +734,27 @@ func NewCertificateAuthorityImpl(
 	}, []string{"type"})
 	stats.MustRegister(signErrorCounter)
 
+	lintErrorCount := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "presign_lint_failures",
+		Help: "Number of precertificates not signed because of a pre-signing lint failure, labelled by lint name",
+	}, []string{"lint"})
+	stats.MustRegister(lintErrorCount)
+
+	maxNamesRejections := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "max_names_rejections",
+		Help: "Number of precertificates not signed because the CSR had more names than the issuance profile allows, labelled by profile",
+	}, []string{"profile"})
+	stats.MustRegister(maxNamesRejections)
+
+	keyTypeRejections := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "key_type_rejections",
+		Help: "Number of precertificates not signed because the issuance profile doesn't allow the CSR's public key, labelled by profile and reason (rsa, rsa_modulus_size, ecdsa, ecdsa_curve, key_type)",
+	}, []string{"profile", "reason"})
+	stats.MustRegister(keyTypeRejections)
+
 	ca = &CertificateAuthorityImpl{
 		sa:                 sa,
 		pa:                 pa,
-		issuers:            internalIssuers,
-		defaultIssuer:      defaultIssuer,
-		rsaProfile:         rsaProfile,
-		ecdsaProfile:       ecdsaProfile,
 		prefix:             config.SerialPrefix,
 		clk:                clk,
 		log:                logger,
@@ -317,12 +767,9 @@ func NewCertificateAuthorityImpl(
 		orphanQueue:        orphanQueue,
 		ocspLifetime:       config.LifespanOCSP.Duration,
 		signErrorCounter:   signErrorCounter,
-	}
-
-	ca.idToIssuer = make(map[int64]*internalIssuer)
-	for _, ii := range ca.issuers {
-		id := idForIssuer(ii.cert)
-		ca.idToIssuer[id] = ii
+		lintErrorCount:     lintErrorCount,
+		maxNamesRejections: maxNamesRejections,
+		keyTypeRejections:  keyTypeRejections,
 	}
 
 	if config.Expiry == "" {
@@ -341,11 +788,74 @@ func NewCertificateAuthorityImpl(
 		ca.backdate = time.Hour
 	}
 
+	ca.defaultProfile = &issuanceProfile{
+		name:             defaultProfileName,
+		validity:         ca.validityPeriod,
+		backdate:         ca.backdate,
+		rsaProfile:       rsaProfile,
+		ecdsaProfile:     ecdsaProfile,
+		maxNames:         config.MaxNames,
+		serialRandomBits: defaultSerialRandomBits,
+	}
+	ca.profiles, err = makeIssuanceProfiles(
+		config.IssuanceProfiles,
+		config.LintProfiles,
+		cfsslConfigObj.Signing,
+		ca.validityPeriod,
+		ca.backdate,
+		config.MaxNames,
+		config.SerialPrefix,
+		rsaProfile,
+		ecdsaProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	// The issuers are created after the issuance profiles so that their signers
+	// see the CFSSL signing profiles added for lint profiles, and so that they
+	// have a signer for each RSA-PSS signature algorithm the profiles use.
+	var pssAlgos []x509.SignatureAlgorithm
+	seenAlgos := make(map[x509.SignatureAlgorithm]bool)
+	for _, profile := range ca.profiles {
+		if profile.sigAlgo != x509.UnknownSignatureAlgorithm && !seenAlgos[profile.sigAlgo] {
+			seenAlgos[profile.sigAlgo] = true
+			pssAlgos = append(pssAlgos, profile.sigAlgo)
+		}
+	}
+	ca.issuers, err = makeInternalIssuers(
+		issuers,
+		cfsslConfigObj.Signing,
+		config.LifespanOCSP.Duration,
+		pssAlgos)
+	if err != nil {
+		return nil, err
+	}
+	ca.defaultIssuer = ca.issuers[issuers[0].Cert.Subject.CommonName]
+
+	ca.idToIssuer = make(map[int64]*internalIssuer)
+	for _, ii := range ca.issuers {
+		id := idForIssuer(ii.cert)
+		ca.idToIssuer[id] = ii
+	}
+
 	ca.maxNames = config.MaxNames
 
 	return ca, nil
 }
 
+// issuanceProfile returns the issuance profile selected by the request, or the
+// default profile if the request doesn't select one.
+func (ca *CertificateAuthorityImpl) issuanceProfile(name string) (*issuanceProfile, error) {
+	if name == "" {
+		return ca.defaultProfile, nil
+	}
+	profile, ok := ca.profiles[name]
+	if !ok {
+		return nil, berrors.MalformedError("unknown certificate profile %q", name)
+	}
+	return profile, nil
+}
+
 // noteSignError is called after operations that may cause a CFSSL
 // or PKCS11 signing error.
 func (ca *CertificateAuthorityImpl) noteSignError(err error) {
@@ -459,7 +969,7 @@ func (ca *CertificateAuthorityImpl) GenerateOCSP(ctx context.Context, req *caPB.
 	} else {
 		cert, err := x509.ParseCertificate(req.CertDER)
 		if err != nil {
-			ca.log.AuditErr(err.Error())
+			blog.ForContext(ctx, ca.log).AuditErr(err.Error())
 			return nil, err
 		}
 
@@ -498,7 +1008,16 @@ func (ca *CertificateAuthorityImpl) GenerateOCSP(ctx context.Context, req *caPB.
 }
 
 func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, issueReq *caPB.IssueCertificateRequest) (*caPB.IssuePrecertificateResponse, error) {
-	serialBigInt, validity, err := ca.generateSerialNumberAndValidity()
+	profile, err := ca.issuanceProfile(issueReq.GetCertificateProfileName())
+	if err != nil {
+		return nil, err
+	}
+
+	var requestedValidity time.Duration
+	if issueReq.RequestedValidity != nil {
+		requestedValidity = time.Duration(*issueReq.RequestedValidity)
+	}
+	serialBigInt, validity, err := ca.generateSerialNumberAndValidity(profile, requestedValidity)
 	if err != nil {
 		return nil, err
 	}
@@ -518,7 +1037,7 @@ func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 		return nil, err
 	}
 
-	precertDER, err := ca.issuePrecertificateInner(ctx, issueReq, serialBigInt, validity)
+	precertDER, err := ca.issuePrecertificateInner(ctx, issueReq, profile, serialBigInt, validity)
 	if err != nil {
 		return nil, err
 	}
@@ -530,21 +1049,23 @@ func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 	})
 	if err != nil {
 		err = berrors.InternalServerError(err.Error())
-		ca.log.AuditInfof("OCSP Signing failure: serial=[%s] err=[%s]", serialHex, err)
+		blog.ForContext(ctx, ca.log).AuditInfof("OCSP Signing failure: serial=[%s] err=[%s]", serialHex, err)
 		return nil, err
 	}
 
 	req := &sapb.AddCertificateRequest{
-		Der:    precertDER,
-		RegID:  &regID,
-		Ocsp:   ocspResp.Response,
-		Issued: &nowNanos,
+		Der:         precertDER,
+		RegID:       &regID,
+		Ocsp:        ocspResp.Response,
+		Issued:      &nowNanos,
+		ProfileName: &profile.name,
 	}
 
 	// we currently only use one issuer, in the future when we support multiple
 	// the issuer will need to be derived from issueReq
 	issuerID := idForIssuer(ca.defaultIssuer.cert)
 	req.IssuerID = &issuerID
+	req.IssuerFingerprint = &ca.defaultIssuer.fingerprint
 
 	_, err = ca.sa.AddPrecertificate(ctx, req)
 	if err != nil {
@@ -552,21 +1073,24 @@ func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 		err = berrors.InternalServerError(err.Error())
 		// Note: This log line is parsed by cmd/orphan-finder. If you make any
 		// changes here, you should make sure they are reflected in orphan-finder.
-		ca.log.AuditErrf("Failed RPC to store at SA, orphaning precertificate: serial=[%s] cert=[%s] err=[%v], regID=[%d], orderID=[%d]",
+		blog.ForContext(ctx, ca.log).AuditErrf("Failed RPC to store at SA, orphaning precertificate: serial=[%s] cert=[%s] err=[%v], regID=[%d], orderID=[%d]",
 			serialHex, hex.EncodeToString(precertDER), err, *issueReq.RegistrationID, *issueReq.OrderID)
 		if ca.orphanQueue != nil {
 			ca.queueOrphan(&orphanedCert{
-				DER:      precertDER,
-				RegID:    regID,
-				OCSPResp: ocspResp.Response,
-				Precert:  true,
+				DER:               precertDER,
+				RegID:             regID,
+				OCSPResp:          ocspResp.Response,
+				Precert:           true,
+				ProfileName:       profile.name,
+				IssuerFingerprint: ca.defaultIssuer.fingerprint,
 			})
 		}
 		return nil, err
 	}
 
 	return &caPB.IssuePrecertificateResponse{
-		DER: precertDER,
+		DER:      precertDER,
+		OmitSCTs: &profile.omitSCTs,
 	}, nil
 }
 
@@ -578,6 +1102,9 @@ func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 // the certificate is signed a OCSP response is generated and the
 // response and certificate are stored in the database.
 //
+// If the precertificate's issuance profile omits SCTs, none may be provided
+// and the poison extension is removed without a SCT list taking its place.
+//
 // It's critical not to sign two different final certificates for the same
 // precertificate. This can happen, for instance, if the caller provides a
 // different set of SCTs on subsequent calls to  IssueCertificateForPrecertificate.
@@ -602,47 +1129,118 @@ func (ca *CertificateAuthorityImpl) IssueCertificateForPrecertificate(ctx contex
 	serialHex := core.SerialToString(precert.SerialNumber)
 	if _, err = ca.sa.GetCertificate(ctx, serialHex); err == nil {
 		err = berrors.InternalServerError("issuance of duplicate final certificate requested: %s", serialHex)
-		ca.log.AuditErr(err.Error())
+		blog.ForContext(ctx, ca.log).AuditErr(err.Error())
 		return emptyCert, err
 	} else if !berrors.Is(err, berrors.NotFound) {
 		return emptyCert, fmt.Errorf("error checking for duplicate issuance of %s: %s", serialHex, err)
 	}
-	var scts []ct.SignedCertificateTimestamp
-	for _, sctBytes := range req.SCTs {
-		var sct ct.SignedCertificateTimestamp
-		_, err = cttls.Unmarshal(sctBytes, &sct)
-		if err != nil {
-			return emptyCert, err
-		}
-		scts = append(scts, sct)
-	}
-	certPEM, err := ca.defaultIssuer.eeSigner.SignFromPrecert(precert, scts)
+	profile, err := ca.issuanceProfile(req.GetCertificateProfileName())
 	if err != nil {
 		return emptyCert, err
 	}
-	ca.signatureCount.WithLabelValues(string(certType)).Inc()
-	block, _ := pem.Decode(certPEM)
-	if block == nil || block.Type != "CERTIFICATE" {
-		err = berrors.InternalServerError("invalid certificate value returned")
-		ca.log.AuditErrf("PEM decode error, aborting: serial=[%s] pem=[%s] err=[%v]", serialHex, certPEM, err)
-		return emptyCert, err
+	var certDER []byte
+	if profile.omitSCTs && len(req.SCTs) != 0 {
+		return emptyCert, berrors.InternalServerError("SCTs provided for certificate profile %q, which omits them", profile.name)
 	}
-	certDER := block.Bytes
-	ca.log.AuditInfof("Signing success: serial=[%s] names=[%s] certificate=[%s]",
-		serialHex, strings.Join(precert.DNSNames, ", "), hex.EncodeToString(req.DER),
+	// An RA whose CT policy requires no SCTs provides none, even for profiles
+	// which embed them, and CFSSL can't sign an empty SCT list.
+	if len(req.SCTs) == 0 {
+		certDER, err = signFromPrecertWithoutSCTs(ca.defaultIssuer, precert)
+		if err != nil {
+			return emptyCert, err
+		}
+		ca.signatureCount.WithLabelValues(string(certType)).Inc()
+	} else {
+		var scts []ct.SignedCertificateTimestamp
+		for _, sctBytes := range req.SCTs {
+			var sct ct.SignedCertificateTimestamp
+			_, err = cttls.Unmarshal(sctBytes, &sct)
+			if err != nil {
+				return emptyCert, err
+			}
+			scts = append(scts, sct)
+		}
+		certPEM, err := ca.defaultIssuer.eeSigner.SignFromPrecert(precert, scts)
+		if err != nil {
+			return emptyCert, err
+		}
+		ca.signatureCount.WithLabelValues(string(certType)).Inc()
+		block, _ := pem.Decode(certPEM)
+		if block == nil || block.Type != "CERTIFICATE" {
+			err = berrors.InternalServerError("invalid certificate value returned")
+			blog.ForContext(ctx, ca.log).AuditErrf("PEM decode error, aborting: serial=[%s] pem=[%s] err=[%v]", serialHex, certPEM, err)
+			return emptyCert, err
+		}
+		certDER = block.Bytes
+	}
+	blog.ForContext(ctx, ca.log).AuditInfof("Signing success: serial=[%s] names=[%s] issuer=[%s] chain=[%s] precertificate=[%s] certificate=[%s]",
+		serialHex, strings.Join(precert.DNSNames, ", "), ca.defaultIssuer.fingerprint,
+		strings.Join(ca.defaultIssuer.chainFingerprints, ", "), hex.EncodeToString(req.DER),
 		hex.EncodeToString(certDER))
 	return ca.storeCertificate(ctx, *req.RegistrationID, *req.OrderID, precert.SerialNumber, certDER)
 }
 
+// signFromPrecertWithoutSCTs signs the final certificate for precert, which
+// is the precertificate with its poison extension removed and, unlike what
+// SignFromPrecert produces, no SCT list extension added in its place.
+func signFromPrecertWithoutSCTs(issuer *internalIssuer, precert *x509.Certificate) ([]byte, error) {
+	if err := precert.CheckSignatureFrom(issuer.cert); err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SignatureAlgorithm:    precert.SignatureAlgorithm,
+		PublicKeyAlgorithm:    precert.PublicKeyAlgorithm,
+		PublicKey:             precert.PublicKey,
+		Version:               precert.Version,
+		SerialNumber:          precert.SerialNumber,
+		Issuer:                precert.Issuer,
+		Subject:               precert.Subject,
+		NotBefore:             precert.NotBefore,
+		NotAfter:              precert.NotAfter,
+		KeyUsage:              precert.KeyUsage,
+		BasicConstraintsValid: precert.BasicConstraintsValid,
+		IsCA:                  precert.IsCA,
+	}
+	poisoned := false
+	for _, ext := range precert.Extensions {
+		if ext.Id.Equal(signer.CTPoisonOID) {
+			poisoned = true
+			continue
+		}
+		template.ExtraExtensions = append(template.ExtraExtensions, ext)
+	}
+	if !poisoned {
+		return nil, errors.New("precertificate has no poison extension")
+	}
+	// The issuer's OCSP signer holds the issuer's key, which the eeSigners
+	// also sign with.
+	return x509.CreateCertificate(rand.Reader, template, issuer.cert, precert.PublicKey, issuer.ocspSigner)
+}
+
 type validity struct {
 	NotBefore time.Time
 	NotAfter  time.Time
 }
 
-func (ca *CertificateAuthorityImpl) generateSerialNumberAndValidity() (*big.Int, validity, error) {
-	// We want 136 bits of random number, plus an 8-bit instance id prefix.
-	const randBits = 136
-	serialBytes := make([]byte, randBits/8+1)
+// generateSerialNumberAndValidity returns a new serial number and the validity
+// period for a certificate issued with the given profile. A non-zero requested
+// validity shorter than the profile's is honored; anything longer is clamped
+// to the profile's validity.
+func (ca *CertificateAuthorityImpl) generateSerialNumberAndValidity(profile *issuanceProfile, requested time.Duration) (*big.Int, validity, error) {
+	validityPeriod := profile.validity
+	if requested != 0 {
+		if requested <= profile.backdate {
+			return nil, validity{}, berrors.MalformedError(
+				"requested validity %s must be longer than %s", requested, profile.backdate)
+		}
+		if requested < validityPeriod {
+			validityPeriod = requested
+		}
+	}
+
+	// The profile's number of random bits (by default 136), plus an 8-bit
+	// instance id prefix.
+	serialBytes := make([]byte, profile.serialRandomBits/8+1)
 	serialBytes[0] = byte(ca.prefix)
 	_, err := rand.Read(serialBytes[1:])
 	if err != nil {
@@ -653,16 +1251,16 @@ func (ca *CertificateAuthorityImpl) generateSerialNumberAndValidity() (*big.Int,
 	serialBigInt := big.NewInt(0)
 	serialBigInt = serialBigInt.SetBytes(serialBytes)
 
-	notBefore := ca.clk.Now().Add(-1 * ca.backdate)
+	notBefore := ca.clk.Now().Add(-1 * profile.backdate)
 	validity := validity{
 		NotBefore: notBefore,
-		NotAfter:  notBefore.Add(ca.validityPeriod),
+		NotAfter:  notBefore.Add(validityPeriod),
 	}
 
 	return serialBigInt, validity, nil
 }
 
-func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context, issueReq *caPB.IssueCertificateRequest, serialBigInt *big.Int, validity validity) ([]byte, error) {
+func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context, issueReq *caPB.IssueCertificateRequest, profile *issuanceProfile, serialBigInt *big.Int, validity validity) ([]byte, error) {
 	csr, err := x509.ParseCertificateRequest(issueReq.Csr)
 	if err != nil {
 		return nil, err
@@ -677,11 +1275,29 @@ func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		ca.forceCNFromSAN,
 		*issueReq.RegistrationID,
 	); err != nil {
-		ca.log.AuditErr(err.Error())
+		blog.ForContext(ctx, ca.log).AuditErr(err.Error())
 		// VerifyCSR returns berror instances that can be passed through as-is
 		// without wrapping.
 		return nil, err
 	}
+	// VerifyCSR has checked the CA-wide maximum number of names and
+	// normalized the CSR's names, so they can be checked against the
+	// profile's possibly lower maximum.
+	if len(csr.DNSNames) > profile.maxNames {
+		ca.maxNamesRejections.WithLabelValues(profile.name).Inc()
+		err := berrors.BadCSRError("CSR contains more than %d DNS names, the maximum for certificate profile %q",
+			profile.maxNames, profile.name)
+		blog.ForContext(ctx, ca.log).AuditErr(err.Error())
+		return nil, err
+	}
+	// VerifyCSR has also checked the key against the CA-wide key policy.
+	if profile.keyTypes != nil {
+		if reason, err := profile.keyTypes.check(profile.name, csr.PublicKey); err != nil {
+			ca.keyTypeRejections.WithLabelValues(profile.name, reason).Inc()
+			blog.ForContext(ctx, ca.log).AuditErr(err.Error())
+			return nil, err
+		}
+	}
 
 	extensions, err := ca.extensionsFromCSR(csr)
 	if err != nil {
@@ -692,7 +1308,7 @@ func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 
 	if issuer.cert.NotAfter.Before(validity.NotAfter) {
 		err = berrors.InternalServerError("cannot issue a certificate that expires after the issuer certificate")
-		ca.log.AuditErr(err.Error())
+		blog.ForContext(ctx, ca.log).AuditErr(err.Error())
 		return nil, err
 	}
 
@@ -702,22 +1318,22 @@ func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		Bytes: csr.Raw,
 	}))
 
-	var profile string
+	var signingProfile string
 	switch csr.PublicKey.(type) {
 	case *rsa.PublicKey:
-		profile = ca.rsaProfile
+		signingProfile = profile.rsaProfile
 	case *ecdsa.PublicKey:
-		profile = ca.ecdsaProfile
+		signingProfile = profile.ecdsaProfile
 	default:
 		err = berrors.InternalServerError("unsupported key type %T", csr.PublicKey)
-		ca.log.AuditErr(err.Error())
+		blog.ForContext(ctx, ca.log).AuditErr(err.Error())
 		return nil, err
 	}
 
 	// Send the cert off for signing
 	req := signer.SignRequest{
 		Request: csrPEM,
-		Profile: profile,
+		Profile: signingProfile,
 		Hosts:   csr.DNSNames,
 		Subject: &signer.Subject{
 			CN: csr.Subject.CommonName,
@@ -735,46 +1351,58 @@ func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		req.Subject.SerialNumber = serialHex
 	}
 
-	ca.log.AuditInfof("Signing: serial=[%s] names=[%s] csr=[%s]",
-		serialHex, strings.Join(csr.DNSNames, ", "), hex.EncodeToString(csr.Raw))
+	blog.ForContext(ctx, ca.log).AuditInfof("Signing: serial=[%s] names=[%s] profile=[%s] notBefore=[%s] issuer=[%s] chain=[%s] csr=[%s]",
+		serialHex, strings.Join(csr.DNSNames, ", "), profile.name,
+		validity.NotBefore.UTC().Format(time.RFC3339), issuer.fingerprint,
+		strings.Join(issuer.chainFingerprints, ", "), hex.EncodeToString(csr.Raw))
 
-	certPEM, err := issuer.eeSigner.Sign(req)
+	eeSigner, err := issuer.signerFor(profile.sigAlgo)
+	if err != nil {
+		err = berrors.InternalServerError("failed to sign certificate: %s", err)
+		blog.ForContext(ctx, ca.log).AuditErrf("Signing failed: serial=[%s] err=[%v]", serialHex, err)
+		return nil, err
+	}
+	certPEM, err := eeSigner.Sign(req)
 	ca.noteSignError(err)
 	if err != nil {
 		// If the Signing error was a pre-issuance lint error then marshal the
 		// linting errors to include in the audit err msg.
 		if lErr, ok := err.(*local.LintError); ok {
+			for name := range lErr.ErrorResults {
+				ca.lintErrorCount.WithLabelValues(name).Inc()
+			}
 			// NOTE(@cpu): We throw away the JSON marshal error here. If marshaling
 			// fails for some reason it's acceptable to log an empty string for the
 			// JSON component.
 			lintErrsJSON, _ := json.Marshal(lErr.ErrorResults)
-			ca.log.AuditErrf("Signing failed: serial=[%s] err=[%v] lintErrors=%s",
+			blog.ForContext(ctx, ca.log).AuditErrf("Signing failed: serial=[%s] err=[%v] lintErrors=%s",
 				serialHex, err, string(lintErrsJSON))
 			return nil, berrors.InternalServerError("failed to sign certificate: %s", err)
 		}
 
 		err = berrors.InternalServerError("failed to sign certificate: %s", err)
-		ca.log.AuditErrf("Signing failed: serial=[%s] err=[%v]", serialHex, err)
+		blog.ForContext(ctx, ca.log).AuditErrf("Signing failed: serial=[%s] err=[%v]", serialHex, err)
 		return nil, err
 	}
 	ca.signatureCount.WithLabelValues(string(precertType)).Inc()
 
 	if len(certPEM) == 0 {
 		err = berrors.InternalServerError("no certificate returned by server")
-		ca.log.AuditErrf("PEM empty from Signer: serial=[%s] err=[%v]", serialHex, err)
+		blog.ForContext(ctx, ca.log).AuditErrf("PEM empty from Signer: serial=[%s] err=[%v]", serialHex, err)
 		return nil, err
 	}
 
 	block, _ := pem.Decode(certPEM)
 	if block == nil || block.Type != "CERTIFICATE" {
 		err = berrors.InternalServerError("invalid certificate value returned")
-		ca.log.AuditErrf("PEM decode error, aborting: serial=[%s] pem=[%s] err=[%v]", serialHex, certPEM, err)
+		blog.ForContext(ctx, ca.log).AuditErrf("PEM decode error, aborting: serial=[%s] pem=[%s] err=[%v]", serialHex, certPEM, err)
 		return nil, err
 	}
 	certDER := block.Bytes
 
-	ca.log.AuditInfof("Signing success: serial=[%s] names=[%s] csr=[%s] precertificate=[%s]",
-		serialHex, strings.Join(csr.DNSNames, ", "), hex.EncodeToString(csr.Raw),
+	blog.ForContext(ctx, ca.log).AuditInfof("Signing success: serial=[%s] names=[%s] issuer=[%s] chain=[%s] csr=[%s] precertificate=[%s]",
+		serialHex, strings.Join(csr.DNSNames, ", "), issuer.fingerprint,
+		strings.Join(issuer.chainFingerprints, ", "), hex.EncodeToString(csr.Raw),
 		hex.EncodeToString(certDER))
 
 	return certDER, nil
@@ -794,7 +1422,7 @@ func (ca *CertificateAuthorityImpl) storeCertificate(
 		err = berrors.InternalServerError(err.Error())
 		// Note: This log line is parsed by cmd/orphan-finder. If you make any
 		// changes here, you should make sure they are reflected in orphan-finder.
-		ca.log.AuditErrf("Failed RPC to store at SA, orphaning certificate: serial=[%s] cert=[%s] err=[%v], regID=[%d], orderID=[%d]",
+		blog.ForContext(ctx, ca.log).AuditErrf("Failed RPC to store at SA, orphaning certificate: serial=[%s] cert=[%s] err=[%v], regID=[%d], orderID=[%d]",
 			core.SerialToString(serialBigInt), hex.EncodeToString(certDER), err, regID, orderID)
 		if ca.orphanQueue != nil {
 			ca.queueOrphan(&orphanedCert{
@@ -813,6 +1441,12 @@ type orphanedCert struct {
 	OCSPResp []byte
 	RegID    int64
 	Precert  bool
+	// ProfileName is the issuance profile of an orphaned precertificate. It's
+	// empty for orphans queued before it was recorded.
+	ProfileName string
+	// IssuerFingerprint is the hex SHA-256 fingerprint of the issuer which
+	// signed an orphaned precertificate. Like ProfileName it may be empty.
+	IssuerFingerprint string
 }
 
 func (ca *CertificateAuthorityImpl) queueOrphan(o *orphanedCert) {
@@ -858,17 +1492,25 @@ func (ca *CertificateAuthorityImpl) integrateOrphan() error {
 	if err != nil {
 		return fmt.Errorf("failed to parse orphan: %s", err)
 	}
-	// When calculating the `NotBefore` at issuance time, we subtracted
-	// ca.backdate. Now, to calculate the actual issuance time from the NotBefore,
-	// we reverse the process and add ca.backdate.
-	issued := cert.NotBefore.Add(ca.backdate)
+	// When calculating the `NotBefore` at issuance time, we subtracted the
+	// backdate of the issuance profile. Now, to calculate the actual issuance
+	// time from the NotBefore, we reverse the process and add it back.
+	// Orphans queued before profile names were recorded, or whose profile has
+	// since been removed, are assumed to have used the CA-wide backdate.
+	backdate := ca.backdate
+	if profile, ok := ca.profiles[orphan.ProfileName]; ok {
+		backdate = profile.backdate
+	}
+	issued := cert.NotBefore.Add(backdate)
 	if orphan.Precert {
 		issuedNanos := issued.UnixNano()
 		_, err = ca.sa.AddPrecertificate(context.Background(), &sapb.AddCertificateRequest{
-			Der:    orphan.DER,
-			RegID:  &orphan.RegID,
-			Ocsp:   orphan.OCSPResp,
-			Issued: &issuedNanos,
+			Der:               orphan.DER,
+			RegID:             &orphan.RegID,
+			Ocsp:              orphan.OCSPResp,
+			Issued:            &issuedNanos,
+			ProfileName:       &orphan.ProfileName,
+			IssuerFingerprint: &orphan.IssuerFingerprint,
 		})
 		if err != nil && !berrors.Is(err, berrors.Duplicate) {
 			return fmt.Errorf("failed to store orphaned precertificate: %s", err)
@@ -150,7 +150,9 @@ type testCtx struct {
 }
 
 type mockSA struct {
-	certificate core.Certificate
+	certificate       core.Certificate
+	precertProfile    string
+	issuerFingerprint string
 }
 
 func (m *mockSA) AddCertificate(ctx context.Context, der []byte, _ int64, _ []byte, _ *time.Time) (string, error) {
@@ -159,6 +161,12 @@ func (m *mockSA) AddCertificate(ctx context.Context, der []byte, _ int64, _ []by
 }
 
 func (m *mockSA) AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error) {
+	if req.ProfileName != nil {
+		m.precertProfile = *req.ProfileName
+	}
+	if req.IssuerFingerprint != nil {
+		m.issuerFingerprint = *req.IssuerFingerprint
+	}
 	return &corepb.Empty{}, nil
 }
 
@@ -271,7 +279,7 @@ func setup(t *testing.T) *testCtx {
 		},
 	}
 
-	issuers := []Issuer{{caKey, caCert}}
+	issuers := []Issuer{{Signer: caKey, Cert: caCert}}
 
 	keyPolicy := goodkey.KeyPolicy{
 		AllowRSA:           true,
@@ -676,6 +684,637 @@ func TestRejectValidityTooLong(t *testing.T) {
 	test.Assert(t, berrors.Is(err, berrors.InternalServer), "Incorrect error type returned")
 }
 
+func TestIssuanceProfiles(t *testing.T) {
+	testCtx := setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"shortlived": {
+			Validity:   cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			RSAProfile: ecdsaProfileName,
+		},
+	}
+	sa := &mockSA{}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		sa,
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	shortLived := "shortlived"
+	testCases := []struct {
+		name             string
+		profile          *string
+		requested        time.Duration
+		expectedValidity time.Duration
+		expectedProfile  string
+		expectedUsage    x509.KeyUsage
+	}{
+		{
+			name:             "default profile",
+			expectedValidity: 8760 * time.Hour,
+			expectedProfile:  defaultProfileName,
+			expectedUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		},
+		{
+			name:             "named profile",
+			profile:          &shortLived,
+			expectedValidity: 7 * 24 * time.Hour,
+			expectedProfile:  shortLived,
+			expectedUsage:    x509.KeyUsageDigitalSignature,
+		},
+		{
+			name:             "shorter requested validity",
+			profile:          &shortLived,
+			requested:        2 * 24 * time.Hour,
+			expectedValidity: 2 * 24 * time.Hour,
+			expectedProfile:  shortLived,
+			expectedUsage:    x509.KeyUsageDigitalSignature,
+		},
+		{
+			name:             "requested validity clamped to profile",
+			profile:          &shortLived,
+			requested:        30 * 24 * time.Hour,
+			expectedValidity: 7 * 24 * time.Hour,
+			expectedProfile:  shortLived,
+			expectedUsage:    x509.KeyUsageDigitalSignature,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testCtx.logger.Clear()
+			issueReq := &caPB.IssueCertificateRequest{
+				Csr:                    CNandSANCSR,
+				RegistrationID:         &arbitraryRegID,
+				CertificateProfileName: tc.profile,
+			}
+			if tc.requested != 0 {
+				requested := int64(tc.requested)
+				issueReq.RequestedValidity = &requested
+			}
+			response, err := ca.IssuePrecertificate(ctx, issueReq)
+			test.AssertNotError(t, err, "Failed to issue precertificate")
+			cert, err := x509.ParseCertificate(response.DER)
+			test.AssertNotError(t, err, "Certificate failed to parse")
+			test.AssertEquals(t, cert.NotAfter.Sub(cert.NotBefore), tc.expectedValidity)
+			test.AssertEquals(t, cert.KeyUsage, tc.expectedUsage)
+			matches := testCtx.logger.GetAllMatching(fmt.Sprintf(`Signing: .* profile=\[%s\]`, tc.expectedProfile))
+			test.AssertEquals(t, len(matches), 1)
+			test.AssertEquals(t, sa.precertProfile, tc.expectedProfile)
+		})
+	}
+
+	unknown := "unknown"
+	_, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         &arbitraryRegID,
+		CertificateProfileName: &unknown,
+	})
+	test.AssertError(t, err, "Issued with an unknown profile")
+	test.Assert(t, berrors.Is(err, berrors.Malformed), "Incorrect error type returned")
+
+	tooShort := int64(time.Minute)
+	_, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:               CNandSANCSR,
+		RegistrationID:    &arbitraryRegID,
+		RequestedValidity: &tooShort,
+	})
+	test.AssertError(t, err, "Issued with a validity shorter than the backdate")
+	test.Assert(t, berrors.Is(err, berrors.Malformed), "Incorrect error type returned")
+}
+
+func TestIssuanceProfileBackdate(t *testing.T) {
+	testCtx := setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"shortlived": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			Backdate: cmd.ConfigDuration{Duration: 5 * time.Minute},
+		},
+	}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	shortLived := "shortlived"
+	for _, tc := range []struct {
+		profile  *string
+		backdate time.Duration
+		validity time.Duration
+	}{
+		{nil, time.Hour, 8760 * time.Hour},
+		{&shortLived, 5 * time.Minute, 7 * 24 * time.Hour},
+	} {
+		testCtx.logger.Clear()
+		response, err := ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+			Csr:                    CNandSANCSR,
+			RegistrationID:         &arbitraryRegID,
+			CertificateProfileName: tc.profile,
+		})
+		test.AssertNotError(t, err, "Failed to issue precertificate")
+		cert, err := x509.ParseCertificate(response.DER)
+		test.AssertNotError(t, err, "Certificate failed to parse")
+		notBefore := testCtx.fc.Now().Add(-tc.backdate).Truncate(time.Second)
+		test.AssertEquals(t, cert.NotBefore, notBefore)
+		test.AssertEquals(t, cert.NotAfter.Sub(cert.NotBefore), tc.validity)
+		matches := testCtx.logger.GetAllMatching(fmt.Sprintf(`Signing: .* notBefore=\[%s\]`, notBefore.UTC().Format(time.RFC3339)))
+		test.AssertEquals(t, len(matches), 1)
+	}
+
+	// Requested validities are checked against the profile's backdate.
+	requested := int64(10 * time.Minute)
+	_, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         &arbitraryRegID,
+		CertificateProfileName: &shortLived,
+		RequestedValidity:      &requested,
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate with a validity longer than the profile's backdate")
+	_, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:               CNandSANCSR,
+		RegistrationID:    &arbitraryRegID,
+		RequestedValidity: &requested,
+	})
+	test.AssertError(t, err, "Issued with a validity shorter than the CA-wide backdate")
+}
+
+func TestIssuanceProfileMaxNames(t *testing.T) {
+	testCtx := setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"single": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			MaxNames: 1,
+		},
+	}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	// CNandSANCSR has two names, which the default profile allows but the
+	// single name profile doesn't.
+	_, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: &arbitraryRegID,
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate with the default profile")
+
+	single := "single"
+	_, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         &arbitraryRegID,
+		CertificateProfileName: &single,
+	})
+	test.AssertError(t, err, "Issued with more names than the profile allows")
+	test.Assert(t, berrors.Is(err, berrors.BadCSR), "Incorrect error type returned")
+	test.AssertEquals(t, test.CountCounterVec("profile", "single", ca.maxNamesRejections), 1)
+	test.AssertEquals(t, test.CountCounterVec("profile", defaultProfileName, ca.maxNamesRejections), 0)
+
+	testCtx.caConfig.IssuanceProfiles = nil
+	testCtx.caConfig.MaxNames = 0
+	_, err = NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertError(t, err, "CA created with MaxNames 0")
+}
+
+func TestIssuanceProfileSerialRandomBits(t *testing.T) {
+	testCtx := setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"long": {
+			Validity:         cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			SerialRandomBits: 152,
+		},
+	}
+	sa := &mockSA{}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		sa,
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	for profileName, octets := range map[string]int{"": 18, "long": 20} {
+		name := profileName
+		resp, err := ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+			Csr:                    CNandSANCSR,
+			RegistrationID:         &arbitraryRegID,
+			CertificateProfileName: &name,
+		})
+		test.AssertNotError(t, err, "Failed to issue precertificate")
+		precert, err := x509.ParseCertificate(resp.DER)
+		test.AssertNotError(t, err, "Failed to parse precertificate")
+		serialBytes := precert.SerialNumber.Bytes()
+		test.AssertEquals(t, len(serialBytes), octets)
+		test.AssertEquals(t, serialBytes[0], byte(17))
+
+		// The serial's string form must be accepted wherever serials are looked
+		// up, e.g. by the SA and the WFE.
+		serial := core.SerialToString(precert.SerialNumber)
+		test.Assert(t, core.ValidSerial(serial), "Serial string isn't valid")
+		parsed, err := core.StringToSerial(serial)
+		test.AssertNotError(t, err, "Failed to parse serial string")
+		test.Assert(t, parsed.Cmp(precert.SerialNumber) == 0, "Serial string doesn't round trip")
+	}
+
+	testCtx.caConfig.SerialPrefix = 0x80
+	_, err = NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		sa,
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertError(t, err, "CA created with 152 random bits and a high serial prefix")
+	test.AssertEquals(t, err.Error(),
+		`issuance profile "long": SerialRandomBits 152 with serial prefix 128 makes 21 octet serials, more than 20`)
+}
+
+func TestIssuanceProfileKeyTypes(t *testing.T) {
+	testCtx := setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"ecdsa": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			KeyTypes: &ca_config.KeyTypesConfig{ECDSACurves: []string{"P-256"}},
+		},
+		"p384": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			KeyTypes: &ca_config.KeyTypesConfig{ECDSACurves: []string{"P-384"}},
+		},
+		"rsa3072": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			KeyTypes: &ca_config.KeyTypesConfig{RSAModulusSizes: []int{3072, 4096}, ECDSACurves: []string{"P-256"}},
+		},
+	}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	// CNandSANCSR has a 2048 bit RSA key and ECDSACSR a P-256 key.
+	testCases := []struct {
+		profile string
+		csr     []byte
+		reason  string
+		errMsg  string
+	}{
+		{"", CNandSANCSR, "", ""},
+		{"ecdsa", ECDSACSR, "", ""},
+		{"ecdsa", CNandSANCSR, "rsa", `certificate profile "ecdsa" doesn't allow RSA keys`},
+		{"p384", ECDSACSR, "ecdsa_curve", `certificate profile "p384" doesn't allow ECDSA keys on curve P-256, only P-384`},
+		{"rsa3072", ECDSACSR, "", ""},
+		{"rsa3072", CNandSANCSR, "rsa_modulus_size", `certificate profile "rsa3072" doesn't allow 2048 bit RSA keys, only 3072, 4096`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.profile+"_"+tc.reason, func(t *testing.T) {
+			profile := tc.profile
+			_, err := ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+				Csr:                    tc.csr,
+				RegistrationID:         &arbitraryRegID,
+				CertificateProfileName: &profile,
+			})
+			if tc.errMsg == "" {
+				test.AssertNotError(t, err, "Failed to issue precertificate")
+				return
+			}
+			test.AssertError(t, err, "Issued with a key the profile doesn't allow")
+			test.Assert(t, berrors.Is(err, berrors.BadPublicKey), "Incorrect error type returned")
+			test.AssertEquals(t, err.Error(), tc.errMsg)
+			test.AssertEquals(t, test.CountCounter(ca.keyTypeRejections.WithLabelValues(tc.profile, tc.reason)), 1)
+		})
+	}
+}
+
+func TestInvalidIssuanceProfiles(t *testing.T) {
+	testCases := []struct {
+		name         string
+		profiles     map[string]ca_config.IssuanceProfileConfig
+		lintProfiles map[string]ca_config.LintProfileConfig
+		errorMsg     string
+	}{
+		{
+			name: "reserved name",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				defaultProfileName: {Validity: cmd.ConfigDuration{Duration: 24 * time.Hour}},
+			},
+			errorMsg: `issuance profile name "default" is reserved`,
+		},
+		{
+			name: "validity not longer than backdate",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"short": {Validity: cmd.ConfigDuration{Duration: time.Hour}},
+			},
+			errorMsg: `issuance profile "short": validity 1h0m0s must be longer than the backdate period 1h0m0s`,
+		},
+		{
+			name: "validity not longer than profile backdate",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"short": {
+					Validity: cmd.ConfigDuration{Duration: 2 * time.Hour},
+					Backdate: cmd.ConfigDuration{Duration: 3 * time.Hour},
+				},
+			},
+			errorMsg: `issuance profile "short": validity 2h0m0s must be longer than the backdate period 3h0m0s`,
+		},
+		{
+			name: "negative backdate",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"short": {
+					Validity: cmd.ConfigDuration{Duration: 24 * time.Hour},
+					Backdate: cmd.ConfigDuration{Duration: -time.Minute},
+				},
+			},
+			errorMsg: `issuance profile "short": backdate -1m0s must not be negative`,
+		},
+		{
+			name: "validity longer than expiry",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"long": {Validity: cmd.ConfigDuration{Duration: 8761 * time.Hour}},
+			},
+			errorMsg: `issuance profile "long": validity 8761h0m0s exceeds the maximum validity period 8760h0m0s`,
+		},
+		{
+			name: "unknown signing profile",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"short": {
+					Validity:     cmd.ConfigDuration{Duration: 24 * time.Hour},
+					ECDSAProfile: "nope",
+				},
+			},
+			errorMsg: `issuance profile "short": unknown CFSSL signing profile "nope"`,
+		},
+		{
+			name: "unknown lint profile",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"short": {
+					Validity:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+					LintProfile: "nope",
+				},
+			},
+			errorMsg: `issuance profile "short": unknown lint profile "nope"`,
+		},
+		{
+			name: "unknown ignored lint",
+			lintProfiles: map[string]ca_config.LintProfileConfig{
+				"strict": {IgnoredLints: []string{"e_not_a_lint"}},
+			},
+			errorMsg: `lint profile "strict": unknown lint name "e_not_a_lint"`,
+		},
+		{
+			name: "unknown ignored lint source",
+			lintProfiles: map[string]ca_config.LintProfileConfig{
+				"strict": {IgnoredLintSources: []string{"nope"}},
+			},
+			errorMsg: `lint profile "strict": unknown lint source "nope"`,
+		},
+		{
+			name: "unknown RSA-PSS hash",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"pss": {
+					Validity: cmd.ConfigDuration{Duration: 24 * time.Hour},
+					RSAPSS:   &ca_config.RSAPSSConfig{Hash: "MD5"},
+				},
+			},
+			errorMsg: `issuance profile "pss": unsupported RSA-PSS hash "MD5"`,
+		},
+		{
+			name: "unsupported RSA-PSS salt length",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"pss": {
+					Validity: cmd.ConfigDuration{Duration: 24 * time.Hour},
+					RSAPSS:   &ca_config.RSAPSSConfig{Hash: "SHA256", SaltLength: 20},
+				},
+			},
+			errorMsg: `issuance profile "pss": unsupported RSA-PSS salt length 20 for SHA256, only 32 is supported`,
+		},
+		{
+			name: "negative MaxNames",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"few": {
+					Validity: cmd.ConfigDuration{Duration: 24 * time.Hour},
+					MaxNames: -1,
+				},
+			},
+			errorMsg: `issuance profile "few": MaxNames -1 must be at least 1`,
+		},
+		{
+			name: "MaxNames above the CA-wide maximum",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"many": {
+					Validity: cmd.ConfigDuration{Duration: 24 * time.Hour},
+					MaxNames: 3,
+				},
+			},
+			errorMsg: `issuance profile "many": MaxNames 3 exceeds the CA-wide MaxNames 2`,
+		},
+		{
+			name: "SerialRandomBits not a multiple of 8",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"odd": {
+					Validity:         cmd.ConfigDuration{Duration: 24 * time.Hour},
+					SerialRandomBits: 140,
+				},
+			},
+			errorMsg: `issuance profile "odd": SerialRandomBits 140 must be a multiple of 8`,
+		},
+		{
+			name: "SerialRandomBits below the minimum",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"weak": {
+					Validity:         cmd.ConfigDuration{Duration: 24 * time.Hour},
+					SerialRandomBits: 56,
+				},
+			},
+			errorMsg: `issuance profile "weak": SerialRandomBits 56 must be at least 64`,
+		},
+		{
+			name: "SerialRandomBits too long",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"long": {
+					Validity:         cmd.ConfigDuration{Duration: 24 * time.Hour},
+					SerialRandomBits: 160,
+				},
+			},
+			errorMsg: `issuance profile "long": SerialRandomBits 160 with serial prefix 17 makes 21 octet serials, more than 20`,
+		},
+		{
+			name: "KeyTypes accepting nothing",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"none": {
+					Validity: cmd.ConfigDuration{Duration: 24 * time.Hour},
+					KeyTypes: &ca_config.KeyTypesConfig{},
+				},
+			},
+			errorMsg: `issuance profile "none": KeyTypes must accept at least one RSA modulus size or ECDSA curve`,
+		},
+		{
+			name: "KeyTypes with a small RSA modulus",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"weak": {
+					Validity: cmd.ConfigDuration{Duration: 24 * time.Hour},
+					KeyTypes: &ca_config.KeyTypesConfig{RSAModulusSizes: []int{1024}},
+				},
+			},
+			errorMsg: `issuance profile "weak": RSA modulus size 1024 must be a multiple of 8 and at least 2048`,
+		},
+		{
+			name: "KeyTypes with an unsupported curve",
+			profiles: map[string]ca_config.IssuanceProfileConfig{
+				"p521": {
+					Validity: cmd.ConfigDuration{Duration: 24 * time.Hour},
+					KeyTypes: &ca_config.KeyTypesConfig{ECDSACurves: []string{"P-521"}},
+				},
+			},
+			errorMsg: `issuance profile "p521": unsupported ECDSA curve "P-521"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testCtx := setup(t)
+			testCtx.caConfig.IssuanceProfiles = tc.profiles
+			testCtx.caConfig.LintProfiles = tc.lintProfiles
+			_, err := NewCertificateAuthorityImpl(
+				testCtx.caConfig,
+				&mockSA{},
+				testCtx.pa,
+				testCtx.fc,
+				testCtx.stats,
+				testCtx.issuers,
+				testCtx.keyPolicy,
+				testCtx.logger,
+				nil)
+			test.AssertError(t, err, "CA created with invalid issuance profiles")
+			test.AssertEquals(t, err.Error(), tc.errorMsg)
+		})
+	}
+}
+
+func TestRSAPSSProfile(t *testing.T) {
+	testCtx := setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"pss": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			RSAPSS:   &ca_config.RSAPSSConfig{Hash: "SHA384", SaltLength: 48},
+		},
+	}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	pss := "pss"
+	precert, err := ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         &arbitraryRegID,
+		OrderID:                new(int64),
+		CertificateProfileName: &pss,
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate")
+	parsedPrecert, err := x509.ParseCertificate(precert.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, parsedPrecert.SignatureAlgorithm, x509.SHA384WithRSAPSS)
+	test.AssertNotError(t, parsedPrecert.CheckSignatureFrom(caCert), "Precertificate signature is invalid")
+
+	sctBytes, err := makeSCTs()
+	test.AssertNotError(t, err, "Failed to make SCTs")
+	cert, err := ca.IssueCertificateForPrecertificate(ctx, &caPB.IssueCertificateForPrecertificateRequest{
+		DER:            precert.DER,
+		SCTs:           sctBytes,
+		RegistrationID: &arbitraryRegID,
+		OrderID:        new(int64),
+	})
+	test.AssertNotError(t, err, "Failed to issue certificate for precertificate")
+	parsedCert, err := x509.ParseCertificate(cert.DER)
+	test.AssertNotError(t, err, "Failed to parse certificate")
+	test.AssertEquals(t, parsedCert.SignatureAlgorithm, parsedPrecert.SignatureAlgorithm)
+	test.AssertNotError(t, parsedCert.CheckSignatureFrom(caCert), "Certificate signature is invalid")
+
+	// Certificates issued with the default profile are still signed with
+	// PKCS #1 v1.5.
+	precert, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: &arbitraryRegID,
+		OrderID:        new(int64),
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate")
+	parsedPrecert, err = x509.ParseCertificate(precert.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, parsedPrecert.SignatureAlgorithm, x509.SHA256WithRSA)
+}
+
+func TestRSAPSSProfileNonRSAIssuer(t *testing.T) {
+	testCtx := setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"pss": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			RSAPSS:   &ca_config.RSAPSSConfig{Hash: "SHA256"},
+		},
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "Failed to generate ECDSA key")
+	_, err = NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		[]Issuer{{Signer: ecdsaKey, Cert: caCert}},
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertError(t, err, "CA created with an RSA-PSS profile and an ECDSA issuer key")
+	test.AssertContains(t, err.Error(), "SHA256-RSAPSS requires an RSA key")
+}
+
 func TestSingleAIAEnforcement(t *testing.T) {
 	pa, err := policy.New(nil)
 	test.AssertNotError(t, err, "Couldn't create PA")
@@ -892,6 +1531,134 @@ func TestIssueCertificateForPrecertificate(t *testing.T) {
 		}
 	}
 	test.Assert(t, list, "returned cert doesn't contain SCT list")
+
+}
+
+func TestIssueCertificateForPrecertificateNoSCTs(t *testing.T) {
+	testCtx := setup(t)
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	precert, err := ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: &arbitraryRegID,
+		OrderID:        new(int64),
+	})
+	test.AssertNotError(t, err, "Failed to issue precert")
+
+	// An RA whose CT policy requires no SCTs provides none, even for the
+	// default profile, which embeds SCTs when there are some.
+	cert, err := ca.IssueCertificateForPrecertificate(ctx, &caPB.IssueCertificateForPrecertificateRequest{
+		DER:            precert.DER,
+		RegistrationID: &arbitraryRegID,
+		OrderID:        new(int64),
+	})
+	test.AssertNotError(t, err, "Failed to issue cert from precert without SCTs")
+	parsedCert, err := x509.ParseCertificate(cert.DER)
+	test.AssertNotError(t, err, "Failed to parse cert")
+	test.AssertNotError(t, parsedCert.CheckSignatureFrom(testCtx.issuers[0].Cert), "Cert not signed by the issuer")
+	for _, ext := range parsedCert.Extensions {
+		test.Assert(t, !ext.Id.Equal(signer.CTPoisonOID), "returned cert is poisoned")
+		test.Assert(t, !ext.Id.Equal(signer.SCTListOID), "returned cert contains SCT list")
+	}
+}
+
+func TestIssuanceProfileOmitSCTs(t *testing.T) {
+	testCtx := setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"stapled": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			OmitSCTs: true,
+		},
+	}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	profile := "stapled"
+	precert, err := ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         &arbitraryRegID,
+		OrderID:                new(int64),
+		CertificateProfileName: &profile,
+	})
+	test.AssertNotError(t, err, "Failed to issue precert")
+	test.Assert(t, precert.GetOmitSCTs(), "precert response doesn't say SCTs are omitted")
+	parsedPrecert, err := x509.ParseCertificate(precert.DER)
+	test.AssertNotError(t, err, "Failed to parse precert")
+
+	sctBytes, err := makeSCTs()
+	test.AssertNotError(t, err, "Failed to make SCTs")
+	_, err = ca.IssueCertificateForPrecertificate(ctx, &caPB.IssueCertificateForPrecertificateRequest{
+		DER:                    precert.DER,
+		SCTs:                   sctBytes,
+		RegistrationID:         &arbitraryRegID,
+		OrderID:                new(int64),
+		CertificateProfileName: &profile,
+	})
+	test.AssertError(t, err, "Issued cert with SCTs for a profile omitting them")
+
+	cert, err := ca.IssueCertificateForPrecertificate(ctx, &caPB.IssueCertificateForPrecertificateRequest{
+		DER:                    precert.DER,
+		RegistrationID:         &arbitraryRegID,
+		OrderID:                new(int64),
+		CertificateProfileName: &profile,
+	})
+	test.AssertNotError(t, err, "Failed to issue cert from precert")
+	parsedCert, err := x509.ParseCertificate(cert.DER)
+	test.AssertNotError(t, err, "Failed to parse cert")
+	test.AssertNotError(t, parsedCert.CheckSignatureFrom(testCtx.issuers[0].Cert), "Cert not signed by the issuer")
+	for _, ext := range parsedCert.Extensions {
+		test.Assert(t, !ext.Id.Equal(signer.CTPoisonOID), "returned cert is poisoned")
+		test.Assert(t, !ext.Id.Equal(signer.SCTListOID), "returned cert contains SCT list")
+	}
+	// Other than the poison extension, the precert's contents are kept.
+	test.AssertEquals(t, len(parsedCert.Extensions), len(parsedPrecert.Extensions)-1)
+	test.AssertDeepEquals(t, parsedCert.SerialNumber, parsedPrecert.SerialNumber)
+	test.AssertDeepEquals(t, parsedCert.DNSNames, parsedPrecert.DNSNames)
+	test.AssertEquals(t, parsedCert.NotAfter, parsedPrecert.NotAfter)
+	test.AssertByteEquals(t, parsedCert.RawSubject, parsedPrecert.RawSubject)
+	test.AssertByteEquals(t, parsedCert.RawSubjectPublicKeyInfo, parsedPrecert.RawSubjectPublicKeyInfo)
+
+	// Profiles omitting SCTs must not use CFSSL signing profiles which get
+	// SCTs themselves.
+	testCtx = setup(t)
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"stapled": {
+			Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			OmitSCTs: true,
+		},
+	}
+	testCtx.caConfig.CFSSL.Signing.Profiles[rsaProfileName].CTLogServers = []string{"http://ct.example.com"}
+	_, err = NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertError(t, err, "CA created with an issuance profile omitting SCTs that CFSSL embeds")
+	test.AssertEquals(t, err.Error(), `issuance profile "stapled": OmitSCTs is set but CFSSL signing profile "rsaEE" submits to CT logs`)
 }
 
 // dupeSA returns a non-error to GetCertificate in order to simulate a request
@@ -1248,6 +2015,106 @@ func TestIssuePrecertificateLinting(t *testing.T) {
 	regex := `ERR: \[AUDIT\] Signing failed: serial=\[.*\] err=\[pre-issuance linting found 2 error results\] lintErrors=\{"foobar":\{"result":"error","details":"foobar is error"\},"foobar2":\{"result":"warn","details":"foobar2 is warning"\}\}`
 	matches := testCtx.logger.GetAllMatching(regex)
 	test.AssertEquals(t, len(matches), 1)
+
+	// Each failing lint should have been counted by name.
+	test.AssertEquals(t, test.CountCounterVec("lint", "foobar", ca.lintErrorCount), 1)
+	test.AssertEquals(t, test.CountCounterVec("lint", "foobar2", ca.lintErrorCount), 1)
+}
+
+// profileTrapSigner records the CFSSL signing profile of the last sign request
+// and fails it.
+type profileTrapSigner struct {
+	profile string
+}
+
+func (s *profileTrapSigner) Sign(req signer.SignRequest) ([]byte, error) {
+	s.profile = req.Profile
+	return nil, errors.New("profileTrapSigner doesn't sign")
+}
+
+func (s *profileTrapSigner) SignFromPrecert(*x509.Certificate, []ct.SignedCertificateTimestamp) ([]byte, error) {
+	return nil, errors.New("SignFromPrecert not implemented for profileTrapSigner")
+}
+
+func TestLintProfiles(t *testing.T) {
+	testCtx := setup(t)
+	policy := testCtx.caConfig.CFSSL.Signing
+	lintProfiles := map[string]ca_config.LintProfileConfig{
+		"strict": {
+			IgnoredLints:       []string{"n_subject_common_name_included"},
+			IgnoredLintSources: []string{"Mozilla"},
+		},
+	}
+	profiles, err := makeIssuanceProfiles(
+		map[string]ca_config.IssuanceProfileConfig{
+			"linted": {
+				Validity:    cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+				LintProfile: "strict",
+			},
+			"alsolinted": {
+				Validity:    cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+				LintProfile: "strict",
+			},
+			"unlinted": {
+				Validity: cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			},
+		},
+		lintProfiles,
+		policy,
+		8760*time.Hour,
+		time.Hour,
+		2,
+		17,
+		rsaProfileName,
+		ecdsaProfileName)
+	test.AssertNotError(t, err, "Failed to make issuance profiles")
+
+	// Profiles selecting the same lint profile share the derived signing
+	// profiles, and profiles without one use the configured ones unchanged.
+	test.AssertEquals(t, profiles["linted"].rsaProfile, "rsaEE+lint:strict")
+	test.AssertEquals(t, profiles["linted"].ecdsaProfile, "ecdsaEE+lint:strict")
+	test.AssertEquals(t, profiles["alsolinted"].rsaProfile, "rsaEE+lint:strict")
+	test.AssertEquals(t, profiles["unlinted"].rsaProfile, rsaProfileName)
+	test.AssertEquals(t, len(policy.Profiles), 4)
+
+	linted := policy.Profiles["rsaEE+lint:strict"]
+	test.AssertEquals(t, linted.LintErrLevel, lint.Warn)
+	test.AssertEquals(t, linted.ExpiryString, policy.Profiles[rsaProfileName].ExpiryString)
+	test.Assert(t, linted.LintRegistry.ByName("n_subject_common_name_included") == nil, "Ignored lint is in the registry")
+	test.AssertEquals(t, len(linted.LintRegistry.BySource(lint.MozillaRootStorePolicy)), 0)
+	test.Assert(t, linted.LintRegistry.ByName("e_sub_cert_aia_missing") != nil, "Lint missing from the registry")
+	test.AssertEquals(t, policy.Profiles[rsaProfileName].LintErrLevel, lint.Reserved)
+
+	// Issuing with the profile should sign with the derived signing profile.
+	testCtx = setup(t)
+	testCtx.caConfig.LintProfiles = lintProfiles
+	testCtx.caConfig.IssuanceProfiles = map[string]ca_config.IssuanceProfileConfig{
+		"linted": {
+			Validity:    cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			LintProfile: "strict",
+		},
+	}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		&mockSA{},
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+	trap := &profileTrapSigner{}
+	ca.defaultIssuer.eeSigner = trap
+	profileName := "linted"
+	_, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         &arbitraryRegID,
+		CertificateProfileName: &profileName,
+	})
+	test.AssertError(t, err, "profileTrapSigner signed a precertificate")
+	test.AssertEquals(t, trap.profile, "rsaEE+lint:strict")
 }
 
 func TestGenerateOCSPWithIssuerID(t *testing.T) {
@@ -1296,3 +2163,49 @@ func TestGenerateOCSPWithIssuerID(t *testing.T) {
 	})
 	test.AssertNotError(t, err, "GenerateOCSP failed")
 }
+
+func TestIssuerChainFingerprints(t *testing.T) {
+	testCtx := setup(t)
+	root, err := core.LoadCert("../test/test-root.pem")
+	test.AssertNotError(t, err, "Failed to load root")
+	testCtx.issuers[0].Chain = []*x509.Certificate{root}
+	sa := &mockSA{}
+	ca, err := NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		sa,
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	_, err = ca.IssuePrecertificate(ctx, &caPB.IssueCertificateRequest{Csr: CNandSANCSR, RegistrationID: &arbitraryRegID})
+	test.AssertNotError(t, err, "Failed to issue precertificate")
+
+	issuerFingerprint := certFingerprint(caCert)
+	chain := fmt.Sprintf("%s, %s", issuerFingerprint, certFingerprint(root))
+	for _, prefix := range []string{"Signing: ", "Signing success: "} {
+		matches := testCtx.logger.GetAllMatching(fmt.Sprintf(`%s.* issuer=\[%s\] chain=\[%s\]`, prefix, issuerFingerprint, chain))
+		test.AssertEquals(t, len(matches), 1)
+	}
+	test.AssertEquals(t, sa.issuerFingerprint, issuerFingerprint)
+
+	// A chain certificate which didn't sign the one before it is rejected.
+	otherRoot, err := core.LoadCert("../test/test-root2.pem")
+	test.AssertNotError(t, err, "Failed to load root")
+	testCtx.issuers[0].Chain = []*x509.Certificate{otherRoot}
+	_, err = NewCertificateAuthorityImpl(
+		testCtx.caConfig,
+		sa,
+		testCtx.pa,
+		testCtx.fc,
+		testCtx.stats,
+		testCtx.issuers,
+		testCtx.keyPolicy,
+		testCtx.logger,
+		nil)
+	test.AssertError(t, err, "CA accepted an issuer chain with an unrelated root")
+}
@@ -33,6 +33,15 @@ type CAConfig struct {
 	// How far back certificates should be backdated, should match backdate
 	// field in cfssl config.
 	Backdate cmd.ConfigDuration
+	// IssuanceProfiles optionally defines named profiles that issuance requests
+	// can select, e.g. to issue short-lived certificates alongside standard
+	// ones. Requests that don't name a profile use Expiry, RSAProfile and
+	// ECDSAProfile.
+	IssuanceProfiles map[string]IssuanceProfileConfig
+	// LintProfiles optionally defines named sets of zlint lints that issuance
+	// profiles can select to have the to-be-signed precertificate checked
+	// against before it is signed.
+	LintProfiles map[string]LintProfileConfig
 	// The maximum number of subjectAltNames in a single certificate
 	MaxNames int
 	CFSSL    cfsslConfig.Config
@@ -60,6 +69,85 @@ type CAConfig struct {
 	Features map[string]bool
 }
 
+// IssuanceProfileConfig describes a named issuance profile.
+type IssuanceProfileConfig struct {
+	// Validity is the maximum validity period of certificates issued with this
+	// profile, measured from the backdated NotBefore. It must be longer than
+	// the profile's backdate and may not be longer than Expiry.
+	Validity cmd.ConfigDuration
+	// Backdate optionally overrides the CA-wide Backdate for certificates
+	// issued with this profile. Since Validity is measured from the backdated
+	// NotBefore, the backdate counts towards the maximum lifetime Expiry.
+	Backdate cmd.ConfigDuration
+	// RSAProfile and ECDSAProfile optionally name CFSSL signing profiles (and
+	// so the key usages) to use in place of the CA-wide RSAProfile and
+	// ECDSAProfile.
+	RSAProfile   string
+	ECDSAProfile string
+	// LintProfile optionally names one of the LintProfiles. If set, a
+	// precertificate issued with this profile is linted before signing and
+	// isn't signed if any lint in the set returns an error or fatal result.
+	// This replaces any pre-issuance linting set up in the CFSSL signing
+	// profile.
+	LintProfile string
+	// RSAPSS optionally makes certificates issued with this profile be signed
+	// with RSA-PSS instead of PKCS #1 v1.5. It requires RSA issuer keys that
+	// support PSS signatures. The final certificate is always signed with the
+	// same algorithm as its precertificate.
+	RSAPSS *RSAPSSConfig
+	// MaxNames optionally limits certificates issued with this profile to
+	// fewer subjectAltNames than the CA-wide MaxNames. If zero, the CA-wide
+	// MaxNames applies.
+	MaxNames int
+	// SerialRandomBits optionally sets the number of random bits following
+	// the SerialPrefix byte in the serials of certificates issued with this
+	// profile. It must be a multiple of 8 and at least 64, and may be at most
+	// 152, or 144 if SerialPrefix is 128 or more, for serials to fit in the
+	// 20 octets RFC 5280 allows. If zero, 136 random bits are used.
+	SerialRandomBits int
+	// KeyTypes optionally restricts the public keys of certificates issued
+	// with this profile, on top of the CA-wide key policy. If unset, any key
+	// the key policy allows is accepted.
+	KeyTypes *KeyTypesConfig
+	// OmitSCTs makes final certificates issued with this profile be signed
+	// without an embedded SCT list, for deployments which deliver SCTs by TLS
+	// extension instead. The RA still gets SCTs for the precertificate before
+	// the final certificate is issued, but doesn't pass them to the CA. The
+	// profile's CFSSL signing profiles must not submit to CT logs.
+	OmitSCTs bool
+}
+
+// KeyTypesConfig lists the public keys an issuance profile accepts. Keys of a
+// type with no entries aren't accepted at all, so e.g. a profile listing only
+// ECDSACurves is ECDSA-only.
+type KeyTypesConfig struct {
+	// RSAModulusSizes lists the accepted RSA modulus sizes, in bits. Each must
+	// be a multiple of 8 and at least 2048.
+	RSAModulusSizes []int
+	// ECDSACurves lists the accepted ECDSA curves: "P-256" and "P-384".
+	ECDSACurves []string
+}
+
+// RSAPSSConfig describes the RSA-PSS parameters used to sign certificates.
+type RSAPSSConfig struct {
+	// Hash is the hash function used for the signature and MGF1: "SHA256",
+	// "SHA384" or "SHA512".
+	Hash string
+	// SaltLength is the salt length in bytes. Only salts as long as the hash
+	// output are supported; zero means that length.
+	SaltLength int
+}
+
+// LintProfileConfig describes a named set of zlint lints. Every lint known to
+// zlint is run except those excluded here.
+type LintProfileConfig struct {
+	// IgnoredLints lists the names of lints to skip.
+	IgnoredLints []string
+	// IgnoredLintSources lists lint sources (e.g. "Mozilla") whose lints are
+	// all skipped.
+	IgnoredLintSources []string
+}
+
 // IssuerConfig contains info about an issuer: private key and issuer cert.
 // It should contain either a File path to a PEM-format private key,
 // or a PKCS11Config defining how to load a module for an HSM.
@@ -72,4 +160,8 @@ type IssuerConfig struct {
 	// Number of sessions to open with the HSM. For maximum performance,
 	// this should be equal to the number of cores in the HSM. Defaults to 1.
 	NumSessions int
+	// ChainFiles are the certificates, in order, which follow this issuer's
+	// certificate in the chain served with the certificates it issues, e.g.
+	// its root. Their fingerprints are included in the issuance audit log.
+	ChainFiles []string
 }
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/core"
@@ -33,15 +34,42 @@ admin-revoker serial-revoke --config <path> <serial> <reason-code>
 admin-revoker batched-serial-revoke --config <path> <serial-file-path> <reason-code> <parallelism>
 admin-revoker reg-revoke --config <path> <registration-id> <reason-code>
 admin-revoker list-reasons --config <path>
+admin-revoker block-domain --config <path> <domain> [comment]
+admin-revoker unblock-domain --config <path> <domain>
+admin-revoker add-rate-limit-override --config <path> <limit-name> <registration-id|key> <threshold> <lifetime> [comment]
+admin-revoker list-rate-limit-overrides --config <path> [limit-name]
+admin-revoker remove-rate-limit-override --config <path> <limit-name> <registration-id|key>
+admin-revoker count-active-certs --config <path> <registration-id> [registration-id...]
+admin-revoker search-names --config <path> [--subdomains] <name>
 
 command descriptions:
   serial-revoke       Revoke a single certificate by the hex serial number
   batched-serial-revoke Revokes all certificates contained in a file of hex serial numbers
   reg-revoke          Revoke all certificates associated with a registration ID
   list-reasons        List all revocation reason codes
+  block-domain        Reject new orders for a domain and its subdomains, or for
+                      the subdomains of a wildcard such as "*.example.com"
+  unblock-domain      Remove a domain or wildcard added with block-domain
+  add-rate-limit-override
+                      Override the threshold of a rate limit, e.g.
+                      "certificatesPerName", for a registration ID or a key
+                      such as a registered domain, until the lifetime (e.g.
+                      "720h") has passed. An existing override is replaced.
+  list-rate-limit-overrides
+                      List the rate limit overrides which haven't expired
+  remove-rate-limit-override
+                      Remove an override added with add-rate-limit-override
+  count-active-certs  Count the certificates issued to each registration ID
+                      which haven't expired or been revoked
+  search-names        List the serial, registration ID and notBefore of every
+                      certificate with the name, which may be a wildcard such
+                      as "*.example.com". With --subdomains the subdomains of
+                      the name, and wildcards of them, also match
 
 args:
   config    File path to the configuration file for this service
+  subdomains
+            Also match the subdomains of the name given to search-names
 `
 
 type config struct {
@@ -167,6 +195,16 @@ func revokeBatch(rac core.RegistrationAuthority, logger blog.Logger, dbMap *db.W
 	return nil
 }
 
+// rateLimitOverrideTarget interprets the registration-id|key argument of the
+// rate limit override commands: an integer is a registration ID, anything
+// else is a key.
+func rateLimitOverrideTarget(arg string) (*int64, *string) {
+	if regID, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		return &regID, nil
+	}
+	return nil, &arg
+}
+
 // This abstraction is needed so that we can use sort.Sort below
 type revocationCodes []revocation.Reason
 
@@ -186,6 +224,8 @@ func main() {
 	command := os.Args[1]
 	flagSet := flag.NewFlagSet(command, flag.ContinueOnError)
 	configFile := flagSet.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flagSet.Bool("validate-config", false, cmd.ValidateConfigUsage)
+	subdomains := flagSet.Bool("subdomains", false, "Also match the subdomains of the name given to search-names")
 	err := flagSet.Parse(os.Args[2:])
 	cmd.FailOnError(err, "Error parsing flagset")
 
@@ -198,6 +238,9 @@ func main() {
 	cmd.FailOnError(err, "Reading JSON config file into config structure")
 	err = features.Set(c.Revoker.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	ctx := context.Background()
 	args := flagSet.Args()
@@ -251,6 +294,134 @@ func main() {
 		})
 		cmd.FailOnError(err, "Couldn't revoke certificate by registration")
 
+	case command == "block-domain" && (len(args) == 1 || len(args) == 2):
+		// 1: domain, 2: optional comment
+		domain := args[0]
+		added := cmd.Clock().Now().UnixNano()
+		req := &sapb.AddBlockedDomainRequest{Domain: &domain, Added: &added}
+		if len(args) == 2 {
+			req.Comment = &args[1]
+		}
+
+		_, logger, _, sac := setupContext(c)
+		_, err = sac.AddBlockedDomain(ctx, req)
+		cmd.FailOnError(err, "Couldn't block domain")
+		logger.AuditInfof("Blocked new orders for domain %q", domain)
+
+	case command == "unblock-domain" && len(args) == 1:
+		// 1: domain
+		domain := args[0]
+
+		_, logger, _, sac := setupContext(c)
+		_, err = sac.RemoveBlockedDomain(ctx, &sapb.RemoveBlockedDomainRequest{Domain: &domain})
+		cmd.FailOnError(err, "Couldn't unblock domain")
+		logger.AuditInfof("Unblocked new orders for domain %q", domain)
+
+	case command == "add-rate-limit-override" && (len(args) == 4 || len(args) == 5):
+		// 1: limit name, 2: registration ID or key, 3: threshold, 4: lifetime,
+		// 5: optional comment
+		limitName := args[0]
+		regID, key := rateLimitOverrideTarget(args[1])
+		threshold, err := strconv.ParseInt(args[2], 10, 64)
+		cmd.FailOnError(err, "Threshold argument must be an integer")
+		lifetime, err := time.ParseDuration(args[3])
+		cmd.FailOnError(err, "Lifetime argument must be a duration")
+		now := cmd.Clock().Now()
+		added := now.UnixNano()
+		expires := now.Add(lifetime).UnixNano()
+		req := &sapb.RateLimitOverride{
+			LimitName:      &limitName,
+			RegistrationID: regID,
+			Key:            key,
+			Threshold:      &threshold,
+			Expires:        &expires,
+			Added:          &added,
+		}
+		if len(args) == 5 {
+			req.Comment = &args[4]
+		}
+
+		_, logger, _, sac := setupContext(c)
+		_, err = sac.AddRateLimitOverride(ctx, req)
+		cmd.FailOnError(err, "Couldn't add rate limit override")
+		logger.AuditInfof("Overrode %s rate limit for %q with threshold %d until %s",
+			limitName, args[1], threshold, time.Unix(0, expires).UTC().Format(time.RFC3339))
+
+	case command == "list-rate-limit-overrides" && len(args) <= 1:
+		// 1: optional limit name
+		now := cmd.Clock().Now().UnixNano()
+		req := &sapb.GetRateLimitOverridesRequest{Now: &now}
+		if len(args) == 1 {
+			req.LimitName = &args[0]
+		}
+
+		_, _, _, sac := setupContext(c)
+		resp, err := sac.GetRateLimitOverrides(ctx, req)
+		cmd.FailOnError(err, "Couldn't list rate limit overrides")
+		for _, override := range resp.Overrides {
+			target := fmt.Sprintf("key %q", override.GetKey())
+			if override.RegistrationID != nil {
+				target = fmt.Sprintf("registration ID %d", *override.RegistrationID)
+			}
+			fmt.Printf("%s for %s: threshold %d, expires %s, comment %q\n",
+				*override.LimitName, target, *override.Threshold,
+				time.Unix(0, *override.Expires).UTC().Format(time.RFC3339), override.GetComment())
+		}
+
+	case command == "remove-rate-limit-override" && len(args) == 2:
+		// 1: limit name, 2: registration ID or key
+		limitName := args[0]
+		regID, key := rateLimitOverrideTarget(args[1])
+
+		_, logger, _, sac := setupContext(c)
+		_, err = sac.RemoveRateLimitOverride(ctx, &sapb.RemoveRateLimitOverrideRequest{
+			LimitName:      &limitName,
+			RegistrationID: regID,
+			Key:            key,
+		})
+		cmd.FailOnError(err, "Couldn't remove rate limit override")
+		logger.AuditInfof("Removed %s rate limit override for %q", limitName, args[1])
+
+	case command == "count-active-certs" && len(args) >= 1:
+		// 1...: registration IDs
+		var regIDs []int64
+		for _, arg := range args {
+			regID, err := strconv.ParseInt(arg, 10, 64)
+			cmd.FailOnError(err, "Registration ID arguments must be integers")
+			regIDs = append(regIDs, regID)
+		}
+
+		_, _, _, sac := setupContext(c)
+		resp, err := sac.CountActiveCertificatesByRegistrations(ctx, &sapb.CountActiveCertificatesByRegistrationsRequest{
+			RegistrationIDs: regIDs,
+		})
+		cmd.FailOnError(err, "Couldn't count active certificates")
+		for _, element := range resp.CountByRegistrations {
+			fmt.Printf("registration ID %d: %d active certificates\n", *element.RegistrationID, *element.Count)
+		}
+
+	case command == "search-names" && len(args) == 1:
+		// 1: name
+		req := &sapb.SearchCertificatesByNameRequest{
+			Name:              &args[0],
+			IncludeSubdomains: subdomains,
+		}
+
+		_, _, _, sac := setupContext(c)
+		for {
+			resp, err := sac.SearchCertificatesByName(ctx, req)
+			cmd.FailOnError(err, "Couldn't search certificate names")
+			for _, match := range resp.Matches {
+				fmt.Printf("%s: serial %s, registration ID %d, notBefore %s\n",
+					*match.Name, *match.Serial, *match.RegistrationID,
+					time.Unix(0, *match.NotBefore).UTC().Format(time.RFC3339))
+			}
+			if resp.NextCursor == nil {
+				break
+			}
+			req.Cursor = resp.NextCursor
+		}
+
 	case command == "list-reasons":
 		var codes revocationCodes
 		for k := range revocation.ReasonToString {
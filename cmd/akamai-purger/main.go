@@ -30,6 +30,9 @@ type config struct {
 		V3Network         string
 		PurgeRetries      int
 		PurgeRetryBackoff cmd.ConfigDuration
+		// PurgeRetryMaxBackoff caps the exponential backoff between retries of
+		// a failed purge request. Defaults to one minute.
+		PurgeRetryMaxBackoff cmd.ConfigDuration
 	}
 	Syslog cmd.SyslogConfig
 }
@@ -52,7 +55,11 @@ func (ap *akamaiPurger) purge() {
 	}
 
 	if err := ap.client.Purge(urls); err != nil {
-		// Add the URLs back to the queue?
+		// Only the URLs from batches that failed need to be added back to the
+		// queue.
+		if batchErr, ok := err.(*akamai.BatchError); ok {
+			urls = batchErr.FailedURLs
+		}
 		ap.mu.Lock()
 		ap.toPurge = append(urls, ap.toPurge...)
 		ap.mu.Unlock()
@@ -78,6 +85,7 @@ func main() {
 	grpcAddr := flag.String("addr", "", "gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -87,6 +95,9 @@ func main() {
 	var c config
 	err := cmd.ReadConfigFile(*configFile, &c)
 	cmd.FailOnError(err, "Reading JSON config file into config structure")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	if *grpcAddr != "" {
 		c.AkamaiPurger.GRPC.Address = *grpcAddr
@@ -116,6 +127,7 @@ func main() {
 		c.AkamaiPurger.V3Network,
 		c.AkamaiPurger.PurgeRetries,
 		c.AkamaiPurger.PurgeRetryBackoff.Duration,
+		c.AkamaiPurger.PurgeRetryMaxBackoff.Duration,
 		logger,
 		scope,
 	)
@@ -3,7 +3,10 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"html/template"
@@ -92,10 +95,36 @@ type unrevokedCertificate struct {
 	IsExpired      bool
 }
 
+// selectUncheckedKeys returns every row in the blockedKeys table that hasn't
+// been checked for extant unrevoked certificates yet.
+func (bkr *badKeyRevoker) selectUncheckedKeys() ([]uncheckedBlockedKey, error) {
+	var rows []uncheckedBlockedKey
+	_, err := bkr.dbMap.Select(
+		&rows,
+		`SELECT keyHash, revokedBy
+		FROM blockedKeys
+		WHERE extantCertificatesChecked = false`,
+	)
+	return rows, err
+}
+
 // findUnrevoked looks for all unexpired, currently valid certificates which have a specific SPKI hash,
 // by looking first at the keyHashToSerial table and then the certificateStatus and certificates tables.
 // If the number of certificates it finds is larger than bkr.maxRevocations it'll error out.
 func (bkr *badKeyRevoker) findUnrevoked(unchecked uncheckedBlockedKey) ([]unrevokedCertificate, error) {
+	unrevokedCerts, err := bkr.findAllUnrevoked(unchecked)
+	if err != nil {
+		return nil, err
+	}
+	if len(unrevokedCerts) > bkr.maxRevocations {
+		return nil, fmt.Errorf("too many certificates to revoke associated with %x: got %d, max %d", unchecked.KeyHash, len(unrevokedCerts), bkr.maxRevocations)
+	}
+	return unrevokedCerts, nil
+}
+
+// findAllUnrevoked is findUnrevoked without the limit on the number of
+// certificates found.
+func (bkr *badKeyRevoker) findAllUnrevoked(unchecked uncheckedBlockedKey) ([]unrevokedCertificate, error) {
 	var unrevokedCerts []unrevokedCertificate
 	initialID := 0
 	for {
@@ -137,9 +166,6 @@ func (bkr *badKeyRevoker) findUnrevoked(unchecked uncheckedBlockedKey) ([]unrevo
 			unrevokedCerts = append(unrevokedCerts, unrevokedCert)
 		}
 	}
-	if len(unrevokedCerts) > bkr.maxRevocations {
-		return nil, fmt.Errorf("too many certificates to revoke associated with %x: got %d, max %d", unchecked.KeyHash, len(unrevokedCerts), bkr.maxRevocations)
-	}
 	return unrevokedCerts, nil
 }
 
@@ -253,36 +279,9 @@ func (bkr *badKeyRevoker) revokeCerts(revokerEmails []string, emailToCerts map[s
 	return nil
 }
 
-// invoke processes a single key in the blockedKeys table and returns whether
-// there were any rows to process or not.
-func (bkr *badKeyRevoker) invoke() (bool, error) {
-	// select a row to process
-	unchecked, err := bkr.selectUncheckedKey()
-	if err != nil {
-		if db.IsNoRows(err) {
-			return true, nil
-		}
-		return false, err
-	}
-	bkr.logger.AuditInfo(fmt.Sprintf("found unchecked block key to work on: %s", unchecked))
-
-	// select all unrevoked, unexpired serials associated with the blocked key hash
-	unrevokedCerts, err := bkr.findUnrevoked(unchecked)
-	if err != nil {
-		bkr.logger.AuditInfo(fmt.Sprintf("finding unrevoked certificates related to %s: %s",
-			unchecked, err))
-		return false, err
-	}
-	if len(unrevokedCerts) == 0 {
-		bkr.logger.AuditInfo(fmt.Sprintf("found no certificates that need revoking related to %s, marking row as checked", unchecked))
-		// mark row as checked
-		err = bkr.markRowChecked(unchecked)
-		if err != nil {
-			return false, err
-		}
-		return false, nil
-	}
-
+// revokeAndNotify revokes the provided certificates, all of which are
+// associated with the key hash of unchecked, and emails their owners.
+func (bkr *badKeyRevoker) revokeAndNotify(unchecked uncheckedBlockedKey, unrevokedCerts []unrevokedCertificate) error {
 	// build a map of registration ID -> certificates, and collect a
 	// list of unique registration IDs
 	ownedBy := map[int64][]unrevokedCertificate{}
@@ -304,7 +303,7 @@ func (bkr *badKeyRevoker) invoke() (bool, error) {
 	// get contact addresses for the list of IDs
 	idToEmails, err := bkr.resolveContacts(ids)
 	if err != nil {
-		return false, err
+		return err
 	}
 
 	// build a map of email -> certificates, this de-duplicates accounts with
@@ -320,8 +319,41 @@ func (bkr *badKeyRevoker) invoke() (bool, error) {
 	bkr.logger.AuditInfo(fmt.Sprintf("revoking certs. revoked emails=%v, emailsToCerts=%v",
 		revokerEmails, emailsToCerts))
 
+	return bkr.revokeCerts(revokerEmails, emailsToCerts)
+}
+
+// invoke processes a single key in the blockedKeys table and returns whether
+// there were any rows to process or not.
+func (bkr *badKeyRevoker) invoke() (bool, error) {
+	// select a row to process
+	unchecked, err := bkr.selectUncheckedKey()
+	if err != nil {
+		if db.IsNoRows(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	bkr.logger.AuditInfo(fmt.Sprintf("found unchecked block key to work on: %s", unchecked))
+
+	// select all unrevoked, unexpired serials associated with the blocked key hash
+	unrevokedCerts, err := bkr.findUnrevoked(unchecked)
+	if err != nil {
+		bkr.logger.AuditInfo(fmt.Sprintf("finding unrevoked certificates related to %s: %s",
+			unchecked, err))
+		return false, err
+	}
+	if len(unrevokedCerts) == 0 {
+		bkr.logger.AuditInfo(fmt.Sprintf("found no certificates that need revoking related to %s, marking row as checked", unchecked))
+		// mark row as checked
+		err = bkr.markRowChecked(unchecked)
+		if err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
 	// revoke each certificate and send emails to their owners
-	err = bkr.revokeCerts(idToEmails[unchecked.RevokedBy], emailsToCerts)
+	err = bkr.revokeAndNotify(unchecked, unrevokedCerts)
 	if err != nil {
 		return false, err
 	}
@@ -334,6 +366,135 @@ func (bkr *badKeyRevoker) invoke() (bool, error) {
 	return false, nil
 }
 
+// readSPKIHashes reads a file containing a newline delimited list of hex
+// encoded SPKI SHA-256 hashes. Blank lines are skipped and a hash which appears
+// more than once is only returned the first time it appears.
+func readSPKIHashes(path string) ([][]byte, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var hashes [][]byte
+	for i, line := range strings.Split(string(contents), "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" {
+			continue
+		}
+		hash, err := hex.DecodeString(line)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("line %d of %q is not a hex encoded SHA-256 hash: %q", i+1, path, line)
+		}
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// revokeBySPKIHashes revokes all unexpired, unrevoked certificates associated
+// with each of the provided SPKI hashes, with reason keyCompromise, and emails
+// their owners. It returns the number of certificates revoked for each hash,
+// keyed by the hex encoded hash. A failure to process one hash is logged and
+// doesn't prevent the remaining hashes from being processed, but causes an
+// error to be returned once all of them have been attempted.
+func (bkr *badKeyRevoker) revokeBySPKIHashes(hashes [][]byte) (map[string]int, error) {
+	revoked := map[string]int{}
+	failed := 0
+	for _, hash := range hashes {
+		unchecked := uncheckedBlockedKey{KeyHash: hash}
+		hexHash := hex.EncodeToString(hash)
+		unrevokedCerts, err := bkr.findUnrevoked(unchecked)
+		if err == nil && len(unrevokedCerts) > 0 {
+			err = bkr.revokeAndNotify(unchecked, unrevokedCerts)
+		}
+		if err != nil {
+			failed++
+			bkr.logger.AuditErrf("failed to revoke certificates for SPKI hash %s: %s", hexHash, err)
+			continue
+		}
+		revoked[hexHash] = len(unrevokedCerts)
+		bkr.logger.AuditInfof("revoked %d certificates for SPKI hash %s", len(unrevokedCerts), hexHash)
+	}
+	if failed > 0 {
+		return revoked, fmt.Errorf("failed to revoke certificates for %d of %d SPKI hashes", failed, len(hashes))
+	}
+	return revoked, nil
+}
+
+// impactReport describes the certificates that would be revoked for a set of
+// key hashes, for review before revoking them.
+type impactReport struct {
+	// Certificates and Accounts are the total number of certificates that
+	// would be revoked and of distinct accounts owning them, across all keys.
+	Certificates int         `json:"certificates"`
+	Accounts     int         `json:"accounts"`
+	Keys         []keyImpact `json:"keys"`
+}
+
+// keyImpact describes the certificates that would be revoked for a single key
+// hash, grouped by the account owning them.
+type keyImpact struct {
+	KeyHash      string          `json:"keyHash"`
+	RevokedBy    int64           `json:"revokedBy,omitempty"`
+	Certificates int             `json:"certificates"`
+	Accounts     []accountImpact `json:"accounts"`
+	// ExceedsMaximum is true if there are more certificates than
+	// MaximumRevocations, in which case revoking them would fail.
+	ExceedsMaximum bool `json:"exceedsMaximum,omitempty"`
+	// Error is set if the certificates for the key couldn't be looked up.
+	Error string `json:"error,omitempty"`
+}
+
+// accountImpact lists the serials of the certificates owned by an account
+// that would be revoked.
+type accountImpact struct {
+	RegistrationID int64    `json:"registrationID"`
+	Serials        []string `json:"serials"`
+}
+
+// impact performs the same lookups as revoking the certificates associated
+// with each of the keys would, without revoking anything or sending any
+// email, and reports what would be revoked. A failure to look up the
+// certificates of one key is recorded in its keyImpact and doesn't prevent
+// the remaining keys from being reported.
+func (bkr *badKeyRevoker) impact(keys []uncheckedBlockedKey) impactReport {
+	report := impactReport{Keys: []keyImpact{}}
+	accounts := map[int64]bool{}
+	for _, key := range keys {
+		ki := keyImpact{
+			KeyHash:   hex.EncodeToString(key.KeyHash),
+			RevokedBy: key.RevokedBy,
+			Accounts:  []accountImpact{},
+		}
+		unrevokedCerts, err := bkr.findAllUnrevoked(key)
+		if err != nil {
+			ki.Error = err.Error()
+			report.Keys = append(report.Keys, ki)
+			continue
+		}
+		byAccount := map[int64]int{}
+		for _, cert := range unrevokedCerts {
+			i, ok := byAccount[cert.RegistrationID]
+			if !ok {
+				i = len(ki.Accounts)
+				byAccount[cert.RegistrationID] = i
+				ki.Accounts = append(ki.Accounts, accountImpact{RegistrationID: cert.RegistrationID})
+			}
+			ki.Accounts[i].Serials = append(ki.Accounts[i].Serials, cert.Serial)
+			accounts[cert.RegistrationID] = true
+		}
+		ki.Certificates = len(unrevokedCerts)
+		ki.ExceedsMaximum = len(unrevokedCerts) > bkr.maxRevocations
+		report.Certificates += ki.Certificates
+		report.Keys = append(report.Keys, ki)
+	}
+	report.Accounts = len(accounts)
+	return report
+}
+
 func main() {
 	var config struct {
 		BadKeyRevoker struct {
@@ -371,6 +532,9 @@ func main() {
 		Syslog cmd.SyslogConfig
 	}
 	configPath := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
+	spkiHashFile := flag.String("spki-hash-file", "", "Path to a newline delimited list of hex encoded SPKI SHA-256 hashes. If set, all certificates matching these hashes are revoked and the process exits instead of processing the blockedKeys table")
+	dryRun := flag.Bool("dry-run", false, "Look up the certificates that would be revoked, for the SPKI hashes in -spki-hash-file or otherwise the unchecked blockedKeys rows, and print a JSON report of them grouped by account to stdout, without revoking anything or sending any email")
 	flag.Parse()
 
 	if *configPath == "" {
@@ -379,6 +543,9 @@ func main() {
 	}
 	err := cmd.ReadConfigFile(*configPath, &config)
 	cmd.FailOnError(err, "Failed reading config file")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&config)
+	}
 
 	scope, logger := cmd.StatsAndLogging(config.Syslog, config.BadKeyRevoker.DebugAddr)
 	clk := cmd.Clock()
@@ -394,6 +561,33 @@ func main() {
 	sa.SetSQLDebug(dbMap, logger)
 	sa.InitDBMetrics(dbMap, scope)
 
+	if *dryRun {
+		bkr := &badKeyRevoker{
+			dbMap:           dbMap,
+			maxRevocations:  config.BadKeyRevoker.MaximumRevocations,
+			serialBatchSize: config.BadKeyRevoker.FindCertificatesBatchSize,
+			logger:          logger,
+		}
+		var keys []uncheckedBlockedKey
+		if *spkiHashFile != "" {
+			hashes, err := readSPKIHashes(*spkiHashFile)
+			cmd.FailOnError(err, "Failed to read SPKI hash file")
+			for _, hash := range hashes {
+				keys = append(keys, uncheckedBlockedKey{KeyHash: hash})
+			}
+		} else {
+			keys, err = bkr.selectUncheckedKeys()
+			cmd.FailOnError(err, "Failed to select unchecked blockedKeys rows")
+		}
+		report := bkr.impact(keys)
+		logger.Infof("Dry run: %d certificates of %d accounts would be revoked for %d keys",
+			report.Certificates, report.Accounts, len(keys))
+		out, err := json.MarshalIndent(report, "", "  ")
+		cmd.FailOnError(err, "Failed to marshal impact report")
+		fmt.Println(string(out))
+		return
+	}
+
 	tlsConfig, err := config.BadKeyRevoker.TLS.Load()
 	cmd.FailOnError(err, "TLS config")
 
@@ -448,6 +642,16 @@ func main() {
 		emailTemplate:   emailTemplate,
 		logger:          logger,
 	}
+
+	if *spkiHashFile != "" {
+		hashes, err := readSPKIHashes(*spkiHashFile)
+		cmd.FailOnError(err, "Failed to read SPKI hash file")
+		logger.Infof("Revoking certificates for %d unique SPKI hashes from %q", len(hashes), *spkiHashFile)
+		_, err = bkr.revokeBySPKIHashes(hashes)
+		cmd.FailOnError(err, "Failed to revoke certificates by SPKI hash")
+		return
+	}
+
 	for {
 		noWork, err := bkr.invoke()
 		if err != nil {
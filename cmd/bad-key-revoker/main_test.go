@@ -3,8 +3,11 @@ package main
 import (
 	"context"
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"html/template"
+	"io/ioutil"
+	"os"
 	"strings"
 	"sync"
 	"testing"
@@ -418,3 +421,142 @@ func TestInvokeRevokerHasNoExtantCerts(t *testing.T) {
 	test.AssertEquals(t, len(mm.Messages), 1)
 	test.AssertEquals(t, mm.Messages[0].To, "b@example.com")
 }
+
+func TestReadSPKIHashes(t *testing.T) {
+	hashA, hashB := randHash(t), randHash(t)
+	f, err := ioutil.TempFile("", "spki-hashes")
+	test.AssertNotError(t, err, "failed to create temp file")
+	defer os.Remove(f.Name())
+	contents := fmt.Sprintf("%x\n\n%X\n%x\n", hashA, hashB, hashA)
+	_, err = f.WriteString(contents)
+	test.AssertNotError(t, err, "failed to write temp file")
+	f.Close()
+
+	hashes, err := readSPKIHashes(f.Name())
+	test.AssertNotError(t, err, "readSPKIHashes failed")
+	test.AssertEquals(t, len(hashes), 2)
+	test.AssertByteEquals(t, hashes[0], hashA)
+	test.AssertByteEquals(t, hashes[1], hashB)
+
+	err = ioutil.WriteFile(f.Name(), []byte("aabbcc\n"), 0644)
+	test.AssertNotError(t, err, "failed to write temp file")
+	_, err = readSPKIHashes(f.Name())
+	test.AssertError(t, err, "readSPKIHashes didn't fail with a short hash")
+
+	err = ioutil.WriteFile(f.Name(), []byte("not hex\n"), 0644)
+	test.AssertNotError(t, err, "failed to write temp file")
+	_, err = readSPKIHashes(f.Name())
+	test.AssertError(t, err, "readSPKIHashes didn't fail with an invalid hash")
+}
+
+func TestRevokeBySPKIHashes(t *testing.T) {
+	dbMap, err := sa.NewDbMap(vars.DBConnSAFullPerms, 0)
+	test.AssertNotError(t, err, "failed setting up db client")
+	defer test.ResetSATestDatabase(t)()
+
+	mm := &mocks.Mailer{}
+	mr := &mockRevoker{}
+	bkr := &badKeyRevoker{
+		dbMap:           dbMap,
+		maxRevocations:  1,
+		serialBatchSize: 1,
+		raClient:        mr,
+		mailer:          mm,
+		emailSubject:    "testing",
+		emailTemplate:   testTemplate,
+		logger:          blog.NewMock(),
+	}
+
+	regIDA := insertRegistration(t, dbMap, "a@example.com")
+	regIDB := insertRegistration(t, dbMap, "b@example.com")
+	hashA, hashB, hashC, hashD := randHash(t), randHash(t), randHash(t), randHash(t)
+	insertGoodCert(t, dbMap, hashA, "ff", regIDA)
+	insertCert(t, dbMap, hashA, "ee", regIDA, Expired, Unrevoked)
+	insertGoodCert(t, dbMap, hashB, "dd", regIDB)
+	insertCert(t, dbMap, hashC, "cc", regIDB, Unexpired, Revoked)
+	// hashD has more certificates than maxRevocations
+	insertGoodCert(t, dbMap, hashD, "bb", regIDB)
+	insertGoodCert(t, dbMap, hashD, "aa", regIDB)
+
+	revoked, err := bkr.revokeBySPKIHashes([][]byte{hashA, hashB, hashC, hashD})
+	test.AssertError(t, err, "revokeBySPKIHashes didn't fail with too many certificates for a hash")
+	test.AssertEquals(t, mr.revoked, 2)
+	test.AssertEquals(t, len(mm.Messages), 2)
+	test.AssertDeepEquals(t, revoked, map[string]int{
+		hex.EncodeToString(hashA): 1,
+		hex.EncodeToString(hashB): 1,
+		hex.EncodeToString(hashC): 0,
+	})
+}
+
+func TestImpact(t *testing.T) {
+	dbMap, err := sa.NewDbMap(vars.DBConnSAFullPerms, 0)
+	test.AssertNotError(t, err, "failed setting up db client")
+	defer test.ResetSATestDatabase(t)()
+
+	mm := &mocks.Mailer{}
+	mr := &mockRevoker{}
+	bkr := &badKeyRevoker{
+		dbMap:           dbMap,
+		maxRevocations:  2,
+		serialBatchSize: 1,
+		raClient:        mr,
+		mailer:          mm,
+		logger:          blog.NewMock(),
+	}
+
+	regIDA := insertRegistration(t, dbMap, "a@example.com")
+	regIDB := insertRegistration(t, dbMap, "b@example.com")
+	hashA, hashB, hashC := randHash(t), randHash(t), randHash(t)
+	insertBlockedRow(t, dbMap, hashA, regIDA, false)
+	insertBlockedRow(t, dbMap, hashB, regIDB, false)
+	insertBlockedRow(t, dbMap, hashC, regIDB, true)
+	insertGoodCert(t, dbMap, hashA, "ff", regIDA)
+	insertGoodCert(t, dbMap, hashA, "ee", regIDB)
+	insertCert(t, dbMap, hashA, "dd", regIDB, Expired, Unrevoked)
+	// hashB has more certificates than maxRevocations
+	insertGoodCert(t, dbMap, hashB, "cc", regIDB)
+	insertGoodCert(t, dbMap, hashB, "bb", regIDB)
+	insertGoodCert(t, dbMap, hashB, "aa", regIDB)
+	insertGoodCert(t, dbMap, hashC, "99", regIDA)
+
+	keys, err := bkr.selectUncheckedKeys()
+	test.AssertNotError(t, err, "selectUncheckedKeys failed")
+	test.AssertEquals(t, len(keys), 2)
+
+	report := bkr.impact([]uncheckedBlockedKey{
+		{KeyHash: hashA, RevokedBy: regIDA},
+		{KeyHash: hashB, RevokedBy: regIDB},
+	})
+	test.AssertDeepEquals(t, report, impactReport{
+		Certificates: 5,
+		Accounts:     2,
+		Keys: []keyImpact{
+			{
+				KeyHash:      hex.EncodeToString(hashA),
+				RevokedBy:    regIDA,
+				Certificates: 2,
+				Accounts: []accountImpact{
+					{RegistrationID: regIDA, Serials: []string{"ff"}},
+					{RegistrationID: regIDB, Serials: []string{"ee"}},
+				},
+			},
+			{
+				KeyHash:      hex.EncodeToString(hashB),
+				RevokedBy:    regIDB,
+				Certificates: 3,
+				Accounts: []accountImpact{
+					{RegistrationID: regIDB, Serials: []string{"cc", "bb", "aa"}},
+				},
+				ExceedsMaximum: true,
+			},
+		},
+	})
+
+	// Nothing is revoked, emailed or marked as checked.
+	test.AssertEquals(t, mr.revoked, 0)
+	test.AssertEquals(t, len(mm.Messages), 0)
+	keys, err = bkr.selectUncheckedKeys()
+	test.AssertNotError(t, err, "selectUncheckedKeys failed")
+	test.AssertEquals(t, len(keys), 2)
+}
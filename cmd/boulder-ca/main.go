@@ -39,9 +39,16 @@ func loadIssuers(c config) ([]ca.Issuer, error) {
 	for _, issuerConfig := range c.CA.Issuers {
 		priv, cert, err := loadIssuer(issuerConfig)
 		cmd.FailOnError(err, "Couldn't load private key")
+		var chain []*x509.Certificate
+		for _, chainFile := range issuerConfig.ChainFiles {
+			chainCert, err := core.LoadCert(chainFile)
+			cmd.FailOnError(err, fmt.Sprintf("Couldn't load chain certificate %q", chainFile))
+			chain = append(chain, chainCert)
+		}
 		issuers = append(issuers, ca.Issuer{
 			Signer: priv,
 			Cert:   cert,
+			Chain:  chain,
 		})
 	}
 	return issuers, nil
@@ -110,6 +117,7 @@ func main() {
 	ocspAddr := flag.String("ocsp-addr", "", "OCSP gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -122,6 +130,9 @@ func main() {
 
 	err = features.Set(c.CA.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	if *caAddr != "" {
 		c.CA.GRPCCA.Address = *caAddr
@@ -133,8 +144,8 @@ func main() {
 		c.CA.DebugAddr = *debugAddr
 	}
 
-	if c.CA.MaxNames == 0 {
-		cmd.Fail("Error in CA config: MaxNames must not be 0")
+	if c.CA.MaxNames < 1 {
+		cmd.Fail("Error in CA config: MaxNames must be at least 1")
 	}
 
 	scope, logger := cmd.StatsAndLogging(c.Syslog, c.CA.DebugAddr)
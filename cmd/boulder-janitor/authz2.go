@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/db"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+const (
+	// authz2StatusPending and authz2StatusInvalid are the values stored in the
+	// authz2 table's status column for pending and invalid authorizations (see
+	// statusToUint in sa/model.go).
+	authz2StatusPending = 0
+	authz2StatusInvalid = 2
+)
+
+type authz2Job struct {
+	*batchedDBJob
+}
+
+// newAuthz2Job returns a job which deletes pending and invalid authorizations
+// that expired more than the config's GracePeriod ago, along with the
+// orderToAuthz2 rows linking them to (expired) orders. Valid and deactivated
+// authorizations are left alone, as are authorizations that are still
+// referenced by an unexpired order.
+func newAuthz2Job(
+	dbMap db.DatabaseMap,
+	log blog.Logger,
+	clk clock.Clock,
+	config CleanupConfig) *batchedDBJob {
+	purgeBefore := config.GracePeriod.Duration
+	workQuery := fmt.Sprintf(`SELECT id, expires FROM authz2
+		 WHERE
+		   id > :startID AND
+		   status IN (%d, %d)
+		 LIMIT :limit`, authz2StatusPending, authz2StatusInvalid)
+	log.Debugf("Creating Authz2 job from config: %#v", config)
+	j := &authz2Job{
+		batchedDBJob: &batchedDBJob{
+			db:          dbMap,
+			log:         log,
+			clk:         clk,
+			purgeBefore: purgeBefore,
+			workSleep:   config.WorkSleep.Duration,
+			batchSize:   config.BatchSize,
+			maxDPS:      config.MaxDPS,
+			parallelism: config.Parallelism,
+			table:       "authz2",
+			workQuery:   workQuery,
+		},
+	}
+	j.batchedDBJob.deleteHandler = j.deleteAuthz
+	return j.batchedDBJob
+}
+
+func (j *authz2Job) deleteAuthz(authzID int64) error {
+	ctx := context.Background()
+	_, err := db.WithTransaction(ctx, j.db, func(txWithCtx db.Executor) (interface{}, error) {
+		// Authorizations expire no earlier than the orders that reference them,
+		// so this should never find anything. It's checked anyway since deleting
+		// an authorization out from under a live order would break the order.
+		var liveOrders int64
+		err := txWithCtx.SelectOne(
+			&liveOrders,
+			`SELECT COUNT(1) FROM orderToAuthz2
+			 JOIN orders ON orders.id = orderToAuthz2.orderID
+			 WHERE orderToAuthz2.authzID = ? AND orders.expires > ?`,
+			authzID,
+			j.clk.Now(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if liveOrders > 0 {
+			j.log.Warningf("not deleting authz ID %d referenced by %d unexpired orders", authzID, liveOrders)
+			return nil, nil
+		}
+		// Repeat the status check in case the authorization changed since the
+		// work query found it.
+		res, err := txWithCtx.Exec(
+			fmt.Sprintf(`DELETE FROM authz2 WHERE id = ? AND status IN (%d, %d)`, authz2StatusPending, authz2StatusInvalid),
+			authzID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, nil
+		}
+		deletedStat.WithLabelValues("authz2").Add(float64(affected))
+		// Then delete the rows linking the authorization to expired orders.
+		res, err = txWithCtx.Exec(`DELETE FROM orderToAuthz2 WHERE authzID = ?`, authzID)
+		if err != nil {
+			return nil, err
+		}
+		affected, err = res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		deletedStat.WithLabelValues("orderToAuthz2").Add(float64(affected))
+		j.log.Debugf("deleted authz ID %d and associated rows", authzID)
+		return nil, nil
+	})
+	return err
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/sa"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/sa/satest"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/letsencrypt/boulder/test/vars"
+)
+
+func TestDeleteAuthz(t *testing.T) {
+	ctx := context.Background()
+	log, fc := setup()
+
+	dbMap, err := sa.NewDbMap(vars.DBConnSA, 0)
+	test.AssertNotError(t, err, "error creating db map")
+	ssa, err := sa.NewSQLStorageAuthority(dbMap, fc, log, metrics.NoopRegisterer, 1)
+	test.AssertNotError(t, err, "error creating SA")
+	defer func() {
+		test.ResetSATestDatabase(t)
+	}()
+
+	reg, err := ssa.NewRegistration(ctx, core.Registration{
+		Key:       satest.GoodJWK(),
+		InitialIP: net.ParseIP("127.0.0.1"),
+	})
+	test.AssertNotError(t, err, "error creating test registration")
+
+	// Create two pending authorizations, one of which is referenced by an
+	// unexpired order.
+	ident := "test.example.com"
+	pending := string(core.StatusPending)
+	expires := fc.Now().Add(time.Hour).UTC().UnixNano()
+	challType := string(core.ChallengeTypeDNS01)
+	tokens := []string{
+		"YXNkAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+		"YXNkAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAB",
+	}
+	var authzs []*corepb.Authorization
+	for i := range tokens {
+		authzs = append(authzs, &corepb.Authorization{
+			Identifier:     &ident,
+			RegistrationID: &reg.ID,
+			Status:         &pending,
+			Expires:        &expires,
+			Challenges: []*corepb.Challenge{
+				{
+					Status: &pending,
+					Type:   &challType,
+					Token:  &tokens[i],
+				},
+			},
+		})
+	}
+	ids, err := ssa.NewAuthorizations2(ctx, &sapb.AddPendingAuthorizationsRequest{Authz: authzs})
+	test.AssertNotError(t, err, "error adding test authz2")
+	test.AssertEquals(t, len(ids.Ids), 2)
+	_, err = ssa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:   &reg.ID,
+		Status:           &pending,
+		Expires:          &expires,
+		Names:            []string{ident},
+		V2Authorizations: []int64{ids.Ids[0]},
+	})
+	test.AssertNotError(t, err, "error creating test order")
+
+	config := CleanupConfig{
+		WorkSleep:   cmd.ConfigDuration{Duration: time.Second},
+		BatchSize:   1,
+		MaxDPS:      1,
+		Parallelism: 1,
+	}
+	// The SA user doesn't have DELETE grants on authz2, so use the janitor user.
+	janitorDbMap, err := sa.NewDbMap("janitor@tcp(boulder-mysql:3306)/boulder_sa_test", 0)
+	test.AssertNotError(t, err, "error creating db map")
+	j := newAuthz2Job(janitorDbMap, log, fc, config)
+
+	// The authorization referenced by the unexpired order isn't deleted.
+	err = j.deleteHandler(ids.Ids[0])
+	test.AssertNotError(t, err, "error calling deleteHandler")
+	_, err = ssa.GetAuthorization2(ctx, &sapb.AuthorizationID2{Id: &ids.Ids[0]})
+	test.AssertNotError(t, err, "authz referenced by a live order was deleted")
+
+	// The unreferenced authorization is.
+	err = j.deleteHandler(ids.Ids[1])
+	test.AssertNotError(t, err, "error calling deleteHandler")
+	_, err = ssa.GetAuthorization2(ctx, &sapb.AuthorizationID2{Id: &ids.Ids[1]})
+	test.AssertError(t, err, "found authz after deleting it")
+	test.AssertEquals(t, berrors.Is(err, berrors.NotFound), true)
+
+	// Once the order has expired its authorization and the orderToAuthz2 row
+	// linking them are deleted.
+	fc.Add(2 * time.Hour)
+	err = j.deleteHandler(ids.Ids[0])
+	test.AssertNotError(t, err, "error calling deleteHandler")
+	var count int64
+	err = janitorDbMap.SelectOne(&count, "SELECT COUNT(1) FROM orderToAuthz2 WHERE authzID = ?", ids.Ids[0])
+	test.AssertNotError(t, err, "error counting orderToAuthz2 rows")
+	test.AssertEquals(t, count, int64(0))
+	err = janitorDbMap.SelectOne(&count, "SELECT COUNT(1) FROM authz2 WHERE id = ?", ids.Ids[0])
+	test.AssertNotError(t, err, "error counting authz2 rows")
+	test.AssertEquals(t, count, int64(0))
+}
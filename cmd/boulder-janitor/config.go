@@ -74,6 +74,11 @@ type Config struct {
 		// Orders describes a cleanup job for the orders table and related rows
 		// (requestedNames, orderToAuthz2, orderFqdnSets).
 		Orders CleanupConfig
+
+		// Authorizations describes a cleanup job for expired pending and
+		// invalid authorizations in the authz2 table and the orderToAuthz2 rows
+		// referencing them.
+		Authorizations CleanupConfig
 	}
 }
 
@@ -99,6 +99,9 @@ func newJobs(
 	if config.Janitor.Orders.Enabled {
 		jobs = append(jobs, newOrdersJob(dbMap, logger, clk, config.Janitor.Orders))
 	}
+	if config.Janitor.Authorizations.Enabled {
+		jobs = append(jobs, newAuthz2Job(dbMap, logger, clk, config.Janitor.Authorizations))
+	}
 	// There must be at least one job
 	if len(jobs) == 0 {
 		return nil, errNoJobsConfigured
@@ -1,23 +1,22 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
-	"io/ioutil"
 
 	"github.com/letsencrypt/boulder/cmd"
 )
 
 func main() {
 	configPath := flag.String("config", "config.json", "Path to boulder-janitor configuration file")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 
-	configJSON, err := ioutil.ReadFile(*configPath)
-	cmd.FailOnError(err, "Failed to read config file")
-
 	var config Config
-	err = json.Unmarshal(configJSON, &config)
-	cmd.FailOnError(err, "Failed to parse JSON config")
+	err := cmd.ReadConfigFile(*configPath, &config)
+	cmd.FailOnError(err, "Failed to read config file")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&config)
+	}
 
 	j, err := New(cmd.Clock(), config)
 	cmd.FailOnError(err, "Failed to build janitor with config")
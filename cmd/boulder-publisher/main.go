@@ -39,6 +39,7 @@ func main() {
 	grpcAddr := flag.String("addr", "", "gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -50,6 +51,9 @@ func main() {
 	cmd.FailOnError(err, "Reading JSON config file into config structure")
 	err = features.Set(c.Publisher.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	runtime.SetBlockProfileRate(c.Publisher.BlockProfileRate)
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	akamaipb "github.com/letsencrypt/boulder/akamai/proto"
+	"github.com/letsencrypt/boulder/bdns"
 	caPB "github.com/letsencrypt/boulder/ca/proto"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/core"
@@ -82,11 +83,39 @@ type config struct {
 		// test them or because they are not yet approved by a browser/root
 		// program but we still want our certs to end up there.
 		InformationalCTLogs []ctconfig.LogDescription
+		// CTLogUnhealthyPeriod is how long after a failed submission a CT log
+		// is tried after the healthy logs in its group. Defaults to one minute.
+		CTLogUnhealthyPeriod cmd.ConfigDuration
 
 		// IssuerCertPath is the path to the intermediate used to issue certificates.
 		// It is used to generate OCSP URLs to purge at revocation time.
 		IssuerCertPath string
 
+		// DNSSECCAAProfiles are the names of the issuance profiles for which
+		// CAA is rechecked at finalization, requiring the lookups for every
+		// name to have been DNSSEC validated.
+		DNSSECCAAProfiles []string
+
+		// ContactValidation configures the checks of account contact emails,
+		// made when accounts are created and updated, beyond their basic syntax
+		// and domain. Each check is disabled unless it's configured.
+		ContactValidation struct {
+			// StrictSyntax rejects contact emails which aren't a bare RFC 5322
+			// addr-spec, e.g. those with a display name or comments.
+			StrictSyntax bool
+			// DisposableDomainsFile is the path to a YAML file listing, under
+			// disposableDomains, the domains of disposable email providers.
+			// Contact emails at them or their subdomains are rejected.
+			DisposableDomainsFile string
+			// CheckMX rejects contact emails at domains which don't exist or
+			// don't accept mail. It costs one or two lookups, made with
+			// DNSResolvers, per contact.
+			CheckMX      bool
+			DNSResolvers []string
+			DNSTimeout   cmd.ConfigDuration
+			DNSTries     int
+		}
+
 		Features map[string]bool
 	}
 
@@ -99,6 +128,7 @@ func main() {
 	grpcAddr := flag.String("addr", "", "gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -111,6 +141,9 @@ func main() {
 
 	err = features.Set(c.RA.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	if *grpcAddr != "" {
 		c.RA.GRPC.Address = *grpcAddr
@@ -186,7 +219,7 @@ func main() {
 			}
 		}
 	}
-	ctp = ctpolicy.New(pubc, c.RA.CTLogGroups2, c.RA.InformationalCTLogs, logger, scope)
+	ctp = ctpolicy.New(pubc, c.RA.CTLogGroups2, c.RA.InformationalCTLogs, c.RA.CTLogUnhealthyPeriod.Duration, logger, scope, clk)
 
 	saConn, err := bgrpc.ClientSetup(c.RA.SAService, tlsConfig, clientMetrics, clk)
 	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
@@ -233,6 +266,30 @@ func main() {
 	policyErr := rai.SetRateLimitPoliciesFile(c.RA.RateLimitPoliciesFilename)
 	cmd.FailOnError(policyErr, "Couldn't load rate limit policies file")
 	rai.PA = pa
+	rai.DNSSECCAAProfiles = make(map[string]bool, len(c.RA.DNSSECCAAProfiles))
+	for _, profile := range c.RA.DNSSECCAAProfiles {
+		rai.DNSSECCAAProfiles[profile] = true
+	}
+	rai.StrictContactSyntax = c.RA.ContactValidation.StrictSyntax
+	if c.RA.ContactValidation.DisposableDomainsFile != "" {
+		rai.DisposableMailDomains, err = ra.LoadDisposableMailDomains(c.RA.ContactValidation.DisposableDomainsFile)
+		cmd.FailOnError(err, "Couldn't load disposable mail domains file")
+	}
+	if c.RA.ContactValidation.CheckMX {
+		contactDNS := c.RA.ContactValidation
+		if len(contactDNS.DNSResolvers) == 0 {
+			cmd.Fail("contactValidation.checkMX requires contactValidation.dnsResolvers")
+		}
+		dnsTimeout := contactDNS.DNSTimeout.Duration
+		if dnsTimeout == 0 {
+			dnsTimeout = 5 * time.Second
+		}
+		dnsTries := contactDNS.DNSTries
+		if dnsTries < 1 {
+			dnsTries = 1
+		}
+		rai.MXResolver = bdns.NewDNSClientImpl(dnsTimeout, contactDNS.DNSResolvers, scope, clk, dnsTries, logger)
+	}
 
 	rai.VA = vac
 	rai.CA = cac
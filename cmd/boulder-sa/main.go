@@ -29,6 +29,7 @@ func main() {
 	grpcAddr := flag.String("addr", "", "gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -41,6 +42,9 @@ func main() {
 
 	err = features.Set(c.SA.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	if *grpcAddr != "" {
 		c.SA.GRPC.Address = *grpcAddr
@@ -2,13 +2,17 @@ package main
 
 import (
 	"flag"
+	"net"
 	"os"
 	"time"
 
+	"google.golang.org/grpc"
+
 	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/features"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/va"
 	vaPB "github.com/letsencrypt/boulder/va/proto"
 )
@@ -19,6 +23,12 @@ type config struct {
 
 		UserAgent string
 
+		// HTTPHeaders are extra request headers, e.g. to help subscribers
+		// identify and allow the VA's requests, sent with every HTTP-01 request.
+		// At most 10 may be configured, and headers the VA sets itself, such as
+		// User-Agent, can't be.
+		HTTPHeaders map[string]string
+
 		IssuerDomain string
 
 		PortConfig cmd.PortConfig
@@ -37,9 +47,26 @@ type config struct {
 		DNSTries     int
 		DNSResolvers []string
 
+		// DNSCacheMaxTTL enables an in-memory cache of DNS responses, so that
+		// names looked up repeatedly during validation (e.g. for CAA and DNS-01)
+		// are resolved once. Responses are cached for their TTL, capped at this
+		// duration. Zero, the default, disables caching so every lookup is sent
+		// to the resolvers.
+		DNSCacheMaxTTL cmd.ConfigDuration
+
 		RemoteVAs                   []cmd.GRPCClientConfig
 		MaxRemoteValidationFailures int
 
+		// RemotePerspectives is the number of the configured RemoteVAs, chosen
+		// at random, asked to perform each validation. Zero means all of them.
+		RemotePerspectives int
+
+		// RemoteQuorum is the number of remote perspectives that must agree
+		// with the primary VA for a validation to succeed. Zero means the quorum
+		// is the number of remote perspectives minus
+		// MaxRemoteValidationFailures.
+		RemoteQuorum int
+
 		Features map[string]bool
 
 		AccountURIPrefixes []string
@@ -50,6 +77,27 @@ type config struct {
 		// disabledAccounts lists to determine whether or not to enforce multi-VA
 		// consensus for an account/domain.
 		MultiVAPolicyFile string
+
+		// ChallengeTimeouts optionally bounds how long a validation of a given
+		// challenge type (e.g. "http-01") may take, independent of the RPC
+		// deadline. Challenge types without an entry are bounded only by the
+		// RPC deadline.
+		ChallengeTimeouts map[string]cmd.ConfigDuration
+
+		// HappyEyeballsDelay is how long an HTTP-01 connection attempt to a
+		// dual-stacked host's IPv6 address is given before an attempt to its
+		// IPv4 address is raced against it, when the HTTP01HappyEyeballs
+		// feature is enabled. Defaults to 250ms.
+		HappyEyeballsDelay cmd.ConfigDuration
+
+		// MaxRedirects is how many redirects an HTTP-01 validation follows
+		// before failing. Defaults to 10.
+		MaxRedirects int
+
+		// DebugGRPC optionally configures a separate gRPC server for the VADebug
+		// service, which validates using a resolver chosen by the caller. Its
+		// ClientNames should only list administrative clients.
+		DebugGRPC *cmd.GRPCServerConfig
 	}
 
 	Syslog cmd.SyslogConfig
@@ -65,6 +113,7 @@ func main() {
 	grpcAddr := flag.String("addr", "", "gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -77,6 +126,9 @@ func main() {
 
 	err = features.Set(c.VA.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	if *grpcAddr != "" {
 		c.VA.GRPC.Address = *grpcAddr
@@ -111,28 +163,29 @@ func main() {
 		dnsTries = 1
 	}
 	clk := cmd.Clock()
-	var resolver bdns.DNSClient
 	if len(c.Common.DNSResolver) != 0 {
 		c.VA.DNSResolvers = append(c.VA.DNSResolvers, c.Common.DNSResolver)
 	}
+	var resolver *bdns.DNSClientImpl
 	if !c.Common.DNSAllowLoopbackAddresses {
-		r := bdns.NewDNSClientImpl(
+		resolver = bdns.NewDNSClientImpl(
 			dnsTimeout,
 			c.VA.DNSResolvers,
 			scope,
 			clk,
 			dnsTries,
 			logger)
-		resolver = r
 	} else {
-		r := bdns.NewTestDNSClientImpl(
+		resolver = bdns.NewTestDNSClientImpl(
 			dnsTimeout,
 			c.VA.DNSResolvers,
 			scope,
 			clk,
 			dnsTries,
 			logger)
-		resolver = r
+	}
+	if c.VA.DNSCacheMaxTTL.Duration > 0 {
+		resolver.EnableCache(c.VA.DNSCacheMaxTTL.Duration, scope)
 	}
 
 	tlsConfig, err := c.VA.TLS.Load()
@@ -154,18 +207,29 @@ func main() {
 		}
 	}
 
+	challengeTimeouts := make(map[string]time.Duration, len(c.VA.ChallengeTimeouts))
+	for challType, timeout := range c.VA.ChallengeTimeouts {
+		challengeTimeouts[challType] = timeout.Duration
+	}
+
 	vai, err := va.NewValidationAuthorityImpl(
 		pc,
 		resolver,
 		remotes,
 		c.VA.MaxRemoteValidationFailures,
 		c.VA.UserAgent,
+		c.VA.HTTPHeaders,
 		c.VA.IssuerDomain,
 		scope,
 		clk,
 		logger,
 		c.VA.AccountURIPrefixes,
-		c.VA.MultiVAPolicyFile)
+		c.VA.MultiVAPolicyFile,
+		challengeTimeouts,
+		c.VA.RemotePerspectives,
+		c.VA.RemoteQuorum,
+		c.VA.HappyEyeballsDelay.Duration,
+		c.VA.MaxRedirects)
 	cmd.FailOnError(err, "Unable to create VA server")
 
 	serverMetrics := bgrpc.NewServerMetrics(scope)
@@ -176,7 +240,31 @@ func main() {
 	vaPB.RegisterCAAServer(grpcSrv, vai)
 	cmd.FailOnError(err, "Unable to register CAA gRPC server")
 
-	go cmd.CatchSignals(logger, grpcSrv.GracefulStop)
+	var debugSrv *grpc.Server
+	if c.VA.DebugGRPC != nil {
+		newResolver := func(addr string) bdns.DNSClient {
+			// Debug resolvers are short lived, so their metrics aren't exported.
+			if c.Common.DNSAllowLoopbackAddresses {
+				return bdns.NewTestDNSClientImpl(dnsTimeout, []string{addr}, metrics.NoopRegisterer, clk, dnsTries, logger)
+			}
+			return bdns.NewDNSClientImpl(dnsTimeout, []string{addr}, metrics.NoopRegisterer, clk, dnsTries, logger)
+		}
+		var debugListener net.Listener
+		debugSrv, debugListener, err = bgrpc.NewServer(c.VA.DebugGRPC, tlsConfig, serverMetrics, clk)
+		cmd.FailOnError(err, "Unable to setup VA debug gRPC server")
+		vaPB.RegisterVADebugServer(debugSrv, va.NewDebugServer(vai, newResolver))
+		go func() {
+			err := cmd.FilterShutdownErrors(debugSrv.Serve(debugListener))
+			cmd.FailOnError(err, "VA debug gRPC service failed")
+		}()
+	}
+
+	go cmd.CatchSignals(logger, func() {
+		if debugSrv != nil {
+			debugSrv.GracefulStop()
+		}
+		grpcSrv.GracefulStop()
+	})
 
 	err = cmd.FilterShutdownErrors(grpcSrv.Serve(l))
 	cmd.FailOnError(err, "VA gRPC service failed")
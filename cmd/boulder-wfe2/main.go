@@ -36,7 +36,26 @@ type config struct {
 		ServerCertificatePath string
 		ServerKeyPath         string
 
+		// AllowOrigins lists the origins from which browser-based clients may
+		// request the directory and new-nonce endpoints, or "*" for any origin.
+		// Other endpoints never allow cross-origin requests. If empty, no CORS
+		// headers are sent.
 		AllowOrigins []string
+		// CORSAllowMethods restricts the methods allowed for cross-origin
+		// requests. If empty, all methods handled by those endpoints are allowed.
+		CORSAllowMethods []string
+		// CORSAllowHeaders lists request headers, in addition to Content-Type,
+		// which cross-origin requests may send.
+		CORSAllowHeaders []string
+		// CORSMaxAge is how long browsers may cache preflight responses. Defaults
+		// to 24 hours.
+		CORSMaxAge cmd.ConfigDuration
+
+		// MaxRequestSizes overrides the maximum size in bytes of request bodies
+		// accepted by individual endpoints, keyed by endpoint path, e.g.
+		// {"/acme/new-order": 200000}. Endpoints not listed keep the WFE's
+		// defaults.
+		MaxRequestSizes map[string]int64
 
 		ShutdownStopTimeout cmd.ConfigDuration
 
@@ -75,6 +94,23 @@ type config struct {
 		// "website" field.
 		DirectoryWebsite string
 
+		// CertificateProfiles maps the names of the issuance profiles clients
+		// may request in new orders to a description of each, which is
+		// advertised in the /directory response's "meta" element. Every name
+		// must be one of the CA's IssuanceProfiles.
+		CertificateProfiles map[string]string
+
+		// MaxConcurrentRequestsPerAccount limits the number of requests each
+		// account (or, for new accounts, each key) may have in flight at once.
+		// Requests beyond the limit are rejected with a rateLimited problem and
+		// a Retry-After header. Zero means no limit.
+		MaxConcurrentRequestsPerAccount int
+
+		// CompressResponses enables gzip compression of JSON and PEM
+		// response bodies, e.g. directory, order and certificate responses,
+		// for clients which send an Accept-Encoding allowing it.
+		CompressResponses bool
+
 		// ACMEv2 requests (outside some registration/revocation messages) use a JWS with
 		// a KeyID header containing the full account URL. For new accounts this
 		// will be a KeyID based on the HTTP request's Host header and the ACMEv2
@@ -271,6 +307,7 @@ func (ew errorWriter) Write(p []byte) (n int, err error) {
 
 func main() {
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -295,6 +332,9 @@ func main() {
 
 	err = features.Set(c.WFE.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	if c.WFE.AlternateCertificateChains != nil {
 		altCertChains, _, err := loadCertificateChains(c.WFE.AlternateCertificateChains, false)
@@ -341,8 +381,15 @@ func main() {
 
 	wfe.SubscriberAgreementURL = c.WFE.SubscriberAgreementURL
 	wfe.AllowOrigins = c.WFE.AllowOrigins
+	wfe.CORSAllowMethods = c.WFE.CORSAllowMethods
+	wfe.CORSAllowHeaders = c.WFE.CORSAllowHeaders
+	wfe.CORSMaxAge = c.WFE.CORSMaxAge.Duration
+	wfe.MaxRequestSizes = c.WFE.MaxRequestSizes
 	wfe.DirectoryCAAIdentity = c.WFE.DirectoryCAAIdentity
 	wfe.DirectoryWebsite = c.WFE.DirectoryWebsite
+	wfe.CertificateProfiles = c.WFE.CertificateProfiles
+	wfe.MaxConcurrentRequestsPerAccount = c.WFE.MaxConcurrentRequestsPerAccount
+	wfe.CompressResponses = c.WFE.CompressResponses
 	wfe.LegacyKeyIDPrefix = c.WFE.LegacyKeyIDPrefix
 
 	wfe.IssuerCert, err = cmd.LoadCert(c.Common.IssuerCert)
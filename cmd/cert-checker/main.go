@@ -1,15 +1,19 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log/syslog"
 	"os"
 	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -31,6 +35,7 @@ import (
 
 const (
 	expectedValidityPeriod = time.Hour * 24 * 90
+	defaultProfileName     = "default"
 )
 
 // For defense-in-depth in addition to using the PA & its hostnamePolicy to
@@ -71,11 +76,58 @@ func (r *report) dump() error {
 	return nil
 }
 
+// dumpNDJSON writes the report as newline delimited JSON: one "certificate"
+// record for each certificate with problems, ordered by serial, followed by a
+// "summary" record with the totals.
+func (r *report) dumpNDJSON(w io.Writer) error {
+	serials := make([]string, 0, len(r.Entries))
+	for serial, entry := range r.Entries {
+		if !entry.Valid {
+			serials = append(serials, serial)
+		}
+	}
+	sort.Strings(serials)
+	enc := json.NewEncoder(w)
+	for _, serial := range serials {
+		entry := r.Entries[serial]
+		err := enc.Encode(ndjsonCertificate{
+			Type:     "certificate",
+			Serial:   serial,
+			Profile:  entry.Profile,
+			Problems: entry.Problems,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return enc.Encode(ndjsonSummary{
+		Type:      "summary",
+		GoodCerts: r.GoodCerts,
+		BadCerts:  r.BadCerts,
+	})
+}
+
 type reportEntry struct {
 	Valid    bool     `json:"valid"`
+	Profile  string   `json:"profile"`
 	Problems []string `json:"problems,omitempty"`
 }
 
+// ndjsonCertificate is the NDJSON record for a certificate with problems.
+type ndjsonCertificate struct {
+	Type     string   `json:"type"`
+	Serial   string   `json:"serial"`
+	Profile  string   `json:"profile"`
+	Problems []string `json:"problems"`
+}
+
+// ndjsonSummary is the NDJSON record with the totals of a run.
+type ndjsonSummary struct {
+	Type      string `json:"type"`
+	GoodCerts int64  `json:"good-certs"`
+	BadCerts  int64  `json:"bad-certs"`
+}
+
 /*
  * certDB is an interface collecting the gorp.DbMap functions that the
  * various parts of cert-checker rely on. Using this adapter shim allows tests to
@@ -94,6 +146,110 @@ type certChecker struct {
 	rMu          *sync.Mutex
 	issuedReport report
 	checkPeriod  time.Duration
+	// profiles maps issuance profile names to the constraints certificates
+	// issued under them are checked against.
+	profiles map[string]certProfile
+	// serialProfiles maps certificate serials to the name of the issuance
+	// profile they were issued under. Certificates which aren't present are
+	// checked against the default profile.
+	serialProfiles map[string]string
+}
+
+// certProfile is the set of constraints which depend on the issuance profile
+// a certificate was issued under.
+type certProfile struct {
+	name              string
+	validityPeriod    time.Duration
+	allowedExtensions map[string]bool
+}
+
+var defaultProfile = certProfile{
+	name:              defaultProfileName,
+	validityPeriod:    expectedValidityPeriod,
+	allowedExtensions: allowedExtensions,
+}
+
+// problem formats a problem found while checking a certificate against the
+// profile, naming the profile if it isn't the default.
+func (p certProfile) problem(format string, a ...interface{}) string {
+	msg := fmt.Sprintf(format, a...)
+	if p.name != defaultProfileName {
+		msg = fmt.Sprintf("%s (profile %q)", msg, p.name)
+	}
+	return msg
+}
+
+// certProfile returns the profile the certificate with the given serial was
+// issued under.
+func (c *certChecker) certProfile(serial string) (certProfile, error) {
+	name, present := c.serialProfiles[serial]
+	if !present || name == defaultProfileName {
+		return defaultProfile, nil
+	}
+	profile, present := c.profiles[name]
+	if !present {
+		return defaultProfile, fmt.Errorf("Certificate was issued under unknown profile %q", name)
+	}
+	return profile, nil
+}
+
+// profileName returns the name of the profile the certificate with the given
+// serial was issued under, even if it isn't a configured profile.
+func (c *certChecker) profileName(serial string) string {
+	if name, present := c.serialProfiles[serial]; present {
+		return name
+	}
+	return defaultProfileName
+}
+
+// signingLinePattern matches the audit log line the CA emits before signing a
+// certificate, capturing the serial and the issuance profile.
+var signingLinePattern = regexp.MustCompile(`Signing: serial=\[([0-9a-f]+)\] names=\[[^\]]*\] profile=\[([^\]]*)\]`)
+
+// readSerialProfiles reads CA audit log lines and returns a map of the serials
+// of the certificates signed to the issuance profile they were signed under.
+func readSerialProfiles(r io.Reader) (map[string]string, error) {
+	serialProfiles := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.Contains(line, "Signing: serial=") {
+			continue
+		}
+		matches := signingLinePattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		serialProfiles[matches[1]] = matches[2]
+	}
+	return serialProfiles, scanner.Err()
+}
+
+// makeProfiles converts the configured profiles into certProfiles.
+func makeProfiles(configs map[string]ProfileConfig) (map[string]certProfile, error) {
+	profiles := make(map[string]certProfile, len(configs))
+	for name, pc := range configs {
+		if name == defaultProfileName {
+			return nil, fmt.Errorf("profile name %q is reserved", name)
+		}
+		if pc.Validity.Duration <= 0 {
+			return nil, fmt.Errorf("profile %q must have a positive validity", name)
+		}
+		profile := certProfile{
+			name:              name,
+			validityPeriod:    pc.Validity.Duration,
+			allowedExtensions: allowedExtensions,
+		}
+		if len(pc.AllowedExtensions) > 0 {
+			profile.allowedExtensions = make(map[string]bool, len(pc.AllowedExtensions))
+			for _, oid := range pc.AllowedExtensions {
+				profile.allowedExtensions[oid] = true
+			}
+		}
+		profiles[name] = profile
+	}
+	return profiles, nil
 }
 
 func newChecker(saDbMap certDB, clk clock.Clock, pa core.PolicyAuthority, period time.Duration) certChecker {
@@ -180,6 +336,7 @@ func (c *certChecker) processCerts(wg *sync.WaitGroup, badResultsOnly bool, igno
 		if !badResultsOnly || (badResultsOnly && !valid) {
 			c.issuedReport.Entries[cert.Serial] = reportEntry{
 				Valid:    valid,
+				Profile:  c.profileName(cert.Serial),
 				Problems: problems,
 			}
 		}
@@ -214,6 +371,11 @@ var expectedExtensionContent = map[string][]byte{
 }
 
 func (c *certChecker) checkCert(cert core.Certificate, ignoredLints map[string]bool) (problems []string) {
+	profile, err := c.certProfile(cert.Serial)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+
 	// Check digests match
 	if cert.Digest != core.Fingerprint256(cert.DER) {
 		problems = append(problems, "Stored digest doesn't match certificate digest")
@@ -257,10 +419,10 @@ func (c *certChecker) checkCert(cert core.Certificate, ignoredLints map[string]b
 		}
 		// Check the cert has the correct validity period
 		validityPeriod := parsedCert.NotAfter.Sub(parsedCert.NotBefore)
-		if validityPeriod > expectedValidityPeriod {
-			problems = append(problems, fmt.Sprintf("Certificate has a validity period longer than %s", expectedValidityPeriod))
-		} else if validityPeriod < expectedValidityPeriod {
-			problems = append(problems, fmt.Sprintf("Certificate has a validity period shorter than %s", expectedValidityPeriod))
+		if validityPeriod > profile.validityPeriod {
+			problems = append(problems, profile.problem("Certificate has a validity period longer than %s", profile.validityPeriod))
+		} else if validityPeriod < profile.validityPeriod {
+			problems = append(problems, profile.problem("Certificate has a validity period shorter than %s", profile.validityPeriod))
 		}
 		// Check the stored issuance time isn't too far back/forward dated
 		if parsedCert.NotBefore.Before(cert.Issued.Add(-6*time.Hour)) || parsedCert.NotBefore.After(cert.Issued.Add(6*time.Hour)) {
@@ -298,8 +460,8 @@ func (c *certChecker) checkCert(cert core.Certificate, ignoredLints map[string]b
 		}
 
 		for _, ext := range parsedCert.Extensions {
-			if _, ok := allowedExtensions[ext.Id.String()]; !ok {
-				problems = append(problems, fmt.Sprintf("Certificate contains an unexpected extension: %s", ext.Id))
+			if _, ok := profile.allowedExtensions[ext.Id.String()]; !ok {
+				problems = append(problems, profile.problem("Certificate contains an unexpected extension: %s", ext.Id))
 			}
 			if expectedContent, ok := expectedExtensionContent[ext.Id.String()]; ok {
 				if !bytes.Equal(ext.Value, expectedContent) {
@@ -326,6 +488,13 @@ type config struct {
 		// the IgnoredLists list are ignored regardless of LintStatus level.
 		IgnoredLints []string
 
+		// Profiles configures the constraints that certificates issued under
+		// each named issuance profile are checked against. Certificates are
+		// matched to the profile they were issued under using the CA audit log
+		// given with -ca-audit-log; all others are checked against the default
+		// constraints.
+		Profiles map[string]ProfileConfig
+
 		Features map[string]bool
 	}
 
@@ -334,19 +503,36 @@ type config struct {
 	Syslog cmd.SyslogConfig
 }
 
+// ProfileConfig describes the constraints for certificates issued under an
+// issuance profile.
+type ProfileConfig struct {
+	// Validity is the exact validity period of certificates issued under
+	// the profile.
+	Validity cmd.ConfigDuration
+	// AllowedExtensions is a list of the extension OIDs certificates issued
+	// under the profile may contain. If empty, the default set is allowed.
+	AllowedExtensions []string
+}
+
 func main() {
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	workers := flag.Int("workers", runtime.NumCPU(), "The number of concurrent workers used to process certificates")
 	badResultsOnly := flag.Bool("bad-results-only", false, "Only collect and display bad results")
 	connect := flag.String("db-connect", "", "SQL URI if not provided in the configuration file")
 	cp := flag.Duration("check-period", time.Hour*2160, "How far back to check")
 	unexpiredOnly := flag.Bool("unexpired-only", false, "Only check currently unexpired certificates")
+	caAuditLog := flag.String("ca-audit-log", "", "Path to a CA audit log used to find the issuance profile each certificate was issued under")
+	outputFormat := flag.String("output-format", "json", `Format of the results: "json" for a single report, or "ndjson" for one line per certificate with problems followed by a summary line`)
 
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
 		os.Exit(1)
 	}
+	if *outputFormat != "json" && *outputFormat != "ndjson" {
+		cmd.Fail(fmt.Sprintf("Unknown output format %q", *outputFormat))
+	}
 
 	var config config
 	err := cmd.ReadConfigFile(*configFile, &config)
@@ -354,6 +540,9 @@ func main() {
 
 	err = features.Set(config.CertChecker.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&config)
+	}
 
 	syslogger, err := syslog.Dial("", "", syslog.LOG_INFO|syslog.LOG_LOCAL0, "")
 	cmd.FailOnError(err, "Failed to dial syslog")
@@ -399,6 +588,16 @@ func main() {
 		pa,
 		config.CertChecker.CheckPeriod.Duration,
 	)
+	checker.profiles, err = makeProfiles(config.CertChecker.Profiles)
+	cmd.FailOnError(err, "Invalid profile configuration")
+	if *caAuditLog != "" {
+		f, err := os.Open(*caAuditLog)
+		cmd.FailOnError(err, "Failed to open CA audit log")
+		checker.serialProfiles, err = readSerialProfiles(f)
+		cmd.FailOnError(err, "Failed to read CA audit log")
+		f.Close()
+		fmt.Fprintf(os.Stderr, "# Found issuance profiles for %d certificates\n", len(checker.serialProfiles))
+	}
 	fmt.Fprintf(os.Stderr, "# Getting certificates issued in the last %s\n", config.CertChecker.CheckPeriod)
 
 	ignoredLintsMap := make(map[string]bool)
@@ -432,7 +631,11 @@ func main() {
 		checker.issuedReport.GoodCerts,
 		checker.issuedReport.BadCerts,
 	)
-	err = checker.issuedReport.dump()
+	if *outputFormat == "ndjson" {
+		err = checker.issuedReport.dumpNDJSON(os.Stdout)
+	} else {
+		err = checker.issuedReport.dump()
+	}
 	cmd.FailOnError(err, "Failed to dump results: %s\n")
 
 }
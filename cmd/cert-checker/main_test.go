@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
@@ -13,12 +14,14 @@ import (
 	mrand "math/rand"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/jmhodges/clock"
 
+	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/core"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics"
@@ -374,6 +377,38 @@ func TestSaveReport(t *testing.T) {
 	test.AssertNotError(t, err, "Failed to dump results")
 }
 
+func TestReportNDJSON(t *testing.T) {
+	r := report{
+		GoodCerts: 1,
+		BadCerts:  2,
+		Entries: map[string]reportEntry{
+			"020000000000004b475da49b91da5c17": {
+				Valid:   true,
+				Profile: "default",
+			},
+			"020000000000004e402bc21035c6634a": {
+				Valid:    false,
+				Profile:  "shortlived",
+				Problems: []string{"Certificate has incorrect key usage extensions", "Certificate has unacceptable validity period"},
+			},
+			"020000000000004d1613e581432cba7e": {
+				Valid:    false,
+				Profile:  "default",
+				Problems: []string{"Certificate has common name >64 characters long (65)"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := r.dumpNDJSON(&buf)
+	test.AssertNotError(t, err, "Failed to dump NDJSON results")
+	test.AssertEquals(t, buf.String(),
+		`{"type":"certificate","serial":"020000000000004d1613e581432cba7e","profile":"default","problems":["Certificate has common name \u003e64 characters long (65)"]}
+{"type":"certificate","serial":"020000000000004e402bc21035c6634a","profile":"shortlived","problems":["Certificate has incorrect key usage extensions","Certificate has unacceptable validity period"]}
+{"type":"summary","good-certs":1,"bad-certs":2}
+`)
+}
+
 func TestIsForbiddenDomain(t *testing.T) {
 	// Note: These testcases are not an exhaustive representation of domains
 	// Boulder won't issue for, but are instead testing the defense-in-depth
@@ -498,3 +533,117 @@ func TestIgnoredLint(t *testing.T) {
 	})
 	test.AssertEquals(t, len(problems), 0)
 }
+
+func TestReadSerialProfiles(t *testing.T) {
+	auditLog := strings.Join([]string{
+		`2020-10-14T12:00:00.000000+00:00 host boulder-ca[100]: 6 boulder-ca abcd [AUDIT] Signing: serial=[00000000000000000000000000000000aa01] names=[example.com, www.example.com] profile=[shortlived] csr=[3082]`,
+		`2020-10-14T12:00:01.000000+00:00 host boulder-ca[100]: 6 boulder-ca abcd [AUDIT] Signing: serial=[00000000000000000000000000000000aa02] names=[example.net] profile=[default] csr=[3082]`,
+		`2020-10-14T12:00:02.000000+00:00 host boulder-ca[100]: 6 boulder-ca abcd [AUDIT] Signing precert success: serial=[00000000000000000000000000000000aa01]`,
+		`not a log line at all`,
+	}, "\n")
+	serialProfiles, err := readSerialProfiles(strings.NewReader(auditLog))
+	test.AssertNotError(t, err, "readSerialProfiles failed")
+	test.AssertDeepEquals(t, serialProfiles, map[string]string{
+		"00000000000000000000000000000000aa01": "shortlived",
+		"00000000000000000000000000000000aa02": "default",
+	})
+}
+
+func TestMakeProfiles(t *testing.T) {
+	_, err := makeProfiles(map[string]ProfileConfig{
+		"default": {Validity: cmd.ConfigDuration{Duration: time.Hour}},
+	})
+	test.AssertError(t, err, "makeProfiles didn't fail with reserved name")
+	_, err = makeProfiles(map[string]ProfileConfig{
+		"shortlived": {},
+	})
+	test.AssertError(t, err, "makeProfiles didn't fail without a validity")
+
+	profiles, err := makeProfiles(map[string]ProfileConfig{
+		"shortlived": {Validity: cmd.ConfigDuration{Duration: time.Hour}},
+		"extended": {
+			Validity:          cmd.ConfigDuration{Duration: time.Hour},
+			AllowedExtensions: []string{"1.3.3.7"},
+		},
+	})
+	test.AssertNotError(t, err, "makeProfiles failed")
+	test.AssertEquals(t, len(profiles), 2)
+	test.AssertEquals(t, profiles["shortlived"].validityPeriod, time.Hour)
+	test.AssertDeepEquals(t, profiles["shortlived"].allowedExtensions, allowedExtensions)
+	test.AssertDeepEquals(t, profiles["extended"].allowedExtensions, map[string]bool{"1.3.3.7": true})
+}
+
+func TestCheckCertProfiles(t *testing.T) {
+	testKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	checker := newChecker(nil, clock.NewFake(), pa, expectedValidityPeriod)
+	checker.profiles = map[string]certProfile{
+		"shortlived": {
+			name:              "shortlived",
+			validityPeriod:    7 * 24 * time.Hour,
+			allowedExtensions: allowedExtensions,
+		},
+	}
+
+	issued := time.Now()
+	makeCert := func(serial int64, validity time.Duration) core.Certificate {
+		template := &x509.Certificate{
+			Subject:               pkix.Name{CommonName: "example.com"},
+			DNSNames:              []string{"example.com"},
+			SerialNumber:          big.NewInt(serial),
+			NotBefore:             issued,
+			NotAfter:              issued.Add(validity),
+			BasicConstraintsValid: true,
+			ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+			ExtraExtensions: []pkix.Extension{
+				{Id: asn1.ObjectIdentifier{1, 3, 3, 7}, Value: []byte{0x05, 0x00}},
+			},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, testKey.Public(), testKey)
+		test.AssertNotError(t, err, "failed to create certificate")
+		return core.Certificate{
+			Serial:  core.SerialToString(big.NewInt(serial)),
+			DER:     der,
+			Digest:  core.Fingerprint256(der),
+			Issued:  issued,
+			Expires: issued.Add(validity),
+		}
+	}
+	profileProblems := func(problems []string) []string {
+		var filtered []string
+		for _, p := range problems {
+			if strings.Contains(p, "validity period") || strings.Contains(p, "unexpected extension") || strings.Contains(p, "profile") {
+				filtered = append(filtered, p)
+			}
+		}
+		sort.Strings(filtered)
+		return filtered
+	}
+
+	shortCert := makeCert(1, 7*24*time.Hour)
+	defaultCert := makeCert(2, expectedValidityPeriod)
+	unknownCert := makeCert(3, expectedValidityPeriod)
+	checker.serialProfiles = map[string]string{
+		shortCert.Serial:   "shortlived",
+		defaultCert.Serial: "shortlived",
+		unknownCert.Serial: "longlived",
+	}
+
+	test.AssertDeepEquals(t, profileProblems(checker.checkCert(shortCert, nil)), []string{
+		`Certificate contains an unexpected extension: 1.3.3.7 (profile "shortlived")`,
+	})
+	test.AssertDeepEquals(t, profileProblems(checker.checkCert(defaultCert, nil)), []string{
+		`Certificate contains an unexpected extension: 1.3.3.7 (profile "shortlived")`,
+		`Certificate has a validity period longer than 168h0m0s (profile "shortlived")`,
+	})
+	test.AssertDeepEquals(t, profileProblems(checker.checkCert(unknownCert, nil)), []string{
+		"Certificate contains an unexpected extension: 1.3.3.7",
+		`Certificate was issued under unknown profile "longlived"`,
+	})
+
+	// Without a recorded profile the default constraints apply
+	delete(checker.serialProfiles, shortCert.Serial)
+	test.AssertDeepEquals(t, profileProblems(checker.checkCert(shortCert, nil)), []string{
+		"Certificate contains an unexpected extension: 1.3.3.7",
+		"Certificate has a validity period shorter than 2160h0m0s",
+	})
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/probs"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+const usageIntro = `
+Introduction:
+
+cert-history prints, as JSON, everything the SA has stored about the
+certificate with the given hex serial: the certificate and precertificate, its
+OCSP and revocation status, the issuance profile it was issued under, and the
+order it was issued for along with that order's authorizations and their
+challenges. Orders and authorizations which have since expired are included.`
+
+type config struct {
+	CertHistory struct {
+		// The tool needs a TLSConfig to set up its gRPC client certs, but
+		// doesn't get the TLS field from ServiceConfig, so declares its own.
+		TLS cmd.TLSConfig
+
+		SAService *cmd.GRPCClientConfig
+
+		Features map[string]bool
+	}
+}
+
+// history is the document printed by cert-history. Times are included as
+// RFC 3339 timestamps rather than the nanoseconds used by the SA's protobufs.
+type history struct {
+	Serial         string                  `json:"serial"`
+	Certificate    *core.Certificate       `json:"certificate,omitempty"`
+	Precertificate *core.Certificate       `json:"precertificate,omitempty"`
+	Status         *core.CertificateStatus `json:"status,omitempty"`
+	ProfileName    string                  `json:"profileName,omitempty"`
+	Order          *order                  `json:"order,omitempty"`
+	Authorizations []core.Authorization    `json:"authorizations,omitempty"`
+}
+
+type order struct {
+	ID                int64                 `json:"id"`
+	RegistrationID    int64                 `json:"registrationID"`
+	Names             []string              `json:"names"`
+	Status            string                `json:"status"`
+	Created           time.Time             `json:"created"`
+	Expires           time.Time             `json:"expires"`
+	CertificateSerial string                `json:"certificateSerial,omitempty"`
+	ProfileName       string                `json:"profileName,omitempty"`
+	Authorizations    []int64               `json:"authorizations"`
+	Error             *probs.ProblemDetails `json:"error,omitempty"`
+}
+
+func pbToOrder(pb *corepb.Order) (*order, error) {
+	if pb.Id == nil || pb.RegistrationID == nil || pb.Expires == nil || pb.Created == nil || pb.Status == nil {
+		return nil, bgrpc.ErrMissingParameters
+	}
+	o := &order{
+		ID:             *pb.Id,
+		RegistrationID: *pb.RegistrationID,
+		Names:          pb.Names,
+		Status:         *pb.Status,
+		Created:        time.Unix(0, *pb.Created).UTC(),
+		Expires:        time.Unix(0, *pb.Expires).UTC(),
+		Authorizations: pb.V2Authorizations,
+	}
+	if pb.CertificateSerial != nil {
+		o.CertificateSerial = *pb.CertificateSerial
+	}
+	if pb.CertificateProfileName != nil {
+		o.ProfileName = *pb.CertificateProfileName
+	}
+	if pb.Error != nil {
+		prob, err := bgrpc.PBToProblemDetails(pb.Error)
+		if err != nil {
+			return nil, err
+		}
+		o.Error = prob
+	}
+	return o, nil
+}
+
+// pbToHistory converts the SA's CertificateHistory into the document printed
+// by cert-history.
+func pbToHistory(pb *sapb.CertificateHistory) (*history, error) {
+	h := &history{Serial: *pb.Serial}
+	if pb.Certificate != nil {
+		cert, err := bgrpc.PBToCert(pb.Certificate)
+		if err != nil {
+			return nil, err
+		}
+		h.Certificate = &cert
+	}
+	if pb.Precertificate != nil {
+		precert, err := bgrpc.PBToCert(pb.Precertificate)
+		if err != nil {
+			return nil, err
+		}
+		h.Precertificate = &precert
+	}
+	if pb.Status != nil {
+		status, err := bgrpc.PBToCertStatus(pb.Status)
+		if err != nil {
+			return nil, err
+		}
+		h.Status = &status
+	}
+	if pb.ProfileName != nil {
+		h.ProfileName = *pb.ProfileName
+	}
+	if pb.Order != nil {
+		o, err := pbToOrder(pb.Order)
+		if err != nil {
+			return nil, err
+		}
+		h.Order = o
+	}
+	for _, authzPB := range pb.Authorizations {
+		authz, err := bgrpc.PBToAuthz(authzPB)
+		if err != nil {
+			return nil, err
+		}
+		h.Authorizations = append(h.Authorizations, authz)
+	}
+	return h, nil
+}
+
+func main() {
+	configFile := flag.String("config", "", "File containing a JSON config.")
+	serial := flag.String("serial", "", "Hex serial of the certificate to export.")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageIntro)
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+	if *configFile == "" || (*serial == "" && !*validateConfig) {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, fmt.Sprintf("Reading %q", *configFile))
+	err = features.Set(c.CertHistory.Features)
+	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
+
+	tlsConfig, err := c.CertHistory.TLS.Load()
+	cmd.FailOnError(err, "TLS config")
+	clientMetrics := bgrpc.NewClientMetrics(metrics.NoopRegisterer)
+	saConn, err := bgrpc.ClientSetup(c.CertHistory.SAService, tlsConfig, clientMetrics, cmd.Clock())
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	sac := bgrpc.NewStorageAuthorityClient(sapb.NewStorageAuthorityClient(saConn))
+
+	resp, err := sac.GetCertificateHistory(context.Background(), &sapb.Serial{Serial: serial})
+	cmd.FailOnError(err, fmt.Sprintf("Failed to get history of certificate %q", *serial))
+	h, err := pbToHistory(resp)
+	cmd.FailOnError(err, "Failed to convert certificate history")
+
+	out, err := json.MarshalIndent(h, "", "  ")
+	cmd.FailOnError(err, "Failed to marshal certificate history")
+	fmt.Println(string(out))
+}
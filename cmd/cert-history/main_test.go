@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/identifier"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestPBToHistory(t *testing.T) {
+	serial := "000000000000000000000000000000000001"
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	cert := core.Certificate{
+		RegistrationID: 1,
+		Serial:         serial,
+		DER:            []byte{1, 2, 3},
+		Issued:         now,
+		Expires:        now.Add(90 * 24 * time.Hour),
+	}
+	status := core.CertificateStatus{
+		Serial:       serial,
+		Status:       core.OCSPStatusRevoked,
+		RevokedDate:  now.Add(time.Hour),
+		OCSPResponse: []byte{4, 5, 6},
+	}
+	expires := now.Add(24 * time.Hour)
+	authzPB, err := bgrpc.AuthzToPB(core.Authorization{
+		ID:             "1",
+		Identifier:     identifier.DNSIdentifier("example.com"),
+		RegistrationID: 1,
+		Status:         core.StatusValid,
+		Expires:        &expires,
+		Challenges: []core.Challenge{{
+			Type:   core.ChallengeTypeHTTP01,
+			Status: core.StatusValid,
+			Token:  "token",
+		}},
+	})
+	test.AssertNotError(t, err, "AuthzToPB failed")
+
+	orderID := int64(2)
+	regID := int64(1)
+	created := now.UnixNano()
+	orderExpires := expires.UnixNano()
+	orderStatus := string(core.StatusValid)
+	profileName := "default"
+	pb := &sapb.CertificateHistory{
+		Serial:         &serial,
+		Certificate:    bgrpc.CertToPB(cert),
+		Status:         bgrpc.CertStatusToPB(status),
+		ProfileName:    &profileName,
+		Authorizations: []*corepb.Authorization{authzPB},
+		Order: &corepb.Order{
+			Id:                &orderID,
+			RegistrationID:    &regID,
+			Created:           &created,
+			Expires:           &orderExpires,
+			Status:            &orderStatus,
+			Names:             []string{"example.com"},
+			CertificateSerial: &serial,
+			V2Authorizations:  []int64{1},
+		},
+	}
+
+	h, err := pbToHistory(pb)
+	test.AssertNotError(t, err, "pbToHistory failed")
+	test.AssertEquals(t, h.Serial, serial)
+	test.AssertByteEquals(t, h.Certificate.DER, cert.DER)
+	test.Assert(t, h.Precertificate == nil, "unexpected precertificate")
+	test.AssertEquals(t, h.Status.Status, core.OCSPStatusRevoked)
+	test.Assert(t, h.Status.RevokedDate.Equal(status.RevokedDate), "wrong revocation date")
+	test.AssertEquals(t, h.ProfileName, profileName)
+	test.AssertEquals(t, h.Order.ID, orderID)
+	test.AssertEquals(t, h.Order.Created, now)
+	test.AssertEquals(t, h.Order.Expires, expires)
+	test.AssertEquals(t, h.Order.CertificateSerial, serial)
+	test.AssertEquals(t, len(h.Authorizations), 1)
+	test.AssertEquals(t, h.Authorizations[0].Challenges[0].Type, core.ChallengeTypeHTTP01)
+
+	// Orders missing required fields are rejected.
+	pb.Order.Created = nil
+	_, err = pbToHistory(pb)
+	test.AssertError(t, err, "pbToHistory didn't fail for an incomplete order")
+}
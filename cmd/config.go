@@ -33,6 +33,14 @@ func (pc *PasswordConfig) Pass() (string, error) {
 	return pc.Password, nil
 }
 
+// Validate checks that the password file, if any, can be read.
+func (pc *PasswordConfig) Validate() error {
+	if pc.PasswordFile != "" {
+		return checkConfigFile("PasswordFile", pc.PasswordFile)
+	}
+	return nil
+}
+
 // ServiceConfig contains config items that are common to all our services, to
 // be embedded in other config structs.
 type ServiceConfig struct {
@@ -42,6 +50,18 @@ type ServiceConfig struct {
 	TLS       TLSConfig
 }
 
+// Validate checks that the debug address is a host and port, and that TLS is
+// configured if a gRPC server is.
+func (sc *ServiceConfig) Validate() error {
+	if err := checkAddress("DebugAddr", sc.DebugAddr); err != nil {
+		return err
+	}
+	if sc.GRPC != nil && (sc.TLS.CertFile == nil || sc.TLS.KeyFile == nil || sc.TLS.CACertFile == nil) {
+		return errors.New("TLS is required by GRPC")
+	}
+	return nil
+}
+
 // DBConfig defines how to connect to a database. The connect string may be
 // stored in a file separate from the config, because it can contain a password,
 // which we want to keep out of configs.
@@ -63,6 +83,22 @@ func (d *DBConfig) URL() (string, error) {
 	return d.DBConnect, nil
 }
 
+// Validate checks that at most one of DBConnect and DBConnectFile is set, that
+// the file, if any, can be read, and that MaxDBConns isn't negative. Neither
+// is required since some configs embed a DBConfig they don't use.
+func (d *DBConfig) Validate() error {
+	if d.DBConnect != "" && d.DBConnectFile != "" {
+		return errors.New("only one of DBConnect and DBConnectFile may be set")
+	}
+	if d.MaxDBConns < 0 {
+		return fmt.Errorf("MaxDBConns must not be negative, got %d", d.MaxDBConns)
+	}
+	if d.DBConnectFile != "" {
+		return checkConfigFile("DBConnectFile", d.DBConnectFile)
+	}
+	return nil
+}
+
 type SMTPConfig struct {
 	PasswordConfig
 	Server   string
@@ -78,12 +114,26 @@ type PAConfig struct {
 	Challenges map[string]bool
 }
 
+// Validate checks the challenges enabled by the PAConfig, see
+// CheckChallenges.
+func (pc *PAConfig) Validate() error {
+	return pc.CheckChallenges()
+}
+
 // HostnamePolicyConfig specifies a file from which to load a policy regarding
 // what hostnames to issue for.
 type HostnamePolicyConfig struct {
 	HostnamePolicyFile string
 }
 
+// Validate checks that the hostname policy file is set and can be read.
+func (hpc *HostnamePolicyConfig) Validate() error {
+	if hpc.HostnamePolicyFile == "" {
+		return errors.New("HostnamePolicyFile is required")
+	}
+	return checkConfigFile("HostnamePolicyFile", hpc.HostnamePolicyFile)
+}
+
 // CheckChallenges checks whether the list of challenges in the PA config
 // actually contains valid challenge names
 func (pc PAConfig) CheckChallenges() error {
@@ -141,6 +191,17 @@ func (t *TLSConfig) Load() (*tls.Config, error) {
 	}, nil
 }
 
+// Validate checks that the certificates and key listed in the TLSConfig can be
+// loaded. A TLSConfig with none of them set is valid, since configs which
+// don't use TLS leave it empty; ServiceConfig checks it's set when needed.
+func (t *TLSConfig) Validate() error {
+	if t.CertFile == nil && t.KeyFile == nil && t.CACertFile == nil {
+		return nil
+	}
+	_, err := t.Load()
+	return err
+}
+
 // RPCServerConfig contains configuration particular to a specific RPC server
 // type (e.g. RA, SA, etc)
 type RPCServerConfig struct {
@@ -152,6 +213,28 @@ type RPCServerConfig struct {
 type SyslogConfig struct {
 	StdoutLevel int
 	SyslogLevel int
+	// SuppressRepeats collapses identical consecutive log messages logged
+	// within RepeatWindow of each other into a "last message repeated N times"
+	// summary.
+	SuppressRepeats bool
+	// RepeatWindow is the window used by SuppressRepeats. Defaults to five
+	// seconds.
+	RepeatWindow ConfigDuration
+}
+
+// Validate checks that the log levels are syslog levels, or -1 to disable
+// logging, and that RepeatWindow isn't negative.
+func (sc *SyslogConfig) Validate() error {
+	if err := checkLogLevel("StdoutLevel", sc.StdoutLevel, -1); err != nil {
+		return err
+	}
+	if err := checkLogLevel("SyslogLevel", sc.SyslogLevel, -1); err != nil {
+		return err
+	}
+	if sc.RepeatWindow.Duration < 0 {
+		return fmt.Errorf("RepeatWindow must not be negative, got %s", sc.RepeatWindow)
+	}
+	return nil
 }
 
 // ConfigDuration is just an alias for time.Duration that allows
@@ -206,6 +289,22 @@ func (d *ConfigDuration) UnmarshalYAML(unmarshal func(interface{}) error) error
 type GRPCClientConfig struct {
 	ServerAddress string
 	Timeout       ConfigDuration
+	// LogLevel is the syslog level (e.g. 6 for info, 7 for debug) at which the
+	// start and end of each RPC made by the client are logged. Zero, the
+	// default, disables this logging.
+	LogLevel int
+}
+
+// Validate checks that the server address is set, that the timeout isn't
+// negative and that the log level is a syslog level, or zero.
+func (c *GRPCClientConfig) Validate() error {
+	if c.ServerAddress == "" {
+		return errors.New("ServerAddress is required")
+	}
+	if c.Timeout.Duration < 0 {
+		return fmt.Errorf("Timeout must not be negative, got %s", c.Timeout)
+	}
+	return checkLogLevel("LogLevel", c.LogLevel, 0)
 }
 
 // GRPCServerConfig contains the information needed to run a gRPC service
@@ -215,6 +314,22 @@ type GRPCServerConfig struct {
 	// (SANs). The server will reject clients that do not present a certificate
 	// with a SAN present on the `ClientNames` list.
 	ClientNames []string `json:"clientNames"`
+	// LogLevel is the syslog level (e.g. 6 for info, 7 for debug) at which the
+	// start and end of each RPC handled by the server are logged. Zero, the
+	// default, disables this logging.
+	LogLevel int `json:"logLevel"`
+}
+
+// Validate checks that the address is set to a host and port, and that the
+// log level is a syslog level, or zero.
+func (c *GRPCServerConfig) Validate() error {
+	if c.Address == "" {
+		return errors.New("address is required")
+	}
+	if err := checkAddress("address", c.Address); err != nil {
+		return err
+	}
+	return checkLogLevel("logLevel", c.LogLevel, 0)
 }
 
 // PortConfig specifies what ports the VA should call to on the remote
@@ -22,6 +22,7 @@ import (
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/db"
+	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/features"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
 	blog "github.com/letsencrypt/boulder/log"
@@ -39,6 +40,8 @@ const (
 
 type regStore interface {
 	GetRegistration(context.Context, int64) (core.Registration, error)
+	GetNotificationPreferences(context.Context, *sapb.RegistrationID) (*sapb.NotificationPreferences, error)
+	ReplacementCertificateExists(context.Context, *sapb.ReplacementCertificateExistsRequest) (*sapb.Exists, error)
 }
 
 type mailer struct {
@@ -49,15 +52,21 @@ type mailer struct {
 	emailTemplate   *template.Template
 	subjectTemplate *template.Template
 	nagTimes        []time.Duration
-	limit           int
-	clk             clock.Clock
-	stats           mailerStats
+	// accountNagTimes are additional lead times which are searched for
+	// expiring certificates, but only nagged about for accounts whose
+	// notification preferences include them.
+	accountNagTimes  []time.Duration
+	nagCheckInterval time.Duration
+	limit            int
+	clk              clock.Clock
+	stats            mailerStats
 }
 
 type mailerStats struct {
 	nagsAtCapacity    *prometheus.GaugeVec
 	errorCount        *prometheus.CounterVec
 	renewalCount      *prometheus.CounterVec
+	replacedCount     *prometheus.CounterVec
 	sendLatency       prometheus.Histogram
 	processingLatency prometheus.Histogram
 }
@@ -172,7 +181,86 @@ func (m *mailer) certIsRenewed(serial string) (renewed bool, err error) {
 	return present == 1, err
 }
 
-func (m *mailer) processCerts(allCerts []core.Certificate) {
+// certIsReplaced returns true if the account that was issued cert has since
+// been issued a certificate, still unexpired and unrevoked, for all of its
+// names. This catches renewals which certIsRenewed doesn't, because they add
+// names to the certificate.
+func (m *mailer) certIsReplaced(ctx context.Context, cert core.Certificate, parsed *x509.Certificate) (bool, error) {
+	if !features.Enabled(features.SkipReplacedCertNags) || len(parsed.DNSNames) == 0 {
+		return false, nil
+	}
+	issuedAfter := cert.Issued.UnixNano()
+	validAt := m.clk.Now().UnixNano()
+	resp, err := m.rs.ReplacementCertificateExists(ctx, &sapb.ReplacementCertificateExistsRequest{
+		RegistrationID: &cert.RegistrationID,
+		Domains:        parsed.DNSNames,
+		IssuedAfter:    &issuedAfter,
+		ValidAt:        &validAt,
+	})
+	if err != nil {
+		return false, err
+	}
+	if *resp.Exists {
+		m.log.Debugf("Cert %s is already replaced", cert.Serial)
+	}
+	return *resp.Exists, nil
+}
+
+// nagWindow is the range of lead times, (start, end], before expiry that a
+// batch of certificates was found in.
+type nagWindow struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// nagSchedule returns the lead times before expiry at which the owner of regID
+// should be nagged. The mailer's default nag times are used unless the
+// NotificationPreferences feature is enabled and the account has preferences
+// which specify otherwise. An account which has disabled notifications has an
+// empty schedule.
+func (m *mailer) nagSchedule(ctx context.Context, regID int64) ([]time.Duration, error) {
+	if !features.Enabled(features.NotificationPreferences) {
+		return m.nagTimes, nil
+	}
+	prefs, err := m.rs.GetNotificationPreferences(ctx, &sapb.RegistrationID{Id: &regID})
+	if err != nil {
+		if berrors.Is(err, berrors.NotFound) {
+			return m.nagTimes, nil
+		}
+		return nil, err
+	}
+	if prefs.Disabled != nil && *prefs.Disabled {
+		return nil, nil
+	}
+	if len(prefs.NagTimes) == 0 {
+		return m.nagTimes, nil
+	}
+	schedule := make([]time.Duration, len(prefs.NagTimes))
+	for i, nagTime := range prefs.NagTimes {
+		schedule[i] = time.Duration(nagTime) + m.nagCheckInterval
+	}
+	return schedule, nil
+}
+
+// nagDue checks a certificate expiring in expiresIn, found in window, against
+// an account's nag schedule. It returns scheduled if the schedule has a lead
+// time within the window, and due if the certificate's expiry is also within
+// that lead time. A scheduled certificate that isn't due yet will be due on a
+// later run without leaving the window, so it must not be marked as nagged.
+func nagDue(schedule []time.Duration, window nagWindow, expiresIn time.Duration) (scheduled, due bool) {
+	for _, nagTime := range schedule {
+		if nagTime <= window.start || nagTime > window.end {
+			continue
+		}
+		scheduled = true
+		if expiresIn <= nagTime {
+			return true, true
+		}
+	}
+	return scheduled, false
+}
+
+func (m *mailer) processCerts(allCerts []core.Certificate, window nagWindow) {
 	ctx := context.Background()
 
 	regIDToCerts := make(map[int64][]core.Certificate)
@@ -200,6 +288,13 @@ func (m *mailer) processCerts(allCerts []core.Certificate) {
 			continue
 		}
 
+		schedule, err := m.nagSchedule(ctx, regID)
+		if err != nil {
+			m.log.AuditErrf("Error fetching notification preferences for registration %d: %s", regID, err)
+			m.stats.errorCount.With(prometheus.Labels{"type": "GetNotificationPreferences"}).Inc()
+			continue
+		}
+
 		parsedCerts := []*x509.Certificate{}
 		for _, cert := range certs {
 			parsedCert, err := x509.ParseCertificate(cert.DER)
@@ -223,6 +318,35 @@ func (m *mailer) processCerts(allCerts []core.Certificate) {
 				continue
 			}
 
+			replaced, err := m.certIsReplaced(ctx, cert, parsedCert)
+			if err != nil {
+				m.log.AuditErrf("expiration-mailer: error checking for a replacement of %s: %v", cert.Serial, err)
+				m.stats.errorCount.With(prometheus.Labels{"type": "ReplacementCertificateExists"}).Inc()
+				// assume not replaced
+			} else if replaced {
+				m.stats.replacedCount.With(prometheus.Labels{}).Inc()
+				if err := m.updateCertStatus(cert.Serial); err != nil {
+					m.log.AuditErrf("Error updating certificate status for %s: %s", cert.Serial, err)
+					m.stats.errorCount.With(prometheus.Labels{"type": "UpdateCertificateStatus"}).Inc()
+				}
+				continue
+			}
+
+			scheduled, due := nagDue(schedule, window, parsedCert.NotAfter.Sub(m.clk.Now()))
+			if !scheduled {
+				// The account doesn't want to be nagged in this window. Record the
+				// certificate as handled so that it isn't selected again until it
+				// reaches the next window.
+				if err := m.updateCertStatus(cert.Serial); err != nil {
+					m.log.AuditErrf("Error updating certificate status for %s: %s", cert.Serial, err)
+					m.stats.errorCount.With(prometheus.Labels{"type": "UpdateCertificateStatus"}).Inc()
+				}
+				continue
+			}
+			if !due {
+				continue
+			}
+
 			parsedCerts = append(parsedCerts, parsedCert)
 		}
 
@@ -253,14 +377,33 @@ func (m *mailer) processCerts(allCerts []core.Certificate) {
 	}
 }
 
+// searchTimes returns the sorted, de-duplicated union of the default and
+// account nag times. Each is the end of a window that expiring certificates are
+// searched for in.
+func (m *mailer) searchTimes() []time.Duration {
+	seen := map[time.Duration]bool{}
+	var times durationSlice
+	for _, nagTime := range append(append([]time.Duration{}, m.nagTimes...), m.accountNagTimes...) {
+		if seen[nagTime] {
+			continue
+		}
+		seen[nagTime] = true
+		times = append(times, nagTime)
+	}
+	sort.Sort(times)
+	return times
+}
+
 func (m *mailer) findExpiringCertificates() error {
 	now := m.clk.Now()
-	// E.g. m.nagTimes = [2, 4, 8, 15] days from expiration
-	for i, expiresIn := range m.nagTimes {
-		left := now
+	searchTimes := m.searchTimes()
+	// E.g. searchTimes = [2, 4, 8, 15] days from expiration
+	for i, expiresIn := range searchTimes {
+		window := nagWindow{end: expiresIn}
 		if i > 0 {
-			left = left.Add(m.nagTimes[i-1])
+			window.start = searchTimes[i-1]
 		}
+		left := now.Add(window.start)
 		right := now.Add(expiresIn)
 
 		m.log.Infof("expiration-mailer: Searching for certificates that expire between %s and %s and had last nag >%s before expiry",
@@ -337,7 +480,7 @@ func (m *mailer) findExpiringCertificates() error {
 		}
 
 		processingStarted := m.clk.Now()
-		m.processCerts(certs)
+		m.processCerts(certs, window)
 		processingEnded := m.clk.Now()
 		elapsed := processingEnded.Sub(processingStarted)
 		m.stats.processingLatency.Observe(elapsed.Seconds())
@@ -371,6 +514,12 @@ type config struct {
 
 		CertLimit int
 		NagTimes  []string
+		// AccountNagTimes are lead times, in addition to NagTimes, that accounts
+		// may choose in their notification preferences. Certificates are searched
+		// for at each of them but only accounts which chose them are nagged. An
+		// account's nag time which falls between two searched times is honored
+		// as long as no other nag time of the account falls between the same two.
+		AccountNagTimes []string
 		// How much earlier (than configured nag intervals) to
 		// send reminders, to account for the expected delay
 		// before the next expiration-mailer invocation.
@@ -418,6 +567,14 @@ func initStats(stats prometheus.Registerer) mailerStats {
 		nil)
 	stats.MustRegister(renewalCount)
 
+	replacedCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "replaced",
+			Help: "Number of messages skipped because the account has a newer certificate for a superset of the names",
+		},
+		nil)
+	stats.MustRegister(replacedCount)
+
 	sendLatency := prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "send_latency",
@@ -437,6 +594,7 @@ func initStats(stats prometheus.Registerer) mailerStats {
 		nagsAtCapacity:    nagsAtCapacity,
 		errorCount:        errorCount,
 		renewalCount:      renewalCount,
+		replacedCount:     replacedCount,
 		sendLatency:       sendLatency,
 		processingLatency: processingLatency,
 	}
@@ -444,6 +602,7 @@ func initStats(stats prometheus.Registerer) mailerStats {
 
 func main() {
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	certLimit := flag.Int("cert_limit", 0, "Count of certificates to process per expiration period")
 	reconnBase := flag.Duration("reconnectBase", 1*time.Second, "Base sleep duration between reconnect attempts")
 	reconnMax := flag.Duration("reconnectMax", 5*60*time.Second, "Max sleep duration between reconnect attempts after exponential backoff")
@@ -461,6 +620,9 @@ func main() {
 	cmd.FailOnError(err, "Reading JSON config file into config structure")
 	err = features.Set(c.Mailer.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	scope, logger := cmd.StatsAndLogging(c.Syslog, c.Mailer.DebugAddr)
 	defer logger.AuditPanic()
@@ -556,23 +718,35 @@ func main() {
 	// Make sure durations are sorted in increasing order
 	sort.Sort(nags)
 
+	var accountNags durationSlice
+	for _, nagDuration := range c.Mailer.AccountNagTimes {
+		dur, err := time.ParseDuration(nagDuration)
+		if err != nil {
+			logger.AuditErrf("Failed to parse account nag duration string [%s]: %s", nagDuration, err)
+			return
+		}
+		accountNags = append(accountNags, dur+nagCheckInterval)
+	}
+
 	m := mailer{
-		log:             logger,
-		dbMap:           dbMap,
-		rs:              sac,
-		mailer:          mailClient,
-		subjectTemplate: subjTmpl,
-		emailTemplate:   tmpl,
-		nagTimes:        nags,
-		limit:           c.Mailer.CertLimit,
-		clk:             clk,
-		stats:           initStats(scope),
+		log:              logger,
+		dbMap:            dbMap,
+		rs:               sac,
+		mailer:           mailClient,
+		subjectTemplate:  subjTmpl,
+		emailTemplate:    tmpl,
+		nagTimes:         nags,
+		accountNagTimes:  accountNags,
+		nagCheckInterval: nagCheckInterval,
+		limit:            c.Mailer.CertLimit,
+		clk:              clk,
+		stats:            initStats(scope),
 	}
 
 	// Prefill this labelled stat with the possible label values, so each value is
 	// set to 0 on startup, rather than being missing from stats collection until
 	// the first mail run.
-	for _, expiresIn := range nags {
+	for _, expiresIn := range m.searchTimes() {
 		m.stats.nagsAtCapacity.With(prometheus.Labels{"nag_group": expiresIn.String()}).Set(0)
 	}
 
@@ -20,10 +20,12 @@ import (
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/db"
 	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/features"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/mocks"
 	"github.com/letsencrypt/boulder/sa"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
 	"github.com/letsencrypt/boulder/sa/satest"
 	"github.com/letsencrypt/boulder/test"
 	"github.com/letsencrypt/boulder/test/vars"
@@ -44,7 +46,11 @@ func intFromB64(b64 string) int {
 }
 
 type fakeRegStore struct {
-	RegByID map[int64]core.Registration
+	RegByID   map[int64]core.Registration
+	PrefsByID map[int64]*sapb.NotificationPreferences
+	// Replaced maps registration IDs to the names of the newest certificate
+	// issued to them.
+	Replaced map[int64][]string
 }
 
 func (f fakeRegStore) GetRegistration(ctx context.Context, id int64) (core.Registration, error) {
@@ -55,8 +61,32 @@ func (f fakeRegStore) GetRegistration(ctx context.Context, id int64) (core.Regis
 	return r, nil
 }
 
+func (f fakeRegStore) GetNotificationPreferences(ctx context.Context, req *sapb.RegistrationID) (*sapb.NotificationPreferences, error) {
+	prefs, ok := f.PrefsByID[*req.Id]
+	if !ok {
+		return nil, berrors.NotFoundError("no notification preferences found for %d", *req.Id)
+	}
+	return prefs, nil
+}
+
+func (f fakeRegStore) ReplacementCertificateExists(ctx context.Context, req *sapb.ReplacementCertificateExistsRequest) (*sapb.Exists, error) {
+	names := map[string]bool{}
+	for _, name := range f.Replaced[*req.RegistrationID] {
+		names[name] = true
+	}
+	exists := len(names) > 0
+	for _, domain := range req.Domains {
+		exists = exists && names[domain]
+	}
+	return &sapb.Exists{Exists: &exists}, nil
+}
+
 func newFakeRegStore() fakeRegStore {
-	return fakeRegStore{RegByID: make(map[int64]core.Registration)}
+	return fakeRegStore{
+		RegByID:   make(map[int64]core.Registration),
+		PrefsByID: make(map[int64]*sapb.NotificationPreferences),
+		Replaced:  make(map[int64][]string),
+	}
 }
 
 func newFakeClock(t *testing.T) clock.FakeClock {
@@ -193,7 +223,7 @@ func TestProcessCerts(t *testing.T) {
 
 	certs := addExpiringCerts(t, testCtx)
 	log.Clear()
-	testCtx.m.processCerts(certs)
+	testCtx.m.processCerts(certs, nagWindow{end: testCtx.m.nagTimes[0]})
 	// Test that the lastExpirationNagSent was updated for the certificate
 	// corresponding to serial4, which is set up as "already renewed" by
 	// addExpiringCerts.
@@ -203,6 +233,149 @@ func TestProcessCerts(t *testing.T) {
 	}
 }
 
+func TestNagDue(t *testing.T) {
+	day := 24 * time.Hour
+	schedule := []time.Duration{30 * day, 7 * day, day}
+	testCases := []struct {
+		name      string
+		window    nagWindow
+		expiresIn time.Duration
+		scheduled bool
+		due       bool
+	}{
+		{
+			name:      "nag time is window end",
+			window:    nagWindow{start: 4 * day, end: 7 * day},
+			expiresIn: 5 * day,
+			scheduled: true,
+			due:       true,
+		},
+		{
+			name:      "nag time inside window, due",
+			window:    nagWindow{start: 20 * day, end: 40 * day},
+			expiresIn: 25 * day,
+			scheduled: true,
+			due:       true,
+		},
+		{
+			name:      "nag time inside window, not yet due",
+			window:    nagWindow{start: 20 * day, end: 40 * day},
+			expiresIn: 35 * day,
+			scheduled: true,
+			due:       false,
+		},
+		{
+			name:      "no nag time in window",
+			window:    nagWindow{start: 8 * day, end: 20 * day},
+			expiresIn: 10 * day,
+			scheduled: false,
+			due:       false,
+		},
+		{
+			name:      "nag time is window start",
+			window:    nagWindow{start: day, end: 4 * day},
+			expiresIn: 2 * day,
+			scheduled: false,
+			due:       false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheduled, due := nagDue(schedule, tc.window, tc.expiresIn)
+			test.AssertEquals(t, scheduled, tc.scheduled)
+			test.AssertEquals(t, due, tc.due)
+		})
+	}
+
+	scheduled, due := nagDue(nil, nagWindow{end: 7 * day}, day)
+	test.Assert(t, !scheduled && !due, "empty schedule was scheduled")
+}
+
+func TestNagSchedule(t *testing.T) {
+	rs := newFakeRegStore()
+	disabled := true
+	enabled := false
+	rs.PrefsByID[2] = &sapb.NotificationPreferences{Disabled: &disabled}
+	rs.PrefsByID[3] = &sapb.NotificationPreferences{Disabled: &enabled, NagTimes: []int64{int64(48 * time.Hour)}}
+	rs.PrefsByID[4] = &sapb.NotificationPreferences{Disabled: &enabled}
+	defaults := []time.Duration{24*time.Hour + time.Minute}
+	m := mailer{
+		rs:               rs,
+		nagTimes:         defaults,
+		nagCheckInterval: time.Minute,
+	}
+
+	// Without the feature enabled preferences are ignored
+	schedule, err := m.nagSchedule(ctx, 2)
+	test.AssertNotError(t, err, "nagSchedule failed")
+	test.AssertDeepEquals(t, schedule, defaults)
+
+	_ = features.Set(map[string]bool{"NotificationPreferences": true})
+	defer features.Reset()
+
+	schedule, err = m.nagSchedule(ctx, 1)
+	test.AssertNotError(t, err, "nagSchedule failed")
+	test.AssertDeepEquals(t, schedule, defaults)
+
+	schedule, err = m.nagSchedule(ctx, 2)
+	test.AssertNotError(t, err, "nagSchedule failed")
+	test.AssertEquals(t, len(schedule), 0)
+
+	schedule, err = m.nagSchedule(ctx, 3)
+	test.AssertNotError(t, err, "nagSchedule failed")
+	test.AssertDeepEquals(t, schedule, []time.Duration{48*time.Hour + time.Minute})
+
+	schedule, err = m.nagSchedule(ctx, 4)
+	test.AssertNotError(t, err, "nagSchedule failed")
+	test.AssertDeepEquals(t, schedule, defaults)
+}
+
+func TestCertIsReplaced(t *testing.T) {
+	rs := newFakeRegStore()
+	rs.Replaced[1] = []string{"example.com", "www.example.com", "new.example.com"}
+	m := mailer{
+		rs:  rs,
+		log: log,
+		clk: newFakeClock(t),
+	}
+	cert := core.Certificate{Serial: "000000000000000000000000000000001337", RegistrationID: 1}
+	parsed := &x509.Certificate{DNSNames: []string{"example.com", "www.example.com"}}
+
+	// Without the feature enabled replacements aren't looked for
+	replaced, err := m.certIsReplaced(ctx, cert, parsed)
+	test.AssertNotError(t, err, "certIsReplaced failed")
+	test.Assert(t, !replaced, "certificate replaced with the feature disabled")
+
+	_ = features.Set(map[string]bool{"SkipReplacedCertNags": true})
+	defer features.Reset()
+
+	// The newer certificate has a superset of the names
+	replaced, err = m.certIsReplaced(ctx, cert, parsed)
+	test.AssertNotError(t, err, "certIsReplaced failed")
+	test.Assert(t, replaced, "certificate not replaced by a superset of its names")
+
+	// But not of these
+	parsed.DNSNames = append(parsed.DNSNames, "old.example.com")
+	replaced, err = m.certIsReplaced(ctx, cert, parsed)
+	test.AssertNotError(t, err, "certIsReplaced failed")
+	test.Assert(t, !replaced, "certificate replaced by a subset of its names")
+
+	// Nor is a newer certificate for another account a replacement
+	cert.RegistrationID = 2
+	parsed.DNSNames = []string{"example.com"}
+	replaced, err = m.certIsReplaced(ctx, cert, parsed)
+	test.AssertNotError(t, err, "certIsReplaced failed")
+	test.Assert(t, !replaced, "certificate replaced by another account's certificate")
+}
+
+func TestSearchTimes(t *testing.T) {
+	m := mailer{
+		nagTimes:        []time.Duration{time.Hour, 3 * time.Hour},
+		accountNagTimes: []time.Duration{4 * time.Hour, time.Hour, 2 * time.Hour},
+	}
+	test.AssertDeepEquals(t, m.searchTimes(), []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour})
+}
+
 func TestFindExpiringCertificates(t *testing.T) {
 	testCtx := setup(t, []time.Duration{time.Hour * 24, time.Hour * 24 * 4, time.Hour * 24 * 7})
 
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
-	"io/ioutil"
 	"time"
 
 	"github.com/jmhodges/clock"
@@ -53,15 +51,17 @@ func deleteExpired(clk clock.Clock, gracePeriod time.Duration, batchSize int, db
 func main() {
 	singleRun := flag.Bool("single-run", false, "Exit after running first delete query instead of running indefinitely")
 	configPath := flag.String("config", "config.json", "Path to Boulder configuration file")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 
-	configJSON, err := ioutil.ReadFile(*configPath)
-	cmd.FailOnError(err, "Failed to read config file")
 	var c config
-	err = json.Unmarshal(configJSON, &c)
-	cmd.FailOnError(err, "Failed to parse config file")
+	err := cmd.ReadConfigFile(*configPath, &c)
+	cmd.FailOnError(err, "Failed to read config file")
 	err = features.Set(c.ExpiredAuthzPurger2.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	var logger blog.Logger
 	if c.ExpiredAuthzPurger2.DebugAddr != "" {
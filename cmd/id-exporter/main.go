@@ -151,6 +151,7 @@ func main() {
 		}
 	}
 	configFile := flag.String("config", "", "File containing a JSON config.")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageIntro)
@@ -159,20 +160,21 @@ func main() {
 	}
 
 	flag.Parse()
-	if *outFile == "" || *configFile == "" {
+	if *configFile == "" || (*outFile == "" && !*validateConfig) {
 		flag.Usage()
 		os.Exit(1)
 	}
 
 	log := cmd.NewLogger(cmd.SyslogConfig{StdoutLevel: 7})
 
-	configData, err := ioutil.ReadFile(*configFile)
-	cmd.FailOnError(err, fmt.Sprintf("Reading %q", *configFile))
 	var cfg config
-	err = json.Unmarshal(configData, &cfg)
-	cmd.FailOnError(err, "Unmarshaling config")
+	err := cmd.ReadConfigFile(*configFile, &cfg)
+	cmd.FailOnError(err, fmt.Sprintf("Reading %q", *configFile))
 	err = features.Set(cfg.ContactExporter.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&cfg)
+	}
 
 	dbURL, err := cfg.ContactExporter.DBConfig.URL()
 	cmd.FailOnError(err, "Couldn't load DB URL")
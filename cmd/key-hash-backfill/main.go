@@ -4,10 +4,8 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"database/sql"
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"time"
 
 	"github.com/letsencrypt/boulder/cmd"
@@ -106,14 +104,16 @@ func main() {
 		Syslog cmd.SyslogConfig
 	}
 	configFile := flag.String("config", "", "File containing a JSON config.")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	initialID := flag.Int("initial-id", 0, "Initial certificate ID to start from")
 	batchSize := flag.Int("batch-size", 1000, "Number of certificates to fetch per batch")
 	flag.Parse()
 
-	configBytes, err := ioutil.ReadFile(*configFile)
+	err := cmd.ReadConfigFile(*configFile, &config)
 	cmd.FailOnError(err, fmt.Sprintf("Reading %q", *configFile))
-	err = json.Unmarshal(configBytes, &config)
-	cmd.FailOnError(err, "Unmarshaling config")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&config)
+	}
 
 	logger := cmd.NewLogger(config.Syslog)
 	defer logger.AuditPanic()
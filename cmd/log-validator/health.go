@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+)
+
+// fileHealth describes the state of a single file in a health response.
+type fileHealth struct {
+	Exists  bool
+	Tailing bool
+	// LastValidLine is when the most recent valid line was read from the file,
+	// omitted if none has been read since startup.
+	LastValidLine *time.Time `json:",omitempty"`
+}
+
+// healthResponse is the body of a response from healthHandler.
+type healthResponse struct {
+	Healthy bool
+	// Problems explains why Healthy is false.
+	Problems []string `json:",omitempty"`
+	Files    map[string]fileHealth
+}
+
+// healthHandler reports whether a tailer is making progress. It is healthy if
+// every file matching the tailer's patterns is either being tailed or doesn't
+// exist, and, if staleAfter is non-zero, a valid line has been read from some
+// file within the last staleAfter. A newly created file matching a glob pattern
+// is reported as not being tailed until the tailer's next rescan.
+type healthHandler struct {
+	t          *tailer
+	staleAfter time.Duration
+}
+
+// check inspects the tailer and returns its current health.
+func (h healthHandler) check() healthResponse {
+	resp := healthResponse{Files: make(map[string]fileHealth)}
+	h.t.Lock()
+	paths, err := expand(h.t.patterns)
+	if err != nil {
+		resp.Problems = append(resp.Problems, "expanding file patterns: "+err.Error())
+	}
+	for _, path := range paths {
+		var fh fileHealth
+		if tl, ok := h.t.tails[path]; ok {
+			select {
+			case <-tl.Dying():
+			default:
+				fh.Tailing = true
+			}
+		}
+		_, err := os.Stat(path)
+		fh.Exists = err == nil
+		if fh.Exists && !fh.Tailing {
+			resp.Problems = append(resp.Problems, path+" exists but is not being tailed")
+		}
+		resp.Files[path] = fh
+	}
+	h.t.Unlock()
+
+	lastValid := h.t.started
+	h.t.countsMu.Lock()
+	for filename, c := range h.t.counts {
+		if c.lastValid.IsZero() {
+			continue
+		}
+		if c.lastValid.After(lastValid) {
+			lastValid = c.lastValid
+		}
+		if fh, ok := resp.Files[filename]; ok {
+			last := c.lastValid
+			fh.LastValidLine = &last
+			resp.Files[filename] = fh
+		}
+	}
+	h.t.countsMu.Unlock()
+	if h.staleAfter != 0 && h.t.clk.Since(lastValid) > h.staleAfter {
+		resp.Problems = append(resp.Problems, "no valid lines read since "+lastValid.Format(time.RFC3339))
+	}
+
+	resp.Healthy = len(resp.Problems) == 0
+	return resp
+}
+
+func (h healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := h.check()
+	body, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestHealthHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+
+	present := filepath.Join(dir, "present.log")
+	absent := filepath.Join(dir, "absent.log")
+	err = ioutil.WriteFile(present, nil, 0600)
+	test.AssertNotError(t, err, "failed to create log file")
+
+	tailer := newTailer(&config{Files: []string{present, absent}}, nil, nil, newTestLineCounter(), blog.NewMock())
+	defer tailer.stop()
+	fc := clock.NewFake()
+	tailer.clk = fc
+	tailer.started = fc.Now()
+	h := healthHandler{t: tailer, staleAfter: time.Minute}
+
+	get := func() (int, healthResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+		var resp healthResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &resp)
+		test.AssertNotError(t, err, "failed to unmarshal health response")
+		return rec.Code, resp
+	}
+
+	// Before scanning, the existing file isn't being tailed.
+	code, resp := get()
+	test.AssertEquals(t, code, http.StatusServiceUnavailable)
+	test.AssertEquals(t, len(resp.Problems), 1)
+	test.AssertContains(t, resp.Problems[0], "present.log exists but is not being tailed")
+
+	err = tailer.scan()
+	test.AssertNotError(t, err, "failed to tail files")
+	code, resp = get()
+	test.AssertEquals(t, code, http.StatusOK)
+	test.Assert(t, resp.Healthy, "expected healthy response")
+	test.AssertEquals(t, resp.Files[present].Tailing, true)
+	test.AssertEquals(t, resp.Files[absent].Exists, false)
+
+	// With no valid lines for longer than staleAfter, the tailer is unhealthy
+	// until another valid line is read.
+	fc.Add(2 * time.Minute)
+	code, resp = get()
+	test.AssertEquals(t, code, http.StatusServiceUnavailable)
+	test.AssertContains(t, resp.Problems[0], "no valid lines read since")
+
+	tailer.validate(present, "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM")
+	code, resp = get()
+	test.AssertEquals(t, code, http.StatusOK)
+	test.Assert(t, resp.Files[present].LastValidLine.Equal(fc.Now()), "wrong last valid line time")
+}
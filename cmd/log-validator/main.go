@@ -1,22 +1,69 @@
 package main
 
 import (
-	"encoding/json"
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/hpcloud/tail"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/letsencrypt/boulder/cmd"
 	blog "github.com/letsencrypt/boulder/log"
 )
 
+// lineFormat describes how the fields of a log line are laid out. The default
+// format, defaultLineFormat, is described in detail by lineValid. Other formats
+// may separate fields with a different delimiter and put the checksum in a
+// later field, but the timestamp, hostname, severity and syslog tag fields
+// always come first, in that order, with the message last.
+type lineFormat struct {
+	delimiter     string
+	checksumField int
+}
+
+// headerFields is the number of fields preceding the checksum in the default
+// format: the timestamp, hostname, datacenter, severity and syslog tag.
+const headerFields = 5
+
+var defaultLineFormat = lineFormat{delimiter: " ", checksumField: headerFields}
+
+// validate returns an error if the format can't be used to parse lines.
+func (f lineFormat) validate() error {
+	if f.delimiter == "" {
+		return errors.New("field delimiter must not be empty")
+	}
+	if f.checksumField < headerFields {
+		return fmt.Errorf("checksum field index %d must be at least %d, since the timestamp, hostname, datacenter, severity and syslog tag fields come first", f.checksumField, headerFields)
+	}
+	return nil
+}
+
+// fields splits a line into at most n fields.
+func (f lineFormat) fields(text string, n int) []string {
+	return strings.SplitN(text, f.delimiter, n)
+}
+
+// lineValid checks a line in the default format.
 func lineValid(text string) error {
+	return defaultLineFormat.valid(text)
+}
+
+// valid checks that a line has enough fields and that its checksum matches its
+// message.
+func (f lineFormat) valid(text string) error {
 	// Line format should match the following rsyslog omfile template:
 	//
 	//   template( name="LELogFormat" type="list" ) {
@@ -34,44 +81,438 @@ func lineValid(text string) error {
 	//
 	// This should result in a log line that looks like this:
 	//   timestamp hostname datacenter syslogseverity binary-name[pid]: checksum msg
+	//
+	// where "checksum msg" is the line as rendered by blog.RenderLine. Since
+	// Boulder always separates the checksum from the message with a space, the
+	// rendered line is everything from the checksum field onwards regardless of
+	// the delimiter used by the template.
 
-	fields := strings.Split(text, " ")
-	// Extract checksum from line
-	if len(fields) < 6 {
-		return errors.New("line doesn't match expected format")
+	fields := f.fields(text, f.checksumField+1)
+	if len(fields) < f.checksumField+1 {
+		return formatError{"line doesn't match expected format"}
 	}
-	checksum := fields[5]
-	// Reconstruct just the message portion of the line
-	line := strings.Join(fields[6:], " ")
-	// Check the extracted checksum against the computed checksum
-	if computedChecksum := blog.LogLineChecksum(line); checksum != computedChecksum {
-		return fmt.Errorf("invalid checksum (expected %q, got %q)", computedChecksum, checksum)
+	// Check the checksum against the message, using whichever algorithm the
+	// checksum names
+	_, err := blog.ParseLine(fields[f.checksumField])
+	if err != nil {
+		return checksumError{err}
 	}
 	return nil
 }
 
-func validateFile(filename string) error {
-	file, err := ioutil.ReadFile(filename)
+// formatError indicates a line which doesn't match the expected format.
+type formatError struct {
+	msg string
+}
+
+func (e formatError) Error() string {
+	return e.msg
+}
+
+// checksumError indicates a line whose checksum doesn't match its message, or
+// names an unknown checksum algorithm.
+type checksumError struct {
+	err error
+}
+
+func (e checksumError) Error() string {
+	return e.err.Error()
+}
+
+// validWithoutChecksum checks that a line matches the format except for the
+// checksum, which is neither required nor verified. This allows checking logs
+// written before checksums were added. Only the number of fields, the
+// timestamp, and the syslog severity are checked, and every error says so.
+func (f lineFormat) validWithoutChecksum(text string) error {
+	fields := f.fields(text, headerFields+1)
+	if len(fields) < headerFields+1 {
+		return formatError{"line doesn't match expected format (checked without checksum)"}
+	}
+	_, err := time.Parse(time.RFC3339, fields[0])
 	if err != nil {
-		return err
+		return formatError{fmt.Sprintf("unparseable timestamp (checked without checksum): %s", err)}
+	}
+	if _, ok := severityNames[fields[3]]; !ok {
+		return formatError{fmt.Sprintf("invalid syslog severity %q (checked without checksum)", fields[3])}
+	}
+	return nil
+}
+
+// timestampError indicates a line whose timestamp couldn't be parsed.
+type timestampError struct {
+	err error
+}
+
+func (e timestampError) Error() string {
+	return fmt.Sprintf("unparseable timestamp: %s", e.err)
+}
+
+// skewError indicates a line whose timestamp is too far from the current time,
+// which suggests that the clock of the host which wrote it has jumped.
+type skewError struct {
+	skew time.Duration
+}
+
+func (e skewError) Error() string {
+	return fmt.Sprintf("timestamp is skewed by %s from the current time", e.skew)
+}
+
+// timestamp parses the RFC 3339 timestamp of a line.
+func (f lineFormat) timestamp(text string) (time.Time, error) {
+	return time.Parse(time.RFC3339, f.fields(text, 2)[0])
+}
+
+// checkTimestamp parses the RFC 3339 timestamp of a line, returning a
+// timestampError if it can't be parsed or a skewError if it is more than
+// maxSkew before or after now.
+func (f lineFormat) checkTimestamp(text string, now time.Time, maxSkew time.Duration) error {
+	ts, err := f.timestamp(text)
+	if err != nil {
+		return timestampError{err}
+	}
+	skew := now.Sub(ts)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxSkew {
+		return skewError{now.Sub(ts)}
+	}
+	return nil
+}
+
+// hostnameError indicates a line from a host other than the one expected for
+// the file it was read from, which suggests misconfigured log forwarding.
+type hostnameError struct {
+	expected, got string
+}
+
+func (e hostnameError) Error() string {
+	return fmt.Sprintf("unexpected hostname (expected %q, got %q)", e.expected, e.got)
+}
+
+// checkHostname returns a hostnameError if the hostname of a line isn't
+// expected.
+func (f lineFormat) checkHostname(text, expected string) error {
+	fields := f.fields(text, 3)
+	if len(fields) < 3 {
+		return formatError{"line doesn't match expected format"}
+	}
+	if fields[1] != expected {
+		return hostnameError{expected, fields[1]}
+	}
+	return nil
+}
+
+// oversizePrefixLength is how many bytes of an oversize line are included in
+// its error.
+const oversizePrefixLength = 100
+
+// oversizeError indicates a line which is too long to be worth validating.
+type oversizeError struct {
+	length, max int
+	prefix      string
+}
+
+func (e oversizeError) Error() string {
+	return fmt.Sprintf("line of %d bytes exceeds maximum length of %d bytes (begins %q)", e.length, e.max, e.prefix)
+}
+
+// checkLength returns an oversizeError if text is longer than max bytes.
+func checkLength(text string, max int) error {
+	if len(text) <= max {
+		return nil
+	}
+	prefix := text
+	if len(prefix) > oversizePrefixLength {
+		prefix = prefix[:oversizePrefixLength]
+	}
+	return oversizeError{len(text), max, prefix}
+}
+
+// lineStatus returns the status used when counting or reporting a line which
+// failed validation with err, derived from the type of err.
+func lineStatus(err error) string {
+	switch err.(type) {
+	case formatError:
+		return "bad_format"
+	case checksumError:
+		return "bad_checksum"
+	case skewError:
+		return "skewed"
+	case timestampError:
+		return "bad_timestamp"
+	case hostnameError:
+		return "wrong_host"
+	case oversizeError:
+		return "oversize"
+	default:
+		return "bad"
+	}
+}
+
+// severityNames maps syslog severity numbers to the keywords used for them by
+// rsyslog.
+var severityNames = map[string]string{
+	"0": "emerg",
+	"1": "alert",
+	"2": "crit",
+	"3": "err",
+	"4": "warning",
+	"5": "notice",
+	"6": "info",
+	"7": "debug",
+}
+
+// severity returns the name of the syslog severity of a line, or "unknown" if
+// the severity is missing or invalid.
+func (f lineFormat) severity(text string) string {
+	fields := f.fields(text, headerFields)
+	if len(fields) < headerFields {
+		return "unknown"
+	}
+	name, ok := severityNames[fields[3]]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// binary returns the name of the binary which wrote a line, taken from the
+// syslog tag with any PID and the trailing colon removed. It returns "unknown"
+// if the tag can't be parsed.
+func (f lineFormat) binary(text string) string {
+	fields := f.fields(text, headerFields+1)
+	if len(fields) < headerFields+1 {
+		return "unknown"
+	}
+	tag := fields[4]
+	if !strings.HasSuffix(tag, ":") {
+		return "unknown"
+	}
+	tag = strings.TrimSuffix(tag, ":")
+	if i := strings.Index(tag, "["); i != -1 {
+		if !strings.HasSuffix(tag, "]") {
+			return "unknown"
+		}
+		tag = tag[:i]
+	}
+	if tag == "" {
+		return "unknown"
+	}
+	return tag
+}
+
+// gzipMagic is the two byte header that begins every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// isCompressed returns true if the filename has a .gz suffix, which is how our
+// log rotation names compressed files.
+func isCompressed(filename string) bool {
+	return strings.HasSuffix(filename, ".gz")
+}
+
+// logReader couples a (possibly decompressing) reader with the underlying
+// file so that callers can close it.
+type logReader struct {
+	io.Reader
+	io.Closer
+}
+
+// stdinFilename is the filename which causes openLog to read from stdin.
+const stdinFilename = "-"
+
+// openLog opens the named file, or stdin if the name is stdinFilename, for
+// reading. If the file is gzip compressed,
+// either by its suffix or by sniffing the gzip magic bytes, the returned reader
+// transparently decompresses it.
+func openLog(filename string) (io.ReadCloser, error) {
+	var f io.ReadCloser
+	if filename == stdinFilename {
+		f = ioutil.NopCloser(os.Stdin)
+	} else {
+		var err error
+		f, err = os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+	}
+	br := bufio.NewReader(f)
+	header, err := br.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		_ = f.Close()
+		return nil, err
 	}
-	badFile := false
-	for i, line := range strings.Split(string(file), "\n") {
+	if !isCompressed(filename) && !bytes.Equal(header, gzipMagic) {
+		return logReader{br, f}, nil
+	}
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("opening %s as gzip: %s", filename, err)
+	}
+	return logReader{gz, f}, nil
+}
+
+const (
+	// maxLineSize is the longest line validateFile will read. Lines longer than
+	// this cause validation of the whole file to fail.
+	maxLineSize = 1024 * 1024
+	// lineBatchSize is the number of lines validateFile hands to a worker at
+	// once, amortizing the cost of coordinating between goroutines.
+	lineBatchSize = 1024
+)
+
+// badLine is a line which failed validation.
+type badLine struct {
+	num int
+	err error
+	raw string
+}
+
+// lineBatch is a run of consecutive lines from a file, the first of which is
+// line number first. Once a worker has validated the batch it sends the
+// outcome on results.
+type lineBatch struct {
+	first   int
+	lines   []string
+	check   func(string) error
+	results chan batchResult
+}
+
+type batchResult struct {
+	total int
+	bad   []badLine
+}
+
+func (b *lineBatch) validate() {
+	var res batchResult
+	for i, line := range b.lines {
 		if line == "" {
 			continue
 		}
-		if err := lineValid(line); err != nil {
-			badFile = true
-			fmt.Fprintf(os.Stderr, "[line %d] %s: %s\n", i+1, err, line)
+		res.total++
+		if err := b.check(line); err != nil {
+			res.bad = append(res.bad, badLine{b.first + i, err, line})
+		}
+	}
+	b.results <- res
+}
+
+// validateFile validates every line of filename with check, reporting each
+// invalid line and a final summary to rep. The file is streamed in batches
+// which are checked by a pool of workers goroutines, so memory use is bounded
+// regardless of the size of the file. Results are reported in line order.
+func validateFile(filename string, rep reporter, workers int, check func(string) error) error {
+	r, err := openLog(filename)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	if workers < 1 {
+		workers = 1
+	}
+	work := make(chan *lineBatch)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for b := range work {
+				b.validate()
+			}
+		}()
+	}
+
+	// pending holds batches in the order they were read so they can be
+	// reported in line order even though workers may finish them out of order.
+	// Its capacity bounds the number of batches in memory at once.
+	pending := make(chan *lineBatch, workers)
+	var scanErr error
+	go func() {
+		defer close(work)
+		defer close(pending)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		num := 0
+		b := &lineBatch{first: 1, check: check, results: make(chan batchResult, 1)}
+		for scanner.Scan() {
+			num++
+			b.lines = append(b.lines, scanner.Text())
+			if len(b.lines) == lineBatchSize {
+				pending <- b
+				work <- b
+				b = &lineBatch{first: num + 1, check: check, results: make(chan batchResult, 1)}
+			}
+		}
+		scanErr = scanner.Err()
+		pending <- b
+		work <- b
+	}()
+
+	var total, invalid int
+	for b := range pending {
+		res := <-b.results
+		total += res.total
+		invalid += len(res.bad)
+		for _, bad := range res.bad {
+			rep.badLine(filename, bad.num, bad.err, bad.raw)
 		}
 	}
+	if scanErr != nil {
+		return fmt.Errorf("reading %s: %s", filename, scanErr)
+	}
+	rep.summary(filename, total, total-invalid, invalid)
 
-	if badFile {
+	if invalid > 0 {
 		return errors.New("file contained invalid lines")
 	}
 	return nil
 }
 
+// validateFiles validates each of filenames with check as validateFile does,
+// reporting to rep. Up to workers files are validated concurrently, sharing
+// workers goroutines between them. With more than one file, a combined summary
+// is reported once every file has been validated, and an error naming every
+// file which couldn't be read or contained invalid lines is returned.
+func validateFiles(filenames []string, rep reporter, workers int, check func(string) error) error {
+	if len(filenames) == 1 {
+		return validateFile(filenames[0], rep, workers, check)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	fileWorkers := workers
+	if fileWorkers > len(filenames) {
+		fileWorkers = len(filenames)
+	}
+	lineWorkers := workers / fileWorkers
+
+	tally := &tallyReporter{rep: rep}
+	errs := make([]error, len(filenames))
+	sem := make(chan struct{}, fileWorkers)
+	var wg sync.WaitGroup
+	for i, filename := range filenames {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, filename string) {
+			defer wg.Done()
+			errs[i] = validateFile(filename, tally, lineWorkers, check)
+			<-sem
+		}(i, filename)
+	}
+	wg.Wait()
+	tally.combined(len(filenames), tally.total, tally.valid, tally.invalid)
+
+	var failures []string
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", filenames[i], err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d files failed validation: %s", len(failures), len(filenames), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
 // tailLogger is an adapter to the hpcloud/tail module's logging interface.
 type tailLogger struct {
 	blog.Logger
@@ -105,73 +546,256 @@ func (tl tailLogger) Println(v ...interface{}) {
 	tl.Info(fmt.Sprint(v...) + "\n")
 }
 
+type config struct {
+	Syslog cmd.SyslogConfig
+	cmd.DebugConfig
+	// Files is a list of glob patterns, as understood by filepath.Glob,
+	// naming the files to tail. It is re-read on SIGHUP. Changes to any other
+	// field require a restart.
+	Files []string
+	// RescanInterval controls how often Files is re-expanded to find newly
+	// created files. Defaults to one minute.
+	RescanInterval cmd.ConfigDuration
+	// OffsetFile, if set, is where the position reached in each tailed file
+	// is persisted, so that tailing resumes there after a restart.
+	OffsetFile string
+	// OffsetFlushInterval controls how often offsets are written to
+	// OffsetFile. Offsets are also written on clean shutdown. Defaults to ten
+	// seconds.
+	OffsetFlushInterval cmd.ConfigDuration
+	// MaxClockSkew, if set, causes tailed lines whose timestamps are further
+	// than this from the current time to be counted as "skewed", and lines
+	// whose timestamps can't be parsed to be counted as "bad_timestamp".
+	MaxClockSkew cmd.ConfigDuration
+	// ExpectedHostnames optionally maps file paths, or glob patterns matching
+	// them, to the only hostname which may appear in lines read from them.
+	// Lines from other hosts are counted as "wrong_host". Files which don't
+	// match any entry may contain lines from any host.
+	ExpectedHostnames map[string]string
+	// StaleAfter, if set, causes the /healthz handler on DebugAddr to report
+	// unhealthy when no valid line has been read from any file for this long.
+	StaleAfter cmd.ConfigDuration
+	// QuarantineFile, if set, is where each invalid tailed line is appended,
+	// along with the file it was read from and why it is invalid.
+	QuarantineFile string
+	// QuarantineMaxSize is the size in bytes at which QuarantineFile is
+	// rotated. One rotated file is kept. Defaults to 100 MiB.
+	QuarantineMaxSize int64
+	// MaxLineLength, if set, is the length in bytes above which tailed lines
+	// are counted as "oversize" without being checksummed, bounding the work
+	// done for pathological input.
+	MaxLineLength int
+	// DrainTimeout is how long to wait on shutdown for lines which have
+	// already been read to be validated. Defaults to five seconds.
+	DrainTimeout cmd.ConfigDuration
+	// Delimiter separates the fields of each tailed line, matching the rsyslog
+	// template which wrote it. Defaults to a single space.
+	Delimiter string
+	// ChecksumField is the zero-based index of the checksum field in each
+	// tailed line. It must follow the timestamp, hostname, datacenter,
+	// severity and syslog tag fields. Defaults to 5.
+	ChecksumField int
+	// ShutdownTimeout, if set, bounds how long shutdown may take. If stopping
+	// the tails, draining or saving offsets hasn't finished by then, the steps
+	// still outstanding are logged and the process exits anyway.
+	ShutdownTimeout cmd.ConfigDuration
+}
+
+// lineFormat returns the format of tailed lines, applying defaults.
+func (c *config) lineFormat() lineFormat {
+	f := defaultLineFormat
+	if c.Delimiter != "" {
+		f.delimiter = c.Delimiter
+	}
+	if c.ChecksumField != 0 {
+		f.checksumField = c.ChecksumField
+	}
+	return f
+}
+
+// Validate checks the config for -validate-config: that there are files to
+// tail, that their glob patterns and those of ExpectedHostnames are well
+// formed, that durations and sizes aren't negative, and that the line format
+// is valid. The Syslog and DebugConfig blocks are checked by their own
+// Validate methods.
+func (c *config) Validate() error {
+	if len(c.Files) == 0 {
+		return errors.New("Files must name at least one file to tail")
+	}
+	for _, pattern := range c.Files {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("Files: invalid glob pattern %q: %s", pattern, err)
+		}
+	}
+	for pattern := range c.ExpectedHostnames {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("ExpectedHostnames: invalid glob pattern %q: %s", pattern, err)
+		}
+	}
+	durations := []struct {
+		name string
+		d    cmd.ConfigDuration
+	}{
+		{"RescanInterval", c.RescanInterval},
+		{"OffsetFlushInterval", c.OffsetFlushInterval},
+		{"MaxClockSkew", c.MaxClockSkew},
+		{"StaleAfter", c.StaleAfter},
+		{"DrainTimeout", c.DrainTimeout},
+		{"ShutdownTimeout", c.ShutdownTimeout},
+	}
+	for _, d := range durations {
+		if d.d.Duration < 0 {
+			return fmt.Errorf("%s must not be negative, got %s", d.name, d.d)
+		}
+	}
+	if c.QuarantineMaxSize < 0 || c.MaxLineLength < 0 {
+		return errors.New("QuarantineMaxSize and MaxLineLength must not be negative")
+	}
+	if err := c.lineFormat().validate(); err != nil {
+		return fmt.Errorf("invalid line format: %s", err)
+	}
+	return nil
+}
+
+// restartRequired returns the names of the fields which differ between prev and
+// next and so can't be applied on reload, which only re-reads Files.
+func restartRequired(prev, next *config) []string {
+	var names []string
+	for _, f := range []struct {
+		name    string
+		changed bool
+	}{
+		{"Syslog", prev.Syslog != next.Syslog},
+		{"DebugAddr", prev.DebugAddr != next.DebugAddr},
+		{"DebugPassword", prev.DebugPassword != next.DebugPassword},
+		{"RescanInterval", prev.RescanInterval != next.RescanInterval},
+		{"OffsetFile", prev.OffsetFile != next.OffsetFile},
+		{"OffsetFlushInterval", prev.OffsetFlushInterval != next.OffsetFlushInterval},
+		{"MaxClockSkew", prev.MaxClockSkew != next.MaxClockSkew},
+		{"ExpectedHostnames", !reflect.DeepEqual(prev.ExpectedHostnames, next.ExpectedHostnames)},
+		{"StaleAfter", prev.StaleAfter != next.StaleAfter},
+		{"QuarantineFile", prev.QuarantineFile != next.QuarantineFile},
+		{"QuarantineMaxSize", prev.QuarantineMaxSize != next.QuarantineMaxSize},
+		{"MaxLineLength", prev.MaxLineLength != next.MaxLineLength},
+		{"DrainTimeout", prev.DrainTimeout != next.DrainTimeout},
+		{"Delimiter", prev.Delimiter != next.Delimiter},
+		{"ChecksumField", prev.ChecksumField != next.ChecksumField},
+		{"ShutdownTimeout", prev.ShutdownTimeout != next.ShutdownTimeout},
+	} {
+		if f.changed {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+func loadConfig(filename string) (*config, error) {
+	var c config
+	err := cmd.ReadConfigFile(filename, &c)
+	if err != nil {
+		return nil, err
+	}
+	err = c.lineFormat().validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid line format: %s", err)
+	}
+	return &c, nil
+}
+
 func main() {
 	configPath := flag.String("config", "", "File path to the configuration file for this service")
-	checkFile := flag.String("check-file", "", "File path to a file to directly validate, if this argument is provided the config will not be parsed and only this file will be inspected")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
+	checkFile := flag.String("check-file", "", "Comma separated file paths of files to directly validate, or \"-\" to read from stdin. If this argument is provided the config will not be parsed and only these files will be inspected")
+	workers := flag.Int("workers", runtime.NumCPU(), "Number of goroutines used to validate lines with -check-file, and the maximum number of files validated at once")
+	format := flag.String("format", "text", "Output format for -check-file results, either \"text\" or \"json\"")
+	delimiter := flag.String("delimiter", defaultLineFormat.delimiter, "Delimiter separating the fields of -check-file lines")
+	checksumField := flag.Int("checksum-field", defaultLineFormat.checksumField, "Zero-based index of the checksum field of -check-file lines")
+	noChecksum := flag.Bool("no-checksum", false, "Validate -check-file lines without requiring or verifying checksums, for logs written before checksums were added")
 	flag.Parse()
 
 	if *checkFile != "" {
-		err := validateFile(*checkFile)
+		// Text results have always been written to stderr, while JSON results
+		// are written to stdout so they can be piped into other tools.
+		out := os.Stderr
+		if *format == "json" {
+			out = os.Stdout
+		}
+		filenames := strings.Split(*checkFile, ",")
+		rep, err := newReporter(*format, out, len(filenames) > 1)
+		cmd.FailOnError(err, "invalid -format")
+		lf := lineFormat{delimiter: *delimiter, checksumField: *checksumField}
+		err = lf.validate()
+		cmd.FailOnError(err, "invalid line format")
+		check := lf.valid
+		if *noChecksum {
+			check = lf.validWithoutChecksum
+		}
+		err = validateFiles(filenames, rep, *workers, check)
 		cmd.FailOnError(err, "validation failed")
 		return
 	}
 
-	var config struct {
-		Syslog    cmd.SyslogConfig
-		DebugAddr string
-		Files     []string
+	c, err := loadConfig(*configPath)
+	cmd.FailOnError(err, "failed to load config file")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(c)
 	}
-	configBytes, err := ioutil.ReadFile(*configPath)
-	cmd.FailOnError(err, "failed to read config file")
-	err = json.Unmarshal(configBytes, &config)
-	cmd.FailOnError(err, "failed to parse config file")
 
-	stats, logger := cmd.StatsAndLogging(config.Syslog, config.DebugAddr)
+	logger := cmd.NewLogger(c.Syslog)
 	lineCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "log_lines",
-		Help: "A counter of log lines processed, with status",
-	}, []string{"filename", "status"})
-	stats.MustRegister(lineCounter)
-
-	var tailers []*tail.Tail
-	for _, filename := range config.Files {
-		t, err := tail.TailFile(filename, tail.Config{
-			ReOpen:    true,
-			MustExist: false, // sometimes files won't exist, so we must tolerate that
-			Follow:    true,
-			Logger:    tailLogger{logger},
-		})
-		cmd.FailOnError(err, "failed to tail file")
+		Help: "A counter of log lines processed, with status, syslog severity, and the binary which wrote them",
+	}, []string{"filename", "status", "severity", "binary"})
 
-		go func() {
-			for line := range t.Lines {
-				if line.Err != nil {
-					logger.Errf("error while tailing %s: %s", t.Filename, err)
-					continue
-				}
-				if err := lineValid(line.Text); err != nil {
-					lineCounter.WithLabelValues(t.Filename, "bad").Inc()
-					logger.Errf("%s: %s %q", t.Filename, err, line.Text)
-				} else {
-					lineCounter.WithLabelValues(t.Filename, "ok").Inc()
-				}
-			}
-		}()
+	rescanInterval := c.RescanInterval.Duration
+	if rescanInterval == 0 {
+		rescanInterval = time.Minute
+	}
+
+	flushInterval := c.OffsetFlushInterval.Duration
+	if flushInterval == 0 {
+		flushInterval = 10 * time.Second
+	}
 
-		tailers = append(tailers, t)
+	var offsets *offsetStore
+	if c.OffsetFile != "" {
+		offsets, err = loadOffsets(c.OffsetFile)
+		cmd.FailOnError(err, "failed to load offsets")
 	}
 
-	cmd.CatchSignals(logger, func() {
-		for _, t := range tailers {
-			// The tail module seems to have a race condition that will generate
-			// errors like this on shutdown:
-			// failed to stop tailing file: <filename>: Failed to detect creation of
-			// <filename>: inotify watcher has been closed
-			// This is probably related to the module's shutdown logic triggering the
-			// "reopen" code path for files that are removed and then recreated.
-			// These errors are harmless so we ignore them to allow clean shutdown.
-			_ = t.Stop()
-			t.Cleanup()
+	var q *quarantine
+	if c.QuarantineFile != "" {
+		maxSize := c.QuarantineMaxSize
+		if maxSize == 0 {
+			maxSize = 100 * 1024 * 1024
 		}
+		q, err = openQuarantine(c.QuarantineFile, maxSize)
+		cmd.FailOnError(err, "failed to open quarantine file")
+	}
+
+	t := newTailer(c, offsets, q, lineCounter, logger)
+	stats := cmd.NewDebugServer(c.DebugConfig, logger, map[string]http.Handler{
+		"/healthz": healthHandler{t: t, staleAfter: c.StaleAfter.Duration},
 	})
+	stats.MustRegister(lineCounter, t.validationLatency, t.tailLag)
+	err = t.scan()
+	cmd.FailOnError(err, "failed to tail files")
+	go t.watch(rescanInterval, flushInterval)
+
+	reload := func() {
+		newConfig, err := loadConfig(*configPath)
+		if err != nil {
+			logger.Errf("failed to reload config file: %s", err)
+			return
+		}
+		if ignored := restartRequired(c, newConfig); len(ignored) != 0 {
+			logger.Warningf("changes to %s require a restart and were ignored", strings.Join(ignored, ", "))
+		}
+		err = t.reload(newConfig.Files)
+		if err != nil {
+			logger.Errf("failed to tail files: %s", err)
+		}
+	}
+
+	cmd.CatchSignalsWithDeadline(logger, reload, c.ShutdownTimeout.Duration, t.shutdownSteps()...)
 }
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/cmd"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestLineValid(t *testing.T) {
+	err := lineValid("2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM")
+	test.AssertNotError(t, err, "valid line was rejected")
+
+	err = lineValid("2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: xxxxxxx Caught SIGTERM")
+	test.AssertError(t, err, "line with bad checksum was accepted")
+
+	err = lineValid("not a log line")
+	test.AssertError(t, err, "malformed line was accepted")
+
+	checksum, err := blog.LogLineChecksumWith(blog.ChecksumSHA256, "Caught SIGTERM")
+	test.AssertNotError(t, err, "failed to compute checksum")
+	err = lineValid("2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: " + checksum + " Caught SIGTERM")
+	test.AssertNotError(t, err, "valid line with sha256 checksum was rejected")
+	err = lineValid("2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: " + checksum + " Caught SIGINT")
+	test.AssertError(t, err, "line with bad sha256 checksum was accepted")
+}
+
+// assertRoundTrip checks that msg, once rendered by the log package and given
+// the fields rsyslog adds, is accepted by lineValid.
+func assertRoundTrip(t *testing.T, msg string) {
+	t.Helper()
+	line := "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: " + blog.RenderLine(msg)
+	test.AssertNotError(t, lineValid(line), fmt.Sprintf("rendered line %q was rejected", line))
+}
+
+func TestRenderedLinesValid(t *testing.T) {
+	for _, msg := range []string{
+		"Caught SIGTERM",
+		"",
+		" leading space",
+		"trailing space ",
+		"multiple  spaces",
+		"tabs\tand\tünïcödé",
+		`[AUDIT] Certificate request - successful JSON={"ID": "a b"}`,
+	} {
+		assertRoundTrip(t, msg)
+	}
+}
+
+func TestValidWithoutChecksum(t *testing.T) {
+	testCases := []struct {
+		line string
+		err  string
+	}{
+		{"2016-03-02T11:12:13.000000+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: Caught SIGTERM", ""},
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: xxxxxxx Caught SIGTERM", ""},
+		{"yesterday 70877f679c72 datacenter 6 boulder-wfe[1595]: Caught SIGTERM", "unparseable timestamp (checked without checksum)"},
+		{"2016-03-02T11:12:13.000000+00:00 70877f679c72 datacenter 9 boulder-wfe[1595]: Caught SIGTERM", `invalid syslog severity "9" (checked without checksum)`},
+		{"not a log line", "line doesn't match expected format (checked without checksum)"},
+		{"2016-03-02T11:12:13.000000+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]:", "line doesn't match expected format (checked without checksum)"},
+	}
+	for _, tc := range testCases {
+		err := defaultLineFormat.validWithoutChecksum(tc.line)
+		if tc.err == "" {
+			test.AssertNotError(t, err, "valid line was rejected")
+		} else {
+			test.AssertError(t, err, "invalid line was accepted")
+			test.AssertContains(t, err.Error(), tc.err)
+		}
+	}
+}
+
+func TestLineFormat(t *testing.T) {
+	// A tab separated format with an extra field before the checksum.
+	f := lineFormat{delimiter: "\t", checksumField: 6}
+	test.AssertNotError(t, f.validate(), "valid format was rejected")
+	const line = "2020-07-06T18:07:43.109389+00:00\t70877f679c72\tdatacenter\t3\tboulder-wfe[1595]:\textra\tkKG6cwA Caught SIGTERM"
+	test.AssertNotError(t, f.valid(line), "valid line was rejected")
+	test.AssertError(t, f.valid(strings.Replace(line, "kKG6cwA", "xxxxxxx", 1)), "line with bad checksum was accepted")
+	test.AssertError(t, f.valid(strings.Replace(line, "\t", " ", -1)), "space separated line was accepted")
+	test.AssertEquals(t, f.severity(line), "err")
+	test.AssertEquals(t, f.binary(line), "boulder-wfe")
+	test.AssertNotError(t, f.checkHostname(line, "70877f679c72"), "expected hostname was rejected")
+
+	err := lineFormat{delimiter: "", checksumField: 5}.validate()
+	test.AssertError(t, err, "empty delimiter was accepted")
+	err = lineFormat{delimiter: " ", checksumField: 4}.validate()
+	test.AssertError(t, err, "checksum field overlapping the syslog tag was accepted")
+	test.AssertContains(t, err.Error(), "checksum field index 4 must be at least 5")
+}
+
+func TestLoadConfigLineFormat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+	configPath := filepath.Join(dir, "log-validator.json")
+
+	err = ioutil.WriteFile(configPath, []byte(`{"delimiter": "\t", "checksumField": 6}`), 0600)
+	test.AssertNotError(t, err, "failed to write config file")
+	c, err := loadConfig(configPath)
+	test.AssertNotError(t, err, "failed to load valid config")
+	test.AssertEquals(t, c.lineFormat(), lineFormat{delimiter: "\t", checksumField: 6})
+
+	err = ioutil.WriteFile(configPath, []byte(`{"checksumField": 2}`), 0600)
+	test.AssertNotError(t, err, "failed to write config file")
+	_, err = loadConfig(configPath)
+	test.AssertError(t, err, "config with invalid checksum field was loaded")
+	test.AssertContains(t, err.Error(), "invalid line format")
+
+	test.AssertEquals(t, (&config{}).lineFormat(), defaultLineFormat)
+}
+
+func TestConfigValidate(t *testing.T) {
+	c := config{Files: []string{"/var/log/*.log"}}
+	test.AssertNotError(t, c.Validate(), "valid config was rejected")
+
+	testCases := []struct {
+		name  string
+		c     config
+		wants string
+	}{
+		{"no files", config{}, "Files must name at least one file"},
+		{"bad glob", config{Files: []string{"/var/log/[.log"}}, "invalid glob pattern"},
+		{"bad hostname glob", config{Files: []string{"a"}, ExpectedHostnames: map[string]string{"[": "host"}}, "ExpectedHostnames: invalid glob pattern"},
+		{"negative duration", config{Files: []string{"a"}, StaleAfter: cmd.ConfigDuration{Duration: -time.Second}}, "StaleAfter must not be negative"},
+		{"bad line format", config{Files: []string{"a"}, ChecksumField: 2}, "invalid line format"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.c.Validate()
+			test.AssertError(t, err, "invalid config was accepted")
+			test.AssertContains(t, err.Error(), tc.wants)
+		})
+	}
+
+	// The Syslog block is checked by cmd.ValidateConfig.
+	c.Syslog.StdoutLevel = 8
+	err := cmd.ValidateConfig(&c)
+	test.AssertError(t, err, "invalid syslog level was accepted")
+	test.AssertContains(t, err.Error(), "Syslog: StdoutLevel")
+}
+
+func TestLineStatus(t *testing.T) {
+	testCases := []struct {
+		err    error
+		status string
+	}{
+		{lineValid("not a log line"), "bad_format"},
+		{lineValid("2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: xxxxxxx Caught SIGTERM"), "bad_checksum"},
+		{defaultLineFormat.validWithoutChecksum("yesterday 70877f679c72 datacenter 6 boulder-wfe[1595]: Caught SIGTERM"), "bad_format"},
+		{defaultLineFormat.checkHostname("2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM", "other"), "wrong_host"},
+		{defaultLineFormat.checkTimestamp("yesterday 70877f679c72", time.Now(), time.Minute), "bad_timestamp"},
+		{defaultLineFormat.checkTimestamp("2020-07-06T18:07:43Z 70877f679c72", time.Now(), time.Minute), "skewed"},
+		{checkLength("AAAA", 2), "oversize"},
+		{errors.New("something else"), "bad"},
+	}
+	for _, tc := range testCases {
+		test.AssertEquals(t, lineStatus(tc.err), tc.status)
+	}
+}
+
+func TestLineSeverity(t *testing.T) {
+	testCases := []struct {
+		line     string
+		severity string
+	}{
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 3 boulder-wfe[1595]: kKG6cwA Caught SIGTERM", "err"},
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM", "info"},
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter x boulder-wfe[1595]: kKG6cwA Caught SIGTERM", "unknown"},
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 12 boulder-wfe[1595]: kKG6cwA Caught SIGTERM", "unknown"},
+		{"not a log line", "unknown"},
+		{"", "unknown"},
+	}
+	for _, tc := range testCases {
+		test.AssertEquals(t, defaultLineFormat.severity(tc.line), tc.severity)
+	}
+}
+
+func TestLineBinary(t *testing.T) {
+	testCases := []struct {
+		line   string
+		binary string
+	}{
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM", "boulder-wfe"},
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe: kKG6cwA Caught SIGTERM", "boulder-wfe"},
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595 kKG6cwA Caught SIGTERM", "unknown"},
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595: kKG6cwA Caught SIGTERM", "unknown"},
+		{"2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 [1595]: kKG6cwA Caught SIGTERM", "unknown"},
+		{"not a log line", "unknown"},
+	}
+	for _, tc := range testCases {
+		test.AssertEquals(t, defaultLineFormat.binary(tc.line), tc.binary)
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filename string
+		valid    bool
+	}{
+		{"plain, all valid", "testdata/valid.log", true},
+		{"plain, some invalid", "testdata/mixed.log", false},
+		{"gzip, all valid", "testdata/valid.log.gz", true},
+		{"gzip, some invalid", "testdata/mixed.log.gz", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateFile(tc.filename, textReporter{ioutil.Discard, false}, 2, lineValid)
+			if tc.valid {
+				test.AssertNotError(t, err, "valid file was rejected")
+			} else {
+				test.AssertError(t, err, "file with invalid lines was accepted")
+			}
+		})
+	}
+}
+
+func TestValidateFileNoChecksum(t *testing.T) {
+	rep := &countingReporter{}
+	err := validateFile("testdata/legacy.log", rep, 2, defaultLineFormat.validWithoutChecksum)
+	test.AssertError(t, err, "file with invalid lines was accepted")
+	test.AssertDeepEquals(t, rep.bad, []int{3, 4, 5})
+	test.AssertEquals(t, rep.valid, 3)
+
+	// The same file fails checksum validation entirely.
+	rep = &countingReporter{}
+	err = validateFile("testdata/legacy.log", rep, 2, lineValid)
+	test.AssertError(t, err, "file without checksums was accepted")
+	test.AssertEquals(t, rep.invalid, 6)
+}
+
+func TestValidateFiles(t *testing.T) {
+	var buf bytes.Buffer
+	filenames := []string{"testdata/valid.log", "testdata/mixed.log", "testdata/mixed.log.gz", "testdata/nonexistent.log"}
+	err := validateFiles(filenames, textReporter{&buf, true}, 3, lineValid)
+	test.AssertError(t, err, "files with invalid lines were accepted")
+	test.AssertContains(t, err.Error(), "3 of 4 files failed validation")
+	test.AssertContains(t, err.Error(), "testdata/mixed.log: file contained invalid lines")
+	test.AssertContains(t, err.Error(), "testdata/mixed.log.gz: file contained invalid lines")
+	test.AssertContains(t, err.Error(), "testdata/nonexistent.log: open testdata/nonexistent.log")
+
+	out := buf.String()
+	test.AssertContains(t, out, "testdata/mixed.log: [line 3] invalid checksum")
+	test.AssertContains(t, out, "testdata/mixed.log.gz: [line 4] line doesn't match expected format")
+	test.AssertContains(t, out, "validated 13 lines from 4 files: 9 valid, 4 invalid\n")
+
+	err = validateFiles([]string{"testdata/valid.log", "testdata/valid.log.gz"}, textReporter{ioutil.Discard, true}, 1, lineValid)
+	test.AssertNotError(t, err, "valid files were rejected")
+}
+
+func TestValidateFileJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := validateFile("testdata/mixed.log", newJSONReporter(&buf), 2, lineValid)
+	test.AssertError(t, err, "file with invalid lines was accepted")
+
+	dec := json.NewDecoder(&buf)
+	var bad []badLineResult
+	for i := 0; i < 2; i++ {
+		var res badLineResult
+		err := dec.Decode(&res)
+		test.AssertNotError(t, err, "failed to decode bad line result")
+		bad = append(bad, res)
+	}
+	test.AssertEquals(t, bad[0].File, "testdata/mixed.log")
+	test.AssertEquals(t, bad[0].Line, 3)
+	test.AssertContains(t, bad[0].Error, "invalid checksum")
+	test.AssertEquals(t, bad[0].Status, "bad_checksum")
+	test.AssertContains(t, bad[0].Raw, "xxxxxxx Caught SIGTERM")
+	test.AssertEquals(t, bad[1].Line, 4)
+	test.AssertEquals(t, bad[1].Status, "bad_format")
+	test.AssertEquals(t, bad[1].Raw, "not a log line")
+
+	var sum summaryResult
+	err = dec.Decode(&sum)
+	test.AssertNotError(t, err, "failed to decode summary result")
+	test.AssertDeepEquals(t, sum, summaryResult{
+		File:    "testdata/mixed.log",
+		Total:   5,
+		Valid:   3,
+		Invalid: 2,
+	})
+	test.Assert(t, !dec.More(), "unexpected trailing output")
+}
+
+// countingReporter records the line numbers of bad lines and the summary.
+type countingReporter struct {
+	bad                   []int
+	total, valid, invalid int
+}
+
+func (r *countingReporter) badLine(_ string, lineNum int, _ error, _ string) {
+	r.bad = append(r.bad, lineNum)
+}
+
+func (r *countingReporter) summary(_ string, total, valid, invalid int) {
+	r.total, r.valid, r.invalid = total, valid, invalid
+}
+
+func (r *countingReporter) combined(int, int, int, int) {}
+
+// writeSyntheticLog writes a log file of n lines to a temporary file, every
+// badEvery'th line of which has an invalid checksum, and returns its name.
+func writeSyntheticLog(n, badEvery int) (string, error) {
+	f, err := ioutil.TempFile("", "log-validator")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	for i := 1; i <= n; i++ {
+		checksum := "kKG6cwA"
+		if i%badEvery == 0 {
+			checksum = "xxxxxxx"
+		}
+		fmt.Fprintf(w, "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: %s Caught SIGTERM\n", checksum)
+	}
+	return f.Name(), w.Flush()
+}
+
+func TestValidateFileLineNumbers(t *testing.T) {
+	// Use enough lines to span several batches, with a bad line at the end of
+	// the first batch and at the start of the second.
+	filename, err := writeSyntheticLog(3*lineBatchSize+17, lineBatchSize)
+	test.AssertNotError(t, err, "failed to write synthetic log")
+	defer os.Remove(filename)
+
+	for _, workers := range []int{0, 1, 4} {
+		rep := &countingReporter{}
+		err := validateFile(filename, rep, workers, lineValid)
+		test.AssertError(t, err, "file with invalid lines was accepted")
+		test.AssertDeepEquals(t, rep.bad, []int{lineBatchSize, 2 * lineBatchSize, 3 * lineBatchSize})
+		test.AssertEquals(t, rep.total, 3*lineBatchSize+17)
+		test.AssertEquals(t, rep.valid, 3*lineBatchSize+14)
+		test.AssertEquals(t, rep.invalid, 3)
+	}
+}
+
+func TestValidateFileLongLine(t *testing.T) {
+	f, err := ioutil.TempFile("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary file")
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(strings.Repeat("a", maxLineSize+1) + "\n")
+	test.AssertNotError(t, err, "failed to write temporary file")
+	test.AssertNotError(t, f.Close(), "failed to close temporary file")
+
+	err = validateFile(f.Name(), &countingReporter{}, 1, lineValid)
+	test.AssertError(t, err, "file with overlong line was accepted")
+	test.AssertContains(t, err.Error(), "token too long")
+}
+
+// validateFileReadAll is the original implementation of validateFile, which
+// reads the entire file into memory and validates it on a single goroutine.
+// It is kept to benchmark against.
+func validateFileReadAll(filename string, rep reporter) error {
+	file, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	var total, invalid int
+	for i, line := range strings.Split(string(file), "\n") {
+		if line == "" {
+			continue
+		}
+		total++
+		if err := lineValid(line); err != nil {
+			invalid++
+			rep.badLine(filename, i+1, err, line)
+		}
+	}
+	rep.summary(filename, total, total-invalid, invalid)
+	if invalid > 0 {
+		return errors.New("file contained invalid lines")
+	}
+	return nil
+}
+
+func BenchmarkValidateFile(b *testing.B) {
+	filename, err := writeSyntheticLog(1000000, 1000)
+	if err != nil {
+		b.Fatalf("failed to write synthetic log: %s", err)
+	}
+	defer os.Remove(filename)
+
+	b.Run("ReadAll", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = validateFileReadAll(filename, &countingReporter{})
+		}
+	})
+	for _, workers := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("Streaming-%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = validateFile(filename, &countingReporter{}, workers, lineValid)
+			}
+		})
+	}
+}
+
+func TestValidateStdin(t *testing.T) {
+	mixed, err := ioutil.ReadFile("testdata/mixed.log.gz")
+	test.AssertNotError(t, err, "failed to read test data")
+
+	r, w, err := os.Pipe()
+	test.AssertNotError(t, err, "failed to create pipe")
+	saved := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = saved }()
+	go func() {
+		_, _ = w.Write(mixed)
+		_ = w.Close()
+	}()
+
+	var buf bytes.Buffer
+	err = validateFile(stdinFilename, newJSONReporter(&buf), 2, lineValid)
+	test.AssertError(t, err, "stdin with invalid lines was accepted")
+	test.AssertContains(t, buf.String(), `{"file":"-","line":3,`)
+	test.AssertContains(t, buf.String(), `{"file":"-","total":5,"valid":3,"invalid":2}`)
+}
+
+func TestRestartRequired(t *testing.T) {
+	prev := &config{Files: []string{"a.log"}, MaxLineLength: 10}
+	test.AssertEquals(t, len(restartRequired(prev, prev)), 0)
+
+	// Files is applied on reload, so changing it alone needs no restart.
+	next := *prev
+	next.Files = []string{"b.log"}
+	test.AssertEquals(t, len(restartRequired(prev, &next)), 0)
+
+	next.MaxLineLength = 20
+	next.ExpectedHostnames = map[string]string{"b.log": "host"}
+	next.QuarantineFile = "quarantine.log"
+	test.AssertDeepEquals(t, restartRequired(prev, &next), []string{"ExpectedHostnames", "QuarantineFile", "MaxLineLength"})
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/hpcloud/tail"
+)
+
+// fileOffset is the position validation had reached in a tailed file. The
+// inode is recorded so that a file which has been rotated away and replaced
+// since the offset was stored can be detected.
+type fileOffset struct {
+	Offset int64
+	Inode  uint64
+}
+
+// offsetStore persists the offset reached in each tailed file to a JSON file,
+// so that log-validator can resume tailing where it left off after a restart
+// instead of missing or re-validating lines.
+type offsetStore struct {
+	path  string
+	saved map[string]fileOffset
+}
+
+// loadOffsets reads previously stored offsets from path. A nonexistent file is
+// treated as an empty store.
+func loadOffsets(path string) (*offsetStore, error) {
+	s := &offsetStore{path: path, saved: make(map[string]fileOffset)}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &s.saved)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// inode returns the inode number of the named file.
+func inode(filename string) (uint64, error) {
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return 0, err
+	}
+	return fi.Sys().(*syscall.Stat_t).Ino, nil
+}
+
+// location returns where tailing of filename should resume, or nil if it
+// should start from the beginning. Tailing starts from the beginning when no
+// offset was stored, or when the file has been rotated or truncated since the
+// offset was stored.
+func (s *offsetStore) location(filename string) *tail.SeekInfo {
+	saved, ok := s.saved[filename]
+	if !ok {
+		return nil
+	}
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return nil
+	}
+	if fi.Sys().(*syscall.Stat_t).Ino != saved.Inode || fi.Size() < saved.Offset {
+		return nil
+	}
+	return &tail.SeekInfo{Offset: saved.Offset, Whence: io.SeekStart}
+}
+
+// save atomically replaces the stored offsets with the provided ones.
+func (s *offsetStore) save(offsets map[string]fileOffset) error {
+	data, err := json.Marshal(offsets)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	_, err = tmp.Write(data)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp.Name(), s.path)
+	if err != nil {
+		return err
+	}
+	s.saved = offsets
+	return nil
+}
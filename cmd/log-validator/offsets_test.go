@@ -0,0 +1,62 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hpcloud/tail"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestOffsetStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+
+	storePath := filepath.Join(dir, "offsets.json")
+	s, err := loadOffsets(storePath)
+	test.AssertNotError(t, err, "loading nonexistent offset file failed")
+	test.AssertEquals(t, len(s.saved), 0)
+
+	logPath := filepath.Join(dir, "boulder-wfe.log")
+	err = ioutil.WriteFile(logPath, []byte("0123456789\n"), 0600)
+	test.AssertNotError(t, err, "failed to write log file")
+	ino, err := inode(logPath)
+	test.AssertNotError(t, err, "failed to stat log file")
+
+	test.Assert(t, s.location(logPath) == nil, "file with no stored offset should start at the beginning")
+
+	err = s.save(map[string]fileOffset{logPath: {Offset: 5, Inode: ino}})
+	test.AssertNotError(t, err, "failed to save offsets")
+
+	s, err = loadOffsets(storePath)
+	test.AssertNotError(t, err, "failed to load saved offsets")
+	test.AssertDeepEquals(t, s.location(logPath), &tail.SeekInfo{Offset: 5, Whence: io.SeekStart})
+
+	// A truncated file should be read from the beginning.
+	err = ioutil.WriteFile(logPath, []byte("012\n"), 0600)
+	test.AssertNotError(t, err, "failed to truncate log file")
+	test.Assert(t, s.location(logPath) == nil, "truncated file should start at the beginning")
+
+	// So should a file which has been rotated away and replaced, even if the
+	// replacement is longer than the stored offset.
+	err = os.Rename(logPath, logPath+".1")
+	test.AssertNotError(t, err, "failed to rotate log file")
+	err = ioutil.WriteFile(logPath, []byte("0123456789\n"), 0600)
+	test.AssertNotError(t, err, "failed to write replacement log file")
+	test.Assert(t, s.location(logPath) == nil, "rotated file should start at the beginning")
+
+	// And so should a file which no longer exists.
+	err = os.Remove(logPath)
+	test.AssertNotError(t, err, "failed to remove log file")
+	test.Assert(t, s.location(logPath) == nil, "missing file should start at the beginning")
+
+	err = ioutil.WriteFile(storePath, []byte("{"), 0600)
+	test.AssertNotError(t, err, "failed to corrupt offset file")
+	_, err = loadOffsets(storePath)
+	test.AssertError(t, err, "corrupt offset file was accepted")
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// quarantineRecord is the JSON representation of an invalid tailed line in the
+// quarantine file.
+type quarantineRecord struct {
+	Time  time.Time `json:"time"`
+	File  string    `json:"file"`
+	Error string    `json:"error"`
+	Raw   string    `json:"raw"`
+}
+
+// quarantine appends invalid tailed lines to a file for later forensic review.
+// To bound disk usage, once appending a line would grow the file past maxSize
+// it is renamed with a ".1" suffix, replacing any previous such file, and a new
+// file is started. At most about twice maxSize bytes are kept on disk.
+type quarantine struct {
+	sync.Mutex
+	path    string
+	maxSize int64
+	f       *os.File
+	size    int64
+}
+
+// openQuarantine opens the quarantine file at path for appending, creating it
+// if necessary.
+func openQuarantine(path string, maxSize int64) (*quarantine, error) {
+	q := &quarantine{path: path, maxSize: maxSize}
+	err := q.open()
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *quarantine) open() error {
+	f, err := os.OpenFile(q.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	q.f = f
+	q.size = fi.Size()
+	return nil
+}
+
+// rotate replaces any previously rotated quarantine file with the current one
+// and starts a new, empty, quarantine file.
+func (q *quarantine) rotate() error {
+	err := q.f.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(q.path, q.path+".1")
+	if err != nil {
+		return err
+	}
+	return q.open()
+}
+
+// add appends a record of a line read from filename which failed validation
+// with lineErr.
+func (q *quarantine) add(now time.Time, filename string, lineErr error, raw string) error {
+	data, err := json.Marshal(quarantineRecord{
+		Time:  now,
+		File:  filename,
+		Error: lineErr.Error(),
+		Raw:   raw,
+	})
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	q.Lock()
+	defer q.Unlock()
+	if q.size > 0 && q.size+int64(len(data)) > q.maxSize {
+		err := q.rotate()
+		if err != nil {
+			return err
+		}
+	}
+	n, err := q.f.Write(data)
+	q.size += int64(n)
+	return err
+}
+
+// close closes the quarantine file.
+func (q *quarantine) close() error {
+	q.Lock()
+	defer q.Unlock()
+	return q.f.Close()
+}
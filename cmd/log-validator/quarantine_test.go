@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// readQuarantine returns the records in the quarantine file at path.
+func readQuarantine(t *testing.T, path string) []quarantineRecord {
+	t.Helper()
+	f, err := os.Open(path)
+	test.AssertNotError(t, err, "failed to open quarantine file")
+	defer f.Close()
+	var records []quarantineRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r quarantineRecord
+		err := json.Unmarshal(scanner.Bytes(), &r)
+		test.AssertNotError(t, err, "failed to unmarshal quarantine record")
+		records = append(records, r)
+	}
+	test.AssertNotError(t, scanner.Err(), "failed to read quarantine file")
+	return records
+}
+
+func TestQuarantine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "quarantine.log")
+	q, err := openQuarantine(path, 250)
+	test.AssertNotError(t, err, "failed to open quarantine file")
+	now := time.Date(2020, 7, 6, 18, 7, 43, 0, time.UTC)
+	err = q.add(now, "a.log", errors.New("first"), "not a log line")
+	test.AssertNotError(t, err, "failed to add line")
+	err = q.add(now, "b.log", errors.New("second"), "not a log line")
+	test.AssertNotError(t, err, "failed to add line")
+	test.AssertEquals(t, len(readQuarantine(t, path)), 2)
+
+	// Reopening appends to the existing file, and exceeding the maximum size
+	// rotates it.
+	err = q.close()
+	test.AssertNotError(t, err, "failed to close quarantine file")
+	q, err = openQuarantine(path, 250)
+	test.AssertNotError(t, err, "failed to reopen quarantine file")
+	defer q.close()
+	err = q.add(now, "c.log", errors.New("third"), "not a log line")
+	test.AssertNotError(t, err, "failed to add line")
+
+	records := readQuarantine(t, path)
+	test.AssertEquals(t, len(records), 1)
+	test.AssertDeepEquals(t, records[0], quarantineRecord{now, "c.log", "third", "not a log line"})
+	records = readQuarantine(t, path+".1")
+	test.AssertEquals(t, len(records), 2)
+	test.AssertEquals(t, records[0].File, "a.log")
+	test.AssertEquals(t, records[1].Error, "second")
+}
+
+func TestTailerQuarantine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "quarantine.log")
+	q, err := openQuarantine(path, 1024*1024)
+	test.AssertNotError(t, err, "failed to open quarantine file")
+	tailer := newTailer(&config{}, nil, q, newTestLineCounter(), blog.NewMock())
+	tailer.validate("a.log", "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM")
+	tailer.validate("a.log", "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: xxxxxxx Caught SIGTERM")
+	tailer.stop()
+
+	records := readQuarantine(t, path)
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].File, "a.log")
+	test.AssertContains(t, records[0].Error, "invalid checksum")
+	test.AssertContains(t, records[0].Raw, "xxxxxxx Caught SIGTERM")
+}
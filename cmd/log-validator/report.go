@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// reporter receives the results of validating a file with -check-file.
+type reporter interface {
+	// badLine is called for each line of filename that failed validation.
+	badLine(filename string, lineNum int, err error, raw string)
+	// summary is called once filename has been completely validated.
+	summary(filename string, total, valid, invalid int)
+	// combined is called once after validating more than one file, with the
+	// number of files and the sum of their summaries.
+	combined(files, total, valid, invalid int)
+}
+
+// textReporter writes a human readable line for each invalid line, prefixed
+// with the name of the file it was read from if showFilename is set, and only
+// a combined summary.
+type textReporter struct {
+	w            io.Writer
+	showFilename bool
+}
+
+func (r textReporter) badLine(filename string, lineNum int, err error, raw string) {
+	if r.showFilename {
+		fmt.Fprintf(r.w, "%s: ", filename)
+	}
+	fmt.Fprintf(r.w, "[line %d] %s: %s\n", lineNum, err, raw)
+}
+
+func (r textReporter) summary(string, int, int, int) {}
+
+func (r textReporter) combined(files, total, valid, invalid int) {
+	fmt.Fprintf(r.w, "validated %d lines from %d files: %d valid, %d invalid\n", total, files, valid, invalid)
+}
+
+// badLineResult is the JSON representation of an invalid line.
+type badLineResult struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Raw    string `json:"raw"`
+}
+
+// summaryResult is the JSON representation of a completely validated file.
+type summaryResult struct {
+	File    string `json:"file"`
+	Total   int    `json:"total"`
+	Valid   int    `json:"valid"`
+	Invalid int    `json:"invalid"`
+}
+
+// combinedResult is the JSON representation of the results of validating more
+// than one file.
+type combinedResult struct {
+	Files   int `json:"files"`
+	Total   int `json:"total"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+}
+
+// jsonReporter writes one JSON object per invalid line, followed by a summary
+// object per file and, for more than one file, a combined summary object, for
+// consumption by CI pipelines.
+type jsonReporter struct {
+	enc *json.Encoder
+}
+
+func newJSONReporter(w io.Writer) jsonReporter {
+	return jsonReporter{json.NewEncoder(w)}
+}
+
+func (r jsonReporter) badLine(filename string, lineNum int, err error, raw string) {
+	_ = r.enc.Encode(badLineResult{
+		File:   filename,
+		Line:   lineNum,
+		Status: lineStatus(err),
+		Error:  err.Error(),
+		Raw:    raw,
+	})
+}
+
+func (r jsonReporter) summary(filename string, total, valid, invalid int) {
+	_ = r.enc.Encode(summaryResult{
+		File:    filename,
+		Total:   total,
+		Valid:   valid,
+		Invalid: invalid,
+	})
+}
+
+func (r jsonReporter) combined(files, total, valid, invalid int) {
+	_ = r.enc.Encode(combinedResult{
+		Files:   files,
+		Total:   total,
+		Valid:   valid,
+		Invalid: invalid,
+	})
+}
+
+// tallyReporter serializes calls to a reporter shared by files being validated
+// concurrently, and sums their summaries.
+type tallyReporter struct {
+	sync.Mutex
+	rep                   reporter
+	total, valid, invalid int
+}
+
+func (r *tallyReporter) badLine(filename string, lineNum int, err error, raw string) {
+	r.Lock()
+	defer r.Unlock()
+	r.rep.badLine(filename, lineNum, err, raw)
+}
+
+func (r *tallyReporter) summary(filename string, total, valid, invalid int) {
+	r.Lock()
+	defer r.Unlock()
+	r.total += total
+	r.valid += valid
+	r.invalid += invalid
+	r.rep.summary(filename, total, valid, invalid)
+}
+
+func (r *tallyReporter) combined(files, total, valid, invalid int) {
+	r.Lock()
+	defer r.Unlock()
+	r.rep.combined(files, total, valid, invalid)
+}
+
+// newReporter returns a reporter for the named output format, writing to w.
+// Text output includes filenames only if showFilenames is set, while JSON
+// output always includes them.
+func newReporter(format string, w io.Writer, showFilenames bool) (reporter, error) {
+	switch format {
+	case "text":
+		return textReporter{w, showFilenames}, nil
+	case "json":
+		return newJSONReporter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,492 @@
+package main
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hpcloud/tail"
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/cmd"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// tailer follows every file matching a set of glob patterns. Since
+// hpcloud/tail only follows a single known filename, the patterns are
+// periodically re-expanded and a new tail.Tail is started for each newly
+// matching path.
+type tailer struct {
+	sync.Mutex
+	patterns    []string
+	tails       map[string]*tail.Tail
+	lineCounter *prometheus.CounterVec
+	// validationLatency and tailLag aren't registered by newTailer; the
+	// caller should register them alongside lineCounter.
+	validationLatency prometheus.Histogram
+	tailLag           *prometheus.GaugeVec
+	logger            blog.Logger
+	done              chan struct{}
+	// offsets, if non-nil, is where the position reached in each file is
+	// persisted so tailing can resume there after a restart.
+	offsets *offsetStore
+	// validated holds, for each followed file, the offset just past the last
+	// line validated from it, which is what's persisted to offsets. It's
+	// only maintained if offsets is non-nil.
+	validatedMu sync.Mutex
+	validated   map[string]fileOffset
+	// quarantine, if non-nil, is where invalid lines are recorded.
+	quarantine *quarantine
+	// maxSkew, if non-zero, is how far a line's timestamp may be from the
+	// current time before it is counted as skewed.
+	maxSkew time.Duration
+	// hostnames maps file paths, or glob patterns matching them, to the only
+	// hostname which may appear in lines read from them.
+	hostnames map[string]string
+	// format describes the layout of lines read from every file.
+	format lineFormat
+	// maxLineLength, if non-zero, is the length above which lines are
+	// counted as oversize without being otherwise validated.
+	maxLineLength int
+	clk           clock.Clock
+	// following tracks the goroutines validating lines from each tail, which
+	// stop waits on for up to drainTimeout.
+	following    sync.WaitGroup
+	drainTimeout time.Duration
+	// started is when the tailer was created, which stands in for the time of
+	// the last valid line until one has been seen.
+	started time.Time
+
+	// counts tallies the lines validated from each file since startup,
+	// independently of lineCounter, for the summary logged on shutdown.
+	countsMu sync.Mutex
+	counts   map[string]*lineCounts
+}
+
+// lineCounts tallies the lines validated from a single file.
+type lineCounts struct {
+	total, valid, invalid int64
+	// drained is how many of the lines were validated after stop was called.
+	drained int64
+	// lastValid is when the most recent valid line was read.
+	lastValid time.Time
+}
+
+// newTailer returns a tailer for the files named by c.Files, configured by the
+// other fields of c. The offsets and quarantine may be nil.
+func newTailer(c *config, offsets *offsetStore, q *quarantine, lineCounter *prometheus.CounterVec, logger blog.Logger) *tailer {
+	clk := clock.New()
+	drainTimeout := c.DrainTimeout.Duration
+	if drainTimeout == 0 {
+		drainTimeout = 5 * time.Second
+	}
+	return &tailer{
+		patterns:    c.Files,
+		tails:       make(map[string]*tail.Tail),
+		lineCounter: lineCounter,
+		validationLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "log_line_validation_seconds",
+			Help:    "Time taken to validate each tailed log line",
+			Buckets: prometheus.ExponentialBuckets(0.000001, 4, 10),
+		}),
+		tailLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "log_tail_lag_seconds",
+			Help: "Difference between the current time and the timestamp of the most recent line validated from each file",
+		}, []string{"filename"}),
+		logger:        logger,
+		done:          make(chan struct{}),
+		offsets:       offsets,
+		validated:     make(map[string]fileOffset),
+		quarantine:    q,
+		maxSkew:       c.MaxClockSkew.Duration,
+		hostnames:     c.ExpectedHostnames,
+		format:        c.lineFormat(),
+		maxLineLength: c.MaxLineLength,
+		drainTimeout:  drainTimeout,
+		clk:           clk,
+		started:       clk.Now(),
+		counts:        make(map[string]*lineCounts),
+	}
+}
+
+// expectedHostname returns the hostname which lines read from filename must
+// have, or the empty string if any hostname is allowed. An exact match for
+// filename takes precedence over a matching glob pattern.
+func (t *tailer) expectedHostname(filename string) string {
+	if hostname, ok := t.hostnames[filename]; ok {
+		return hostname
+	}
+	for pattern, hostname := range t.hostnames {
+		if matched, _ := filepath.Match(pattern, filename); matched {
+			return hostname
+		}
+	}
+	return ""
+}
+
+// expand returns the sorted, de-duplicated set of paths matching the provided
+// glob patterns. A pattern without any glob metacharacters is returned as-is
+// even when no such file exists yet, since hpcloud/tail will wait for it to be
+// created. Compressed files are never returned: they have already been rotated
+// away and will never be appended to, so tailing them would only re-count old
+// lines. They can be validated directly with -check-file instead.
+func expand(patterns []string) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, `*?[\`) {
+			seen[pattern] = true
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			seen[match] = true
+		}
+	}
+	var paths []string
+	for path := range seen {
+		if isCompressed(path) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// scan expands the tailer's patterns and starts following any matching file
+// that isn't already being followed.
+func (t *tailer) scan() error {
+	t.Lock()
+	defer t.Unlock()
+	paths, err := expand(t.patterns)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if _, ok := t.tails[path]; ok {
+			continue
+		}
+		err := t.follow(path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchesAny returns true if filename matches any of the provided glob patterns.
+func matchesAny(filename string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, filename); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// reload replaces the tailer's patterns, stopping the tails of files which no
+// longer match any pattern and starting tails of newly matching files. Tails of
+// files which match both the old and new patterns are left untouched.
+func (t *tailer) reload(patterns []string) error {
+	_, err := expand(patterns)
+	if err != nil {
+		return err
+	}
+	t.Lock()
+	t.patterns = patterns
+	for filename, tl := range t.tails {
+		if matchesAny(filename, patterns) {
+			continue
+		}
+		t.logger.Infof("no longer tailing %s", filename)
+		stopTail(tl)
+		delete(t.tails, filename)
+		t.validatedMu.Lock()
+		delete(t.validated, filename)
+		t.validatedMu.Unlock()
+	}
+	t.Unlock()
+	return t.scan()
+}
+
+// follow starts tailing the named file and validating each line read from it.
+// The caller must hold the tailer's lock.
+func (t *tailer) follow(filename string) error {
+	var location *tail.SeekInfo
+	if t.offsets != nil {
+		location = t.offsets.location(filename)
+	}
+	tl, err := tail.TailFile(filename, tail.Config{
+		Location:  location,
+		ReOpen:    true,
+		MustExist: false, // sometimes files won't exist, so we must tolerate that
+		Follow:    true,
+		Logger:    tailLogger{t.logger},
+	})
+	if err != nil {
+		return err
+	}
+	t.tails[filename] = tl
+	if t.offsets != nil {
+		position := fileOffset{}
+		if location != nil {
+			position.Offset = location.Offset
+		}
+		// A file which doesn't exist yet gets its inode when its first line
+		// is validated.
+		position.Inode, _ = inode(filename)
+		t.validatedMu.Lock()
+		t.validated[filename] = position
+		t.validatedMu.Unlock()
+	}
+
+	t.following.Add(1)
+	go func() {
+		defer t.following.Done()
+		for line := range tl.Lines {
+			if line.Err != nil {
+				t.logger.Errf("error while tailing %s: %s", tl.Filename, line.Err)
+				continue
+			}
+			t.validate(tl.Filename, line.Text)
+			t.advance(tl.Filename, line.Text)
+			select {
+			case <-t.done:
+				t.countDrained(tl.Filename)
+			default:
+			}
+		}
+	}()
+	return nil
+}
+
+// validate checks a line read from filename, logging it if it is invalid and
+// counting it by status.
+func (t *tailer) validate(filename, text string) {
+	start := t.clk.Now()
+	defer func() {
+		t.validationLatency.Observe(t.clk.Since(start).Seconds())
+	}()
+	if ts, err := t.format.timestamp(text); err == nil {
+		t.tailLag.WithLabelValues(filename).Set(start.Sub(ts).Seconds())
+	}
+
+	var err error
+	if t.maxLineLength != 0 {
+		err = checkLength(text, t.maxLineLength)
+	}
+	if err == nil {
+		err = t.format.valid(text)
+	}
+	if expected := t.expectedHostname(filename); err == nil && expected != "" {
+		err = t.format.checkHostname(text, expected)
+	}
+	if err == nil && t.maxSkew != 0 {
+		err = t.format.checkTimestamp(text, t.clk.Now(), t.maxSkew)
+	}
+	status := "ok"
+	if err != nil {
+		status = lineStatus(err)
+		// An oversize line's error already includes as much of the line as is
+		// useful, so the whole line is neither logged nor quarantined.
+		raw := text
+		if oe, ok := err.(oversizeError); ok {
+			raw = oe.prefix
+			t.logger.Errf("%s: %s", filename, err)
+		} else {
+			t.logger.Errf("%s: %s %q", filename, err, raw)
+		}
+		if t.quarantine != nil {
+			qErr := t.quarantine.add(t.clk.Now(), filename, err, raw)
+			if qErr != nil {
+				t.logger.Errf("failed to quarantine line from %s: %s", filename, qErr)
+			}
+		}
+	}
+	t.lineCounter.WithLabelValues(filename, status, t.format.severity(text), t.format.binary(text)).Inc()
+	t.count(filename, err == nil)
+}
+
+// count records a validated line in the summary counts for filename.
+func (t *tailer) count(filename string, valid bool) {
+	t.countsMu.Lock()
+	defer t.countsMu.Unlock()
+	c, ok := t.counts[filename]
+	if !ok {
+		c = &lineCounts{}
+		t.counts[filename] = c
+	}
+	c.total++
+	if valid {
+		c.valid++
+		c.lastValid = t.clk.Now()
+	} else {
+		c.invalid++
+	}
+}
+
+// countDrained records that a line from filename was validated after stop was
+// called.
+func (t *tailer) countDrained(filename string) {
+	t.countsMu.Lock()
+	defer t.countsMu.Unlock()
+	if c, ok := t.counts[filename]; ok {
+		c.drained++
+	}
+}
+
+// logSummary logs the number of lines validated from each file since startup.
+func (t *tailer) logSummary() {
+	t.countsMu.Lock()
+	defer t.countsMu.Unlock()
+	var filenames []string
+	for filename := range t.counts {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		c := t.counts[filename]
+		t.logger.Infof("summary for %s: total=%d valid=%d invalid=%d drained=%d", filename, c.total, c.valid, c.invalid, c.drained)
+	}
+}
+
+// advance moves the validated offset of filename past text, a line read
+// from it, if filename's offset is being tracked.
+func (t *tailer) advance(filename, text string) {
+	t.validatedMu.Lock()
+	defer t.validatedMu.Unlock()
+	position, ok := t.validated[filename]
+	if !ok {
+		return
+	}
+	if position.Inode == 0 {
+		position.Inode, _ = inode(filename)
+	}
+	// hpcloud/tail strips the newline ending each line.
+	position.Offset += int64(len(text)) + 1
+	t.validated[filename] = position
+}
+
+// flushOffsets persists the offset just past the last line validated from
+// every tailed file, if the tailer has an offset store, so that no line read
+// but not yet validated is skipped after a restart. Files which haven't
+// existed since they were first followed are omitted. Once a file has been
+// rotated its offset keeps the old file's inode, so a restart validates the
+// new file from its beginning.
+func (t *tailer) flushOffsets() error {
+	if t.offsets == nil {
+		return nil
+	}
+	t.validatedMu.Lock()
+	offsets := make(map[string]fileOffset)
+	for filename, position := range t.validated {
+		if position.Inode == 0 {
+			continue
+		}
+		offsets[filename] = position
+	}
+	t.validatedMu.Unlock()
+	return t.offsets.save(offsets)
+}
+
+// watch calls scan every rescanInterval, picking up files that begin matching
+// the tailer's patterns after startup, and flushes offsets every
+// flushInterval, until stop is called.
+func (t *tailer) watch(rescanInterval, flushInterval time.Duration) {
+	rescan := time.NewTicker(rescanInterval)
+	defer rescan.Stop()
+	flush := time.NewTicker(flushInterval)
+	defer flush.Stop()
+	for {
+		select {
+		case <-rescan.C:
+			err := t.scan()
+			if err != nil {
+				t.logger.Errf("failed to scan for new files: %s", err)
+			}
+		case <-flush.C:
+			err := t.flushOffsets()
+			if err != nil {
+				t.logger.Errf("failed to save offsets: %s", err)
+			}
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// stop ends any watch loop, stops following every file and waits for the
+// lines already read to be validated, flushes offsets, closes the quarantine
+// file, and logs a summary of the lines validated since startup.
+func (t *tailer) stop() {
+	for _, step := range t.shutdownSteps() {
+		step.Run()
+	}
+}
+
+// shutdownSteps returns the steps taken by stop, named so that any which
+// don't finish before the shutdown deadline can be logged.
+func (t *tailer) shutdownSteps() []cmd.ShutdownStep {
+	return []cmd.ShutdownStep{
+		{Name: "stop tails", Run: func() {
+			close(t.done)
+			t.Lock()
+			for _, tl := range t.tails {
+				stopTail(tl)
+			}
+			t.Unlock()
+		}},
+		{Name: "drain", Run: t.drain},
+		// Offsets are saved once the lines already read have been validated,
+		// so that they include those lines.
+		{Name: "save offsets", Run: func() {
+			err := t.flushOffsets()
+			if err != nil {
+				t.logger.Errf("failed to save offsets: %s", err)
+			}
+		}},
+		{Name: "close quarantine file", Run: func() {
+			if t.quarantine != nil {
+				err := t.quarantine.close()
+				if err != nil {
+					t.logger.Errf("failed to close quarantine file: %s", err)
+				}
+			}
+		}},
+		{Name: "log summary", Run: t.logSummary},
+	}
+}
+
+// drain waits up to drainTimeout for the goroutines validating lines from
+// stopped tails to finish, so that lines read before shutdown aren't lost.
+func (t *tailer) drain() {
+	drained := make(chan struct{})
+	go func() {
+		t.following.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(t.drainTimeout):
+		t.logger.Warningf("gave up waiting for tailed lines to be validated after %s", t.drainTimeout)
+	}
+}
+
+// stopTail stops following a file.
+func stopTail(tl *tail.Tail) {
+	// The tail module seems to have a race condition that will generate
+	// errors like this on shutdown:
+	// failed to stop tailing file: <filename>: Failed to detect creation of
+	// <filename>: inotify watcher has been closed
+	// This is probably related to the module's shutdown logic triggering the
+	// "reopen" code path for files that are removed and then recreated.
+	// These errors are harmless so we ignore them to allow clean shutdown.
+	_ = tl.Stop()
+	tl.Cleanup()
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/cmd"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestExpand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{
+		"boulder-wfe.log.2024-01-01",
+		"boulder-wfe.log.2024-01-02",
+		"boulder-wfe.log.2023-12-31.gz",
+		"boulder-ra.log",
+	} {
+		err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0600)
+		test.AssertNotError(t, err, "failed to create file")
+	}
+
+	paths, err := expand([]string{
+		filepath.Join(dir, "boulder-wfe.log.*"),
+		filepath.Join(dir, "boulder-ra.log"),
+		filepath.Join(dir, "boulder-ra.*"),
+		filepath.Join(dir, "not-yet-created.log"),
+	})
+	test.AssertNotError(t, err, "expand failed")
+	test.AssertDeepEquals(t, paths, []string{
+		filepath.Join(dir, "boulder-ra.log"),
+		filepath.Join(dir, "boulder-wfe.log.2024-01-01"),
+		filepath.Join(dir, "boulder-wfe.log.2024-01-02"),
+		filepath.Join(dir, "not-yet-created.log"),
+	})
+
+	_, err = expand([]string{"["})
+	test.AssertError(t, err, "malformed pattern was accepted")
+}
+
+func TestTailerSavesValidatedOffsets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "a.log")
+	const line = "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM\n"
+	err = ioutil.WriteFile(filename, []byte(strings.Repeat(line, 5)), 0600)
+	test.AssertNotError(t, err, "failed to write log file")
+	ino, err := inode(filename)
+	test.AssertNotError(t, err, "failed to get inode")
+
+	// Resume after the first two lines, as if they were validated before a
+	// restart.
+	storePath := filepath.Join(dir, "offsets.json")
+	offsets, err := loadOffsets(storePath)
+	test.AssertNotError(t, err, "failed to load offsets")
+	err = offsets.save(map[string]fileOffset{filename: {Offset: int64(2 * len(line)), Inode: ino}})
+	test.AssertNotError(t, err, "failed to save offsets")
+
+	lineCounter := newTestLineCounter()
+	tailer := newTailer(&config{Files: []string{filename}}, offsets, nil, lineCounter, blog.NewMock())
+	err = tailer.scan()
+	test.AssertNotError(t, err, "failed to tail files")
+	ok := lineCounter.WithLabelValues(filename, "ok", "info", "boulder-wfe")
+	for i := 0; i < 100 && test.CountCounter(ok) < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	tailer.stop()
+	test.AssertEquals(t, test.CountCounter(ok), 3)
+
+	// The offset saved on shutdown is just past the last line validated.
+	offsets, err = loadOffsets(storePath)
+	test.AssertNotError(t, err, "failed to load saved offsets")
+	test.AssertDeepEquals(t, offsets.saved[filename], fileOffset{Offset: int64(5 * len(line)), Inode: ino})
+
+	// Lines which were read but not validated aren't counted in the offset.
+	tailer = newTailer(&config{}, offsets, nil, lineCounter, blog.NewMock())
+	tailer.validated[filename] = fileOffset{Offset: 0, Inode: ino}
+	tailer.advance(filename, strings.TrimSuffix(line, "\n"))
+	err = tailer.flushOffsets()
+	test.AssertNotError(t, err, "failed to flush offsets")
+	test.AssertDeepEquals(t, offsets.saved[filename], fileOffset{Offset: int64(len(line)), Inode: ino})
+}
+
+func newTestLineCounter() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_lines",
+	}, []string{"filename", "status", "severity", "binary"})
+}
+
+func countLines(lineCounter *prometheus.CounterVec, filename, status string) int {
+	return test.CountCounter(lineCounter.With(prometheus.Labels{
+		"filename": filename,
+		"status":   status,
+		"severity": "info",
+		"binary":   "boulder-wfe",
+	}))
+}
+
+func TestTailerValidateSkew(t *testing.T) {
+	lineCounter := newTestLineCounter()
+	log := blog.NewMock()
+	tailer := newTailer(&config{MaxClockSkew: cmd.ConfigDuration{Duration: time.Minute}}, nil, nil, lineCounter, log)
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 7, 6, 18, 7, 43, 0, time.UTC))
+	tailer.clk = fc
+
+	const line = "%s 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM"
+	tailer.validate("a.log", fmt.Sprintf(line, "2020-07-06T18:07:43.109389+00:00"))
+	tailer.validate("a.log", fmt.Sprintf(line, "2020-07-06T18:06:50+00:00"))
+	tailer.validate("a.log", fmt.Sprintf(line, "2020-07-06T18:09:43.109389+00:00"))
+	tailer.validate("a.log", fmt.Sprintf(line, "2020-07-06T17:07:43Z"))
+	tailer.validate("a.log", fmt.Sprintf(line, "yesterday"))
+	test.AssertEquals(t, countLines(lineCounter, "a.log", "ok"), 2)
+	test.AssertEquals(t, countLines(lineCounter, "a.log", "skewed"), 2)
+	test.AssertEquals(t, countLines(lineCounter, "a.log", "bad_timestamp"), 1)
+	test.AssertEquals(t, len(log.GetAllMatching("skewed by")), 2)
+
+	// With no maximum skew, timestamps aren't checked at all.
+	tailer.maxSkew = 0
+	tailer.validate("b.log", fmt.Sprintf(line, "yesterday"))
+	test.AssertEquals(t, countLines(lineCounter, "b.log", "ok"), 1)
+}
+
+func TestTailerValidateHostname(t *testing.T) {
+	lineCounter := newTestLineCounter()
+	log := blog.NewMock()
+	tailer := newTailer(&config{
+		ExpectedHostnames: map[string]string{
+			"/var/log/boulder-wfe.log":    "wfe-host",
+			"/var/log/boulder-wfe.log.*":  "other-host",
+			"/var/log/archive/*/boulder*": "archive-host",
+		},
+	}, nil, nil, lineCounter, log)
+
+	const line = "2020-07-06T18:07:43.109389+00:00 %s datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM"
+	tailer.validate("/var/log/boulder-wfe.log", fmt.Sprintf(line, "wfe-host"))
+	tailer.validate("/var/log/boulder-wfe.log", fmt.Sprintf(line, "other-host"))
+	tailer.validate("/var/log/archive/1/boulder-wfe.log", fmt.Sprintf(line, "archive-host"))
+	tailer.validate("/var/log/archive/1/boulder-wfe.log", fmt.Sprintf(line, "wfe-host"))
+	tailer.validate("/var/log/boulder-ra.log", fmt.Sprintf(line, "anything"))
+
+	test.AssertEquals(t, countLines(lineCounter, "/var/log/boulder-wfe.log", "ok"), 1)
+	test.AssertEquals(t, countLines(lineCounter, "/var/log/boulder-wfe.log", "wrong_host"), 1)
+	test.AssertEquals(t, countLines(lineCounter, "/var/log/archive/1/boulder-wfe.log", "ok"), 1)
+	test.AssertEquals(t, countLines(lineCounter, "/var/log/archive/1/boulder-wfe.log", "wrong_host"), 1)
+	test.AssertEquals(t, countLines(lineCounter, "/var/log/boulder-ra.log", "ok"), 1)
+	test.AssertEquals(t, len(log.GetAllMatching(`unexpected hostname \(expected "wfe-host", got "other-host"\)`)), 1)
+}
+
+func TestTailerValidateOversize(t *testing.T) {
+	lineCounter := newTestLineCounter()
+	log := blog.NewMock()
+	tailer := newTailer(&config{MaxLineLength: 200}, nil, nil, lineCounter, log)
+
+	const line = "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM"
+	tailer.validate("a.log", line)
+	tailer.validate("a.log", line+strings.Repeat("A", 200))
+	test.AssertEquals(t, countLines(lineCounter, "a.log", "ok"), 1)
+	test.AssertEquals(t, countLines(lineCounter, "a.log", "oversize"), 1)
+	logged := log.GetAllMatching("exceeds maximum length")
+	test.AssertEquals(t, len(logged), 1)
+	test.AssertContains(t, logged[0], "line of 300 bytes exceeds maximum length of 200 bytes")
+	test.AssertContains(t, logged[0], fmt.Sprintf("(begins %q)", line[:oversizePrefixLength]))
+	test.Assert(t, !strings.Contains(logged[0], "AAAA"), "oversize line was logged in full")
+}
+
+func TestTailerMetrics(t *testing.T) {
+	tailer := newTailer(&config{}, nil, nil, newTestLineCounter(), blog.NewMock())
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 7, 6, 18, 8, 43, 109389000, time.UTC))
+	tailer.clk = fc
+
+	tailer.validate("a.log", "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM")
+	tailer.validate("b.log", "2020-07-06T18:08:33.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM")
+	tailer.validate("b.log", "not a log line")
+	test.AssertEquals(t, test.CountHistogramSamples(tailer.validationLatency), 3)
+	lag, err := test.GaugeValueWithLabels(tailer.tailLag, prometheus.Labels{"filename": "a.log"})
+	test.AssertNotError(t, err, "failed to read tail lag")
+	test.AssertEquals(t, lag, 60)
+	// Lines without a timestamp don't affect the lag.
+	lag, err = test.GaugeValueWithLabels(tailer.tailLag, prometheus.Labels{"filename": "b.log"})
+	test.AssertNotError(t, err, "failed to read tail lag")
+	test.AssertEquals(t, lag, 10)
+}
+
+func TestTailerSummary(t *testing.T) {
+	log := blog.NewMock()
+	tailer := newTailer(&config{}, nil, nil, newTestLineCounter(), log)
+
+	const valid = "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM"
+	const invalid = "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: xxxxxxx Caught SIGTERM"
+	tailer.validate("b.log", valid)
+	tailer.validate("a.log", valid)
+	tailer.validate("a.log", invalid)
+	tailer.validate("a.log", valid)
+	log.Clear()
+
+	tailer.stop()
+	test.AssertDeepEquals(t, log.GetAllMatching("summary for"), []string{
+		"INFO: summary for a.log: total=3 valid=2 invalid=1 drained=0",
+		"INFO: summary for b.log: total=1 valid=1 invalid=0 drained=0",
+	})
+}
+
+func TestTailerDrain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "a.log")
+	const line = "2020-07-06T18:07:43.109389+00:00 70877f679c72 datacenter 6 boulder-wfe[1595]: kKG6cwA Caught SIGTERM\n"
+	err = ioutil.WriteFile(filename, []byte(strings.Repeat(line, 100)), 0600)
+	test.AssertNotError(t, err, "failed to write log file")
+
+	lineCounter := newTestLineCounter()
+	tailer := newTailer(&config{Files: []string{filename}}, nil, nil, lineCounter, blog.NewMock())
+	err = tailer.scan()
+	test.AssertNotError(t, err, "failed to tail files")
+	for i := 0; i < 100 && countLines(lineCounter, filename, "ok") == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	tailer.stop()
+
+	// Once stop has returned, every line received from the tail has been
+	// validated and counted.
+	counted := countLines(lineCounter, filename, "ok")
+	test.Assert(t, counted > 0, "no lines were validated")
+	time.Sleep(10 * time.Millisecond)
+	test.AssertEquals(t, countLines(lineCounter, filename, "ok"), counted)
+	c := tailer.counts[filename]
+	test.AssertEquals(t, c.total, int64(counted))
+
+	// A goroutine which never finishes doesn't prevent stop from returning.
+	log := blog.NewMock()
+	tailer = newTailer(&config{DrainTimeout: cmd.ConfigDuration{Duration: time.Millisecond}}, nil, nil, lineCounter, log)
+	tailer.following.Add(1)
+	tailer.stop()
+	test.AssertEquals(t, len(log.GetAllMatching("gave up waiting for tailed lines to be validated after 1ms")), 1)
+}
+
+func tailedFiles(t *tailer) []string {
+	t.Lock()
+	defer t.Unlock()
+	var filenames []string
+	for filename := range t.tails {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	return filenames
+}
+
+func TestTailerReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log-validator")
+	test.AssertNotError(t, err, "failed to create temporary directory")
+	defer os.RemoveAll(dir)
+
+	a := filepath.Join(dir, "a.log")
+	b := filepath.Join(dir, "b.log")
+	c := filepath.Join(dir, "c.log")
+	for _, filename := range []string{a, b, c} {
+		err := ioutil.WriteFile(filename, nil, 0600)
+		test.AssertNotError(t, err, "failed to create log file")
+	}
+
+	configPath := filepath.Join(dir, "log-validator.json")
+	writeConfig := func(files ...string) {
+		t.Helper()
+		data := fmt.Sprintf(`{"debugAddr": ":8016", "files": [%q, %q]}`, files[0], files[1])
+		err := ioutil.WriteFile(configPath, []byte(data), 0600)
+		test.AssertNotError(t, err, "failed to write config file")
+	}
+
+	writeConfig(a, b)
+	conf, err := loadConfig(configPath)
+	test.AssertNotError(t, err, "failed to load config")
+
+	lineCounter := newTestLineCounter()
+	tailer := newTailer(conf, nil, nil, lineCounter, blog.NewMock())
+	defer tailer.stop()
+	err = tailer.scan()
+	test.AssertNotError(t, err, "failed to tail files")
+	test.AssertDeepEquals(t, tailedFiles(tailer), []string{a, b})
+	tailB := tailer.tails[b]
+
+	writeConfig(b, c)
+	conf, err = loadConfig(configPath)
+	test.AssertNotError(t, err, "failed to reload config")
+	err = tailer.reload(conf.Files)
+	test.AssertNotError(t, err, "failed to apply reloaded config")
+	test.AssertDeepEquals(t, tailedFiles(tailer), []string{b, c})
+	test.Assert(t, tailer.tails[b] == tailB, "tail of unchanged file was restarted")
+
+	err = tailer.reload([]string{"["})
+	test.AssertError(t, err, "malformed pattern was accepted")
+	test.AssertDeepEquals(t, tailedFiles(tailer), []string{b, c})
+}
@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/letsencrypt/boulder/cmd"
 	corepb "github.com/letsencrypt/boulder/core/proto"
@@ -17,7 +21,30 @@ type config struct {
 		Syslog      cmd.SyslogConfig
 		MaxUsed     int
 		NoncePrefix string
+
+		// SigningKeyFile is the path to a file containing a hex encoded AES key
+		// that new nonces are encrypted with. If it is empty a random key is
+		// generated at startup.
+		SigningKeyFile string
+		// VerificationKeyFiles are paths to files containing hex encoded AES
+		// keys that are accepted when redeeming nonces, in addition to the
+		// signing key. This allows outstanding nonces to remain valid while
+		// the signing key is rotated.
+		VerificationKeyFiles []string
+	}
+}
+
+// loadKey reads a hex encoded nonce key from the file at the given path.
+func loadKey(path string) ([]byte, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding nonce key from %q: %s", path, err)
 	}
+	return key, nil
 }
 
 type nonceServer struct {
@@ -41,11 +68,15 @@ func main() {
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	prefixOverride := flag.String("prefix", "", "Override the configured nonce prefix")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 
 	var c config
 	err := cmd.ReadConfigFile(*configFile, &c)
 	cmd.FailOnError(err, "Reading JSON config file into config structure")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	if *grpcAddr != "" {
 		c.NonceService.GRPC.Address = *grpcAddr
@@ -61,8 +92,32 @@ func main() {
 	defer logger.AuditPanic()
 	logger.Info(cmd.VersionString())
 
-	ns, err := nonce.NewNonceService(scope, c.NonceService.MaxUsed, c.NonceService.NoncePrefix)
-	cmd.FailOnError(err, "Failed to initialize nonce service")
+	var ns *nonce.NonceService
+	if c.NonceService.SigningKeyFile == "" {
+		if len(c.NonceService.VerificationKeyFiles) > 0 {
+			cmd.Fail("verificationKeyFiles cannot be used without a signingKeyFile")
+		}
+		ns, err = nonce.NewNonceService(scope, c.NonceService.MaxUsed, c.NonceService.NoncePrefix)
+		cmd.FailOnError(err, "Failed to initialize nonce service")
+	} else {
+		signingKey, err := loadKey(c.NonceService.SigningKeyFile)
+		cmd.FailOnError(err, "Failed to load nonce signing key")
+		logger.Infof("Signing nonces with key ID %s", nonce.KeyID(signingKey))
+		var verificationKeys [][]byte
+		for _, path := range c.NonceService.VerificationKeyFiles {
+			key, err := loadKey(path)
+			cmd.FailOnError(err, "Failed to load nonce verification key")
+			logger.Infof("Accepting nonces with key ID %s", nonce.KeyID(key))
+			verificationKeys = append(verificationKeys, key)
+		}
+		ns, err = nonce.NewNonceServiceWithKeys(
+			scope,
+			c.NonceService.MaxUsed,
+			c.NonceService.NoncePrefix,
+			signingKey,
+			verificationKeys)
+		cmd.FailOnError(err, "Failed to initialize nonce service")
+	}
 
 	tlsConfig, err := c.NonceService.TLS.Load()
 	cmd.FailOnError(err, "tlsConfig config")
@@ -37,6 +37,16 @@ type mailer struct {
 	destinations  []recipient
 	targetRange   interval
 	sleepInterval time.Duration
+	// sendInterval is the shortest time allowed between the start of one
+	// message and the next, limiting the rate messages are sent at. Zero
+	// doesn't limit it.
+	sendInterval time.Duration
+	// sendWindow, if set, restricts sending to certain times of day.
+	sendWindow *sendWindow
+	// checkpointFile, if set, names the file recording the last address
+	// handled, so that an interrupted run can resume where it stopped.
+	checkpointFile   string
+	progressInterval time.Duration
 }
 
 // interval defines a range of email addresses to send to, alphabetically.
@@ -66,6 +76,55 @@ func (i *interval) includes(s string) bool {
 	return s >= i.start && s < i.end
 }
 
+// sendWindow defines a daily range of times of day, in UTC, during which
+// email may be sent. The "start" field is inclusive and the "end" field is
+// exclusive. A window whose end is before its start spans midnight.
+type sendWindow struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// parseSendWindow parses a send window of the form "HH:MM-HH:MM".
+func parseSendWindow(s string) (*sendWindow, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("send window %q isn't of the form HH:MM-HH:MM", s)
+	}
+	var bounds [2]time.Duration
+	for i, part := range parts {
+		t, err := time.Parse("15:04", strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("send window %q isn't of the form HH:MM-HH:MM: %s", s, err)
+		}
+		bounds[i] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if bounds[0] == bounds[1] {
+		return nil, fmt.Errorf("send window %q is empty", s)
+	}
+	return &sendWindow{start: bounds[0], end: bounds[1]}, nil
+}
+
+// wait returns how long it is from now until the window next opens, zero if
+// it's open now.
+func (w *sendWindow) wait(now time.Time) time.Duration {
+	now = now.UTC()
+	timeOfDay := now.Sub(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	var open bool
+	if w.start < w.end {
+		open = timeOfDay >= w.start && timeOfDay < w.end
+	} else {
+		open = timeOfDay >= w.start || timeOfDay < w.end
+	}
+	if open {
+		return 0
+	}
+	wait := w.start - timeOfDay
+	if wait < 0 {
+		wait += 24 * time.Hour
+	}
+	return wait
+}
+
 func (m *mailer) ok() error {
 	// Make sure the checkpoint range is OK
 	if checkpointErr := m.targetRange.ok(); checkpointErr != nil {
@@ -78,9 +137,74 @@ func (m *mailer) ok() error {
 			"sleep interval (%d) is < 0", m.sleepInterval)
 	}
 
+	if m.sendInterval < 0 {
+		return fmt.Errorf(
+			"send interval (%d) is < 0", m.sendInterval)
+	}
+
 	return nil
 }
 
+// waitToSend sleeps until the next message may be sent: until at least
+// sendInterval has passed since the previous message was sent at lastSend,
+// and until the send window, if there is one, is open.
+func (m *mailer) waitToSend(lastSend time.Time) {
+	if m.sendInterval > 0 && !lastSend.IsZero() {
+		if wait := m.sendInterval - m.clk.Since(lastSend); wait > 0 {
+			m.clk.Sleep(wait)
+		}
+	}
+	if m.sendWindow != nil {
+		if wait := m.sendWindow.wait(m.clk.Now()); wait > 0 {
+			m.log.Infof("Outside the send window. Waiting %s for it to open.", wait)
+			m.clk.Sleep(wait)
+		}
+	}
+}
+
+// printProgress logs how far through the run the mailer is, having handled
+// (sent, or had rejected by the server) done of total messages.
+func (m *mailer) printProgress(done, rejected, total int, start time.Time) {
+	elapsed := m.clk.Since(start)
+	var remaining time.Duration
+	if done > 0 {
+		remaining = elapsed / time.Duration(done) * time.Duration(total-done)
+	}
+	m.log.Infof("Progress: handled %d of %d messages [%.2f%%], %d rejected by the server. Elapsed: %s. Estimated remaining: %s",
+		done, total, (float32(done)/float32(total))*100, rejected, elapsed, remaining)
+}
+
+// readCheckpoint returns the address recorded in the checkpoint file, or the
+// empty string if there's no checkpoint file or it doesn't exist yet.
+func readCheckpoint(filename string) (string, error) {
+	if filename == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeCheckpoint records address, the last one handled, in the checkpoint
+// file. The file is replaced by renaming a new one over it so that it's never
+// left partially written.
+func writeCheckpoint(filename, address string) error {
+	if filename == "" {
+		return nil
+	}
+	tmp := filename + ".tmp"
+	err := ioutil.WriteFile(tmp, []byte(address+"\n"), 0640)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
 func (m *mailer) printStatus(to string, cur, total int, start time.Time) {
 	// Should never happen
 	if total <= 0 || cur < 1 || cur > total {
@@ -127,52 +251,97 @@ func (m *mailer) run() error {
 	}
 	m.log.Infof("Most frequent address %q had %d associated accounts", biggestAddress, biggest)
 
-	err = m.mailer.Connect()
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = m.mailer.Close()
-	}()
-
-	startTime := m.clk.Now()
-
 	sortedAddresses := sortAddresses(addressesToRecipients)
 	numAddresses := len(addressesToRecipients)
 
-	var sent int
-	for i, address := range sortedAddresses {
+	// Render every message before sending any so that a template referencing
+	// a variable missing for some recipient fails the run up front instead of
+	// part way through.
+	bodies := make(map[string]string, numAddresses)
+	for _, address := range sortedAddresses {
 		if !m.targetRange.includes(address) {
-			m.log.Debugf("skipping %q: out of target range")
+			m.log.Debugf("skipping %q: out of target range", address)
 			continue
 		}
 		if err := policy.ValidEmail(address); err != nil {
 			m.log.Infof("skipping %q: %s", address, err)
 			continue
 		}
-		recipients := addressesToRecipients[address]
-		m.printStatus(address, i+1, numAddresses, startTime)
 		var mailBody bytes.Buffer
-		err = m.emailTemplate.Execute(&mailBody, recipients)
+		err = m.emailTemplate.Execute(&mailBody, addressesToRecipients[address])
 		if err != nil {
-			return err
+			return fmt.Errorf("rendering message for %q: %s", address, err)
 		}
 		if mailBody.Len() == 0 {
 			return fmt.Errorf("email body was empty after interpolation.")
 		}
-		err := m.mailer.SendMail([]string{address}, m.subject, mailBody.String())
-		if err != nil {
-			switch err.(type) {
+		bodies[address] = mailBody.String()
+	}
+
+	// Addresses up to and including the checkpoint were handled by an earlier
+	// run, and since addresses are sent to in order, every address after it
+	// wasn't.
+	checkpoint, err := readCheckpoint(m.checkpointFile)
+	if err != nil {
+		return fmt.Errorf("reading checkpoint file %q: %s", m.checkpointFile, err)
+	}
+	if checkpoint != "" {
+		m.log.Infof("Resuming after checkpointed address %q", checkpoint)
+		for address := range bodies {
+			if address <= checkpoint {
+				delete(bodies, address)
+			}
+		}
+	}
+
+	err = m.mailer.Connect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = m.mailer.Close()
+	}()
+
+	startTime := m.clk.Now()
+	lastProgress := startTime
+	var lastSend time.Time
+
+	var sent, rejected int
+	for i, address := range sortedAddresses {
+		body, ok := bodies[address]
+		if !ok {
+			continue
+		}
+		m.waitToSend(lastSend)
+		m.printStatus(address, i+1, numAddresses, startTime)
+		lastSend = m.clk.Now()
+		sendErr := m.mailer.SendMail([]string{address}, m.subject, body)
+		if sendErr != nil {
+			switch sendErr.(type) {
 			case bmail.RecoverableSMTPError:
-				m.log.Errf("address %q was rejected by server: %s", address, err)
-				continue
+				m.log.Errf("address %q was rejected by server: %s", address, sendErr)
+				rejected++
 			default:
 				return fmt.Errorf("sending mail %d of %d to %q: %s",
-					i, len(sortedAddresses), address, err)
+					i, len(sortedAddresses), address, sendErr)
 			}
+		} else {
+			sent++
+		}
+		err := writeCheckpoint(m.checkpointFile, address)
+		if err != nil {
+			return fmt.Errorf("writing checkpoint file %q: %s", m.checkpointFile, err)
+		}
+		if m.progressInterval > 0 && m.clk.Since(lastProgress) >= m.progressInterval {
+			m.printProgress(sent+rejected, rejected, len(bodies), startTime)
+			lastProgress = m.clk.Now()
+		}
+		if sendErr == nil {
+			m.clk.Sleep(m.sleepInterval)
 		}
-		sent++
-		m.clk.Sleep(m.sleepInterval)
+	}
+	if len(bodies) > 0 {
+		m.printProgress(sent+rejected, rejected, len(bodies), startTime)
 	}
 	if sent == 0 {
 		return fmt.Errorf("sent zero messages. Check recipients and configured interval")
@@ -182,11 +351,21 @@ func (m *mailer) run() error {
 
 // resolveEmailAddresses looks up the id of each recipient to find that
 // account's email addresses, then adds that recipient to a map from address to
-// recipient struct.
+// recipient struct. Recipients given by address are added as is.
 func (m *mailer) resolveEmailAddresses() (emailToRecipientMap, error) {
 	result := make(emailToRecipientMap, len(m.destinations))
 
 	for _, r := range m.destinations {
+		if r.address != "" {
+			parsedEmail, err := mail.ParseAddress(r.address)
+			if err != nil {
+				return nil, fmt.Errorf("unparsable recipient address %q: %s", r.address, err)
+			}
+			addr := parsedEmail.Address
+			result[addr] = append(result[addr], r)
+			continue
+		}
+
 		// Get the email address for the reg ID
 		emails, err := emailsForReg(r.id, m.dbMap)
 		if err != nil {
@@ -245,11 +424,12 @@ func emailsForReg(id int, dbMap dbSelector) ([]string, error) {
 	return addresses, nil
 }
 
-// recipient represents one line in the input CSV, containing an account and
-// (optionally) some extra fields related to that account.
+// recipient represents one line in the input CSV, containing an account or an
+// email address and (optionally) some extra fields related to it.
 type recipient struct {
-	id    int
-	Extra map[string]string
+	id      int
+	address string
+	Extra   map[string]string
 }
 
 // emailToRecipientMap maps from an email address to a list of recipients with
@@ -257,9 +437,14 @@ type recipient struct {
 type emailToRecipientMap map[string][]recipient
 
 // readRecipientsList reads a CSV filename and parses that file into a list of
-// recipient structs. It puts any columns after the first into a per-recipient
-// map from column name -> value.
+// recipient structs. The first column is either "id", for registration IDs,
+// or "address", for email addresses. It puts any columns after the first into
+// a per-recipient map from column name -> value. Files ending in ".json" are
+// read with readRecipientsJSON instead.
 func readRecipientsList(filename string) ([]recipient, error) {
+	if strings.HasSuffix(filename, ".json") {
+		return readRecipientsJSON(filename)
+	}
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, err
@@ -272,8 +457,9 @@ func readRecipientsList(filename string) ([]recipient, error) {
 	if len(record) == 0 {
 		return nil, fmt.Errorf("no entries in CSV")
 	}
-	if record[0] != "id" {
-		return nil, fmt.Errorf("first field of CSV input must be an ID.")
+	byAddress := record[0] == "address"
+	if record[0] != "id" && !byAddress {
+		return nil, fmt.Errorf("first field of CSV input must be an ID or an address.")
 	}
 	var columnNames []string
 	for _, v := range record[1:] {
@@ -299,14 +485,17 @@ func readRecipientsList(filename string) ([]recipient, error) {
 			return nil, fmt.Errorf("Number of columns in CSV line didn't match header columns."+
 				" Got %d, expected %d. Line: %v", len(record), len(columnNames)+1, record)
 		}
-		id, err := strconv.Atoi(record[0])
-		if err != nil {
-			return nil, err
-		}
 		recip := recipient{
-			id:    id,
 			Extra: make(map[string]string),
 		}
+		if byAddress {
+			recip.address = strings.TrimSpace(record[0])
+		} else {
+			recip.id, err = strconv.Atoi(record[0])
+			if err != nil {
+				return nil, err
+			}
+		}
 		for i, v := range record[1:] {
 			recip.Extra[columnNames[i]] = v
 		}
@@ -314,6 +503,37 @@ func readRecipientsList(filename string) ([]recipient, error) {
 	}
 }
 
+// readRecipientsJSON reads a JSON file containing an object that maps email
+// addresses to objects of template variables and parses it into a list of
+// recipient structs, e.g.:
+//
+//	{
+//	  "a@example.com": {"accountID": "1234", "domains": "example.com"},
+//	  "b@example.net": {"accountID": "5678", "domains": "example.net"}
+//	}
+func readRecipientsJSON(filename string) ([]recipient, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var addressesToExtra map[string]map[string]string
+	err = json.Unmarshal(data, &addressesToExtra)
+	if err != nil {
+		return nil, err
+	}
+	if len(addressesToExtra) == 0 {
+		return nil, fmt.Errorf("no entries in JSON")
+	}
+	results := make([]recipient, 0, len(addressesToExtra))
+	for address, extra := range addressesToExtra {
+		if extra == nil {
+			extra = make(map[string]string)
+		}
+		results = append(results, recipient{address: address, Extra: extra})
+	}
+	return results, nil
+}
+
 const usageIntro = `
 Introduction:
 
@@ -336,6 +556,15 @@ The additional fields will be interpolated with Golang templating, e.g.:
 		{{ range . }} {{ .Extra.lastIssuance }}
 		{{ end }}
 
+To send recipient-specific content to addresses that aren't looked up from
+accounts, use "address" as the first column instead, or pass a JSON file (with
+a .json extension) mapping each address to its template variables:
+
+	{"jane@example.com": {"accountID": "1234", "domains": "example.com"}}
+
+If the template references a field that is missing for any recipient, the run
+fails before any email is sent.
+
 To help the operator gain confidence in the mailing run before committing fully
 three safety features are supported: dry runs, intervals and a sleep between emails.
 
@@ -360,6 +589,18 @@ opportunity for the operator to terminate early in the event of error. The
 -sleep flag honours durations with a unit suffix (e.g. 1m for 1 minute, 10s for
 10 seconds, etc). Using -sleep=0 will disable the sleep and send at full speed.
 
+To stay within the limits of an SMTP provider the -rate argument caps the
+number of messages sent per second, e.g. -rate=5, however long each takes to
+send. The -sendWindow argument restricts sending to a daily window of UTC times
+of day, e.g. -sendWindow=09:00-17:00, waiting for the window to open whenever
+it's closed. A window ending before it starts, e.g. 22:00-06:00, spans midnight.
+
+For long mailing runs the -checkpointFile argument names a file in which the
+last address handled is recorded after each message. If the run is
+interrupted, running it again with the same arguments resumes after that
+address without resending any messages. Progress is logged every
+-progressInterval, along with an estimate of the time remaining.
+
 Examples:
   Send an email with subject "Hello!" from the email "hello@goodbye.com" with
   the contents read from "test_msg_body.txt" to every email associated with the
@@ -396,12 +637,16 @@ Required arguments:
 func main() {
 	from := flag.String("from", "", "From header for emails. Must be a bare email address.")
 	subject := flag.String("subject", "", "Subject of emails")
-	recipientListFile := flag.String("recipientList", "", "File containing a CSV list of registration IDs and extra info.")
+	recipientListFile := flag.String("recipientList", "", "File containing a CSV list of registration IDs or addresses and extra info, or a JSON object mapping addresses to extra info.")
 	bodyFile := flag.String("body", "", "File containing the email body in Golang template format.")
 	dryRun := flag.Bool("dryRun", true, "Whether to do a dry run.")
 	sleep := flag.Duration("sleep", 500*time.Millisecond, "How long to sleep between emails.")
 	start := flag.String("start", "", "Alphabetically lowest email address to include.")
 	end := flag.String("end", "\xFF", "Alphabetically highest email address (exclusive).")
+	rate := flag.Float64("rate", 0, "Most messages to send per second. Zero doesn't limit the rate.")
+	window := flag.String("sendWindow", "", "Daily window of UTC times of day, as HH:MM-HH:MM, during which to send emails.")
+	checkpointFile := flag.String("checkpointFile", "", "File in which to record the last address handled, and to resume after it from.")
+	progressInterval := flag.Duration("progressInterval", 1*time.Minute, "How often to report progress. Zero only reports it at the end.")
 	reconnBase := flag.Duration("reconnectBase", 1*time.Second, "Base sleep duration between reconnect attempts")
 	reconnMax := flag.Duration("reconnectMax", 5*60*time.Second, "Max sleep duration between reconnect attempts after exponential backoff")
 	type config struct {
@@ -414,6 +659,7 @@ func main() {
 		Syslog cmd.SyslogConfig
 	}
 	configFile := flag.String("config", "", "File containing a JSON config.")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageIntro)
@@ -422,19 +668,20 @@ func main() {
 	}
 
 	flag.Parse()
-	if *from == "" || *subject == "" || *bodyFile == "" || *configFile == "" ||
-		*recipientListFile == "" {
+	if *configFile == "" || (!*validateConfig && (*from == "" || *subject == "" || *bodyFile == "" ||
+		*recipientListFile == "")) {
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	configData, err := ioutil.ReadFile(*configFile)
-	cmd.FailOnError(err, fmt.Sprintf("Reading %q", *configFile))
 	var cfg config
-	err = json.Unmarshal(configData, &cfg)
-	cmd.FailOnError(err, "Unmarshaling config")
+	err := cmd.ReadConfigFile(*configFile, &cfg)
+	cmd.FailOnError(err, fmt.Sprintf("Reading %q", *configFile))
 	err = features.Set(cfg.NotifyMailer.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&cfg)
+	}
 
 	log := cmd.NewLogger(cfg.Syslog)
 	defer log.AuditPanic()
@@ -447,7 +694,7 @@ func main() {
 	// Load email body
 	body, err := ioutil.ReadFile(*bodyFile)
 	cmd.FailOnError(err, fmt.Sprintf("Reading %q", *bodyFile))
-	template, err := template.New("email").Parse(string(body))
+	template, err := template.New("email").Option("missingkey=error").Parse(string(body))
 	cmd.FailOnError(err, fmt.Sprintf("Parsing template %q", *bodyFile))
 
 	address, err := mail.ParseAddress(*from)
@@ -461,6 +708,20 @@ func main() {
 		end:   *end,
 	}
 
+	if *rate < 0 {
+		cmd.Fail(fmt.Sprintf("rate (%g) is < 0", *rate))
+	}
+	var sendInterval time.Duration
+	if *rate > 0 {
+		sendInterval = time.Duration(float64(time.Second) / *rate)
+	}
+
+	var sendWindow *sendWindow
+	if *window != "" {
+		sendWindow, err = parseSendWindow(*window)
+		cmd.FailOnError(err, "Parsing send window")
+	}
+
 	var mailClient bmail.Mailer
 	if *dryRun {
 		log.Infof("Doing a dry run.")
@@ -491,6 +752,11 @@ func main() {
 		emailTemplate: template,
 		targetRange:   targetRange,
 		sleepInterval: *sleep,
+
+		sendInterval:     sendInterval,
+		sendWindow:       sendWindow,
+		checkpointFile:   *checkpointFile,
+		progressInterval: *progressInterval,
 	}
 
 	err = m.run()
@@ -119,6 +119,148 @@ func TestSleepInterval(t *testing.T) {
 	test.AssertEquals(t, m.clk.Now(), expectedEnd.Now())
 }
 
+func TestSendInterval(t *testing.T) {
+	mc := &mocks.Mailer{}
+	tmpl := template.Must(template.New("letter").Parse("an email body"))
+	recipients := []recipient{{id: 1}, {id: 2}, {id: 3}}
+	m := &mailer{
+		log:           blog.UseMock(),
+		mailer:        mc,
+		emailTemplate: tmpl,
+		sleepInterval: 0,
+		sendInterval:  2 * time.Second,
+		targetRange:   interval{end: "\xFF"},
+		clk:           newFakeClock(t),
+		destinations:  recipients,
+		dbMap:         mockEmailResolver{},
+	}
+
+	// The first message is sent straight away and each after it waits for
+	// the send interval to pass.
+	err := m.run()
+	test.AssertNotError(t, err, "error calling mailer run()")
+	test.AssertEquals(t, len(mc.Messages), len(recipients))
+	expectedEnd := newFakeClock(t)
+	expectedEnd.Add(2 * 2 * time.Second)
+	test.AssertEquals(t, m.clk.Now(), expectedEnd.Now())
+
+	// Time spent sleeping between messages counts towards the send interval.
+	mc.Clear()
+	m.clk = newFakeClock(t)
+	m.sleepInterval = 3 * time.Second
+	err = m.run()
+	test.AssertNotError(t, err, "error calling mailer run()")
+	expectedEnd = newFakeClock(t)
+	expectedEnd.Add(3 * 3 * time.Second)
+	test.AssertEquals(t, m.clk.Now(), expectedEnd.Now())
+
+	m.sendInterval = -1
+	test.AssertError(t, m.run(), "negative send interval was accepted")
+}
+
+func TestParseSendWindow(t *testing.T) {
+	w, err := parseSendWindow("09:00-17:30")
+	test.AssertNotError(t, err, "parsing valid send window")
+	test.AssertEquals(t, *w, sendWindow{start: 9 * time.Hour, end: 17*time.Hour + 30*time.Minute})
+
+	for _, bad := range []string{"", "09:00", "09:00-17:00-18:00", "9am-5pm", "25:00-01:00", "09:00-09:00"} {
+		_, err := parseSendWindow(bad)
+		test.AssertError(t, err, fmt.Sprintf("invalid send window %q was accepted", bad))
+	}
+}
+
+func TestSendWindowWait(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2020, 10, 1, hour, min, 0, 0, time.UTC)
+	}
+	day := &sendWindow{start: 9 * time.Hour, end: 17 * time.Hour}
+	test.AssertEquals(t, day.wait(at(9, 0)), time.Duration(0))
+	test.AssertEquals(t, day.wait(at(16, 59)), time.Duration(0))
+	test.AssertEquals(t, day.wait(at(8, 30)), 30*time.Minute)
+	test.AssertEquals(t, day.wait(at(17, 0)), 16*time.Hour)
+
+	// A window ending before it starts spans midnight.
+	night := &sendWindow{start: 22 * time.Hour, end: 6 * time.Hour}
+	test.AssertEquals(t, night.wait(at(23, 0)), time.Duration(0))
+	test.AssertEquals(t, night.wait(at(5, 59)), time.Duration(0))
+	test.AssertEquals(t, night.wait(at(6, 0)), 16*time.Hour)
+	test.AssertEquals(t, night.wait(at(21, 0)), time.Hour)
+
+	// Times in other zones are compared in UTC.
+	test.AssertEquals(t, day.wait(at(9, 0).In(time.FixedZone("UTC-5", -5*60*60))), time.Duration(0))
+}
+
+func TestSendWindow(t *testing.T) {
+	mc := &mocks.Mailer{}
+	tmpl := template.Must(template.New("letter").Parse("an email body"))
+	m := &mailer{
+		log:           blog.UseMock(),
+		mailer:        mc,
+		emailTemplate: tmpl,
+		sleepInterval: 0,
+		// The fake clock starts just before 15:04:06 UTC.
+		sendWindow:   &sendWindow{start: 16 * time.Hour, end: 17 * time.Hour},
+		targetRange:  interval{end: "\xFF"},
+		clk:          newFakeClock(t),
+		destinations: []recipient{{id: 1}, {id: 2}, {id: 3}},
+		dbMap:        mockEmailResolver{},
+	}
+
+	// Nothing is sent until the window opens, then everything is.
+	err := m.run()
+	test.AssertNotError(t, err, "error calling mailer run()")
+	test.AssertEquals(t, len(mc.Messages), 3)
+	test.AssertEquals(t, m.clk.Now(), time.Date(2006, 1, 2, 16, 0, 0, 0, time.UTC))
+}
+
+func TestCheckpoint(t *testing.T) {
+	mc := &mocks.Mailer{}
+	tmpl := template.Must(template.New("letter").Parse("an email body"))
+	dir, err := ioutil.TempDir("", "notify-mailer")
+	test.AssertNotError(t, err, "creating temporary directory")
+	defer os.RemoveAll(dir)
+	checkpointFile := dir + "/checkpoint"
+
+	m := &mailer{
+		log:            blog.UseMock(),
+		mailer:         mc,
+		emailTemplate:  tmpl,
+		sleepInterval:  0,
+		targetRange:    interval{end: "\xFF"},
+		clk:            newFakeClock(t),
+		destinations:   []recipient{{id: 1}, {id: 2}, {id: 3}, {id: 4}},
+		dbMap:          mockEmailResolver{},
+		checkpointFile: checkpointFile,
+	}
+
+	// A run interrupted after example@letsencrypt.org resumes after it,
+	// without resending to it or the addresses before it.
+	err = ioutil.WriteFile(checkpointFile, []byte("example@letsencrypt.org\n"), 0640)
+	test.AssertNotError(t, err, "writing checkpoint file")
+	err = m.run()
+	test.AssertNotError(t, err, "error calling mailer run()")
+	test.AssertEquals(t, len(mc.Messages), 2)
+	test.AssertEquals(t, mc.Messages[0].To, "test-example-updated@letsencrypt.org")
+	test.AssertEquals(t, mc.Messages[1].To, "test-test-test@letsencrypt.org")
+
+	// The checkpoint now records the last address, so running again sends
+	// nothing.
+	checkpoint, err := readCheckpoint(checkpointFile)
+	test.AssertNotError(t, err, "reading checkpoint file")
+	test.AssertEquals(t, checkpoint, "test-test-test@letsencrypt.org")
+	mc.Clear()
+	err = m.run()
+	test.AssertError(t, err, "run with every address checkpointed succeeded")
+	test.AssertEquals(t, len(mc.Messages), 0)
+
+	// Without a checkpoint file, every address is sent to.
+	err = os.Remove(checkpointFile)
+	test.AssertNotError(t, err, "removing checkpoint file")
+	err = m.run()
+	test.AssertNotError(t, err, "error calling mailer run()")
+	test.AssertEquals(t, len(mc.Messages), 4)
+}
+
 func TestMailIntervals(t *testing.T) {
 	const testSubject = "Test Subject"
 	dbMap := mockEmailResolver{}
@@ -362,6 +504,108 @@ Thanks`,
 	}, mc.Messages[0])
 }
 
+func TestReadRecipientsListAddresses(t *testing.T) {
+	csvFileName, err := makeFile(`address, accountID
+jane@letsencrypt.org,1234
+joe@letsencrypt.org,5678`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(csvFileName)
+	recipients, err := readRecipientsList(csvFileName)
+	test.AssertNotError(t, err, "reading address CSV failed")
+	test.AssertDeepEquals(t, recipients, []recipient{
+		{address: "jane@letsencrypt.org", Extra: map[string]string{"accountID": "1234"}},
+		{address: "joe@letsencrypt.org", Extra: map[string]string{"accountID": "5678"}},
+	})
+
+	jsonFileName, err := makeFile(`{"jane@letsencrypt.org": {"accountID": "1234"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(jsonFileName)
+	err = os.Rename(jsonFileName, jsonFileName+".json")
+	test.AssertNotError(t, err, "renaming JSON file failed")
+	defer os.Remove(jsonFileName + ".json")
+	recipients, err = readRecipientsList(jsonFileName + ".json")
+	test.AssertNotError(t, err, "reading JSON failed")
+	test.AssertDeepEquals(t, recipients, []recipient{
+		{address: "jane@letsencrypt.org", Extra: map[string]string{"accountID": "1234"}},
+	})
+
+	badFileName, err := makeFile(`name, accountID
+jane,1234`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(badFileName)
+	_, err = readRecipientsList(badFileName)
+	test.AssertError(t, err, "reading CSV without an id or address column should have errored")
+}
+
+// Send mail with per-address variables to recipients given by address, without
+// looking up any accounts.
+func TestMessageContentByAddress(t *testing.T) {
+	recipients := []recipient{
+		{address: "jane@letsencrypt.org", Extra: map[string]string{"accountID": "1234"}},
+		{address: "joe@letsencrypt.org", Extra: map[string]string{"accountID": "5678"}},
+	}
+	mc := &mocks.Mailer{}
+	m := &mailer{
+		log:          blog.UseMock(),
+		mailer:       mc,
+		dbMap:        mockEmailResolver{},
+		subject:      "Test Subject",
+		destinations: recipients,
+		emailTemplate: template.Must(template.New("letter").Option("missingkey=error").Parse(
+			`account {{range .}}{{ .Extra.accountID }}{{end}}`)),
+		targetRange:   interval{end: "\xFF"},
+		sleepInterval: 0,
+		clk:           newFakeClock(t),
+	}
+
+	err := m.run()
+	test.AssertNotError(t, err, "error calling mailer run()")
+	test.AssertEquals(t, len(mc.Messages), 2)
+	test.AssertEquals(t, mocks.MailerMessage{
+		To:      "jane@letsencrypt.org",
+		Subject: "Test Subject",
+		Body:    "account 1234",
+	}, mc.Messages[0])
+	test.AssertEquals(t, mocks.MailerMessage{
+		To:      "joe@letsencrypt.org",
+		Subject: "Test Subject",
+		Body:    "account 5678",
+	}, mc.Messages[1])
+}
+
+// A template referencing a variable missing for one recipient fails the run
+// before any mail is sent.
+func TestMessageContentMissingVariable(t *testing.T) {
+	recipients := []recipient{
+		{address: "jane@letsencrypt.org", Extra: map[string]string{"accountID": "1234"}},
+		{address: "joe@letsencrypt.org", Extra: map[string]string{}},
+	}
+	mc := &mocks.Mailer{}
+	m := &mailer{
+		log:          blog.UseMock(),
+		mailer:       mc,
+		dbMap:        mockEmailResolver{},
+		subject:      "Test Subject",
+		destinations: recipients,
+		emailTemplate: template.Must(template.New("letter").Option("missingkey=error").Parse(
+			`account {{range .}}{{ .Extra.accountID }}{{end}}`)),
+		targetRange:   interval{end: "\xFF"},
+		sleepInterval: 0,
+		clk:           newFakeClock(t),
+	}
+
+	err := m.run()
+	test.AssertError(t, err, "run() should have failed on a missing template variable")
+	test.AssertContains(t, err.Error(), "joe@letsencrypt.org")
+	test.AssertEquals(t, len(mc.Messages), 0)
+}
+
 // the `mockEmailResolver` implements the `dbSelector` interface from
 // `notify-mailer/main.go` to allow unit testing without using a backing
 // database
@@ -4,10 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto"
-	"crypto/sha1"
 	"crypto/x509"
-	"crypto/x509/pkix"
-	"encoding/asn1"
 	"encoding/hex"
 	"flag"
 	"fmt"
@@ -157,23 +154,13 @@ func makeDBSource(dbMap dbSelector, issuerCert string, reqSerialPrefixes []strin
 	if err != nil {
 		return nil, fmt.Errorf("Could not parse issuer cert %s: %s", issuerCert, err)
 	}
-	// The issuerKeyHash in OCSP requests is constructed over the DER
-	// encoding of the public key per RFC 6960 (defined in RFC 4055 for
-	// RSA and RFC  5480 for ECDSA). We can't use MarshalPKIXPublicKey
-	// for this since it encodes keys using the SPKI structure itself,
-	// and we just want the contents of the subjectPublicKey for the
-	// hash, so we need  to extract it ourselves.
-	var spki struct {
-		Algo      pkix.AlgorithmIdentifier
-		BitString asn1.BitString
-	}
-	if _, err := asn1.Unmarshal(caCert.RawSubjectPublicKeyInfo, &spki); err != nil {
+	keyHash, err := bocsp.IssuerKeyHash(caCert)
+	if err != nil {
 		return nil, err
 	}
-	keyHash := sha1.Sum(spki.BitString.Bytes)
 
 	// Construct a DB backed response source
-	return NewSourceFromDatabase(dbMap, keyHash[:], reqSerialPrefixes, timeout, log)
+	return NewSourceFromDatabase(dbMap, keyHash, reqSerialPrefixes, timeout, log)
 }
 
 type config struct {
@@ -187,11 +174,32 @@ type config struct {
 		// If DBConfig has non-empty fields, it takes precedence over this.
 		Source string
 
+		// ResponseDirectory, if its Path is set, makes the responder serve the
+		// precomputed DER responses in that directory for the issuer in
+		// Common.IssuerCert instead of using Source or the database, e.g. as a
+		// read-only disaster recovery mode when the database is unavailable.
+		ResponseDirectory struct {
+			Path string
+			// ReloadInterval is how often to check the directory for added,
+			// removed or modified responses. If zero, it is only read at
+			// startup.
+			ReloadInterval cmd.ConfigDuration
+			// TryLaterForMissing makes the responder answer requests for
+			// serials with no response in the directory with tryLater instead
+			// of unauthorized.
+			TryLaterForMissing bool
+		}
+
 		Path          string
 		ListenAddress string
-		// MaxAge is the max-age to set in the Cache-Control response
-		// header. It is a time.Duration formatted string.
+		// MaxAge is the largest max-age to set in the Cache-Control response
+		// header. It is a time.Duration formatted string. If zero, max-age
+		// isn't clamped.
 		MaxAge cmd.ConfigDuration
+		// MaxAgeFraction is the fraction, between 0 and 1, of a response's
+		// remaining validity (until its nextUpdate) to use as the max-age before
+		// clamping to MaxAge. If zero, all of the remaining validity is used.
+		MaxAgeFraction float64
 
 		// When to timeout a request. This should be slightly lower than the
 		// upstream's timeout when making request to ocsp-responder.
@@ -201,6 +209,18 @@ type config struct {
 
 		RequiredSerialPrefixes []string
 
+		// CacheSize, if non-zero, is how many responses to keep in an
+		// in-memory cache, so that repeated requests for the same
+		// certificate don't each require a database lookup.
+		CacheSize int
+		// CacheTTL is the longest a response is cached for. Responses are
+		// never cached past their nextUpdate. It must be set if CacheSize
+		// is. Cached responses aren't invalidated when the ocsp-updater
+		// produces a newer one, e.g. after a revocation: until a cached
+		// response expires, which may take up to CacheTTL, it's served in
+		// place of the newer one.
+		CacheTTL cmd.ConfigDuration
+
 		Features map[string]bool
 	}
 
@@ -213,12 +233,14 @@ type config struct {
 
 func main() {
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		fmt.Fprintf(os.Stderr, `Usage of %s:
 Config JSON should contain either a DBConnectFile or a Source value containing a file: URL.
 If Source is a file: URL, the file should contain a list of OCSP responses in base64-encoded DER,
 as generated by Boulder's ceremony command.
+Alternatively ResponseDirectory.Path may name a directory of DER-encoded OCSP responses.
 `, os.Args[0])
 		flag.PrintDefaults()
 		os.Exit(1)
@@ -229,6 +251,9 @@ as generated by Boulder's ceremony command.
 	cmd.FailOnError(err, "Reading JSON config file into config structure")
 	err = features.Set(c.OCSPResponder.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	stats, logger := cmd.StatsAndLogging(c.Syslog, c.OCSPResponder.DebugAddr)
 	defer logger.AuditPanic()
@@ -237,7 +262,20 @@ as generated by Boulder's ceremony command.
 	config := c.OCSPResponder
 	var source bocsp.Source
 
-	if strings.HasPrefix(config.Source, "file:") {
+	if config.ResponseDirectory.Path != "" {
+		caCertDER, err := cmd.LoadCert(c.Common.IssuerCert)
+		cmd.FailOnError(err, fmt.Sprintf("Couldn't read issuer cert %s", c.Common.IssuerCert))
+		caCert, err := x509.ParseCertificate(caCertDER)
+		cmd.FailOnError(err, fmt.Sprintf("Couldn't parse issuer cert %s", c.Common.IssuerCert))
+		source, err = bocsp.NewDirectorySource(
+			config.ResponseDirectory.Path,
+			[]*x509.Certificate{caCert},
+			config.ResponseDirectory.ReloadInterval.Duration,
+			config.ResponseDirectory.TryLaterForMissing,
+			logger,
+			stats)
+		cmd.FailOnError(err, fmt.Sprintf("Couldn't load responses from %s", config.ResponseDirectory.Path))
+	} else if strings.HasPrefix(config.Source, "file:") {
 		url, err := url.Parse(config.Source)
 		cmd.FailOnError(err, "Source was not a URL")
 		filename := url.Path
@@ -277,7 +315,18 @@ as generated by Boulder's ceremony command.
 		dbConnStat.Set(float64(config.DBConfig.MaxDBConns))
 	}
 
-	m := mux(stats, c.OCSPResponder.Path, source)
+	if config.CacheSize > 0 {
+		if config.CacheTTL.Duration <= 0 {
+			cmd.Fail("CacheTTL must be set when CacheSize is non-zero")
+		}
+		source = bocsp.NewCachingSource(source, config.CacheSize, config.CacheTTL.Duration, cmd.Clock(), stats)
+	}
+
+	if config.MaxAgeFraction < 0 || config.MaxAgeFraction > 1 {
+		cmd.Fail(fmt.Sprintf("MaxAgeFraction must be between 0 and 1, got %g", config.MaxAgeFraction))
+	}
+
+	m := mux(stats, c.OCSPResponder.Path, source, config.MaxAge.Duration, config.MaxAgeFraction)
 	srv := &http.Server{
 		Addr:    c.OCSPResponder.ListenAddress,
 		Handler: m,
@@ -317,8 +366,8 @@ func (om *ocspMux) Handler(_ *http.Request) (http.Handler, string) {
 	return om.handler, "/"
 }
 
-func mux(stats prometheus.Registerer, responderPath string, source bocsp.Source) http.Handler {
-	stripPrefix := http.StripPrefix(responderPath, bocsp.NewResponder(source, stats))
+func mux(stats prometheus.Registerer, responderPath string, source bocsp.Source, maxAge time.Duration, maxAgeFraction float64) http.Handler {
+	stripPrefix := http.StripPrefix(responderPath, bocsp.NewResponder(source, maxAge, maxAgeFraction, stats))
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "GET" && r.URL.Path == "/" {
 			w.Header().Set("Cache-Control", "max-age=43200") // Cache for 12 hours
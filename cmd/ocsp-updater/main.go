@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"flag"
 	"fmt"
+	mrand "math/rand"
 	"os"
 	"time"
 
@@ -55,6 +56,11 @@ type OCSPUpdater struct {
 
 	// Used to calculate how far back stale OCSP responses should be looked for
 	ocspMinTimeToExpiry time.Duration
+	// Maximum amount by which the recorded ocspLastUpdated of a new response is
+	// randomly backdated, so that responses generated together (e.g. for
+	// certificates issued in a burst) become stale at different times rather
+	// than all being regenerated in the same tick.
+	ocspRegenerationJitter time.Duration
 	// Maximum number of individual OCSP updates to attempt in parallel. Making
 	// these requests in parallel allows us to get higher total throughput.
 	parallelGenerateOCSPRequests int
@@ -85,6 +91,12 @@ func newUpdater(
 	if config.OldOCSPWindow.Duration == 0 {
 		return nil, fmt.Errorf("Loop window sizes must be non-zero")
 	}
+	// Backdating ocspLastUpdated by at least OCSPMinTimeToExpiry would make
+	// responses stale as soon as they're stored.
+	if config.OCSPRegenerationJitter.Duration != 0 &&
+		config.OCSPRegenerationJitter.Duration >= config.OCSPMinTimeToExpiry.Duration {
+		return nil, fmt.Errorf("OCSPRegenerationJitter must be less than OCSPMinTimeToExpiry")
+	}
 	if config.ParallelGenerateOCSPRequests == 0 {
 		// Default to 1
 		config.ParallelGenerateOCSPRequests = 1
@@ -118,6 +130,7 @@ func newUpdater(
 		log:                          log,
 		sac:                          sac,
 		ocspMinTimeToExpiry:          config.OCSPMinTimeToExpiry.Duration,
+		ocspRegenerationJitter:       config.OCSPRegenerationJitter.Duration,
 		parallelGenerateOCSPRequests: config.ParallelGenerateOCSPRequests,
 		purgerService:                apc,
 		genStoreHistogram:            genStoreHistogram,
@@ -215,12 +228,24 @@ func (updater *OCSPUpdater) generateResponse(ctx context.Context, status core.Ce
 		return nil, err
 	}
 
-	status.OCSPLastUpdated = updater.clk.Now()
+	status.OCSPLastUpdated = updater.jitteredLastUpdated(updater.clk.Now())
 	status.OCSPResponse = ocspResponse.Response
 
 	return &status, nil
 }
 
+// jitteredLastUpdated returns the ocspLastUpdated time to store for a response
+// generated at now: now moved back by a random duration of less than
+// ocspRegenerationJitter. The jitter only ever makes a response stale, and so
+// regenerated, earlier than it would otherwise be, so it can't cause a response
+// to be served past its nextUpdate.
+func (updater *OCSPUpdater) jitteredLastUpdated(now time.Time) time.Time {
+	if updater.ocspRegenerationJitter <= 0 {
+		return now
+	}
+	return now.Add(-time.Duration(mrand.Int63n(int64(updater.ocspRegenerationJitter))))
+}
+
 func (updater *OCSPUpdater) storeResponse(status *core.CertificateStatus) error {
 	// Update the certificateStatus table with the new OCSP response, the status
 	// WHERE is used make sure we don't overwrite a revoked response with a one
@@ -335,7 +360,12 @@ type OCSPUpdaterConfig struct {
 	OldOCSPWindow    cmd.ConfigDuration
 	OldOCSPBatchSize int
 
-	OCSPMinTimeToExpiry          cmd.ConfigDuration
+	OCSPMinTimeToExpiry cmd.ConfigDuration
+	// OCSPRegenerationJitter spreads out the regeneration of responses that
+	// were generated at the same time: each response is regenerated up to
+	// this much earlier than OCSPMinTimeToExpiry after it was generated. It
+	// must be less than OCSPMinTimeToExpiry. Zero disables the jitter.
+	OCSPRegenerationJitter       cmd.ConfigDuration
 	ParallelGenerateOCSPRequests int
 
 	AkamaiBaseURL           string
@@ -414,6 +444,7 @@ func (updater *OCSPUpdater) tick() {
 
 func main() {
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flag.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -427,6 +458,9 @@ func main() {
 	conf := c.OCSPUpdater
 	err = features.Set(conf.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	if *validateConfig {
+		cmd.ValidateConfigAndExit(&c)
+	}
 
 	stats, logger := cmd.StatsAndLogging(c.Syslog, conf.DebugAddr)
 	defer logger.AuditPanic()
@@ -517,3 +517,40 @@ func TestTickSleep(t *testing.T) {
 	test.AssertEquals(t, took, updater.tickWindow)
 
 }
+
+func TestRegenerationJitter(t *testing.T) {
+	newWithJitter := func(jitter time.Duration) (*OCSPUpdater, error) {
+		return newUpdater(
+			metrics.NoopRegisterer,
+			clock.NewFake(),
+			nil,
+			&mockOCSP{},
+			nil,
+			nil,
+			OCSPUpdaterConfig{
+				OldOCSPBatchSize:       1,
+				OldOCSPWindow:          cmd.ConfigDuration{Duration: time.Second},
+				OCSPMinTimeToExpiry:    cmd.ConfigDuration{Duration: time.Hour},
+				OCSPRegenerationJitter: cmd.ConfigDuration{Duration: jitter},
+			},
+			"",
+			blog.NewMock(),
+		)
+	}
+
+	// Jitter of at least OCSPMinTimeToExpiry is rejected.
+	_, err := newWithJitter(time.Hour)
+	test.AssertError(t, err, "newUpdater accepted jitter equal to OCSPMinTimeToExpiry")
+
+	updater, err := newWithJitter(10 * time.Minute)
+	test.AssertNotError(t, err, "Failed to create newUpdater")
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		lastUpdated := updater.jitteredLastUpdated(now)
+		test.Assert(t, !lastUpdated.After(now), "ocspLastUpdated was moved forward")
+		test.Assert(t, now.Sub(lastUpdated) < 10*time.Minute, "ocspLastUpdated was backdated by more than the jitter")
+	}
+
+	updater.ocspRegenerationJitter = 0
+	test.AssertEquals(t, updater.jitteredLastUpdated(now), now)
+}
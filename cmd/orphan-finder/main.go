@@ -249,7 +249,8 @@ func generateOCSP(ctx context.Context, ca ocspGenerator, certDER []byte) ([]byte
 	return ocspResponse.Response, nil
 }
 
-func setup(configFile string) (blog.Logger, core.StorageAuthority, capb.OCSPGeneratorClient) {
+// loadConfig reads the config file and sets the feature flags it enables.
+func loadConfig(configFile string) config {
 	configJSON, err := ioutil.ReadFile(configFile)
 	cmd.FailOnError(err, "Failed to read config file")
 	var conf config
@@ -257,6 +258,11 @@ func setup(configFile string) (blog.Logger, core.StorageAuthority, capb.OCSPGene
 	cmd.FailOnError(err, "Failed to parse config file")
 	err = features.Set(conf.Features)
 	cmd.FailOnError(err, "Failed to set feature flags")
+	return conf
+}
+
+func setup(configFile string) (blog.Logger, core.StorageAuthority, capb.OCSPGeneratorClient) {
+	conf := loadConfig(configFile)
 	logger := cmd.NewLogger(conf.Syslog)
 
 	tlsConfig, err := conf.TLS.Load()
@@ -284,6 +290,7 @@ func main() {
 	command := os.Args[1]
 	flagSet := flag.NewFlagSet(command, flag.ContinueOnError)
 	configFile := flagSet.String("config", "", "File path to the configuration file for this service")
+	validateConfig := flagSet.Bool("validate-config", false, cmd.ValidateConfigUsage)
 	logPath := flagSet.String("log-file", "", "Path to boulder-ca log file to parse")
 	derPath := flagSet.String("der-file", "", "Path to DER certificate file")
 	regID := flagSet.Int64("regID", 0, "Registration ID of user who requested the certificate")
@@ -299,6 +306,10 @@ func main() {
 	if *configFile == "" {
 		usage()
 	}
+	if *validateConfig {
+		conf := loadConfig(*configFile)
+		cmd.ValidateConfigAndExit(&conf)
+	}
 
 	switch command {
 	case "parse-ca-log":
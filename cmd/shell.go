@@ -2,6 +2,8 @@
 package cmd
 
 import (
+	"bytes"
+	"crypto/subtle"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
@@ -15,8 +17,10 @@ import (
 	"os"
 	"os/signal"
 	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -145,14 +149,14 @@ func (lw logWriter) Write(p []byte) (n int, err error) {
 // StatsAndLogging constructs a prometheus registerer and an AuditLogger based
 // on its config parameters, and return them both. It also spawns off an HTTP
 // server on the provided port to report the stats and provide pprof profiling
-// handlers. NewLogger and newStatsRegistry will call os.Exit on errors.
+// handlers. NewLogger and NewStatsRegistry will call os.Exit on errors.
 // Also sets the constructed AuditLogger as the default logger, and configures
 // the cfssl, mysql, and grpc packages to use our logger.
 // This must be called before any gRPC code is called, because gRPC's SetLogger
 // doesn't use any locking.
 func StatsAndLogging(logConf SyslogConfig, addr string) (prometheus.Registerer, blog.Logger) {
 	logger := NewLogger(logConf)
-	return newStatsRegistry(addr, logger), logger
+	return NewStatsRegistry(addr, logger, nil), logger
 }
 
 func NewLogger(logConf SyslogConfig) blog.Logger {
@@ -169,6 +173,14 @@ func NewLogger(logConf SyslogConfig) blog.Logger {
 	}
 	logger, err := blog.New(syslogger, logConf.StdoutLevel, syslogLevel)
 	FailOnError(err, "Could not connect to Syslog")
+	if logConf.SuppressRepeats {
+		window := logConf.RepeatWindow.Duration
+		if window == 0 {
+			window = 5 * time.Second
+		}
+		logger, err = blog.SuppressRepeats(logger, window)
+		FailOnError(err, "Could not configure repeat suppression")
+	}
 
 	_ = blog.Set(logger)
 	// We set the cfssl logging level to Debug as it
@@ -193,45 +205,106 @@ func NewLogger(logConf SyslogConfig) blog.Logger {
 	return logger
 }
 
-func newStatsRegistry(addr string, logger blog.Logger) prometheus.Registerer {
+// NewStatsRegistry constructs a prometheus registerer and spawns off an HTTP
+// server on the provided port to report the stats and provide pprof profiling
+// handlers, along with any extra handlers provided, keyed by the pattern they
+// should be registered under. Most callers should use StatsAndLogging instead.
+func NewStatsRegistry(addr string, logger blog.Logger, handlers map[string]http.Handler) prometheus.Registerer {
+	return NewDebugServer(DebugConfig{DebugAddr: addr}, logger, handlers)
+}
+
+// DebugConfig configures the debug HTTP server of a binary.
+type DebugConfig struct {
+	// DebugAddr is the address to run the debug server on.
+	DebugAddr string
+	// DebugPassword, if set, is required as the HTTP basic auth password (with
+	// any username) to access the /debug/ handlers. See DebugHandler.
+	DebugPassword PasswordConfig
+}
+
+// Validate checks that the debug address, if set, is a host and port.
+func (dc *DebugConfig) Validate() error {
+	return checkAddress("DebugAddr", dc.DebugAddr)
+}
+
+// NewDebugServer constructs a prometheus registerer and spawns off an HTTP
+// server on conf.DebugAddr serving DebugHandler for it. It calls os.Exit if
+// the password can't be read or the server can't be started.
+func NewDebugServer(conf DebugConfig, logger blog.Logger, handlers map[string]http.Handler) prometheus.Registerer {
+	password, err := conf.DebugPassword.Pass()
+	FailOnError(err, "Failed to read debug server password")
+
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(prometheus.NewGoCollector())
 	registry.MustRegister(prometheus.NewProcessCollector(
 		prometheus.ProcessCollectorOpts{}))
 
-	mux := http.NewServeMux()
+	server := http.Server{
+		Addr:    conf.DebugAddr,
+		Handler: DebugHandler(registry, logger, password, handlers),
+	}
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil {
+			logger.Errf("unable to boot debug server on %s: %v", conf.DebugAddr, err)
+			os.Exit(1)
+		}
+	}()
+	return registry
+}
+
+// DebugHandler returns a handler serving the debug endpoints common to all
+// Boulder binaries: pprof profiles under /debug/pprof/, expvar at /debug/vars
+// and the metrics gathered by registry at /metrics, along with any extra
+// handlers provided, keyed by the pattern they should be registered under. If
+// password is non-empty the /debug/ handlers require it as the HTTP basic auth
+// password. /metrics and the extra handlers, which are scraped and polled by
+// monitoring, never require a password.
+func DebugHandler(registry prometheus.Gatherer, logger blog.Logger, password string, handlers map[string]http.Handler) http.Handler {
+	debug := http.NewServeMux()
 	// Register the available pprof handlers. These are all registered on
 	// DefaultServeMux just by importing pprof, but since we eschew
 	// DefaultServeMux, we need to explicitly register them on our own mux.
-	mux.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
-	mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
-	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
-	mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
+	debug.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
+	debug.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
+	debug.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
+	debug.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
 	// These handlers are defined in runtime/pprof instead of net/http/pprof, and
 	// have to be accessed through net/http/pprof's Handler func.
-	mux.Handle("/debug/pprof/goroutine", pprof.Handler("goroutine"))
-	mux.Handle("/debug/pprof/block", pprof.Handler("block"))
-	mux.Handle("/debug/pprof/heap", pprof.Handler("heap"))
-	mux.Handle("/debug/pprof/mutex", pprof.Handler("mutex"))
-	mux.Handle("/debug/pprof/threadcreate", pprof.Handler("threadcreate"))
+	debug.Handle("/debug/pprof/goroutine", pprof.Handler("goroutine"))
+	debug.Handle("/debug/pprof/block", pprof.Handler("block"))
+	debug.Handle("/debug/pprof/heap", pprof.Handler("heap"))
+	debug.Handle("/debug/pprof/mutex", pprof.Handler("mutex"))
+	debug.Handle("/debug/pprof/threadcreate", pprof.Handler("threadcreate"))
+	debug.Handle("/debug/vars", expvar.Handler())
 
-	mux.Handle("/debug/vars", expvar.Handler())
+	mux := http.NewServeMux()
+	if password != "" {
+		mux.Handle("/debug/", requirePassword(password, debug))
+	} else {
+		mux.Handle("/debug/", debug)
+	}
 	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{
 		ErrorLog: promLogger{logger},
 	}))
-
-	server := http.Server{
-		Addr:    addr,
-		Handler: mux,
+	for pattern, handler := range handlers {
+		mux.Handle(pattern, handler)
 	}
-	go func() {
-		err := server.ListenAndServe()
-		if err != nil {
-			logger.Errf("unable to boot debug server on %s: %v", addr, err)
-			os.Exit(1)
+	return mux
+}
+
+// requirePassword wraps handler so that it's only called for requests with
+// the given HTTP basic auth password.
+func requirePassword(password string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, given, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="debug"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
 		}
-	}()
-	return registry
+		handler.ServeHTTP(w, r)
+	})
 }
 
 // Fail exits and prints an error message to stderr and the logger audit log.
@@ -276,14 +349,103 @@ func LoadCert(path string) (cert []byte, err error) {
 // ReadConfigFile takes a file path as an argument and attempts to
 // unmarshal the content of the file into a struct containing a
 // configuration of a boulder component.
+//
+// The top-level JSON object of the file may have an "include" key naming a
+// file, or a list of files, whose contents are merged in before the rest of
+// the file. Relative paths are relative to the directory of the including
+// file, and included files may themselves include others. Objects are merged
+// recursively, key by key, with keys from later files (and finally the
+// including file) overriding earlier ones. As with encoding/json keys are
+// matched case-insensitively. Any other value, including an array, replaces
+// the earlier value entirely.
 func ReadConfigFile(filename string, out interface{}) error {
-	configData, err := ioutil.ReadFile(filename)
+	merged, err := readConfigIncludes(filename, nil)
+	if err != nil {
+		return err
+	}
+	configData, err := json.Marshal(merged)
 	if err != nil {
 		return err
 	}
 	return json.Unmarshal(configData, out)
 }
 
+// readConfigIncludes reads the JSON object in filename and merges it over the
+// files it includes. including is the chain of files which led to filename
+// being read, used to detect include cycles.
+func readConfigIncludes(filename string, including []string) (map[string]interface{}, error) {
+	for _, f := range including {
+		if f == filename {
+			return nil, fmt.Errorf("config include cycle: %s -> %s", strings.Join(including, " -> "), filename)
+		}
+	}
+	configData, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	// Decode numbers as json.Number so that large integers survive being
+	// re-encoded after merging.
+	decoder := json.NewDecoder(bytes.NewReader(configData))
+	decoder.UseNumber()
+	var config map[string]interface{}
+	err = decoder.Decode(&config)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %s", filename, err)
+	}
+
+	var includes []string
+	switch include := config["include"].(type) {
+	case nil:
+	case string:
+		includes = []string{include}
+	case []interface{}:
+		for _, i := range include {
+			s, ok := i.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: include must be a file name or a list of file names", filename)
+			}
+			includes = append(includes, s)
+		}
+	default:
+		return nil, fmt.Errorf("%s: include must be a file name or a list of file names", filename)
+	}
+	delete(config, "include")
+
+	merged := map[string]interface{}{}
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(filename), include)
+		}
+		base, err := readConfigIncludes(include, append(including, filename))
+		if err != nil {
+			return nil, err
+		}
+		mergeConfig(merged, base)
+	}
+	mergeConfig(merged, config)
+	return merged, nil
+}
+
+// mergeConfig merges the JSON object src into dst, which is modified. See
+// ReadConfigFile for the rules.
+func mergeConfig(dst, src map[string]interface{}) {
+	for key, value := range src {
+		for existing := range dst {
+			if existing != key && strings.EqualFold(existing, key) {
+				dst[key] = dst[existing]
+				delete(dst, existing)
+			}
+		}
+		srcObject, srcIsObject := value.(map[string]interface{})
+		dstObject, dstIsObject := dst[key].(map[string]interface{})
+		if srcIsObject && dstIsObject {
+			mergeConfig(dstObject, srcObject)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
 // VersionString produces a friendly Application version string.
 func VersionString() string {
 	name := path.Base(os.Args[0])
@@ -299,18 +461,56 @@ var signalToName = map[os.Signal]string{
 // CatchSignals catches SIGTERM, SIGINT, SIGHUP and executes a callback
 // method before exiting
 func CatchSignals(logger blog.Logger, callback func()) {
+	CatchSignalsWithReload(logger, nil, callback)
+}
+
+// CatchSignalsWithReload is like CatchSignals, except that when reload is
+// non-nil, SIGHUP calls reload and continues waiting for signals instead of
+// exiting.
+func CatchSignalsWithReload(logger blog.Logger, reload func(), callback func()) {
+	var steps []ShutdownStep
+	if callback != nil {
+		steps = append(steps, ShutdownStep{Name: "cleanup", Run: callback})
+	}
+	CatchSignalsWithDeadline(logger, reload, 0, steps...)
+}
+
+// ShutdownStep is a named cleanup function run by CatchSignalsWithDeadline.
+type ShutdownStep struct {
+	Name string
+	Run  func()
+}
+
+// CatchSignalsWithDeadline is like CatchSignalsWithReload, except that on
+// shutdown it runs each of steps in turn, and exits once they have all
+// returned or deadline has passed, whichever is sooner. If the deadline
+// passes, the steps which hadn't finished are logged and the process exits
+// with a non-zero status. A zero deadline waits for the steps indefinitely.
+func CatchSignalsWithDeadline(logger blog.Logger, reload func(), deadline time.Duration, steps ...ShutdownStep) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGTERM)
 	signal.Notify(sigChan, syscall.SIGINT)
 	signal.Notify(sigChan, syscall.SIGHUP)
 
 	sig := <-sigChan
+	for sig == syscall.SIGHUP && reload != nil {
+		if logger != nil {
+			logger.Infof("Caught %s, reloading", signalToName[sig])
+		}
+		reload()
+		sig = <-sigChan
+	}
 	if logger != nil {
 		logger.Infof("Caught %s", signalToName[sig])
 	}
 
-	if callback != nil {
-		callback()
+	unfinished := runShutdownSteps(deadline, steps)
+	if len(unfinished) > 0 {
+		if logger != nil {
+			logger.Errf("Exiting after shutdown deadline of %s with unfinished steps: %s",
+				deadline, strings.Join(unfinished, ", "))
+		}
+		os.Exit(1)
 	}
 
 	if logger != nil {
@@ -319,6 +519,36 @@ func CatchSignals(logger blog.Logger, callback func()) {
 	os.Exit(0)
 }
 
+// runShutdownSteps runs each of steps in turn and returns the names of those
+// which hadn't finished after deadline, or none if deadline is zero.
+func runShutdownSteps(deadline time.Duration, steps []ShutdownStep) []string {
+	// finished is the number of steps which have returned.
+	var finished int32
+	done := make(chan struct{})
+	go func() {
+		for _, step := range steps {
+			step.Run()
+			atomic.AddInt32(&finished, 1)
+		}
+		close(done)
+	}()
+
+	if deadline == 0 {
+		<-done
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-time.After(deadline):
+	}
+	var unfinished []string
+	for _, step := range steps[atomic.LoadInt32(&finished):] {
+		unfinished = append(unfinished, step.Name)
+	}
+	return unfinished
+}
+
 // FilterShutdownErrors returns the input error, with the exception of "use of
 // closed network connection," on which it returns nil
 // Per https://github.com/grpc/grpc-go/issues/1017, a gRPC server's `Serve()`
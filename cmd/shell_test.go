@@ -3,10 +3,18 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/letsencrypt/boulder/core"
 	blog "github.com/letsencrypt/boulder/log"
@@ -194,3 +202,117 @@ func TestReadConfigFile(t *testing.T) {
 	test.AssertNotError(t, err, "ReadConfigFile(../test/config/notify-mailer.json) errored")
 	test.AssertEquals(t, c.NotifyMailer.SMTPConfig.Server, "localhost")
 }
+
+func TestDebugHandler(t *testing.T) {
+	extra := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	get := func(h http.Handler, path, password string) int {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		if password != "" {
+			req.SetBasicAuth("anyone", password)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	h := DebugHandler(prometheus.NewRegistry(), blog.NewMock(), "", map[string]http.Handler{"/extra": extra})
+	test.AssertEquals(t, get(h, "/debug/pprof/", ""), http.StatusOK)
+	test.AssertEquals(t, get(h, "/debug/vars", ""), http.StatusOK)
+	test.AssertEquals(t, get(h, "/metrics", ""), http.StatusOK)
+	test.AssertEquals(t, get(h, "/extra", ""), http.StatusTeapot)
+
+	// With a password the /debug/ handlers require it, but /metrics and the
+	// extra handlers don't.
+	h = DebugHandler(prometheus.NewRegistry(), blog.NewMock(), "hunter2", map[string]http.Handler{"/extra": extra})
+	test.AssertEquals(t, get(h, "/debug/pprof/", ""), http.StatusUnauthorized)
+	test.AssertEquals(t, get(h, "/debug/vars", "wrong"), http.StatusUnauthorized)
+	test.AssertEquals(t, get(h, "/debug/pprof/", "hunter2"), http.StatusOK)
+	test.AssertEquals(t, get(h, "/debug/vars", "hunter2"), http.StatusOK)
+	test.AssertEquals(t, get(h, "/metrics", ""), http.StatusOK)
+	test.AssertEquals(t, get(h, "/extra", ""), http.StatusTeapot)
+}
+
+func TestReadConfigFileIncludes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config-includes")
+	test.AssertNotError(t, err, "Failed to create temp dir")
+	defer os.RemoveAll(dir)
+	write := func(name, contents string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		err := ioutil.WriteFile(path, []byte(contents), 0600)
+		test.AssertNotError(t, err, "Failed to write config file")
+		return path
+	}
+
+	write("syslog.json", `{"syslog": {"stdoutlevel": 6, "sysloglevel": 6}, "service": {"debugAddr": ":8000", "names": ["a", "b"]}}`)
+	write("db.json", `{"service": {"dbConnectFile": "db.url", "maxOpenConns": 10}}`)
+	path := write("service.json", `{
+  "include": ["syslog.json", "db.json"],
+  "service": {"DebugAddr": ":8001", "names": ["c"], "maxOpenConns": 9007199254740993},
+  "syslog": {"stdoutlevel": 7}
+}`)
+
+	var c struct {
+		Syslog  SyslogConfig
+		Service struct {
+			DebugAddr     string
+			Names         []string
+			DBConnectFile string
+			MaxOpenConns  int64
+		}
+	}
+	err = ReadConfigFile(path, &c)
+	test.AssertNotError(t, err, "ReadConfigFile failed")
+	// Objects are merged key by key, case-insensitively, with the including
+	// file winning, but arrays are replaced.
+	test.AssertEquals(t, c.Syslog.StdoutLevel, 7)
+	test.AssertEquals(t, c.Syslog.SyslogLevel, 6)
+	test.AssertEquals(t, c.Service.DebugAddr, ":8001")
+	test.AssertDeepEquals(t, c.Service.Names, []string{"c"})
+	test.AssertEquals(t, c.Service.DBConnectFile, "db.url")
+	test.AssertEquals(t, c.Service.MaxOpenConns, int64(9007199254740993))
+
+	// Includes may be nested, and a single file name needn't be in a list.
+	write("nested.json", `{"include": "service.json", "syslog": {"sysloglevel": 3}}`)
+	err = ReadConfigFile(filepath.Join(dir, "nested.json"), &c)
+	test.AssertNotError(t, err, "ReadConfigFile failed")
+	test.AssertEquals(t, c.Syslog.StdoutLevel, 7)
+	test.AssertEquals(t, c.Syslog.SyslogLevel, 3)
+
+	write("cycle-a.json", `{"include": "cycle-b.json"}`)
+	write("cycle-b.json", `{"include": "cycle-a.json"}`)
+	err = ReadConfigFile(filepath.Join(dir, "cycle-a.json"), &c)
+	test.AssertError(t, err, "ReadConfigFile accepted an include cycle")
+
+	write("bad-include.json", `{"include": 1}`)
+	err = ReadConfigFile(filepath.Join(dir, "bad-include.json"), &c)
+	test.AssertError(t, err, "ReadConfigFile accepted a non-string include")
+
+	write("missing-include.json", `{"include": "missing.json"}`)
+	err = ReadConfigFile(filepath.Join(dir, "missing-include.json"), &c)
+	test.AssertError(t, err, "ReadConfigFile accepted a missing include")
+}
+
+func TestRunShutdownSteps(t *testing.T) {
+	var ran []string
+	step := func(name string) ShutdownStep {
+		return ShutdownStep{Name: name, Run: func() { ran = append(ran, name) }}
+	}
+	unfinished := runShutdownSteps(0, []ShutdownStep{step("a"), step("b")})
+	test.AssertEquals(t, len(unfinished), 0)
+	test.AssertDeepEquals(t, ran, []string{"a", "b"})
+
+	// A step which never returns, and those after it, are reported once the
+	// deadline passes.
+	stuck := make(chan struct{})
+	defer close(stuck)
+	unfinished = runShutdownSteps(10*time.Millisecond, []ShutdownStep{
+		{Name: "a", Run: func() {}},
+		{Name: "stuck", Run: func() { <-stuck }},
+		{Name: "c", Run: func() {}},
+	})
+	test.AssertDeepEquals(t, unfinished, []string{"stuck", "c"})
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/syslog"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// ValidateConfigUsage is the usage string of the -validate-config flag, which
+// every binary that reads a JSON config file supports. Such binaries read
+// their config as usual, then pass it to ValidateConfigAndExit if the flag is
+// set.
+const ValidateConfigUsage = "Check the config file and exit, zero if it's valid and non-zero (after printing the problems found) otherwise"
+
+// ConfigValidator is implemented by config types which can check their values
+// beyond what unmarshaling them does, e.g. that required fields are set and
+// that the files they name exist.
+type ConfigValidator interface {
+	Validate() error
+}
+
+var configValidatorType = reflect.TypeOf((*ConfigValidator)(nil)).Elem()
+
+// ValidateConfig checks config, a pointer to the struct a binary's config file
+// was read into, by calling Validate on every value within it, including
+// config itself, which implements ConfigValidator. Nil pointers are skipped,
+// so optional sections are only checked when they're present. All the
+// problems found are returned together, each prefixed with the path of the
+// value which has it.
+func ValidateConfig(config interface{}) error {
+	v := &configValidation{seen: map[string]bool{}}
+	v.walk("", reflect.ValueOf(config))
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(v.problems, "\n"))
+}
+
+// ValidateConfigAndExit calls ValidateConfig on config, prints the result and
+// exits, with status 1 if the config isn't valid.
+func ValidateConfigAndExit(config interface{}) {
+	err := ValidateConfig(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid config:\n%s\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("config is valid")
+	os.Exit(0)
+}
+
+type configValidation struct {
+	problems []string
+	// seen is used to report each problem once. Validate methods promoted
+	// from embedded fields are called for both the embedding and embedded
+	// values, which have the same path since embedded fields don't add to it.
+	seen map[string]bool
+}
+
+func (cv *configValidation) walk(path string, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		cv.walk(path, v.Elem())
+		return
+	}
+
+	cv.validate(path, v)
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				// Unexported fields can't be set from the config file.
+				continue
+			}
+			fieldPath := path
+			if !field.Anonymous {
+				fieldPath = joinConfigPath(path, configFieldName(field))
+			}
+			cv.walk(fieldPath, v.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			cv.walk(fmt.Sprintf("%s[%d]", path, i), v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			cv.walk(fmt.Sprintf("%s[%v]", path, iter.Key()), iter.Value())
+		}
+	}
+}
+
+// validate calls the Validate method of v, if it has one, recording any
+// problem found.
+func (cv *configValidation) validate(path string, v reflect.Value) {
+	var validator ConfigValidator
+	if v.CanAddr() && v.Addr().Type().Implements(configValidatorType) {
+		validator = v.Addr().Interface().(ConfigValidator)
+	} else if v.Type().Implements(configValidatorType) && v.CanInterface() {
+		validator = v.Interface().(ConfigValidator)
+	} else {
+		return
+	}
+	err := validator.Validate()
+	if err == nil {
+		return
+	}
+	problem := err.Error()
+	if path != "" {
+		problem = path + ": " + problem
+	}
+	if !cv.seen[problem] {
+		cv.seen[problem] = true
+		cv.problems = append(cv.problems, problem)
+	}
+}
+
+// configFieldName returns the name of field in the config file.
+func configFieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+func joinConfigPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// checkConfigFile returns an error if filename, named by the config field
+// field, can't be opened for reading.
+func checkConfigFile(field, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("%s: %s", field, err)
+	}
+	return f.Close()
+}
+
+// checkAddress returns an error if addr, named by the config field field, is
+// set but isn't a host and port.
+func checkAddress(field, addr string) error {
+	if addr == "" {
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return fmt.Errorf("%s: %s", field, err)
+	}
+	return nil
+}
+
+// checkLogLevel returns an error if level, named by the config field field,
+// is neither a syslog level (0 to 7) nor min, which is allowed to disable
+// logging.
+func checkLogLevel(field string, level, min int) error {
+	if (level < 0 || level > int(syslog.LOG_DEBUG)) && level != min {
+		return fmt.Errorf("%s must be a syslog level between %d and %d, got %d", field, min, syslog.LOG_DEBUG, level)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+type testSection struct {
+	Required string
+}
+
+func (ts testSection) Validate() error {
+	if ts.Required == "" {
+		return errors.New("Required is required")
+	}
+	return nil
+}
+
+func TestValidateConfig(t *testing.T) {
+	cert := "testdata/cert.pem"
+	key := "testdata/key.pem"
+	caCert := "testdata/minica.pem"
+	missing := "testdata/missing"
+
+	type config struct {
+		Service struct {
+			ServiceConfig
+			DBConfig
+			SAService *GRPCClientConfig
+			Sections  []testSection
+			Optional  *testSection
+		}
+		Syslog SyslogConfig
+	}
+
+	var c config
+	c.Service.DebugAddr = ":8000"
+	c.Service.GRPC = &GRPCServerConfig{Address: ":9000"}
+	c.Service.TLS = TLSConfig{CertFile: &cert, KeyFile: &key, CACertFile: &caCert}
+	c.Service.DBConnectFile = "testdata/test_dburl"
+	c.Service.SAService = &GRPCClientConfig{ServerAddress: "sa.boulder:9095"}
+	c.Service.Sections = []testSection{{Required: "yes"}}
+	c.Syslog = SyslogConfig{StdoutLevel: -1, SyslogLevel: 6}
+	test.AssertNotError(t, ValidateConfig(&c), "valid config was rejected")
+
+	c.Service.DebugAddr = "8000"
+	c.Service.GRPC.LogLevel = 8
+	c.Service.TLS.KeyFile = &missing
+	c.Service.DBConnectFile = "testdata/missing"
+	c.Service.SAService.ServerAddress = ""
+	c.Service.Sections = append(c.Service.Sections, testSection{})
+	c.Service.Optional = &testSection{}
+	c.Syslog.SyslogLevel = 9
+	err := ValidateConfig(&c)
+	test.AssertError(t, err, "invalid config was accepted")
+	problems := strings.Split(err.Error(), "\n")
+	expected := []string{
+		"Service: DebugAddr: address 8000: missing port in address",
+		"Service.GRPC: logLevel must be a syslog level between 0 and 7, got 8",
+		"Service.TLS: loading key pair from \"testdata/cert.pem\" and \"testdata/missing\": open testdata/missing: no such file or directory",
+		"Service: DBConnectFile: open testdata/missing: no such file or directory",
+		"Service.SAService: ServerAddress is required",
+		"Service.Sections[1]: Required is required",
+		"Service.Optional: Required is required",
+		"Syslog: SyslogLevel must be a syslog level between -1 and 7, got 9",
+	}
+	test.AssertDeepEquals(t, problems, expected)
+
+	// A config with a gRPC server must configure TLS.
+	var s ServiceConfig
+	s.GRPC = &GRPCServerConfig{Address: ":9000"}
+	test.AssertError(t, ValidateConfig(&s), "gRPC server without TLS was accepted")
+}
+
+func TestValidateConfigPromoted(t *testing.T) {
+	// The problems of embedded configs, whose Validate methods are promoted
+	// to the configs embedding them, are reported once.
+	var c struct {
+		DBConfig
+		MaxNames int
+	}
+	c.DBConnect = "db"
+	c.DBConnectFile = "testdata/test_dburl"
+	err := ValidateConfig(&c)
+	test.AssertError(t, err, "invalid config was accepted")
+	test.AssertEquals(t, err.Error(), "only one of DBConnect and DBConnectFile may be set")
+}
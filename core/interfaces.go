@@ -87,6 +87,12 @@ type RegistrationAuthority interface {
 	// [WebFrontEnd]
 	FinalizeOrder(ctx context.Context, req *rapb.FinalizeOrderRequest) (*corepb.Order, error)
 
+	// [WebFrontEnd]
+	GetRateLimits(ctx context.Context, req *rapb.GetRateLimitsRequest) (*rapb.RateLimits, error)
+
+	// [Operators]
+	DryRunIssuance(ctx context.Context, req *rapb.DryRunIssuanceRequest) (*rapb.DryRunIssuanceResponse, error)
+
 	// [AdminRevoker]
 	AdministrativelyRevokeCertificate(ctx context.Context, cert x509.Certificate, code revocation.Reason, adminName string) error
 }
@@ -136,6 +142,20 @@ type StorageGetter interface {
 	GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error)
 	SerialExists(ctx context.Context, req *sapb.Serial) (*sapb.Exists, error)
 	KeyBlocked(ctx context.Context, req *sapb.KeyBlockedRequest) (*sapb.Exists, error)
+	GetNotificationPreferences(ctx context.Context, req *sapb.RegistrationID) (*sapb.NotificationPreferences, error)
+	GetCertificatesByRegistration(ctx context.Context, req *sapb.GetCertificatesByRegistrationRequest) (*sapb.Certificates, error)
+	GetCertificateProfile(ctx context.Context, req *sapb.Serial) (*sapb.CertificateProfile, error)
+	GetCertificateIssuer(ctx context.Context, req *sapb.Serial) (*sapb.CertificateIssuer, error)
+	SearchCertificatesByName(ctx context.Context, req *sapb.SearchCertificatesByNameRequest) (*sapb.CertificateNameMatches, error)
+	GetCertificatesExpiring(ctx context.Context, req *sapb.GetCertificatesExpiringRequest) (*sapb.Certificates, error)
+	GetOrderForSerial(ctx context.Context, req *sapb.Serial) (*sapb.OrderForSerial, error)
+	GetDeactivatedRegistrations(ctx context.Context, req *sapb.GetDeactivatedRegistrationsRequest) (*sapb.DeactivatedRegistrations, error)
+	DomainsBlocked(ctx context.Context, req *sapb.DomainsBlockedRequest) (*sapb.BlockedDomains, error)
+	ReplacementCertificateExists(ctx context.Context, req *sapb.ReplacementCertificateExistsRequest) (*sapb.Exists, error)
+	GetCertificateHistory(ctx context.Context, req *sapb.Serial) (*sapb.CertificateHistory, error)
+	GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error)
+	CountActiveCertificates(ctx context.Context, req *sapb.RegistrationID) (*sapb.Count, error)
+	CountActiveCertificatesByRegistrations(ctx context.Context, req *sapb.CountActiveCertificatesByRegistrationsRequest) (*sapb.CountByRegistrations, error)
 }
 
 // StorageAdder are the Boulder SA's write/update methods
@@ -145,7 +165,7 @@ type StorageAdder interface {
 	AddCertificate(ctx context.Context, der []byte, regID int64, ocsp []byte, issued *time.Time) (digest string, err error)
 	AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error)
 	AddSerial(ctx context.Context, req *sapb.AddSerialRequest) (*corepb.Empty, error)
-	DeactivateRegistration(ctx context.Context, id int64) error
+	DeactivateRegistration(ctx context.Context, id int64, reason string) error
 	NewOrder(ctx context.Context, order *corepb.Order) (*corepb.Order, error)
 	SetOrderProcessing(ctx context.Context, order *corepb.Order) error
 	FinalizeOrder(ctx context.Context, order *corepb.Order) error
@@ -156,6 +176,10 @@ type StorageAdder interface {
 	FinalizeAuthorization2(ctx context.Context, req *sapb.FinalizeAuthorizationRequest) error
 	DeactivateAuthorization2(ctx context.Context, req *sapb.AuthorizationID2) (*corepb.Empty, error)
 	AddBlockedKey(ctx context.Context, req *sapb.AddBlockedKeyRequest) (*corepb.Empty, error)
+	AddBlockedDomain(ctx context.Context, req *sapb.AddBlockedDomainRequest) (*corepb.Empty, error)
+	RemoveBlockedDomain(ctx context.Context, req *sapb.RemoveBlockedDomainRequest) (*corepb.Empty, error)
+	AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*corepb.Empty, error)
+	RemoveRateLimitOverride(ctx context.Context, req *sapb.RemoveRateLimitOverrideRequest) (*corepb.Empty, error)
 }
 
 // StorageAuthority interface represents a simple key/value
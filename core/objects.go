@@ -153,11 +153,13 @@ type ValidationRecord struct {
 	AddressUsed       net.IP   `json:"addressUsed,omitempty"`
 	// AddressesTried contains a list of addresses tried before the `AddressUsed`.
 	// Presently this will only ever be one IP from `AddressesResolved` since the
-	// only retry is in the case of a v6 failure with one v4 fallback. E.g. if
-	// a record with `AddressesResolved: { 127.0.0.1, ::1 }` were processed for
-	// a challenge validation with the IPv6 first flag on and the ::1 address
-	// failed but the 127.0.0.1 retry succeeded then the record would end up
-	// being:
+	// only retry is in the case of a v6 failure with one v4 fallback. For
+	// HTTP-01 the failed attempt keeps its own record, and the record of the
+	// retry lists the failed address here. For TLS-ALPN-01 there is only one
+	// record, e.g. if a record with `AddressesResolved: { 127.0.0.1, ::1 }`
+	// were processed for a challenge validation with the IPv6 first flag on and
+	// the ::1 address failed but the 127.0.0.1 retry succeeded then the record
+	// would end up being:
 	// {
 	//   ...
 	//   AddressesResolved: [ 127.0.0.1, ::1 ],
@@ -166,6 +168,11 @@ type ValidationRecord struct {
 	//   ...
 	// }
 	AddressesTried []net.IP `json:"addressesTried,omitempty"`
+
+	// Timeout is the effective timeout the VA applied to the validation, if
+	// a per-challenge-type timeout was configured. It is kept for debugging
+	// slow or timed out validations.
+	Timeout time.Duration `json:"timeout,omitempty"`
 }
 
 func looksLikeKeyAuthorization(str string) error {
@@ -583,3 +590,43 @@ type SCTDERs [][]byte
 // CertDER is a convenience type that helps differentiate what the
 // underlying byte slice contains
 type CertDER []byte
+
+// RenewalInfo is the object returned by the ACME renewalInfo (ARI) endpoint,
+// suggesting when a client should renew a certificate.
+type RenewalInfo struct {
+	SuggestedWindow SuggestedWindow `json:"suggestedWindow"`
+}
+
+// SuggestedWindow is the period of time during which a client should renew a
+// certificate.
+type SuggestedWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// RenewalInfoSimple returns a RenewalInfo whose suggested window is centred on
+// the point two thirds of the way through the certificate's validity period,
+// and is two percent of that period long.
+func RenewalInfoSimple(notBefore time.Time, notAfter time.Time) RenewalInfo {
+	validity := notAfter.Sub(notBefore)
+	idealRenewal := notAfter.Add(-validity / 3)
+	return RenewalInfo{
+		SuggestedWindow: SuggestedWindow{
+			Start: idealRenewal.Add(-validity / 100),
+			End:   idealRenewal.Add(validity / 100),
+		},
+	}
+}
+
+// RenewalInfoImmediate returns a RenewalInfo whose suggested window is entirely
+// in the past, telling the client to renew right away, e.g. because the
+// certificate has been revoked.
+func RenewalInfoImmediate(now time.Time) RenewalInfo {
+	oneHourAgo := now.Add(-time.Hour)
+	return RenewalInfo{
+		SuggestedWindow: SuggestedWindow{
+			Start: oneHourAgo,
+			End:   oneHourAgo.Add(30 * time.Minute),
+		},
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"net"
 	"testing"
+	"time"
 
 	"gopkg.in/square/go-jose.v2"
 
@@ -166,3 +167,23 @@ func TestFindChallengeByType(t *testing.T) {
 	test.AssertEquals(t, 1, authz.FindChallengeByStringID(authz.Challenges[1].StringID()))
 	test.AssertEquals(t, -1, authz.FindChallengeByStringID("hello"))
 }
+
+func TestRenewalInfo(t *testing.T) {
+	notBefore := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(90 * 24 * time.Hour)
+	ri := RenewalInfoSimple(notBefore, notAfter)
+	// Two thirds of the way through a 90 day certificate is day 60, and the
+	// window extends 1% of the validity (21.6 hours) either side of that.
+	test.AssertEquals(t, ri.SuggestedWindow.Start, notBefore.Add(60*24*time.Hour-1296*time.Minute))
+	test.AssertEquals(t, ri.SuggestedWindow.End, notBefore.Add(60*24*time.Hour+1296*time.Minute))
+
+	now := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	ri = RenewalInfoImmediate(now)
+	test.Assert(t, ri.SuggestedWindow.Start.Before(ri.SuggestedWindow.End), "Window starts after it ends")
+	test.Assert(t, ri.SuggestedWindow.End.Before(now), "Immediate window isn't in the past")
+
+	encoded, err := json.Marshal(ri)
+	test.AssertNotError(t, err, "Failed to marshal RenewalInfo")
+	test.AssertEquals(t, string(encoded),
+		`{"suggestedWindow":{"start":"2020-01-31T23:00:00Z","end":"2020-01-31T23:30:00Z"}}`)
+}
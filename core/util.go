@@ -151,9 +151,14 @@ func PublicKeysEqual(a, b interface{}) (bool, error) {
 }
 
 // SerialToString converts a certificate serial number (big.Int) to a String
-// consistently.
+// consistently. Serials are zero-padded to 36 hex characters, and longer
+// serials to an even number of characters, so that they decode as bytes.
 func SerialToString(serial *big.Int) string {
-	return fmt.Sprintf("%036x", serial)
+	s := fmt.Sprintf("%036x", serial)
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	return s
 }
 
 // StringToSerial converts a string into a certificate serial number (big.Int)
@@ -163,18 +168,21 @@ func StringToSerial(serial string) (*big.Int, error) {
 	if !ValidSerial(serial) {
 		return &serialNum, errors.New("Invalid serial number")
 	}
-	_, err := fmt.Sscanf(serial, "%036x", &serialNum)
-	return &serialNum, err
+	if _, ok := serialNum.SetString(serial, 16); !ok {
+		return &serialNum, errors.New("Invalid serial number")
+	}
+	return &serialNum, nil
 }
 
 // ValidSerial tests whether the input string represents a syntactically
-// valid serial number, i.e., that it is a valid hex string between 32
-// and 36 characters long.
+// valid serial number, i.e., that it is a valid hex string either 32
+// characters long or an even length from 36 to 40 characters.
 func ValidSerial(serial string) bool {
 	// Originally, serial numbers were 32 hex characters long. We later increased
 	// them to 36, but we allow the shorter ones because they exist in some
-	// production databases.
-	if len(serial) != 32 && len(serial) != 36 {
+	// production databases. Issuance profiles may configure longer serials, up
+	// to the 20 octets RFC 5280 allows.
+	if len(serial) != 32 && (len(serial) < 36 || len(serial) > 40) {
 		return false
 	}
 	_, err := hex.DecodeString(serial)
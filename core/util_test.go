@@ -50,6 +50,14 @@ func TestSerialUtils(t *testing.T) {
 		t.Fatalf("Incorrect conversion, got %d", serialNum)
 	}
 
+	// Serials longer than 18 octets are padded to whole octets.
+	long := new(big.Int).Lsh(big.NewInt(1), 152)
+	serial = SerialToString(long)
+	test.AssertEquals(t, serial, "01"+strings.Repeat("0", 38))
+	serialNum, err = StringToSerial(serial)
+	test.AssertNotError(t, err, "Couldn't convert long serial number to *big.Int")
+	test.Assert(t, serialNum.Cmp(long) == 0, "Incorrect conversion of long serial")
+
 	badSerial, err := StringToSerial("doop!!!!000")
 	test.AssertEquals(t, fmt.Sprintf("%v", err), "Invalid serial number")
 	fmt.Println(badSerial)
@@ -123,6 +131,12 @@ func TestValidSerial(t *testing.T) {
 	test.AssertEquals(t, isValidSerial, true)
 	isValidSerial = ValidSerial(length36)
 	test.AssertEquals(t, isValidSerial, true)
+	isValidSerial = ValidSerial(strings.Repeat("A", 40))
+	test.AssertEquals(t, isValidSerial, true)
+	isValidSerial = ValidSerial(strings.Repeat("A", 37))
+	test.AssertEquals(t, isValidSerial, false)
+	isValidSerial = ValidSerial(strings.Repeat("A", 42))
+	test.AssertEquals(t, isValidSerial, false)
 }
 
 func TestRetryBackoff(t *testing.T) {
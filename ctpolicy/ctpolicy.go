@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"sync"
 	"time"
 
+	"github.com/jmhodges/clock"
 	"github.com/letsencrypt/boulder/canceled"
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/ctpolicy/ctconfig"
 	berrors "github.com/letsencrypt/boulder/errors"
 	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
 	pubpb "github.com/letsencrypt/boulder/publisher/proto"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -23,17 +26,40 @@ type CTPolicy struct {
 	informational []ctconfig.LogDescription
 	finalLogs     []ctconfig.LogDescription
 	log           blog.Logger
+	clk           clock.Clock
 
-	winnerCounter *prometheus.CounterVec
+	// unhealthyPeriod is how long after a failed submission a log is
+	// considered unhealthy. Unhealthy logs are tried after the healthy logs in
+	// their group, rather than being skipped, so a group whose logs are all
+	// unhealthy can still provide an SCT.
+	unhealthyPeriod time.Duration
+
+	// failedAt records when submissions to each log, keyed by URI, last
+	// failed. It's cleared when a submission to the log succeeds.
+	failedMu sync.Mutex
+	failedAt map[string]time.Time
+
+	winnerCounter     *prometheus.CounterVec
+	submissionLatency *prometheus.HistogramVec
 }
 
-// New creates a new CTPolicy struct
+// DefaultUnhealthyPeriod is the unhealthy period used when New is given zero.
+const DefaultUnhealthyPeriod = time.Minute
+
+// New creates a new CTPolicy struct. Logs are considered unhealthy for
+// unhealthyPeriod after a failed submission, or DefaultUnhealthyPeriod if it's
+// zero.
 func New(pub core.Publisher,
 	groups []ctconfig.CTGroup,
 	informational []ctconfig.LogDescription,
+	unhealthyPeriod time.Duration,
 	log blog.Logger,
 	stats prometheus.Registerer,
+	clk clock.Clock,
 ) *CTPolicy {
+	if unhealthyPeriod == 0 {
+		unhealthyPeriod = DefaultUnhealthyPeriod
+	}
 	var finalLogs []ctconfig.LogDescription
 	for _, group := range groups {
 		for _, log := range group.Logs {
@@ -57,14 +83,73 @@ func New(pub core.Publisher,
 	)
 	stats.MustRegister(winnerCounter)
 
+	submissionLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ct_log_submission_latency",
+			Help:    "Latency of precertificate submissions to logs in CT log groups, by log and result (success or failure)",
+			Buckets: metrics.InternetFacingBuckets,
+		},
+		[]string{"log", "result"},
+	)
+	stats.MustRegister(submissionLatency)
+
 	return &CTPolicy{
-		pub:           pub,
-		groups:        groups,
-		informational: informational,
-		finalLogs:     finalLogs,
-		log:           log,
-		winnerCounter: winnerCounter,
+		pub:               pub,
+		groups:            groups,
+		informational:     informational,
+		finalLogs:         finalLogs,
+		log:               log,
+		clk:               clk,
+		unhealthyPeriod:   unhealthyPeriod,
+		failedAt:          make(map[string]time.Time),
+		winnerCounter:     winnerCounter,
+		submissionLatency: submissionLatency,
+	}
+}
+
+// healthy returns false if a submission to the log with the given URI failed
+// within the last unhealthyPeriod.
+func (ctp *CTPolicy) healthy(uri string) bool {
+	ctp.failedMu.Lock()
+	defer ctp.failedMu.Unlock()
+	failedAt, ok := ctp.failedAt[uri]
+	return !ok || ctp.clk.Since(failedAt) >= ctp.unhealthyPeriod
+}
+
+// recordSubmission updates the health and latency metrics of the log with the
+// given URI after a submission to it. Submissions that were canceled, which
+// generally means another log won the race, aren't recorded.
+func (ctp *CTPolicy) recordSubmission(uri string, took time.Duration, err error) {
+	if canceled.Is(err) {
+		return
+	}
+	result := "success"
+	ctp.failedMu.Lock()
+	if err != nil {
+		result = "failure"
+		ctp.failedAt[uri] = ctp.clk.Now()
+	} else {
+		delete(ctp.failedAt, uri)
+	}
+	ctp.failedMu.Unlock()
+	ctp.submissionLatency.With(prometheus.Labels{"log": uri, "result": result}).Observe(took.Seconds())
+}
+
+// submissionOrder returns the order in which to submit to the logs in a group,
+// as indexes into group.Logs. The order is random, so we maximize the
+// distribution of logs we get SCTs from, except that logs which have recently
+// failed are placed after those which haven't.
+func (ctp *CTPolicy) submissionOrder(group ctconfig.CTGroup, expiration time.Time) []int {
+	var healthy, unhealthy []int
+	for _, logNum := range rand.Perm(len(group.Logs)) {
+		uri, _, err := group.Logs[logNum].Info(expiration)
+		if err == nil && !ctp.healthy(uri) {
+			unhealthy = append(unhealthy, logNum)
+			continue
+		}
+		healthy = append(healthy, logNum)
 	}
+	return append(healthy, unhealthy...)
 }
 
 type result struct {
@@ -81,8 +166,9 @@ func (ctp *CTPolicy) race(ctx context.Context, cert core.CertDER, group ctconfig
 	results := make(chan result, len(group.Logs))
 	isPrecert := true
 	// Randomize the order in which we send requests to the logs in a group
-	// so we maximize the distribution of logs we get SCTs from.
-	for i, logNum := range rand.Perm(len(group.Logs)) {
+	// so we maximize the distribution of logs we get SCTs from, while routing
+	// around logs that are currently failing.
+	for i, logNum := range ctp.submissionOrder(group, expiration) {
 		ld := group.Logs[logNum]
 		go func(i int, ld ctconfig.LogDescription) {
 			// Each submission waits a bit longer than the previous one, to give the
@@ -98,12 +184,14 @@ func (ctp *CTPolicy) race(ctx context.Context, cert core.CertDER, group ctconfig
 				ctp.log.Errf("unable to get log info: %s", err)
 				return
 			}
+			start := ctp.clk.Now()
 			sct, err := ctp.pub.SubmitToSingleCTWithResult(ctx, &pubpb.Request{
 				LogURL:       uri,
 				LogPublicKey: key,
 				Der:          cert,
 				Precert:      isPrecert,
 			})
+			ctp.recordSubmission(uri, ctp.clk.Since(start), err)
 			if err != nil {
 				// Only log the error if it is not a result of the context being canceled
 				if !canceled.Is(err) {
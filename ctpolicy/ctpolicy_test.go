@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jmhodges/clock"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/ctpolicy/ctconfig"
@@ -117,7 +118,7 @@ func TestGetSCTs(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			ctp := New(tc.mock, tc.groups, nil, blog.NewMock(), metrics.NoopRegisterer)
+			ctp := New(tc.mock, tc.groups, nil, 0, blog.NewMock(), metrics.NoopRegisterer, clock.New())
 			ret, err := ctp.GetSCTs(tc.ctx, []byte{0}, time.Time{})
 			if tc.result != nil {
 				test.AssertDeepEquals(t, ret, tc.result)
@@ -171,7 +172,7 @@ func TestGetSCTsMetrics(t *testing.T) {
 				{URI: "ghi", Key: "jkl"},
 			},
 		},
-	}, nil, blog.NewMock(), metrics.NoopRegisterer)
+	}, nil, 0, blog.NewMock(), metrics.NoopRegisterer, clock.New())
 	_, err := ctp.GetSCTs(context.Background(), []byte{0}, time.Time{})
 	test.AssertNotError(t, err, "GetSCTs failed")
 	test.AssertEquals(t, test.CountCounter(ctp.winnerCounter.With(prometheus.Labels{"log": "ghi", "group": "a"})), 1)
@@ -188,7 +189,7 @@ func TestGetSCTsFailMetrics(t *testing.T) {
 				{URI: "abc", Key: "def"},
 			},
 		},
-	}, nil, blog.NewMock(), metrics.NoopRegisterer)
+	}, nil, 0, blog.NewMock(), metrics.NoopRegisterer, clock.New())
 	_, err := ctp.GetSCTs(context.Background(), []byte{0}, time.Time{})
 	if err == nil {
 		t.Fatal("GetSCTs should have failed")
@@ -206,7 +207,7 @@ func TestGetSCTsFailMetrics(t *testing.T) {
 				{URI: "abc", Key: "def"},
 			},
 		},
-	}, nil, blog.NewMock(), metrics.NoopRegisterer)
+	}, nil, 0, blog.NewMock(), metrics.NoopRegisterer, clock.New())
 	_, err = ctp.GetSCTs(ctx, []byte{0}, time.Time{})
 	if err == nil {
 		t.Fatal("GetSCTs should have failed")
@@ -235,10 +236,68 @@ func TestStagger(t *testing.T) {
 				{URI: "ghi", Key: "jkl"},
 			},
 		},
-	}, nil, blog.NewMock(), metrics.NoopRegisterer)
+	}, nil, 0, blog.NewMock(), metrics.NoopRegisterer, clock.New())
 	_, err := ctp.GetSCTs(context.Background(), []byte{0}, time.Time{})
 	test.AssertNotError(t, err, "GetSCTs failed")
 	if countingPub.count != 1 {
 		t.Errorf("wrong number of requests to publisher. got %d, expected 1", countingPub.count)
 	}
 }
+
+func TestUnhealthyLogsLast(t *testing.T) {
+	group := ctconfig.CTGroup{
+		Name:    "a",
+		Stagger: cmd.ConfigDuration{Duration: 10 * time.Second},
+		Logs: []ctconfig.LogDescription{
+			{URI: "abc", Key: "def"},
+			{URI: "ghi", Key: "jkl"},
+		},
+	}
+	fc := clock.NewFake()
+	ctp := New(&failOne{badURL: "abc"}, []ctconfig.CTGroup{group}, nil, 10*time.Minute, blog.NewMock(), metrics.NoopRegisterer, fc)
+
+	test.AssertEquals(t, len(ctp.submissionOrder(group, time.Time{})), 2)
+
+	// Canceled submissions don't affect a log's health.
+	ctp.recordSubmission("abc", time.Second, context.Canceled)
+	test.AssertEquals(t, ctp.healthy("abc"), true)
+
+	ctp.recordSubmission("abc", time.Second, errors.New("BAD"))
+	test.AssertEquals(t, ctp.healthy("abc"), false)
+	test.AssertEquals(t, test.CountHistogramSamples(ctp.submissionLatency.With(prometheus.Labels{"log": "abc", "result": "failure"})), 1)
+	for i := 0; i < 10; i++ {
+		test.AssertDeepEquals(t, ctp.submissionOrder(group, time.Time{}), []int{1, 0})
+	}
+
+	// With the failing log submitted to last, the healthy log provides an SCT
+	// without waiting for the stagger.
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := ctp.GetSCTs(ctx, []byte{0}, time.Time{})
+		cancel()
+		test.AssertNotError(t, err, "GetSCTs failed")
+	}
+	test.AssertEquals(t, test.CountCounter(ctp.winnerCounter.With(prometheus.Labels{"log": "ghi", "group": "a"})), 5)
+	test.AssertEquals(t, test.CountHistogramSamples(ctp.submissionLatency.With(prometheus.Labels{"log": "ghi", "result": "success"})), 5)
+
+	// A log is healthy again once the unhealthy period has passed since it
+	// failed, or once a submission to it succeeds.
+	fc.Add(10*time.Minute - time.Second)
+	test.AssertEquals(t, ctp.healthy("abc"), false)
+	fc.Add(time.Second)
+	test.AssertEquals(t, ctp.healthy("abc"), true)
+	ctp.recordSubmission("ghi", time.Second, errors.New("BAD"))
+	test.AssertEquals(t, ctp.healthy("ghi"), false)
+	ctp.recordSubmission("ghi", time.Second, nil)
+	test.AssertEquals(t, ctp.healthy("ghi"), true)
+}
+
+func TestDefaultUnhealthyPeriod(t *testing.T) {
+	fc := clock.NewFake()
+	ctp := New(&mockPub{}, nil, nil, 0, blog.NewMock(), metrics.NoopRegisterer, fc)
+	ctp.recordSubmission("abc", time.Second, errors.New("BAD"))
+	fc.Add(DefaultUnhealthyPeriod - time.Second)
+	test.AssertEquals(t, ctp.healthy("abc"), false)
+	fc.Add(time.Second)
+	test.AssertEquals(t, ctp.healthy("abc"), true)
+}
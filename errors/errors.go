@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/letsencrypt/boulder/identifier"
 )
@@ -27,6 +28,9 @@ const (
 	DNS
 	BadPublicKey
 	BadCSR
+	// Conflict indicates that an update was based on a stale copy of the
+	// object being updated, which has since been changed by another update.
+	Conflict
 )
 
 // BoulderError represents internal Boulder errors
@@ -34,6 +38,10 @@ type BoulderError struct {
 	Type      ErrorType
 	Detail    string
 	SubErrors []SubBoulderError
+	// RetryAfter is how long the client should wait before retrying, for
+	// errors (e.g. rate limits) which are resolved by waiting. Zero if
+	// unknown.
+	RetryAfter time.Duration
 }
 
 // SubBoulderError represents sub-errors specific to an identifier that are
@@ -51,9 +59,10 @@ func (be *BoulderError) Error() string {
 // provided subErrs to the existing BoulderError.
 func (be *BoulderError) WithSubErrors(subErrs []SubBoulderError) *BoulderError {
 	return &BoulderError{
-		Type:      be.Type,
-		Detail:    be.Detail,
-		SubErrors: append(be.SubErrors, subErrs...),
+		Type:       be.Type,
+		Detail:     be.Detail,
+		SubErrors:  append(be.SubErrors, subErrs...),
+		RetryAfter: be.RetryAfter,
 	}
 }
 
@@ -90,10 +99,13 @@ func NotFoundError(msg string, args ...interface{}) error {
 	return New(NotFound, msg, args...)
 }
 
-func RateLimitError(msg string, args ...interface{}) error {
+// RateLimitError returns a RateLimit BoulderError. The retryAfter is when the
+// limit will next allow the request, zero if that isn't known.
+func RateLimitError(retryAfter time.Duration, msg string, args ...interface{}) error {
 	return &BoulderError{
-		Type:   RateLimit,
-		Detail: fmt.Sprintf(msg+": see https://letsencrypt.org/docs/rate-limits/", args...),
+		Type:       RateLimit,
+		Detail:     fmt.Sprintf(msg+": see https://letsencrypt.org/docs/rate-limits/", args...),
+		RetryAfter: retryAfter,
 	}
 }
 
@@ -140,3 +152,7 @@ func BadPublicKeyError(msg string, args ...interface{}) error {
 func BadCSRError(msg string, args ...interface{}) error {
 	return New(BadCSR, msg, args...)
 }
+
+func ConflictError(msg string, args ...interface{}) error {
+	return New(Conflict, msg, args...)
+}
@@ -30,11 +30,23 @@ func _() {
 	_ = x[StoreRevokerInfo-19]
 	_ = x[RestrictRSAKeySizes-20]
 	_ = x[FasterNewOrdersRateLimit-21]
+	_ = x[NotificationPreferences-22]
+	_ = x[ServeRenewalInfo-23]
+	_ = x[StoreCertificateProfiles-24]
+	_ = x[HTTP01HappyEyeballs-25]
+	_ = x[StoreDeactivationInfo-26]
+	_ = x[BlockedDomainsTable-27]
+	_ = x[StoreOrderProfiles-28]
+	_ = x[LocalizeProblems-29]
+	_ = x[OrderVersions-30]
+	_ = x[SkipReplacedCertNags-31]
+	_ = x[RateLimitOverridesTable-32]
+	_ = x[StoreIssuerFingerprints-33]
 }
 
-const _FeatureFlag_name = "unusedWriteIssuedNamesPrecertHeadNonceStatusOKRemoveWFE2AccountIDCheckRenewalFirstParallelCheckFailedValidationDeleteUnusedChallengesBlockedKeyTableCAAValidationMethodsCAAAccountURIEnforceMultiVAMultiVAFullResultsMandatoryPOSTAsGETAllowV1RegistrationV1DisableNewValidationsPrecertificateRevocationStripDefaultSchemePortStoreIssuerInfoStoreKeyHashesStoreRevokerInfoRestrictRSAKeySizesFasterNewOrdersRateLimit"
+const _FeatureFlag_name = "unusedWriteIssuedNamesPrecertHeadNonceStatusOKRemoveWFE2AccountIDCheckRenewalFirstParallelCheckFailedValidationDeleteUnusedChallengesBlockedKeyTableCAAValidationMethodsCAAAccountURIEnforceMultiVAMultiVAFullResultsMandatoryPOSTAsGETAllowV1RegistrationV1DisableNewValidationsPrecertificateRevocationStripDefaultSchemePortStoreIssuerInfoStoreKeyHashesStoreRevokerInfoRestrictRSAKeySizesFasterNewOrdersRateLimitNotificationPreferencesServeRenewalInfoStoreCertificateProfilesHTTP01HappyEyeballsStoreDeactivationInfoBlockedDomainsTableStoreOrderProfilesLocalizeProblemsOrderVersionsSkipReplacedCertNagsRateLimitOverridesTableStoreIssuerFingerprints"
 
-var _FeatureFlag_index = [...]uint16{0, 6, 29, 46, 65, 82, 111, 133, 148, 168, 181, 195, 213, 231, 250, 273, 297, 319, 334, 348, 364, 383, 407}
+var _FeatureFlag_index = [...]uint16{0, 6, 29, 46, 65, 82, 111, 133, 148, 168, 181, 195, 213, 231, 250, 273, 297, 319, 334, 348, 364, 383, 407, 430, 446, 470, 489, 510, 529, 547, 563, 576, 596, 619, 642}
 
 func (i FeatureFlag) String() string {
 	if i < 0 || i >= FeatureFlag(len(_FeatureFlag_index)-1) {
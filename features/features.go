@@ -59,6 +59,49 @@ const (
 	// FasterNewOrdersRateLimit enables use of a separate table for counting the
 	// new orders rate limit.
 	FasterNewOrdersRateLimit
+	// NotificationPreferences causes the expiration mailer to honor per-account
+	// notification preferences stored in the notificationPreferences table.
+	NotificationPreferences
+	// ServeRenewalInfo exposes the ACME renewalInfo (ARI) endpoint in the WFE2
+	// and its directory.
+	ServeRenewalInfo
+	// StoreCertificateProfiles enables storage of the name of the issuance
+	// profile a certificate was issued under in the certificateProfiles table.
+	StoreCertificateProfiles
+	// HTTP01HappyEyeballs makes the VA race connections to the IPv6 and IPv4
+	// addresses of dual-stacked HTTP-01 targets, rather than falling back to
+	// IPv4 only after the IPv6 dial has failed.
+	HTTP01HappyEyeballs
+	// StoreDeactivationInfo enables storage of when and why accounts were
+	// deactivated in the deactivatedRegistrations table.
+	StoreDeactivationInfo
+	// BlockedDomainsTable makes the RA reject new orders for names blocked by
+	// an entry in the blockedDomains table.
+	BlockedDomainsTable
+	// StoreOrderProfiles enables storage of the issuance profile requested for
+	// an order in the orderProfiles table. Without it, the SA refuses new
+	// orders which request a profile.
+	StoreOrderProfiles
+	// LocalizeProblems makes the WFEs translate the detail of problem
+	// documents into the language preferred by the request's Accept-Language
+	// header, where a translation is available.
+	LocalizeProblems
+	// OrderVersions makes the SA increment the version column of an order's
+	// row on every update to it, and reject updates which carry a stale
+	// version with a Conflict error.
+	OrderVersions
+	// SkipReplacedCertNags makes the expiration mailer skip certificates
+	// whose account has been issued a newer, unexpired and unrevoked
+	// certificate for a superset of their names.
+	SkipReplacedCertNags
+	// RateLimitOverridesTable makes the RA apply the unexpired overrides in
+	// the rateLimitOverrides table on top of those in its rate limit policy
+	// file.
+	RateLimitOverridesTable
+	// StoreIssuerFingerprints enables storage of the SHA-256 fingerprint of the
+	// issuer certificate a certificate was signed by in the certificateIssuers
+	// table.
+	StoreIssuerFingerprints
 )
 
 // List of features and their default value, protected by fMu
@@ -85,6 +128,18 @@ var features = map[FeatureFlag]bool{
 	RestrictRSAKeySizes:           false,
 	FasterNewOrdersRateLimit:      false,
 	BlockedKeyTable:               false,
+	NotificationPreferences:       false,
+	ServeRenewalInfo:              false,
+	StoreCertificateProfiles:      false,
+	HTTP01HappyEyeballs:           false,
+	StoreDeactivationInfo:         false,
+	BlockedDomainsTable:           false,
+	StoreOrderProfiles:            false,
+	LocalizeProblems:              false,
+	OrderVersions:                 false,
+	SkipReplacedCertNags:          false,
+	RateLimitOverridesTable:       false,
+	StoreIssuerFingerprints:       false,
 }
 
 var fMu = new(sync.RWMutex)
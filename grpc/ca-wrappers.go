@@ -89,7 +89,9 @@ func (cas *CertificateAuthorityServerWrapper) IssuePrecertificate(ctx context.Co
 }
 
 func (cas *CertificateAuthorityServerWrapper) IssueCertificateForPrecertificate(ctx context.Context, req *capb.IssueCertificateForPrecertificateRequest) (*corepb.Certificate, error) {
-	if req == nil || req.DER == nil || req.OrderID == nil || req.RegistrationID == nil || req.SCTs == nil {
+	// SCTs aren't required since issuance profiles may omit them. The CA
+	// checks they're present if the precertificate's profile embeds them.
+	if req == nil || req.DER == nil || req.OrderID == nil || req.RegistrationID == nil {
 		return nil, errIncompleteRequest
 	}
 	cert, err := cas.inner.IssueCertificateForPrecertificate(ctx, req)
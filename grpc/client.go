@@ -9,6 +9,7 @@ import (
 	"github.com/jmhodges/clock"
 	"github.com/letsencrypt/boulder/cmd"
 	bcreds "github.com/letsencrypt/boulder/grpc/creds"
+	blog "github.com/letsencrypt/boulder/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 )
@@ -39,12 +40,21 @@ func ClientSetup(c *cmd.GRPCClientConfig, tlsConfig *tls.Config, metrics clientM
 		return nil, err
 	}
 	creds := bcreds.NewClientCredentials(tlsConfig.RootCAs, tlsConfig.Certificates, host)
-	return grpc.Dial(
-		"dns:///"+c.ServerAddress,
+	opts := []grpc.DialOption{
 		grpc.WithBalancerName("round_robin"),
 		grpc.WithTransportCredentials(creds),
-		grpc.WithUnaryInterceptor(ci.intercept),
-	)
+	}
+	if c.LogLevel > 0 {
+		// The RPC logger runs before the client interceptor so that it logs the
+		// status of the RPC after Boulder errors have been unwrapped.
+		rl := blog.NewRPCLogger(blog.Get(), c.LogLevel, clk)
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(rl.UnaryClientInterceptor, ci.intercept),
+			grpc.WithStreamInterceptor(rl.StreamClientInterceptor))
+	} else {
+		opts = append(opts, grpc.WithUnaryInterceptor(ci.intercept))
+	}
+	return grpc.Dial("dns:///"+c.ServerAddress, opts...)
 }
 
 type registry interface {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -47,6 +48,12 @@ func wrapError(ctx context.Context, err error) error {
 			pairs = append(pairs, string(jsonSubErrs))
 		}
 
+		// If there is a RetryAfter then extend the metadata pairs to include
+		// it, in nanoseconds.
+		if berr.RetryAfter > 0 {
+			pairs = append(pairs, "retryafter", strconv.FormatInt(int64(berr.RetryAfter), 10))
+		}
+
 		// Ignoring the error return here is safe because if setting the metadata
 		// fails, we'll still return an error, but it will be interpreted on the
 		// other side as an InternalServerError instead of a more specific one.
@@ -98,6 +105,23 @@ func unwrapError(err error, md metadata.MD) error {
 			}
 			outErr = (outErr.(*berrors.BoulderError)).WithSubErrors(suberrs)
 		}
+		if retryAfterStrs, ok := md["retryafter"]; ok {
+			if len(retryAfterStrs) != 1 {
+				return berrors.InternalServerError(
+					"multiple retryafter metadata, wrapped error %q",
+					unwrappedErr,
+				)
+			}
+			retryAfter, err := strconv.ParseInt(retryAfterStrs[0], 10, 64)
+			if err != nil {
+				return berrors.InternalServerError(
+					"failed to decode retryafter %q, wrapped error %q",
+					retryAfterStrs[0],
+					unwrappedErr,
+				)
+			}
+			outErr.(*berrors.BoulderError).RetryAfter = time.Duration(retryAfter)
+		}
 		return outErr
 	}
 	return err
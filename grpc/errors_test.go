@@ -50,6 +50,11 @@ func TestErrorWrapping(t *testing.T) {
 	test.Assert(t, err != nil, fmt.Sprintf("nil error returned, expected: %s", err))
 	test.AssertDeepEquals(t, err, es.err)
 
+	// A RetryAfter is carried along with the error.
+	es.err = berrors.RateLimitError(90*time.Minute, "slow down")
+	_, err = client.Chill(context.Background(), &testproto.Time{})
+	test.AssertDeepEquals(t, err, es.err)
+
 	test.AssertEquals(t, wrapError(context.Background(), nil), nil)
 	test.AssertEquals(t, unwrapError(nil, nil), nil)
 }
@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
 )
 
 const (
@@ -22,6 +23,7 @@ const (
 	meaningfulWorkOverhead = 100 * time.Millisecond
 	clientRequestTimeKey   = "client-request-time"
 	serverLatencyKey       = "server-latency"
+	requestIDKey           = "request-id"
 )
 
 // serverInterceptor is a gRPC interceptor that adds Prometheus
@@ -53,6 +55,12 @@ func (si *serverInterceptor) intercept(ctx context.Context, req interface{}, inf
 		}
 	}
 
+	// If the client attached a request ID, make it available to the handler
+	// so that it can be included in log lines with blog.ForContext.
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[requestIDKey]) > 0 {
+		ctx = blog.WithRequestID(ctx, md[requestIDKey][0])
+	}
+
 	// Shave 20 milliseconds off the deadline to ensure that if the RPC server times
 	// out any sub-calls it makes (like DNS lookups, or onwards RPCs), it has a
 	// chance to report that timeout to the client. This allows for more specific
@@ -155,6 +163,11 @@ func (ci *clientInterceptor) intercept(
 	// Create a grpc/metadata.Metadata instance for the request metadata.
 	// Initialize it with the request time.
 	reqMD := metadata.New(map[string]string{clientRequestTimeKey: nowTS})
+	// Pass along the ID of the request being handled, if any, so that the
+	// server can log it.
+	if id := blog.RequestIDFromContext(ctx); id != "" {
+		reqMD.Set(requestIDKey, id)
+	}
 	// Configure the localCtx with the metadata so it gets sent along in the request
 	localCtx = metadata.NewOutgoingContext(localCtx, reqMD)
 
@@ -206,3 +219,7 @@ func (dd deadlineDetails) Error() string {
 	return fmt.Sprintf("%s.%s timed out after %d ms",
 		dd.service, dd.method, int64(dd.latency/time.Millisecond))
 }
+
+// Timeout returns true so that callers can distinguish RPCs that timed out from
+// ones that failed without needing the unexported type.
+func (dd deadlineDetails) Timeout() bool { return true }
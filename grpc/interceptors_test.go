@@ -18,6 +18,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/letsencrypt/boulder/grpc/test_proto"
+	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/test"
 )
@@ -73,6 +74,33 @@ func TestClientInterceptor(t *testing.T) {
 	test.AssertError(t, err, "ci.intercept didn't fail when handler returned a error")
 }
 
+func TestRequestIDPropagation(t *testing.T) {
+	ci := clientInterceptor{
+		timeout: time.Second,
+		metrics: NewClientMetrics(metrics.NoopRegisterer),
+		clk:     clock.NewFake(),
+	}
+	var sent metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	ctx := blog.WithRequestID(context.Background(), "abc123")
+	err := ci.intercept(ctx, "-service-test", nil, nil, nil, invoker)
+	test.AssertNotError(t, err, "ci.intercept failed")
+	test.AssertDeepEquals(t, sent[requestIDKey], []string{"abc123"})
+
+	si := newServerInterceptor(NewServerMetrics(metrics.NoopRegisterer), clock.NewFake())
+	var received string
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		received = blog.RequestIDFromContext(ctx)
+		return nil, nil
+	}
+	_, err = si.intercept(metadata.NewIncomingContext(context.Background(), sent), nil, &grpc.UnaryServerInfo{FullMethod: "-service-test"}, handler)
+	test.AssertNotError(t, err, "si.intercept failed")
+	test.AssertEquals(t, received, "abc123")
+}
+
 // TestFailFastFalse sends a gRPC request to a backend that is
 // unavailable, and ensures that the request doesn't error out until the
 // timeout is reached, i.e. that FailFast is set to false.
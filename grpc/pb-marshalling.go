@@ -16,6 +16,7 @@ import (
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/revocation"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 	vapb "github.com/letsencrypt/boulder/va/proto"
 )
@@ -145,14 +146,21 @@ func ValidationRecordToPB(record core.ValidationRecord) (*corepb.ValidationRecor
 	if err != nil {
 		return nil, err
 	}
-	return &corepb.ValidationRecord{
+	pb := &corepb.ValidationRecord{
 		Hostname:          &record.Hostname,
 		Port:              &record.Port,
 		AddressesResolved: addrs,
 		AddressUsed:       addrUsed,
 		Url:               &record.URL,
 		AddressesTried:    addrsTried,
-	}, nil
+	}
+	// Only set the timeout when one was applied so that records without one
+	// round trip through storage unchanged.
+	if record.Timeout != 0 {
+		timeout := int64(record.Timeout)
+		pb.Timeout = &timeout
+	}
+	return pb, nil
 }
 
 func PBToValidationRecord(in *corepb.ValidationRecord) (record core.ValidationRecord, err error) {
@@ -175,6 +183,10 @@ func PBToValidationRecord(in *corepb.ValidationRecord) (record core.ValidationRe
 	if err != nil {
 		return
 	}
+	var timeout time.Duration
+	if in.Timeout != nil {
+		timeout = time.Duration(*in.Timeout)
+	}
 	return core.ValidationRecord{
 		Hostname:          *in.Hostname,
 		Port:              *in.Port,
@@ -182,6 +194,7 @@ func PBToValidationRecord(in *corepb.ValidationRecord) (record core.ValidationRe
 		AddressUsed:       addrUsed,
 		URL:               *in.Url,
 		AddressesTried:    addrsTried,
+		Timeout:           timeout,
 	}, nil
 }
 
@@ -223,7 +236,7 @@ func pbToValidationResult(in *vapb.ValidationResult) ([]core.ValidationRecord, *
 	return recordAry, prob, nil
 }
 
-func performValidationReqToArgs(in *vapb.PerformValidationRequest) (domain string, challenge core.Challenge, authz core.Authorization, err error) {
+func PerformValidationReqToArgs(in *vapb.PerformValidationRequest) (domain string, challenge core.Challenge, authz core.Authorization, err error) {
 	if in == nil {
 		err = ErrMissingParameters
 		return
@@ -245,7 +258,7 @@ func performValidationReqToArgs(in *vapb.PerformValidationRequest) (domain strin
 	return domain, challenge, authz, nil
 }
 
-func argsToPerformValidationRequest(domain string, challenge core.Challenge, authz core.Authorization) (*vapb.PerformValidationRequest, error) {
+func ArgsToPerformValidationRequest(domain string, challenge core.Challenge, authz core.Authorization) (*vapb.PerformValidationRequest, error) {
 	pbChall, err := ChallengeToPB(challenge)
 	if err != nil {
 		return nil, err
@@ -428,6 +441,44 @@ func PBToCert(pb *corepb.Certificate) (core.Certificate, error) {
 	}, nil
 }
 
+func CertStatusToPB(certStatus core.CertificateStatus) *sapb.CertificateStatus {
+	ocspLastUpdatedNano := certStatus.OCSPLastUpdated.UnixNano()
+	revokedDateNano := certStatus.RevokedDate.UnixNano()
+	lastExpirationNagSentNano := certStatus.LastExpirationNagSent.UnixNano()
+	notAfterNano := certStatus.NotAfter.UnixNano()
+	reason := int64(certStatus.RevokedReason)
+	status := string(certStatus.Status)
+
+	return &sapb.CertificateStatus{
+		Serial:                &certStatus.Serial,
+		Status:                &status,
+		OcspLastUpdated:       &ocspLastUpdatedNano,
+		RevokedDate:           &revokedDateNano,
+		RevokedReason:         &reason,
+		LastExpirationNagSent: &lastExpirationNagSentNano,
+		OcspResponse:          certStatus.OCSPResponse,
+		NotAfter:              &notAfterNano,
+		IsExpired:             &certStatus.IsExpired,
+	}
+}
+
+func PBToCertStatus(pb *sapb.CertificateStatus) (core.CertificateStatus, error) {
+	if pb == nil || pb.Serial == nil || pb.Status == nil || pb.OcspLastUpdated == nil || pb.RevokedDate == nil || pb.RevokedReason == nil || pb.LastExpirationNagSent == nil || pb.OcspResponse == nil || pb.NotAfter == nil || pb.IsExpired == nil {
+		return core.CertificateStatus{}, errIncompleteResponse
+	}
+	return core.CertificateStatus{
+		Serial:                *pb.Serial,
+		Status:                core.OCSPStatus(*pb.Status),
+		OCSPLastUpdated:       time.Unix(0, *pb.OcspLastUpdated),
+		RevokedDate:           time.Unix(0, *pb.RevokedDate),
+		RevokedReason:         revocation.Reason(*pb.RevokedReason),
+		LastExpirationNagSent: time.Unix(0, *pb.LastExpirationNagSent),
+		OCSPResponse:          pb.OcspResponse,
+		NotAfter:              time.Unix(0, *pb.NotAfter),
+		IsExpired:             *pb.IsExpired,
+	}, nil
+}
+
 // PBToAuthzMap converts a protobuf map of domains mapped to protobuf authorizations to a
 // golang map[string]*core.Authorization.
 func PBToAuthzMap(pb *sapb.Authorizations) (map[string]*core.Authorization, error) {
@@ -137,6 +137,7 @@ func TestValidationRecord(t *testing.T) {
 		AddressUsed:       ip,
 		URL:               "url",
 		AddressesTried:    []net.IP{ip},
+		Timeout:           5 * time.Second,
 	}
 
 	pb, err := ValidationRecordToPB(vr)
@@ -192,12 +193,12 @@ func TestPerformValidationReq(t *testing.T) {
 	}
 	authz := core.Authorization{ID: "asd", RegistrationID: 10}
 
-	pb, err := argsToPerformValidationRequest(domain, chall, authz)
-	test.AssertNotError(t, err, "argsToPerformValidationRequest failed")
+	pb, err := ArgsToPerformValidationRequest(domain, chall, authz)
+	test.AssertNotError(t, err, "ArgsToPerformValidationRequest failed")
 	test.Assert(t, pb != nil, "Return vapb.PerformValidationRequest is nil")
 
-	reconDomain, reconChall, reconAuthz, err := performValidationReqToArgs(pb)
-	test.AssertNotError(t, err, "performValidationReqToArgs failed")
+	reconDomain, reconChall, reconAuthz, err := PerformValidationReqToArgs(pb)
+	test.AssertNotError(t, err, "PerformValidationReqToArgs failed")
 	test.AssertEquals(t, reconDomain, domain)
 	test.AssertDeepEquals(t, reconChall, chall)
 	test.AssertDeepEquals(t, reconAuthz, authz)
@@ -186,6 +186,34 @@ func (ras *RegistrationAuthorityClientWrapper) FinalizeOrder(ctx context.Context
 	return resp, nil
 }
 
+func (ras *RegistrationAuthorityClientWrapper) DryRunIssuance(ctx context.Context, request *rapb.DryRunIssuanceRequest) (*rapb.DryRunIssuanceResponse, error) {
+	resp, err := ras.inner.DryRunIssuance(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Allowed == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (ras *RegistrationAuthorityClientWrapper) GetRateLimits(ctx context.Context, request *rapb.GetRateLimitsRequest) (*rapb.RateLimits, error) {
+	resp, err := ras.inner.GetRateLimits(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	for _, limit := range resp.Limits {
+		if limit == nil || limit.Limit == nil || limit.Threshold == nil || limit.Count == nil ||
+			limit.Window == nil || limit.ResetBy == nil {
+			return nil, errIncompleteResponse
+		}
+	}
+	return resp, nil
+}
+
 // RegistrationAuthorityServerWrapper is the gRPC version of a core.RegistrationAuthority server
 type RegistrationAuthorityServerWrapper struct {
 	inner core.RegistrationAuthority
@@ -342,3 +370,17 @@ func (ras *RegistrationAuthorityServerWrapper) FinalizeOrder(ctx context.Context
 
 	return ras.inner.FinalizeOrder(ctx, request)
 }
+
+func (ras *RegistrationAuthorityServerWrapper) DryRunIssuance(ctx context.Context, request *rapb.DryRunIssuanceRequest) (*rapb.DryRunIssuanceResponse, error) {
+	if request == nil || request.RegistrationID == nil {
+		return nil, errIncompleteRequest
+	}
+	return ras.inner.DryRunIssuance(ctx, request)
+}
+
+func (ras *RegistrationAuthorityServerWrapper) GetRateLimits(ctx context.Context, request *rapb.GetRateLimitsRequest) (*rapb.RateLimits, error) {
+	if request == nil || request.RegistrationID == nil {
+		return nil, errIncompleteRequest
+	}
+	return ras.inner.GetRateLimits(ctx, request)
+}
@@ -15,7 +15,6 @@ import (
 
 	"github.com/letsencrypt/boulder/core"
 	corepb "github.com/letsencrypt/boulder/core/proto"
-	"github.com/letsencrypt/boulder/revocation"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 )
 
@@ -85,21 +84,7 @@ func (sac StorageAuthorityClientWrapper) GetCertificateStatus(ctx context.Contex
 		return core.CertificateStatus{}, err
 	}
 
-	if response == nil || response.Serial == nil || response.Status == nil || response.OcspLastUpdated == nil || response.RevokedDate == nil || response.RevokedReason == nil || response.LastExpirationNagSent == nil || response.OcspResponse == nil || response.NotAfter == nil || response.IsExpired == nil {
-		return core.CertificateStatus{}, errIncompleteResponse
-	}
-
-	return core.CertificateStatus{
-		Serial:                *response.Serial,
-		Status:                core.OCSPStatus(*response.Status),
-		OCSPLastUpdated:       time.Unix(0, *response.OcspLastUpdated),
-		RevokedDate:           time.Unix(0, *response.RevokedDate),
-		RevokedReason:         revocation.Reason(*response.RevokedReason),
-		LastExpirationNagSent: time.Unix(0, *response.LastExpirationNagSent),
-		OCSPResponse:          response.OcspResponse,
-		NotAfter:              time.Unix(0, *response.NotAfter),
-		IsExpired:             *response.IsExpired,
-	}, nil
+	return PBToCertStatus(response)
 }
 
 func (sac StorageAuthorityClientWrapper) CountCertificatesByNames(ctx context.Context, domains []string, earliest, latest time.Time) ([]*sapb.CountByNames_MapElement, error) {
@@ -322,8 +307,8 @@ func (sac StorageAuthorityClientWrapper) AddCertificate(
 	return *response.Digest, nil
 }
 
-func (sac StorageAuthorityClientWrapper) DeactivateRegistration(ctx context.Context, id int64) error {
-	_, err := sac.inner.DeactivateRegistration(ctx, &sapb.RegistrationID{Id: &id})
+func (sac StorageAuthorityClientWrapper) DeactivateRegistration(ctx context.Context, id int64, reason string) error {
+	_, err := sac.inner.DeactivateRegistration(ctx, &sapb.DeactivateRegistrationRequest{Id: &id, Reason: &reason})
 	if err != nil {
 		return err
 	}
@@ -510,6 +495,171 @@ func (sac StorageAuthorityClientWrapper) KeyBlocked(ctx context.Context, req *sa
 	return sac.inner.KeyBlocked(ctx, req)
 }
 
+func (sac StorageAuthorityClientWrapper) AddBlockedDomain(ctx context.Context, req *sapb.AddBlockedDomainRequest) (*corepb.Empty, error) {
+	// All return checking is done at the call site
+	return sac.inner.AddBlockedDomain(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) RemoveBlockedDomain(ctx context.Context, req *sapb.RemoveBlockedDomainRequest) (*corepb.Empty, error) {
+	// All return checking is done at the call site
+	return sac.inner.RemoveBlockedDomain(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) DomainsBlocked(ctx context.Context, req *sapb.DomainsBlockedRequest) (*sapb.BlockedDomains, error) {
+	resp, err := sac.inner.DomainsBlocked(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) ReplacementCertificateExists(ctx context.Context, req *sapb.ReplacementCertificateExistsRequest) (*sapb.Exists, error) {
+	resp, err := sac.inner.ReplacementCertificateExists(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Exists == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetCertificateHistory(ctx context.Context, req *sapb.Serial) (*sapb.CertificateHistory, error) {
+	resp, err := sac.inner.GetCertificateHistory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Serial == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*corepb.Empty, error) {
+	// All return checking is done at the call site
+	return sac.inner.AddRateLimitOverride(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) RemoveRateLimitOverride(ctx context.Context, req *sapb.RemoveRateLimitOverrideRequest) (*corepb.Empty, error) {
+	// All return checking is done at the call site
+	return sac.inner.RemoveRateLimitOverride(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	resp, err := sac.inner.GetRateLimitOverrides(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	for _, override := range resp.Overrides {
+		if override == nil || override.LimitName == nil || override.Threshold == nil || override.Expires == nil {
+			return nil, errIncompleteResponse
+		}
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) CountActiveCertificates(ctx context.Context, req *sapb.RegistrationID) (*sapb.Count, error) {
+	count, err := sac.inner.CountActiveCertificates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if count == nil || count.Count == nil {
+		return nil, errIncompleteResponse
+	}
+	return count, nil
+}
+
+func (sac StorageAuthorityClientWrapper) CountActiveCertificatesByRegistrations(ctx context.Context, req *sapb.CountActiveCertificatesByRegistrationsRequest) (*sapb.CountByRegistrations, error) {
+	resp, err := sac.inner.CountActiveCertificatesByRegistrations(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	for _, element := range resp.CountByRegistrations {
+		if element == nil || element.RegistrationID == nil || element.Count == nil {
+			return nil, errIncompleteResponse
+		}
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetNotificationPreferences(ctx context.Context, req *sapb.RegistrationID) (*sapb.NotificationPreferences, error) {
+	// All return checking is done at the call site
+	return sac.inner.GetNotificationPreferences(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) GetCertificatesByRegistration(ctx context.Context, req *sapb.GetCertificatesByRegistrationRequest) (*sapb.Certificates, error) {
+	// All return checking is done at the call site
+	return sac.inner.GetCertificatesByRegistration(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) GetCertificatesExpiring(ctx context.Context, req *sapb.GetCertificatesExpiringRequest) (*sapb.Certificates, error) {
+	// All return checking is done at the call site
+	return sac.inner.GetCertificatesExpiring(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) SearchCertificatesByName(ctx context.Context, req *sapb.SearchCertificatesByNameRequest) (*sapb.CertificateNameMatches, error) {
+	// All return checking is done at the call site
+	return sac.inner.SearchCertificatesByName(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) GetCertificateProfile(ctx context.Context, req *sapb.Serial) (*sapb.CertificateProfile, error) {
+	resp, err := sac.inner.GetCertificateProfile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Name == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetCertificateIssuer(ctx context.Context, req *sapb.Serial) (*sapb.CertificateIssuer, error) {
+	resp, err := sac.inner.GetCertificateIssuer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Fingerprint == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetOrderForSerial(ctx context.Context, req *sapb.Serial) (*sapb.OrderForSerial, error) {
+	resp, err := sac.inner.GetOrderForSerial(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.OrderID == nil || resp.RegistrationID == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetDeactivatedRegistrations(ctx context.Context, req *sapb.GetDeactivatedRegistrationsRequest) (*sapb.DeactivatedRegistrations, error) {
+	resp, err := sac.inner.GetDeactivatedRegistrations(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	for _, reg := range resp.Registrations {
+		if reg == nil || reg.RegistrationID == nil || reg.DeactivatedAt == nil || reg.Reason == nil {
+			return nil, errIncompleteResponse
+		}
+	}
+	return resp, nil
+}
+
 // StorageAuthorityServerWrapper is the gRPC version of a core.ServerAuthority server
 type StorageAuthorityServerWrapper struct {
 	// TODO(#3119): Don't use core.StorageAuthority
@@ -583,24 +733,7 @@ func (sas StorageAuthorityServerWrapper) GetCertificateStatus(ctx context.Contex
 		return nil, err
 	}
 
-	ocspLastUpdatedNano := certStatus.OCSPLastUpdated.UnixNano()
-	revokedDateNano := certStatus.RevokedDate.UnixNano()
-	lastExpirationNagSentNano := certStatus.LastExpirationNagSent.UnixNano()
-	notAfterNano := certStatus.NotAfter.UnixNano()
-	reason := int64(certStatus.RevokedReason)
-	status := string(certStatus.Status)
-
-	return &sapb.CertificateStatus{
-		Serial:                &certStatus.Serial,
-		Status:                &status,
-		OcspLastUpdated:       &ocspLastUpdatedNano,
-		RevokedDate:           &revokedDateNano,
-		RevokedReason:         &reason,
-		LastExpirationNagSent: &lastExpirationNagSentNano,
-		OcspResponse:          certStatus.OCSPResponse,
-		NotAfter:              &notAfterNano,
-		IsExpired:             &certStatus.IsExpired,
-	}, nil
+	return CertStatusToPB(certStatus), nil
 }
 
 func (sas StorageAuthorityServerWrapper) CountCertificatesByNames(ctx context.Context, request *sapb.CountCertificatesByNamesRequest) (*sapb.CountByNames, error) {
@@ -759,12 +892,12 @@ func (sas StorageAuthorityServerWrapper) AddCertificate(ctx context.Context, req
 	return &sapb.AddCertificateResponse{Digest: &digest}, nil
 }
 
-func (sas StorageAuthorityServerWrapper) DeactivateRegistration(ctx context.Context, request *sapb.RegistrationID) (*corepb.Empty, error) {
+func (sas StorageAuthorityServerWrapper) DeactivateRegistration(ctx context.Context, request *sapb.DeactivateRegistrationRequest) (*corepb.Empty, error) {
 	if request == nil || request.Id == nil {
 		return nil, errIncompleteRequest
 	}
 
-	err := sas.inner.DeactivateRegistration(ctx, *request.Id)
+	err := sas.inner.DeactivateRegistration(ctx, *request.Id, request.GetReason())
 	if err != nil {
 		return nil, err
 	}
@@ -936,3 +1069,99 @@ func (sas StorageAuthorityServerWrapper) KeyBlocked(ctx context.Context, req *sa
 	// All request checking is done in the method
 	return sas.inner.KeyBlocked(ctx, req)
 }
+
+func (sas StorageAuthorityServerWrapper) AddBlockedDomain(ctx context.Context, req *sapb.AddBlockedDomainRequest) (*corepb.Empty, error) {
+	// All request checking is done in the method
+	return sas.inner.AddBlockedDomain(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) RemoveBlockedDomain(ctx context.Context, req *sapb.RemoveBlockedDomainRequest) (*corepb.Empty, error) {
+	// All request checking is done in the method
+	return sas.inner.RemoveBlockedDomain(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) DomainsBlocked(ctx context.Context, req *sapb.DomainsBlockedRequest) (*sapb.BlockedDomains, error) {
+	// All request checking is done in the method
+	return sas.inner.DomainsBlocked(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) ReplacementCertificateExists(ctx context.Context, req *sapb.ReplacementCertificateExistsRequest) (*sapb.Exists, error) {
+	// All request checking is done in the method
+	return sas.inner.ReplacementCertificateExists(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetCertificateHistory(ctx context.Context, req *sapb.Serial) (*sapb.CertificateHistory, error) {
+	// All request checking is done in the method
+	return sas.inner.GetCertificateHistory(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*corepb.Empty, error) {
+	// All request checking is done in the method
+	return sas.inner.AddRateLimitOverride(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) RemoveRateLimitOverride(ctx context.Context, req *sapb.RemoveRateLimitOverrideRequest) (*corepb.Empty, error) {
+	// All request checking is done in the method
+	return sas.inner.RemoveRateLimitOverride(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	// All request checking is done in the method
+	return sas.inner.GetRateLimitOverrides(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) CountActiveCertificates(ctx context.Context, req *sapb.RegistrationID) (*sapb.Count, error) {
+	if req == nil || req.Id == nil {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.CountActiveCertificates(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) CountActiveCertificatesByRegistrations(ctx context.Context, req *sapb.CountActiveCertificatesByRegistrationsRequest) (*sapb.CountByRegistrations, error) {
+	if req == nil {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.CountActiveCertificatesByRegistrations(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetNotificationPreferences(ctx context.Context, req *sapb.RegistrationID) (*sapb.NotificationPreferences, error) {
+	// All request checking is done in the method
+	return sas.inner.GetNotificationPreferences(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetCertificatesByRegistration(ctx context.Context, req *sapb.GetCertificatesByRegistrationRequest) (*sapb.Certificates, error) {
+	// All request checking is done in the method
+	return sas.inner.GetCertificatesByRegistration(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetCertificatesExpiring(ctx context.Context, req *sapb.GetCertificatesExpiringRequest) (*sapb.Certificates, error) {
+	// All request checking is done in the method
+	return sas.inner.GetCertificatesExpiring(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetCertificateProfile(ctx context.Context, req *sapb.Serial) (*sapb.CertificateProfile, error) {
+	// All request checking is done in the method
+	return sas.inner.GetCertificateProfile(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetCertificateIssuer(ctx context.Context, req *sapb.Serial) (*sapb.CertificateIssuer, error) {
+	// All request checking is done in the method
+	return sas.inner.GetCertificateIssuer(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) SearchCertificatesByName(ctx context.Context, req *sapb.SearchCertificatesByNameRequest) (*sapb.CertificateNameMatches, error) {
+	// All request checking is done in the method
+	return sas.inner.SearchCertificatesByName(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetOrderForSerial(ctx context.Context, req *sapb.Serial) (*sapb.OrderForSerial, error) {
+	// All request checking is done in the method
+	return sas.inner.GetOrderForSerial(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetDeactivatedRegistrations(ctx context.Context, req *sapb.GetDeactivatedRegistrationsRequest) (*sapb.DeactivatedRegistrations, error) {
+	// All request checking is done in the method
+	return sas.inner.GetDeactivatedRegistrations(ctx, req)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jmhodges/clock"
 	"github.com/letsencrypt/boulder/cmd"
 	bcreds "github.com/letsencrypt/boulder/grpc/creds"
+	blog "github.com/letsencrypt/boulder/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 )
@@ -47,10 +48,18 @@ func NewServer(c *cmd.GRPCServerConfig, tlsConfig *tls.Config, metrics serverMet
 	}
 
 	si := newServerInterceptor(metrics, clk)
-	return grpc.NewServer(
-		grpc.Creds(creds),
-		grpc.UnaryInterceptor(si.intercept),
-	), l, nil
+	opts := []grpc.ServerOption{grpc.Creds(creds)}
+	if c.LogLevel > 0 {
+		// The RPC logger runs after the server interceptor so that the request
+		// ID it extracts from the request metadata is logged.
+		rl := blog.NewRPCLogger(blog.Get(), c.LogLevel, clk)
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(si.intercept, rl.UnaryServerInterceptor),
+			grpc.StreamInterceptor(rl.StreamServerInterceptor))
+	} else {
+		opts = append(opts, grpc.UnaryInterceptor(si.intercept))
+	}
+	return grpc.NewServer(opts...), l, nil
 }
 
 // serverMetrics is a struct type used to return a few registered metrics from
@@ -21,7 +21,7 @@ type ValidationAuthorityGRPCServer struct {
 }
 
 func (s *ValidationAuthorityGRPCServer) PerformValidation(ctx context.Context, in *vaPB.PerformValidationRequest) (*vaPB.ValidationResult, error) {
-	domain, challenge, authz, err := performValidationReqToArgs(in)
+	domain, challenge, authz, err := PerformValidationReqToArgs(in)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,7 @@ func NewValidationAuthorityGRPCClient(cc *ggrpc.ClientConn) core.ValidationAutho
 // PerformValidation has the VA revalidate the specified challenge and returns
 // the updated Challenge object.
 func (vac ValidationAuthorityGRPCClient) PerformValidation(ctx context.Context, domain string, challenge core.Challenge, authz core.Authorization) ([]core.ValidationRecord, error) {
-	req, err := argsToPerformValidationRequest(domain, challenge, authz)
+	req, err := ArgsToPerformValidationRequest(domain, challenge, authz)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,97 @@
+package log
+
+import (
+	"context"
+	"fmt"
+)
+
+// requestIDKey is the context.Context key under which request IDs are stored.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the provided request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or the empty
+// string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ForContext returns a Logger which prefixes every message with the request ID
+// carried by ctx, so that the lines logged by every service involved in a
+// single request can be correlated. If ctx carries no request ID, logger is
+// returned unchanged.
+func ForContext(ctx context.Context, logger Logger) Logger {
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		return logger
+	}
+	return requestIDLogger{logger, fmt.Sprintf("[%s] ", id)}
+}
+
+// requestIDLogger prefixes every message passed to the embedded Logger. The
+// prefix is applied to the message itself, so for audit messages it follows
+// the audit tag.
+type requestIDLogger struct {
+	Logger
+	prefix string
+}
+
+func (l requestIDLogger) Err(msg string) {
+	l.Logger.Err(l.prefix + msg)
+}
+
+func (l requestIDLogger) Errf(format string, a ...interface{}) {
+	l.Logger.Err(l.prefix + fmt.Sprintf(format, a...))
+}
+
+func (l requestIDLogger) Warning(msg string) {
+	l.Logger.Warning(l.prefix + msg)
+}
+
+func (l requestIDLogger) Warningf(format string, a ...interface{}) {
+	l.Logger.Warning(l.prefix + fmt.Sprintf(format, a...))
+}
+
+func (l requestIDLogger) Info(msg string) {
+	l.Logger.Info(l.prefix + msg)
+}
+
+func (l requestIDLogger) Infof(format string, a ...interface{}) {
+	l.Logger.Info(l.prefix + fmt.Sprintf(format, a...))
+}
+
+func (l requestIDLogger) Debug(msg string) {
+	l.Logger.Debug(l.prefix + msg)
+}
+
+func (l requestIDLogger) Debugf(format string, a ...interface{}) {
+	l.Logger.Debugf("%s"+format, append([]interface{}{l.prefix}, a...)...)
+}
+
+func (l requestIDLogger) AuditInfo(msg string) {
+	l.Logger.AuditInfo(l.prefix + msg)
+}
+
+func (l requestIDLogger) AuditInfof(format string, a ...interface{}) {
+	l.Logger.AuditInfo(l.prefix + fmt.Sprintf(format, a...))
+}
+
+func (l requestIDLogger) AuditObject(msg string, obj interface{}) {
+	l.Logger.AuditObject(l.prefix+msg, obj)
+}
+
+func (l requestIDLogger) AuditErr(msg string) {
+	l.Logger.AuditErr(l.prefix + msg)
+}
+
+func (l requestIDLogger) AuditErrf(format string, a ...interface{}) {
+	l.Logger.AuditErr(l.prefix + fmt.Sprintf(format, a...))
+}
+
+func (l requestIDLogger) WithFields(fields map[string]interface{}) Logger {
+	return requestIDLogger{l.Logger.WithFields(fields), l.prefix}
+}
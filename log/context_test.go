@@ -0,0 +1,37 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestRequestIDContext(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	test.AssertEquals(t, RequestIDFromContext(ctx), "")
+	ctx = WithRequestID(ctx, "abc123")
+	test.AssertEquals(t, RequestIDFromContext(ctx), "abc123")
+}
+
+func TestForContext(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	test.AssertEquals(t, ForContext(context.Background(), m), Logger(m))
+
+	logger := ForContext(WithRequestID(context.Background(), "abc123"), m)
+	logger.Info("info")
+	logger.Errf("err %d", 1)
+	logger.AuditInfof("audit %s", "info")
+	logger.Debugf("debug %s", "100%")
+	logger.WithFields(map[string]interface{}{"k": "v"}).Warning("warning")
+
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"INFO: [abc123] info",
+		"ERR: [AUDIT] [abc123] err 1",
+		"INFO: [AUDIT] [abc123] audit info",
+		"DEBUG: [abc123] debug 100%",
+		"WARNING: [abc123] warning k=v",
+	})
+}
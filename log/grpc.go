@@ -0,0 +1,152 @@
+package log
+
+import (
+	"context"
+	"io"
+	"log/syslog"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// RPCLogger logs a line when each gRPC request starts and another when it
+// ends, with the method, duration and status of the request and the request
+// ID carried by its context, if any. It provides interceptors for both gRPC
+// servers and clients.
+type RPCLogger struct {
+	log   Logger
+	level syslog.Priority
+	clk   clock.Clock
+}
+
+// NewRPCLogger returns an RPCLogger which logs to logger at the given syslog
+// level (e.g. 6 for info, 7 for debug). A level of zero disables logging, and
+// the interceptors then only call through to the handler or invoker.
+func NewRPCLogger(logger Logger, level int, clk clock.Clock) *RPCLogger {
+	return &RPCLogger{log: logger, level: syslog.Priority(level), clk: clk}
+}
+
+func (rl *RPCLogger) enabled() bool {
+	return rl.level > 0
+}
+
+// logAt logs msg with fields at the RPCLogger's level. Levels more severe
+// than error are logged as errors.
+func (rl *RPCLogger) logAt(fields map[string]interface{}, msg string) {
+	logger := rl.log.WithFields(fields)
+	switch {
+	case rl.level <= syslog.LOG_ERR:
+		logger.Err(msg)
+	case rl.level == syslog.LOG_WARNING:
+		logger.Warning(msg)
+	case rl.level <= syslog.LOG_INFO:
+		logger.Info(msg)
+	default:
+		logger.Debug(msg)
+	}
+}
+
+func (rl *RPCLogger) start(ctx context.Context, kind, method string) time.Time {
+	fields := map[string]interface{}{"method": method}
+	if id := RequestIDFromContext(ctx); id != "" {
+		fields["requestID"] = id
+	}
+	rl.logAt(fields, "gRPC "+kind+" started")
+	return rl.clk.Now()
+}
+
+func (rl *RPCLogger) end(ctx context.Context, kind, method string, began time.Time, err error) {
+	fields := map[string]interface{}{
+		"method":   method,
+		"duration": rl.clk.Since(began).String(),
+		"status":   status.Code(err).String(),
+	}
+	if err != nil {
+		// Boulder errors don't carry a gRPC status code, so the error itself is
+		// logged as well.
+		fields["error"] = err.Error()
+	}
+	if id := RequestIDFromContext(ctx); id != "" {
+		fields["requestID"] = id
+	}
+	rl.logAt(fields, "gRPC "+kind+" finished")
+}
+
+// UnaryServerInterceptor logs each unary request handled by a server. It
+// should run after the request ID has been extracted from the request
+// metadata, so that it can be logged.
+func (rl *RPCLogger) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !rl.enabled() {
+		return handler(ctx, req)
+	}
+	began := rl.start(ctx, "request", info.FullMethod)
+	resp, err := handler(ctx, req)
+	rl.end(ctx, "request", info.FullMethod, began, err)
+	return resp, err
+}
+
+// StreamServerInterceptor logs each stream handled by a server, from when
+// it's opened until the handler returns.
+func (rl *RPCLogger) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !rl.enabled() {
+		return handler(srv, ss)
+	}
+	ctx := ss.Context()
+	began := rl.start(ctx, "stream", info.FullMethod)
+	err := handler(srv, ss)
+	rl.end(ctx, "stream", info.FullMethod, began, err)
+	return err
+}
+
+// UnaryClientInterceptor logs each unary request made by a client.
+func (rl *RPCLogger) UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !rl.enabled() {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	began := rl.start(ctx, "request", method)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	rl.end(ctx, "request", method, began, err)
+	return err
+}
+
+// StreamClientInterceptor logs each stream opened by a client. The stream is
+// considered finished when receiving from it first returns an error, io.EOF
+// being a successful end.
+func (rl *RPCLogger) StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if !rl.enabled() {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	began := rl.start(ctx, "stream", method)
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		rl.end(ctx, "stream", method, began, err)
+		return nil, err
+	}
+	return &loggedClientStream{ClientStream: cs, rl: rl, ctx: ctx, method: method, began: began}, nil
+}
+
+// loggedClientStream logs the end of a client stream the first time RecvMsg
+// returns an error.
+type loggedClientStream struct {
+	grpc.ClientStream
+	rl     *RPCLogger
+	ctx    context.Context
+	method string
+	began  time.Time
+	done   bool
+}
+
+func (s *loggedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil && !s.done {
+		s.done = true
+		if err == io.EOF {
+			s.rl.end(s.ctx, "stream", s.method, s.began, nil)
+		} else {
+			s.rl.end(s.ctx, "stream", s.method, s.began, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestRPCLoggerUnaryServer(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	clk := clock.NewFake()
+	rl := NewRPCLogger(m, 6, clk)
+	info := &grpc.UnaryServerInfo{FullMethod: "/sa.StorageAuthority/GetOrder"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		clk.Add(time.Second)
+		return nil, status.Error(codes.NotFound, "no order")
+	}
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	_, err := rl.UnaryServerInterceptor(ctx, nil, info, handler)
+	test.AssertEquals(t, status.Code(err), codes.NotFound)
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"INFO: gRPC request started method=/sa.StorageAuthority/GetOrder requestID=abc123",
+		"INFO: gRPC request finished duration=1s error=\"rpc error: code = NotFound desc = no order\" method=/sa.StorageAuthority/GetOrder requestID=abc123 status=NotFound",
+	})
+
+	// At level zero nothing is logged.
+	m.Clear()
+	rl = NewRPCLogger(m, 0, clk)
+	_, err = rl.UnaryServerInterceptor(ctx, nil, info, handler)
+	test.AssertEquals(t, status.Code(err), codes.NotFound)
+	test.AssertEquals(t, len(m.GetAll()), 0)
+}
+
+func TestRPCLoggerUnaryClient(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	rl := NewRPCLogger(m, 7, clock.NewFake())
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	err := rl.UnaryClientInterceptor(context.Background(), "/ca.CertificateAuthority/IssuePrecertificate", nil, nil, nil, invoker)
+	test.AssertNotError(t, err, "interceptor failed")
+	test.AssertDeepEquals(t, m.GetAllDebug(), []string{
+		"DEBUG: gRPC request started method=/ca.CertificateAuthority/IssuePrecertificate",
+		"DEBUG: gRPC request finished duration=0s method=/ca.CertificateAuthority/IssuePrecertificate status=OK",
+	})
+}
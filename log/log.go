@@ -1,6 +1,7 @@
 package log
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
@@ -11,6 +12,8 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -35,11 +38,16 @@ type Logger interface {
 	AuditObject(string, interface{})
 	AuditErr(string)
 	AuditErrf(format string, a ...interface{})
+	WithFields(fields map[string]interface{}) Logger
 }
 
 // impl implements Logger.
 type impl struct {
 	w writer
+	// fields are appended to every message, already rendered by renderFields.
+	// fieldMap holds the same fields so that derived loggers can inherit them.
+	fields   string
+	fieldMap map[string]interface{}
 }
 
 // singleton defines the object of a Singleton pattern
@@ -60,7 +68,7 @@ func New(log *syslog.Writer, stdoutLogLevel int, syslogLogLevel int) (Logger, er
 		return nil, errors.New("Attempted to use a nil System Logger.")
 	}
 	return &impl{
-		&bothWriter{log, stdoutLogLevel, syslogLogLevel, clock.New()},
+		w: &bothWriter{log, stdoutLogLevel, syslogLogLevel, clock.New()},
 	}, nil
 }
 
@@ -110,6 +118,9 @@ func Get() Logger {
 
 type writer interface {
 	logAtLevel(syslog.Priority, string)
+	// enabled returns false if messages at the given level would be discarded,
+	// so that callers can skip formatting them.
+	enabled(syslog.Priority) bool
 }
 
 // bothWriter implements writer and writes to both syslog and stdout.
@@ -120,6 +131,8 @@ type bothWriter struct {
 	clk         clock.Clock
 }
 
+// LogLineChecksum returns the checksum of line computed with the default
+// algorithm, ChecksumCRC32.
 func LogLineChecksum(line string) string {
 	crc := crc32.ChecksumIEEE([]byte(line))
 	// Using the hash.Hash32 doesn't make this any easier
@@ -129,16 +142,102 @@ func LogLineChecksum(line string) string {
 	return base64.RawURLEncoding.EncodeToString(buf)
 }
 
+const (
+	// ChecksumCRC32 names the original checksum algorithm, a varint-encoded
+	// CRC32. Its checksums carry no algorithm prefix so that lines written
+	// before prefixes were introduced continue to verify.
+	ChecksumCRC32 = "crc32"
+	// ChecksumSHA256 names a checksum made of the first eight bytes of the
+	// SHA-256 digest of the line.
+	ChecksumSHA256 = "sha256"
+)
+
+// checksumSeparator separates the algorithm prefix of a checksum from the
+// digest. It can't appear in the unpadded URL-safe base64 used for digests.
+const checksumSeparator = ":"
+
+var checksumAlgorithms = map[string]func(line string) string{
+	ChecksumCRC32: LogLineChecksum,
+	ChecksumSHA256: func(line string) string {
+		digest := sha256.Sum256([]byte(line))
+		return ChecksumSHA256 + checksumSeparator + base64.RawURLEncoding.EncodeToString(digest[:8])
+	},
+}
+
+// LogLineChecksumWith returns the checksum of line computed with the named
+// algorithm. Every algorithm other than ChecksumCRC32 prefixes the checksum
+// with its name, so that the checksum describes how to verify itself.
+func LogLineChecksumWith(algorithm, line string) (string, error) {
+	checksum, ok := checksumAlgorithms[algorithm]
+	if !ok {
+		return "", fmt.Errorf("unknown checksum algorithm %q", algorithm)
+	}
+	return checksum(line), nil
+}
+
+// VerifyLogLineChecksum checks that checksum, which was computed by
+// LogLineChecksumWith with any algorithm, matches line.
+func VerifyLogLineChecksum(checksum, line string) error {
+	algorithm := ChecksumCRC32
+	if i := strings.Index(checksum, checksumSeparator); i != -1 {
+		algorithm = checksum[:i]
+	}
+	computed, err := LogLineChecksumWith(algorithm, line)
+	if err != nil {
+		return err
+	}
+	if checksum != computed {
+		return fmt.Errorf("invalid checksum (expected %q, got %q)", computed, checksum)
+	}
+	return nil
+}
+
+// RenderLine returns msg exactly as the logger writes it to syslog and stdout:
+// prefixed with its checksum, computed with the default algorithm, and a
+// space. rsyslog then adds its own fields, such as the timestamp and hostname,
+// before the rendered line, as described by log-validator.
+func RenderLine(msg string) string {
+	return LogLineChecksum(msg) + " " + msg
+}
+
+// ParseLine is the inverse of RenderLine. It splits a rendered line into its
+// checksum and message, and returns the message if it matches the checksum. A
+// line consisting of only a checksum has an empty message.
+func ParseLine(line string) (string, error) {
+	checksum := line
+	var msg string
+	if i := strings.Index(line, " "); i != -1 {
+		checksum, msg = line[:i], line[i+1:]
+	}
+	err := VerifyLogLineChecksum(checksum, msg)
+	if err != nil {
+		return "", err
+	}
+	return msg, nil
+}
+
+// enabled returns true if messages at level would be written to either syslog
+// or stdout.
+func (w *bothWriter) enabled(level syslog.Priority) bool {
+	return int(level) <= w.syslogLevel || int(level) <= w.stdoutLevel
+}
+
 // Log the provided message at the appropriate level, writing to
 // both stdout and the Logger
 func (w *bothWriter) logAtLevel(level syslog.Priority, msg string) {
+	// Skip computing the checksum of messages which won't be written anywhere,
+	// which keeps disabled Debug logging cheap.
+	if !w.enabled(level) {
+		return
+	}
+
 	var prefix string
 	var err error
 
 	const red = "\033[31m\033[1m"
 	const yellow = "\033[33m"
 
-	msg = fmt.Sprintf("%s %s", LogLineChecksum(msg), msg)
+	msg = RenderLine(msg)
 
 	switch syslogAllowed := int(level) <= w.syslogLevel; level {
 	case syslog.LOG_ERR:
@@ -186,9 +285,53 @@ func (w *bothWriter) logAtLevel(level syslog.Priority, msg string) {
 	}
 }
 
+// renderFields renders fields as space separated key=value pairs, sorted by
+// key so that the output is stable. Values containing spaces, quotes, or equals
+// signs are quoted so that the pairs can be parsed unambiguously.
+func renderFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		v := fmt.Sprintf("%v", fields[k])
+		if v == "" || strings.ContainsAny(v, " \t\n\"=") {
+			v = strconv.Quote(v)
+		}
+		fmt.Fprintf(&b, " %s=%s", k, v)
+	}
+	return b.String()
+}
+
+// WithFields returns a Logger which appends the provided fields, along with
+// any fields of this Logger, to every message in key=value format. Fields
+// provided here take precedence over inherited fields with the same key. The
+// fields become part of the message, so they are covered by its checksum.
+func (log *impl) WithFields(fields map[string]interface{}) Logger {
+	merged := make(map[string]interface{}, len(log.fieldMap)+len(fields))
+	for k, v := range log.fieldMap {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &impl{
+		w:        log.w,
+		fields:   renderFields(merged),
+		fieldMap: merged,
+	}
+}
+
+// logAtLevel appends the logger's fields to msg and writes it at level.
+func (log *impl) logAtLevel(level syslog.Priority, msg string) {
+	log.w.logAtLevel(level, msg+log.fields)
+}
+
 func (log *impl) auditAtLevel(level syslog.Priority, msg string) {
 	text := fmt.Sprintf("%s %s", auditTag, msg)
-	log.w.logAtLevel(level, text)
+	log.logAtLevel(level, text)
 }
 
 // AuditPanic catches panicking executables. This method should be added
@@ -220,7 +363,7 @@ func (log *impl) Errf(format string, a ...interface{}) {
 
 // Warning level messages pass through normally.
 func (log *impl) Warning(msg string) {
-	log.w.logAtLevel(syslog.LOG_WARNING, msg)
+	log.logAtLevel(syslog.LOG_WARNING, msg)
 }
 
 // Warningf level messages pass through normally.
@@ -230,7 +373,7 @@ func (log *impl) Warningf(format string, a ...interface{}) {
 
 // Info level messages pass through normally.
 func (log *impl) Info(msg string) {
-	log.w.logAtLevel(syslog.LOG_INFO, msg)
+	log.logAtLevel(syslog.LOG_INFO, msg)
 }
 
 // Infof level messages pass through normally.
@@ -238,13 +381,18 @@ func (log *impl) Infof(format string, a ...interface{}) {
 	log.Info(fmt.Sprintf(format, a...))
 }
 
-// Debug level messages pass through normally.
+// Debug level messages pass through normally. They are discarded unless
+// the stdout or syslog level is at least LOG_DEBUG.
 func (log *impl) Debug(msg string) {
-	log.w.logAtLevel(syslog.LOG_DEBUG, msg)
+	log.logAtLevel(syslog.LOG_DEBUG, msg)
 }
 
-// Debugf level messages pass through normally.
+// Debugf level messages pass through normally. When debug logging is
+// disabled the message is not even formatted.
 func (log *impl) Debugf(format string, a ...interface{}) {
+	if !log.w.enabled(syslog.LOG_DEBUG) {
+		return
+	}
 	log.Debug(fmt.Sprintf(format, a...))
 }
 
@@ -340,3 +340,101 @@ func TestStdoutFailure(t *testing.T) {
 	// Try to audit log something
 	log.AuditInfo("This should cause a panic, stdout is closed!")
 }
+
+func TestLogLineChecksumWith(t *testing.T) {
+	t.Parallel()
+	const line = "Caught SIGTERM"
+
+	checksum, err := LogLineChecksumWith(ChecksumCRC32, line)
+	test.AssertNotError(t, err, "failed to compute crc32 checksum")
+	test.AssertEquals(t, checksum, LogLineChecksum(line))
+	test.AssertNotError(t, VerifyLogLineChecksum(checksum, line), "crc32 checksum didn't verify")
+
+	checksum, err = LogLineChecksumWith(ChecksumSHA256, line)
+	test.AssertNotError(t, err, "failed to compute sha256 checksum")
+	test.Assert(t, strings.HasPrefix(checksum, "sha256:"), "sha256 checksum lacks algorithm prefix")
+	test.AssertNotError(t, VerifyLogLineChecksum(checksum, line), "sha256 checksum didn't verify")
+	test.AssertError(t, VerifyLogLineChecksum(checksum, line+"!"), "sha256 checksum of a different line verified")
+
+	_, err = LogLineChecksumWith("md4", line)
+	test.AssertError(t, err, "unknown algorithm was accepted")
+	test.AssertError(t, VerifyLogLineChecksum("md4:AAAA", line), "checksum with unknown algorithm verified")
+	test.AssertError(t, VerifyLogLineChecksum("AAAAAAA", line), "bad crc32 checksum verified")
+}
+
+func TestRenderParseLine(t *testing.T) {
+	t.Parallel()
+	for _, msg := range []string{
+		"Caught SIGTERM",
+		"",
+		"  leading and trailing spaces  ",
+		"tabs\tand\tünïcödé",
+		`[AUDIT] JSON={"a": "b c"}`,
+	} {
+		line := RenderLine(msg)
+		test.AssertEquals(t, line, LogLineChecksum(msg)+" "+msg)
+		parsed, err := ParseLine(line)
+		test.AssertNotError(t, err, "rendered line didn't parse")
+		test.AssertEquals(t, parsed, msg)
+	}
+
+	msg, err := ParseLine(LogLineChecksum(""))
+	test.AssertNotError(t, err, "line with only a checksum didn't parse")
+	test.AssertEquals(t, msg, "")
+
+	_, err = ParseLine("xxxxxxx Caught SIGTERM")
+	test.AssertError(t, err, "line with bad checksum parsed")
+	_, err = ParseLine(RenderLine("Caught SIGTERM") + "!")
+	test.AssertError(t, err, "modified line parsed")
+}
+
+func TestLevelEnabled(t *testing.T) {
+	t.Parallel()
+	w := &bothWriter{stdoutLevel: int(syslog.LOG_WARNING), syslogLevel: int(syslog.LOG_INFO)}
+	test.Assert(t, w.enabled(syslog.LOG_ERR), "err should be enabled")
+	test.Assert(t, w.enabled(syslog.LOG_INFO), "info should be enabled by the syslog level")
+	test.Assert(t, !w.enabled(syslog.LOG_DEBUG), "debug should be disabled")
+
+	w.stdoutLevel = int(syslog.LOG_DEBUG)
+	test.Assert(t, w.enabled(syslog.LOG_DEBUG), "debug should be enabled by the stdout level")
+
+	// A disabled message must not reach syslog, which would panic here since
+	// the writer has no syslog connection.
+	w.stdoutLevel = int(syslog.LOG_INFO)
+	logger := &impl{w: w}
+	logger.Debug("dropped")
+	logger.Debugf("dropped %s", "too")
+}
+
+func TestMockDebug(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	m.Info("info message")
+	m.Debug("debug message")
+	m.Debugf("debug %s", "formatted")
+	test.AssertEquals(t, len(m.GetAll()), 3)
+	test.AssertDeepEquals(t, m.GetAllDebug(), []string{
+		"DEBUG: debug message",
+		"DEBUG: debug formatted",
+	})
+}
+
+func TestWithFields(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	parent := m.WithFields(map[string]interface{}{"regID": 1234, "serial": "00ab"})
+	parent.Info("issued")
+	child := parent.WithFields(map[string]interface{}{"serial": "00cd", "names": "a.com b.com"})
+	child.AuditInfof("issued %d", 2)
+	child.Warning("")
+	m.Info("no fields")
+
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"INFO: issued regID=1234 serial=00ab",
+		`INFO: [AUDIT] issued 2 names="a.com b.com" regID=1234 serial=00cd`,
+		`WARNING:  names="a.com b.com" regID=1234 serial=00cd`,
+		"INFO: no fields",
+	})
+
+	test.AssertEquals(t, renderFields(map[string]interface{}{"a": "=", "b": "", "c": true}), ` a="=" b="" c=true`)
+}
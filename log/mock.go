@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/syslog"
 	"regexp"
+	"strings"
 )
 
 // UseMock sets a mock logger as the default logger, and returns it.
@@ -15,7 +16,7 @@ func UseMock() *Mock {
 
 // NewMock creates a mock logger.
 func NewMock() *Mock {
-	return &Mock{impl{newMockWriter()}}
+	return &Mock{impl{w: newMockWriter()}}
 }
 
 // Mock is a logger that stores all log messages in memory to be examined by a
@@ -46,6 +47,11 @@ func (w *mockWriter) logAtLevel(p syslog.Priority, msg string) {
 	w.msgChan <- fmt.Sprintf("%s: %s", levelName[p&7], msg)
 }
 
+// enabled always returns true: the mock records messages at every level.
+func (w *mockWriter) enabled(syslog.Priority) bool {
+	return true
+}
+
 // newMockWriter returns a new mockWriter
 func newMockWriter() *mockWriter {
 	msgChan := make(chan string)
@@ -103,6 +109,22 @@ func (m *Mock) GetAllMatching(reString string) []string {
 	return matches
 }
 
+// GetAllDebug returns all messages logged at debug level since instantiation
+// or the last call to Clear(), in the same format as GetAll.
+//
+// The caller must not modify the elements of the returned slice.
+func (m *Mock) GetAllDebug() []string {
+	var debug []string
+	w := m.w.(*mockWriter)
+	prefix := levelName[syslog.LOG_DEBUG] + ": "
+	for _, logMsg := range <-w.getChan {
+		if strings.HasPrefix(logMsg, prefix) {
+			debug = append(debug, logMsg)
+		}
+	}
+	return debug
+}
+
 // Clear resets the log buffer.
 func (m *Mock) Clear() {
 	w := m.w.(*mockWriter)
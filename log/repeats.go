@@ -0,0 +1,126 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"log/syslog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+)
+
+// repeatWriter implements writer. It collapses identical consecutive messages
+// written within a window of the first into a single summary line, so that a
+// code path failing in a tight loop can't bury every other message.
+type repeatWriter struct {
+	sync.Mutex
+	w      writer
+	window time.Duration
+	clk    clock.Clock
+
+	last      string
+	lastLevel syslog.Priority
+	since     time.Time
+	repeats   int
+	// stopFlush, if non-nil, cancels the flush scheduled for when the window
+	// of the message being suppressed elapses.
+	stopFlush chan struct{}
+}
+
+func (w *repeatWriter) enabled(level syslog.Priority) bool {
+	return w.w.enabled(level)
+}
+
+// logAtLevel writes msg unless it is identical to the previous message and
+// the window that began when the previous message was written hasn't elapsed.
+// The count of suppressed messages is written, as a message of its own, when a
+// different message arrives or when the window has elapsed, even if no other
+// message follows. Both the original and summary messages pass through the
+// underlying writer, so both carry valid checksums. Audit messages are never
+// suppressed: each one is written, after any pending summary.
+func (w *repeatWriter) logAtLevel(level syslog.Priority, msg string) {
+	w.Lock()
+	defer w.Unlock()
+	if strings.HasPrefix(msg, auditTag) {
+		w.flush()
+		w.last = ""
+		w.w.logAtLevel(level, msg)
+		return
+	}
+	now := w.clk.Now()
+	if msg == w.last && level == w.lastLevel && now.Sub(w.since) < w.window {
+		w.repeats++
+		if w.repeats == 1 {
+			w.scheduleFlush(w.since.Add(w.window).Sub(now))
+		}
+		return
+	}
+	w.flush()
+	w.last, w.lastLevel, w.since = msg, level, now
+	w.w.logAtLevel(level, msg)
+}
+
+// scheduleFlush flushes the suppressed messages after d, the remainder of
+// their window, unless they're flushed before then. The caller must hold the
+// lock.
+func (w *repeatWriter) scheduleFlush(d time.Duration) {
+	timer := w.clk.NewTimer(d)
+	stop := make(chan struct{})
+	w.stopFlush = stop
+	go func() {
+		select {
+		case <-timer.C:
+			w.Lock()
+			defer w.Unlock()
+			select {
+			case <-stop:
+				// Flushed while waiting for the lock.
+				return
+			default:
+			}
+			w.flush()
+		case <-stop:
+			timer.Stop()
+		}
+	}()
+}
+
+// flush writes a summary of any suppressed messages, cancelling any scheduled
+// flush. The caller must hold the lock.
+func (w *repeatWriter) flush() {
+	if w.stopFlush != nil {
+		close(w.stopFlush)
+		w.stopFlush = nil
+	}
+	if w.repeats == 0 {
+		return
+	}
+	w.w.logAtLevel(w.lastLevel, fmt.Sprintf("last message repeated %d times", w.repeats))
+	w.repeats = 0
+}
+
+// SuppressRepeats returns a Logger which writes to the same backend as logger,
+// which must have been returned by New or NewMock, but which collapses
+// identical consecutive messages logged within window of each other into a
+// periodic "last message repeated N times" summary. A pending summary is
+// written when the window of the first message elapses, or before the next
+// different message if that is logged first. Audit messages are always
+// written in full.
+func SuppressRepeats(logger Logger, window time.Duration) (Logger, error) {
+	var l *impl
+	switch v := logger.(type) {
+	case *impl:
+		l = v
+	case *Mock:
+		l = &v.impl
+	default:
+		return nil, errors.New("repeat suppression requires a Logger returned by New")
+	}
+	return &impl{
+		w:        &repeatWriter{w: l.w, window: window, clk: clock.New()},
+		fields:   l.fields,
+		fieldMap: l.fieldMap,
+	}, nil
+}
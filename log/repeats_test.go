@@ -0,0 +1,102 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestSuppressRepeats(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	logger, err := SuppressRepeats(m, time.Second)
+	test.AssertNotError(t, err, "SuppressRepeats failed")
+	fc := clock.NewFake()
+	logger.(*impl).w.(*repeatWriter).clk = fc
+
+	for i := 0; i < 5; i++ {
+		logger.Warning("database unavailable")
+	}
+	logger.Info("something else")
+	logger.Info("something else")
+	logger.Warning("something else")
+
+	// Identical messages beyond the window are written again, along with a
+	// summary of what was suppressed in the previous window.
+	for i := 0; i < 4; i++ {
+		logger.Info("tick")
+		fc.Add(400 * time.Millisecond)
+	}
+
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"WARNING: database unavailable",
+		"WARNING: last message repeated 4 times",
+		"INFO: something else",
+		"INFO: last message repeated 1 times",
+		"WARNING: something else",
+		"INFO: tick",
+		"INFO: last message repeated 2 times",
+		"INFO: tick",
+	})
+
+	_, err = SuppressRepeats(requestIDLogger{m, "[x] "}, time.Second)
+	test.AssertError(t, err, "SuppressRepeats accepted a wrapped Logger")
+}
+
+func TestSuppressRepeatsFlushesWhenWindowElapses(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	logger, err := SuppressRepeats(m, time.Second)
+	test.AssertNotError(t, err, "SuppressRepeats failed")
+	fc := clock.NewFake()
+	logger.(*impl).w.(*repeatWriter).clk = fc
+
+	// A flood which ends on a repeated message still has its count logged,
+	// once the window has elapsed.
+	for i := 0; i < 3; i++ {
+		logger.Info("tick")
+	}
+	fc.Add(999 * time.Millisecond)
+	test.AssertDeepEquals(t, m.GetAll(), []string{"INFO: tick"})
+	fc.Add(time.Millisecond)
+	for i := 0; i < 100 && len(m.GetAll()) < 2; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"INFO: tick",
+		"INFO: last message repeated 2 times",
+	})
+
+	// The summary isn't written twice when a different message arrives.
+	logger.Info("tock")
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"INFO: tick",
+		"INFO: last message repeated 2 times",
+		"INFO: tock",
+	})
+}
+
+func TestSuppressRepeatsWritesAuditMessages(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	logger, err := SuppressRepeats(m, time.Second)
+	test.AssertNotError(t, err, "SuppressRepeats failed")
+	logger.(*impl).w.(*repeatWriter).clk = clock.NewFake()
+
+	logger.Info("tick")
+	logger.Info("tick")
+	logger.AuditInfo("revoked certificate")
+	logger.AuditInfo("revoked certificate")
+	logger.Info("tick")
+
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"INFO: tick",
+		"INFO: last message repeated 1 times",
+		"INFO: [AUDIT] revoked certificate",
+		"INFO: [AUDIT] revoked certificate",
+		"INFO: tick",
+	})
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sampleState tracks the messages logged under one sample key during the
+// current interval.
+type sampleState struct {
+	since time.Time
+	count int64
+}
+
+// Sampler logs high-volume Info messages through a Logger, keeping only a
+// sample of them. For each caller-provided sample key, the first messages in
+// each interval are all logged and after that only one in every so many.
+// Sampler deliberately has no audit, warning or error methods: those messages
+// must always be logged, so they should be logged with the Logger directly.
+// Sample keys should come from a small fixed set, since each is kept in memory
+// and used as a metric label.
+type Sampler struct {
+	sync.Mutex
+	logger     Logger
+	first      int64
+	thereafter int64
+	interval   time.Duration
+	clk        clock.Clock
+	keys       map[string]*sampleState
+
+	lines *prometheus.CounterVec
+}
+
+// NewSampler returns a Sampler which, for each sample key, logs the first
+// messages of every interval through logger and then one in every thereafter
+// messages until the interval ends. If thereafter is zero, no messages are
+// logged for a key once first have been logged in the interval. The number of
+// messages logged and dropped for each key is counted in the log_sampler_lines
+// metric of stats.
+func NewSampler(logger Logger, first, thereafter int, interval time.Duration, stats prometheus.Registerer) *Sampler {
+	lines := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "log_sampler_lines",
+			Help: "Number of sampled Info log lines sliced by sample key and result (emitted or dropped)",
+		},
+		[]string{"key", "result"},
+	)
+	stats.MustRegister(lines)
+	return &Sampler{
+		logger:     logger,
+		first:      int64(first),
+		thereafter: int64(thereafter),
+		interval:   interval,
+		clk:        clock.New(),
+		keys:       make(map[string]*sampleState),
+		lines:      lines,
+	}
+}
+
+// sample returns true if the next message logged under key should be logged.
+func (s *Sampler) sample(key string) bool {
+	s.Lock()
+	defer s.Unlock()
+	now := s.clk.Now()
+	state, ok := s.keys[key]
+	if !ok || now.Sub(state.since) >= s.interval {
+		state = &sampleState{since: now}
+		s.keys[key] = state
+	}
+	state.count++
+	emit := state.count <= s.first ||
+		(s.thereafter > 0 && (state.count-s.first)%s.thereafter == 0)
+	result := "dropped"
+	if emit {
+		result = "emitted"
+	}
+	s.lines.With(prometheus.Labels{"key": key, "result": result}).Inc()
+	return emit
+}
+
+// Info logs msg at Info level if it is sampled for key.
+func (s *Sampler) Info(key, msg string) {
+	if s.sample(key) {
+		s.logger.Info(msg)
+	}
+}
+
+// Infof formats and logs a message at Info level if it is sampled for key. The
+// message is only formatted if it is sampled.
+func (s *Sampler) Infof(key, format string, a ...interface{}) {
+	if s.sample(key) {
+		s.logger.Info(fmt.Sprintf(format, a...))
+	}
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestSampler(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	s := NewSampler(m, 2, 3, time.Second, prometheus.NewRegistry())
+	fc := clock.NewFake()
+	s.clk = fc
+
+	// The first two messages of the interval are logged, then every third.
+	for i := 1; i <= 9; i++ {
+		s.Infof("request", "request %d", i)
+	}
+	// Keys are sampled independently.
+	s.Info("other", "other 1")
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"INFO: request 1",
+		"INFO: request 2",
+		"INFO: request 5",
+		"INFO: request 8",
+		"INFO: other 1",
+	})
+	test.AssertEquals(t, test.CountCounter(s.lines.With(prometheus.Labels{"key": "request", "result": "emitted"})), 4)
+	test.AssertEquals(t, test.CountCounter(s.lines.With(prometheus.Labels{"key": "request", "result": "dropped"})), 5)
+
+	// Sampling starts over in the next interval.
+	m.Clear()
+	fc.Add(time.Second)
+	s.Info("request", "request 10")
+	s.Info("request", "request 11")
+	s.Info("request", "request 12")
+	test.AssertDeepEquals(t, m.GetAll(), []string{
+		"INFO: request 10",
+		"INFO: request 11",
+	})
+}
+
+func TestSamplerDropAfterFirst(t *testing.T) {
+	t.Parallel()
+	m := NewMock()
+	s := NewSampler(m, 1, 0, time.Second, prometheus.NewRegistry())
+	for i := 0; i < 10; i++ {
+		s.Info("request", "request")
+	}
+	test.AssertDeepEquals(t, m.GetAll(), []string{"INFO: request"})
+}
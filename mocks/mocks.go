@@ -310,7 +310,7 @@ func (sa *StorageAuthority) GetCertificate(_ context.Context, serial string) (co
 			Issued:         sa.clk.Now(),
 		}, nil
 	} else {
-		return core.Certificate{}, errors.New("No cert")
+		return core.Certificate{}, berrors.NotFoundError("No cert")
 	}
 }
 
@@ -458,7 +458,7 @@ func (sa *StorageAuthority) DeactivateAuthorization(_ context.Context, _ string)
 }
 
 // DeactivateRegistration is a mock
-func (sa *StorageAuthority) DeactivateRegistration(_ context.Context, _ int64) error {
+func (sa *StorageAuthority) DeactivateRegistration(_ context.Context, _ int64, _ string) error {
 	return nil
 }
 
@@ -711,6 +711,143 @@ func (sa *StorageAuthority) KeyBlocked(ctx context.Context, req *sapb.KeyBlocked
 	return &sapb.Exists{Exists: &exists}, nil
 }
 
+// AddBlockedDomain is a mock
+func (sa *StorageAuthority) AddBlockedDomain(context.Context, *sapb.AddBlockedDomainRequest) (*corepb.Empty, error) {
+	return &corepb.Empty{}, nil
+}
+
+// RemoveBlockedDomain is a mock
+func (sa *StorageAuthority) RemoveBlockedDomain(context.Context, *sapb.RemoveBlockedDomainRequest) (*corepb.Empty, error) {
+	return &corepb.Empty{}, nil
+}
+
+// DomainsBlocked is a mock. No domains are blocked.
+func (sa *StorageAuthority) DomainsBlocked(ctx context.Context, req *sapb.DomainsBlockedRequest) (*sapb.BlockedDomains, error) {
+	return &sapb.BlockedDomains{}, nil
+}
+
+// ReplacementCertificateExists is a mock. No certificate has a replacement.
+func (sa *StorageAuthority) ReplacementCertificateExists(ctx context.Context, req *sapb.ReplacementCertificateExistsRequest) (*sapb.Exists, error) {
+	f := false
+	return &sapb.Exists{Exists: &f}, nil
+}
+
+// GetCertificateHistory is a mock. No certificate has a history.
+func (sa *StorageAuthority) GetCertificateHistory(ctx context.Context, req *sapb.Serial) (*sapb.CertificateHistory, error) {
+	return nil, berrors.NotFoundError("no certificate with serial %q", *req.Serial)
+}
+
+// AddRateLimitOverride is a mock
+func (sa *StorageAuthority) AddRateLimitOverride(context.Context, *sapb.RateLimitOverride) (*corepb.Empty, error) {
+	return &corepb.Empty{}, nil
+}
+
+// RemoveRateLimitOverride is a mock
+func (sa *StorageAuthority) RemoveRateLimitOverride(context.Context, *sapb.RemoveRateLimitOverrideRequest) (*corepb.Empty, error) {
+	return &corepb.Empty{}, nil
+}
+
+// GetRateLimitOverrides is a mock. No rate limits are overridden.
+func (sa *StorageAuthority) GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	return &sapb.RateLimitOverrides{}, nil
+}
+
+// CountActiveCertificates is a mock. No registration has active certificates.
+func (sa *StorageAuthority) CountActiveCertificates(ctx context.Context, req *sapb.RegistrationID) (*sapb.Count, error) {
+	var count int64
+	return &sapb.Count{Count: &count}, nil
+}
+
+// CountActiveCertificatesByRegistrations is a mock. No registration has active
+// certificates.
+func (sa *StorageAuthority) CountActiveCertificatesByRegistrations(ctx context.Context, req *sapb.CountActiveCertificatesByRegistrationsRequest) (*sapb.CountByRegistrations, error) {
+	resp := &sapb.CountByRegistrations{}
+	for _, regID := range req.RegistrationIDs {
+		regID := regID
+		var count int64
+		resp.CountByRegistrations = append(resp.CountByRegistrations, &sapb.CountByRegistrations_MapElement{
+			RegistrationID: &regID,
+			Count:          &count,
+		})
+	}
+	return resp, nil
+}
+
+// GetNotificationPreferences is a mock
+func (sa *StorageAuthority) GetNotificationPreferences(ctx context.Context, req *sapb.RegistrationID) (*sapb.NotificationPreferences, error) {
+	return nil, berrors.NotFoundError("no notification preferences for registration")
+}
+
+// GetCertificatesByRegistration is a mock. Registration ID 1 has two
+// certificates which are returned one per page, other registrations have none.
+func (sa *StorageAuthority) GetCertificatesByRegistration(ctx context.Context, req *sapb.GetCertificatesByRegistrationRequest) (*sapb.Certificates, error) {
+	if req.RegistrationID == nil || *req.RegistrationID != 1 {
+		return &sapb.Certificates{}, nil
+	}
+	serials := []string{"0000000000000000000000000000000000b2", "0000000000000000000000000000000000ee"}
+	page := 0
+	if req.Cursor != nil && *req.Cursor != "" {
+		page = -1
+		for i, serial := range serials {
+			if serial == *req.Cursor {
+				page = i + 1
+			}
+		}
+		if page < 0 {
+			return nil, berrors.MalformedError("invalid certificates cursor %q", *req.Cursor)
+		}
+	}
+	resp := &sapb.Certificates{}
+	if page >= len(serials) {
+		return resp, nil
+	}
+	cert := bgrpc.CertToPB(core.Certificate{
+		RegistrationID: 1,
+		Serial:         serials[page],
+		Issued:         sa.clk.Now().Add(-1 * time.Hour),
+		Expires:        sa.clk.Now().Add(89 * 24 * time.Hour),
+	})
+	resp.Certificates = append(resp.Certificates, cert)
+	if page+1 < len(serials) {
+		resp.NextCursor = &serials[page]
+	}
+	return resp, nil
+}
+
+// GetCertificatesExpiring is a mock. No certificates are expiring.
+func (sa *StorageAuthority) GetCertificatesExpiring(ctx context.Context, req *sapb.GetCertificatesExpiringRequest) (*sapb.Certificates, error) {
+	return &sapb.Certificates{}, nil
+}
+
+// GetCertificateProfile is a mock. Every certificate was issued under an
+// unknown profile.
+func (sa *StorageAuthority) GetCertificateProfile(ctx context.Context, req *sapb.Serial) (*sapb.CertificateProfile, error) {
+	name := ""
+	return &sapb.CertificateProfile{Name: &name}, nil
+}
+
+// GetCertificateIssuer is a mock. Every certificate was signed by an unknown
+// issuer.
+func (sa *StorageAuthority) GetCertificateIssuer(ctx context.Context, req *sapb.Serial) (*sapb.CertificateIssuer, error) {
+	fingerprint := ""
+	return &sapb.CertificateIssuer{Fingerprint: &fingerprint}, nil
+}
+
+// SearchCertificatesByName is a mock. No certificate has any name.
+func (sa *StorageAuthority) SearchCertificatesByName(ctx context.Context, req *sapb.SearchCertificatesByNameRequest) (*sapb.CertificateNameMatches, error) {
+	return &sapb.CertificateNameMatches{}, nil
+}
+
+// GetOrderForSerial is a mock. No certificate was issued for an order.
+func (sa *StorageAuthority) GetOrderForSerial(ctx context.Context, req *sapb.Serial) (*sapb.OrderForSerial, error) {
+	return nil, berrors.NotFoundError("no order found for serial %q", *req.Serial)
+}
+
+// GetDeactivatedRegistrations is a mock. No registrations were deactivated.
+func (sa *StorageAuthority) GetDeactivatedRegistrations(ctx context.Context, req *sapb.GetDeactivatedRegistrationsRequest) (*sapb.DeactivatedRegistrations, error) {
+	return &sapb.DeactivatedRegistrations{}, nil
+}
+
 // Publisher is a mock
 type Publisher struct {
 	// empty
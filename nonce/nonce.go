@@ -11,6 +11,10 @@
 // The MaxUsed value determines how long a generated nonce can be used before it
 // is forgotten. To calculate that period, divide the MaxUsed value by average
 // redemption rate (valid POSTs per second).
+// To support key rotation the nonce service may be configured with several
+// keys: new nonces are always encrypted with the signing key, and every nonce
+// carries the identifier of the key used to encrypt it, so that nonces encrypted
+// with any of the configured verification keys can still be redeemed.
 package nonce
 
 import (
@@ -19,7 +23,9 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -32,7 +38,8 @@ import (
 
 const (
 	defaultMaxUsed = 65536
-	nonceLen       = 32
+	keyIDLen       = 4
+	nonceLen       = keyIDLen + 32
 )
 
 var errInvalidNonceLength = errors.New("invalid nonce length")
@@ -44,14 +51,49 @@ type NonceService struct {
 	earliest         int64
 	used             map[int64]bool
 	usedHeap         *int64Heap
-	gcm              cipher.AEAD
+	signingKey       *nonceKey
+	keys             map[[keyIDLen]byte]*nonceKey
 	maxUsed          int
 	prefix           string
 	nonceCreates     prometheus.Counter
 	nonceRedeems     *prometheus.CounterVec
+	nonceKeyRedeems  *prometheus.CounterVec
 	nonceHeapLatency prometheus.Histogram
 }
 
+// nonceKey is a key which nonces can be encrypted or decrypted with, along
+// with the identifier that is embedded in the nonces it encrypts.
+type nonceKey struct {
+	id  [keyIDLen]byte
+	gcm cipher.AEAD
+}
+
+// KeyID returns the identifier that is embedded in nonces encrypted with the
+// provided key, as it appears in the nonce_key_redeems metric.
+func KeyID(key []byte) string {
+	id := keyID(key)
+	return hex.EncodeToString(id[:])
+}
+
+func keyID(key []byte) [keyIDLen]byte {
+	var id [keyIDLen]byte
+	digest := sha256.Sum256(key)
+	copy(id[:], digest[:])
+	return id
+}
+
+func newNonceKey(key []byte) (*nonceKey, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(c)
+	if err != nil {
+		return nil, err
+	}
+	return &nonceKey{id: keyID(key), gcm: gcm}, nil
+}
+
 type int64Heap []int64
 
 func (h int64Heap) Len() int           { return len(h) }
@@ -70,8 +112,26 @@ func (h *int64Heap) Pop() interface{} {
 	return x
 }
 
-// NewNonceService constructs a NonceService with defaults
+// NewNonceService constructs a NonceService with defaults, using a randomly
+// generated key to encrypt and decrypt nonces.
 func NewNonceService(stats prometheus.Registerer, maxUsed int, prefix string) (*NonceService, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return NewNonceServiceWithKeys(stats, maxUsed, prefix, key, nil)
+}
+
+// NewNonceServiceWithKeys constructs a NonceService which encrypts new nonces
+// with signingKey and accepts nonces encrypted with either signingKey or any of
+// verificationKeys. All keys must be valid AES keys (16, 24, or 32 bytes).
+func NewNonceServiceWithKeys(
+	stats prometheus.Registerer,
+	maxUsed int,
+	prefix string,
+	signingKey []byte,
+	verificationKeys [][]byte,
+) (*NonceService, error) {
 	// If a prefix is provided it must be four characters and valid
 	// base64. The prefix is required to be base64url as RFC8555
 	// section 6.5.1 requires that nonces use that encoding.
@@ -87,18 +147,20 @@ func NewNonceService(stats prometheus.Registerer, maxUsed int, prefix string) (*
 		}
 	}
 
-	key := make([]byte, 16)
-	if _, err := rand.Read(key); err != nil {
-		return nil, err
-	}
-
-	c, err := aes.NewCipher(key)
+	signer, err := newNonceKey(signingKey)
 	if err != nil {
-		panic("Failure in NewCipher: " + err.Error())
+		return nil, fmt.Errorf("invalid nonce signing key: %s", err)
 	}
-	gcm, err := cipher.NewGCM(c)
-	if err != nil {
-		panic("Failure in NewGCM: " + err.Error())
+	keys := map[[keyIDLen]byte]*nonceKey{signer.id: signer}
+	for i, verificationKey := range verificationKeys {
+		k, err := newNonceKey(verificationKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce verification key %d: %s", i, err)
+		}
+		if _, present := keys[k.id]; present {
+			return nil, fmt.Errorf("nonce verification key %d duplicates key ID %x", i, k.id)
+		}
+		keys[k.id] = k
 	}
 
 	if maxUsed <= 0 {
@@ -115,6 +177,11 @@ func NewNonceService(stats prometheus.Registerer, maxUsed int, prefix string) (*
 		Help: "A counter of nonce validations labelled by result",
 	}, []string{"result", "error"})
 	stats.MustRegister(nonceRedeems)
+	nonceKeyRedeems := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "nonce_key_redeems",
+		Help: "A counter of valid nonce redemptions labelled by the ID of the key the nonce was encrypted with",
+	}, []string{"key_id"})
+	stats.MustRegister(nonceKeyRedeems)
 	nonceHeapLatency := prometheus.NewHistogram(prometheus.HistogramOpts{
 		Name: "nonce_heap_latency",
 		Help: "A histogram of latencies of heap pop operations",
@@ -126,11 +193,13 @@ func NewNonceService(stats prometheus.Registerer, maxUsed int, prefix string) (*
 		latest:           0,
 		used:             make(map[int64]bool, maxUsed),
 		usedHeap:         &int64Heap{},
-		gcm:              gcm,
+		signingKey:       signer,
+		keys:             keys,
 		maxUsed:          maxUsed,
 		prefix:           prefix,
 		nonceCreates:     nonceCreates,
 		nonceRedeems:     nonceRedeems,
+		nonceKeyRedeems:  nonceKeyRedeems,
 		nonceHeapLatency: nonceHeapLatency,
 	}, nil
 }
@@ -151,35 +220,45 @@ func (ns *NonceService) encrypt(counter int64) (string, error) {
 	pad := 8 - len(ctr.Bytes())
 	copy(pt[pad:], ctr.Bytes())
 
-	// Encrypt
+	// Encrypt, authenticating the key ID alongside the counter
+	key := ns.signingKey
 	ret := make([]byte, nonceLen)
-	ct := ns.gcm.Seal(nil, nonce, pt, nil)
-	copy(ret, nonce[4:])
-	copy(ret[8:], ct)
+	ct := key.gcm.Seal(nil, nonce, pt, key.id[:])
+	copy(ret, key.id[:])
+	copy(ret[keyIDLen:], nonce[4:])
+	copy(ret[keyIDLen+8:], ct)
 
 	return ns.prefix + base64.RawURLEncoding.EncodeToString(ret), nil
 }
 
-func (ns *NonceService) decrypt(nonce string) (int64, error) {
+func (ns *NonceService) decrypt(nonce string) (int64, *nonceKey, error) {
 	body := nonce
 	if ns.prefix != "" {
 		var prefix string
 		var err error
 		prefix, body, err = splitNonce(nonce)
 		if err != nil {
-			return 0, err
+			return 0, nil, err
 		}
 		if ns.prefix != prefix {
-			return 0, fmt.Errorf("nonce contains invalid prefix: expected %q, got %q", ns.prefix, prefix)
+			return 0, nil, fmt.Errorf("nonce contains invalid prefix: expected %q, got %q", ns.prefix, prefix)
 		}
 	}
 	decoded, err := base64.RawURLEncoding.DecodeString(body)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 	if len(decoded) != nonceLen {
-		return 0, errInvalidNonceLength
+		return 0, nil, errInvalidNonceLength
+	}
+
+	var id [keyIDLen]byte
+	copy(id[:], decoded[:keyIDLen])
+	key, present := ns.keys[id]
+	if !present {
+		return 0, nil, fmt.Errorf("nonce encrypted with unknown key ID %x", id)
 	}
+	decoded = decoded[keyIDLen:]
 
 	n := make([]byte, 12)
 	for i := 0; i < 4; i++ {
@@ -187,14 +266,14 @@ func (ns *NonceService) decrypt(nonce string) (int64, error) {
 	}
 	copy(n[4:], decoded[:8])
 
-	pt, err := ns.gcm.Open(nil, n, decoded[8:], nil)
+	pt, err := key.gcm.Open(nil, n, decoded[8:], key.id[:])
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	ctr := big.NewInt(0)
 	ctr.SetBytes(pt)
-	return ctr.Int64(), nil
+	return ctr.Int64(), key, nil
 }
 
 // Nonce provides a new Nonce.
@@ -210,7 +289,7 @@ func (ns *NonceService) Nonce() (string, error) {
 // Valid determines whether the provided Nonce string is valid, returning
 // true if so.
 func (ns *NonceService) Valid(nonce string) bool {
-	c, err := ns.decrypt(nonce)
+	c, key, err := ns.decrypt(nonce)
 	if err != nil {
 		ns.nonceRedeems.WithLabelValues("invalid", "decrypt").Inc()
 		return false
@@ -243,6 +322,7 @@ func (ns *NonceService) Valid(nonce string) bool {
 	}
 
 	ns.nonceRedeems.WithLabelValues("valid", "").Inc()
+	ns.nonceKeyRedeems.WithLabelValues(hex.EncodeToString(key.id[:])).Inc()
 	return true
 }
 
@@ -210,3 +210,39 @@ func TestNoncePrefixValidation(t *testing.T) {
 	_, err = NewNonceService(metrics.NoopRegisterer, 0, "heyy")
 	test.AssertNotError(t, err, "NewNonceService failed with valid nonce prefix")
 }
+
+func TestKeyRotation(t *testing.T) {
+	oldKey := []byte("0123456789abcdef")
+	newKey := []byte("fedcba9876543210")
+
+	oldNS, err := NewNonceServiceWithKeys(metrics.NoopRegisterer, 0, "", oldKey, nil)
+	test.AssertNotError(t, err, "Could not create nonce service")
+	_, err = oldNS.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+	oldNonce, err := oldNS.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+
+	ns, err := NewNonceServiceWithKeys(metrics.NoopRegisterer, 0, "", newKey, [][]byte{oldKey})
+	test.AssertNotError(t, err, "Could not create nonce service")
+	newNonce, err := ns.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+	_, err = ns.Nonce()
+	test.AssertNotError(t, err, "Could not create nonce")
+
+	test.Assert(t, !oldNS.Valid(newNonce), "Accepted a nonce encrypted with an unconfigured key")
+	test.Assert(t, ns.Valid(newNonce), "Rejected a nonce encrypted with the signing key")
+	test.Assert(t, ns.Valid(oldNonce), "Rejected a nonce encrypted with a verification key")
+
+	test.AssertEquals(t, test.CountCounterVec("key_id", KeyID(newKey), ns.nonceKeyRedeems), 1)
+	test.AssertEquals(t, test.CountCounterVec("key_id", KeyID(oldKey), ns.nonceKeyRedeems), 1)
+}
+
+func TestInvalidKeys(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	_, err := NewNonceServiceWithKeys(metrics.NoopRegisterer, 0, "", []byte("short"), nil)
+	test.AssertError(t, err, "NewNonceServiceWithKeys didn't fail with invalid signing key")
+	_, err = NewNonceServiceWithKeys(metrics.NoopRegisterer, 0, "", key, [][]byte{[]byte("short")})
+	test.AssertError(t, err, "NewNonceServiceWithKeys didn't fail with invalid verification key")
+	_, err = NewNonceServiceWithKeys(metrics.NoopRegisterer, 0, "", key, [][]byte{key})
+	test.AssertError(t, err, "NewNonceServiceWithKeys didn't fail with duplicate key")
+}
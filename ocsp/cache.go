@@ -0,0 +1,160 @@
+package ocsp
+
+import (
+	"container/list"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+)
+
+// cacheEntry is a response held by a CachingSource.
+type cacheEntry struct {
+	key        string
+	response   []byte
+	headers    http.Header
+	thisUpdate time.Time
+	expires    time.Time
+}
+
+// CachingSource is a Source which keeps the most recently used responses from
+// another Source in memory, so that repeated requests for popular certificates
+// don't each require a lookup. A response is cached for at most the configured
+// TTL, and never past its NextUpdate. Since cache hits skip the underlying
+// Source entirely, nothing invalidates a cached response when a newer one is
+// produced: stale responses may be served for up to the TTL. Of the responses
+// returned by concurrent lookups which missed the cache, the one with the
+// latest ThisUpdate is kept.
+type CachingSource struct {
+	sync.Mutex
+	source  Source
+	size    int
+	ttl     time.Duration
+	clk     clock.Clock
+	entries map[string]*list.Element
+	// lru holds *cacheEntry values, most recently used first.
+	lru     *list.List
+	lookups *prometheus.CounterVec
+}
+
+// NewCachingSource returns a CachingSource holding up to size responses from
+// source for up to ttl each.
+func NewCachingSource(source Source, size int, ttl time.Duration, clk clock.Clock, stats prometheus.Registerer) *CachingSource {
+	lookups := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ocsp_responder_cache_lookups",
+			Help: "Number of OCSP response cache lookups, by whether they were a hit or a miss",
+		},
+		[]string{"result"},
+	)
+	stats.MustRegister(lookups)
+	return &CachingSource{
+		source:  source,
+		size:    size,
+		ttl:     ttl,
+		clk:     clk,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		lookups: lookups,
+	}
+}
+
+// cacheKey identifies the certificate a request is for.
+func cacheKey(req *ocsp.Request) string {
+	return fmt.Sprintf("%d:%x:%x", req.HashAlgorithm, req.IssuerKeyHash, req.SerialNumber)
+}
+
+// Response returns a cached response for the request if there is an unexpired
+// one, and otherwise looks it up in the underlying Source and caches it.
+// Errors from the underlying Source are never cached.
+func (src *CachingSource) Response(req *ocsp.Request) ([]byte, http.Header, error) {
+	response, headers, _, err := src.cachedResponse(req)
+	return response, headers, err
+}
+
+// cachedResponse is Response, additionally returning whether the response was
+// served from the cache.
+func (src *CachingSource) cachedResponse(req *ocsp.Request) ([]byte, http.Header, bool, error) {
+	key := cacheKey(req)
+	if response, headers, ok := src.get(key); ok {
+		src.lookups.WithLabelValues("hit").Inc()
+		return response, headers, true, nil
+	}
+	src.lookups.WithLabelValues("miss").Inc()
+
+	response, headers, err := src.source.Response(req)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	parsed, err := ocsp.ParseResponse(response, nil)
+	if err != nil {
+		// The Responder will fail to parse it too and report the problem.
+		return response, headers, false, nil
+	}
+	src.put(&cacheEntry{
+		key:        key,
+		response:   response,
+		headers:    headers,
+		thisUpdate: parsed.ThisUpdate,
+		expires:    src.expiry(parsed),
+	})
+	return response, headers, false, nil
+}
+
+// expiry returns when a newly fetched response should be evicted.
+func (src *CachingSource) expiry(resp *ocsp.Response) time.Time {
+	expires := src.clk.Now().Add(src.ttl)
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(expires) {
+		expires = resp.NextUpdate
+	}
+	return expires
+}
+
+// get returns the cached response for key, if there is one which hasn't
+// expired, marking it as recently used.
+func (src *CachingSource) get(key string) ([]byte, http.Header, bool) {
+	src.Lock()
+	defer src.Unlock()
+	el, ok := src.entries[key]
+	if !ok {
+		return nil, nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if !src.clk.Now().Before(entry.expires) {
+		src.remove(el)
+		return nil, nil, false
+	}
+	src.lru.MoveToFront(el)
+	return entry.response, entry.headers, true
+}
+
+// put caches entry, replacing any cached response for the same certificate
+// unless the cached response is newer, and evicts the least recently used
+// responses if the cache is over its size.
+func (src *CachingSource) put(entry *cacheEntry) {
+	if !src.clk.Now().Before(entry.expires) {
+		return
+	}
+	src.Lock()
+	defer src.Unlock()
+	if el, ok := src.entries[entry.key]; ok {
+		if el.Value.(*cacheEntry).thisUpdate.After(entry.thisUpdate) {
+			return
+		}
+		src.remove(el)
+	}
+	src.entries[entry.key] = src.lru.PushFront(entry)
+	for src.lru.Len() > src.size {
+		src.remove(src.lru.Back())
+	}
+}
+
+// remove evicts a cached response. The caller must hold the lock.
+func (src *CachingSource) remove(el *list.Element) {
+	src.lru.Remove(el)
+	delete(src.entries, el.Value.(*cacheEntry).key)
+}
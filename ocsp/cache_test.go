@@ -0,0 +1,172 @@
+package ocsp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	goocsp "golang.org/x/crypto/ocsp"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+// countingSource returns the response in its responses map for the requested
+// serial, counting the number of lookups.
+type countingSource struct {
+	responses map[int64][]byte
+	lookups   int
+}
+
+func (cs *countingSource) Response(req *goocsp.Request) ([]byte, http.Header, error) {
+	cs.lookups++
+	resp, ok := cs.responses[req.SerialNumber.Int64()]
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+	return resp, nil, nil
+}
+
+// cacheTestIssuer is a self-signed certificate and key used to sign responses.
+type cacheTestIssuer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newCacheTestIssuer(t *testing.T) cacheTestIssuer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate key")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test issuer"},
+		NotBefore:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "failed to create certificate")
+	cert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	return cacheTestIssuer{cert, key}
+}
+
+func (i cacheTestIssuer) response(t *testing.T, serial int64, thisUpdate, nextUpdate time.Time) []byte {
+	resp, err := goocsp.CreateResponse(i.cert, i.cert, goocsp.Response{
+		Status:       goocsp.Good,
+		SerialNumber: big.NewInt(serial),
+		ThisUpdate:   thisUpdate,
+		NextUpdate:   nextUpdate,
+	}, i.key)
+	test.AssertNotError(t, err, "failed to create response")
+	return resp
+}
+
+func cacheTestRequest(serial int64) *goocsp.Request {
+	return &goocsp.Request{IssuerKeyHash: []byte{1, 2, 3}, SerialNumber: big.NewInt(serial)}
+}
+
+func TestCachingSource(t *testing.T) {
+	issuer := newCacheTestIssuer(t)
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+	now := fc.Now()
+	source := &countingSource{responses: map[int64][]byte{
+		1: issuer.response(t, 1, now, now.Add(72*time.Hour)),
+		2: issuer.response(t, 2, now, now.Add(72*time.Hour)),
+		3: issuer.response(t, 3, now, now.Add(72*time.Hour)),
+		// This response's NextUpdate is sooner than the cache TTL.
+		4: issuer.response(t, 4, now, now.Add(30*time.Minute)),
+	}}
+	cache := NewCachingSource(source, 2, time.Hour, fc, prometheus.NewRegistry())
+
+	lookup := func(serial int64) []byte {
+		t.Helper()
+		resp, _, err := cache.Response(cacheTestRequest(serial))
+		test.AssertNotError(t, err, "lookup failed")
+		return resp
+	}
+
+	// Repeated requests are served from the cache.
+	test.AssertByteEquals(t, lookup(1), source.responses[1])
+	test.AssertByteEquals(t, lookup(1), source.responses[1])
+	test.AssertEquals(t, source.lookups, 1)
+	test.AssertEquals(t, test.CountCounterVec("result", "hit", cache.lookups), 1)
+	test.AssertEquals(t, test.CountCounterVec("result", "miss", cache.lookups), 1)
+
+	// Requests for the same serial from a different issuer aren't.
+	_, _, err := cache.Response(&goocsp.Request{IssuerKeyHash: []byte{4, 5, 6}, SerialNumber: big.NewInt(1)})
+	test.AssertNotError(t, err, "lookup failed")
+	test.AssertEquals(t, source.lookups, 2)
+
+	// The cache holds two responses, evicting the least recently used.
+	lookup(1)
+	lookup(2)
+	lookup(3)
+	test.AssertEquals(t, source.lookups, 4)
+	lookup(1)
+	test.AssertEquals(t, source.lookups, 5)
+
+	// Errors aren't cached.
+	_, _, err = cache.Response(cacheTestRequest(5))
+	test.AssertEquals(t, err, ErrNotFound)
+	_, _, err = cache.Response(cacheTestRequest(5))
+	test.AssertEquals(t, err, ErrNotFound)
+	test.AssertEquals(t, source.lookups, 7)
+
+	// Responses expire after the TTL, or their NextUpdate if that's sooner.
+	lookup(4)
+	fc.Add(31 * time.Minute)
+	lookup(4)
+	test.AssertEquals(t, source.lookups, 9)
+	lookup(1)
+	test.AssertEquals(t, source.lookups, 9)
+	fc.Add(30 * time.Minute)
+	lookup(1)
+	test.AssertEquals(t, source.lookups, 10)
+}
+
+func TestCachingSourceReplacement(t *testing.T) {
+	issuer := newCacheTestIssuer(t)
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+	now := fc.Now()
+	older := issuer.response(t, 1, now.Add(-time.Hour), now.Add(72*time.Hour))
+	newer := issuer.response(t, 1, now, now.Add(72*time.Hour))
+	source := &countingSource{responses: map[int64][]byte{1: newer}}
+	cache := NewCachingSource(source, 10, time.Hour, fc, prometheus.NewRegistry())
+
+	// An older response doesn't replace a newer cached one.
+	resp, _, err := cache.Response(cacheTestRequest(1))
+	test.AssertNotError(t, err, "lookup failed")
+	test.AssertByteEquals(t, resp, newer)
+	parsed, err := goocsp.ParseResponse(older, nil)
+	test.AssertNotError(t, err, "failed to parse response")
+	cache.put(&cacheEntry{key: cacheKey(cacheTestRequest(1)), response: older, thisUpdate: parsed.ThisUpdate, expires: cache.expiry(parsed)})
+	resp, _, err = cache.Response(cacheTestRequest(1))
+	test.AssertNotError(t, err, "lookup failed")
+	test.AssertByteEquals(t, resp, newer)
+
+	// Once the cached response expires, a newer one from the source replaces
+	// it.
+	newest := issuer.response(t, 1, now.Add(30*time.Minute), now.Add(72*time.Hour))
+	source.responses[1] = newest
+	fc.Add(time.Hour)
+	resp, _, err = cache.Response(cacheTestRequest(1))
+	test.AssertNotError(t, err, "lookup failed")
+	test.AssertByteEquals(t, resp, newest)
+
+	// Responses which fail to parse are passed through uncached.
+	source.responses[2] = []byte("not a response")
+	for i := 0; i < 2; i++ {
+		resp, _, err = cache.Response(cacheTestRequest(2))
+		test.AssertNotError(t, err, "lookup failed")
+		test.AssertByteEquals(t, resp, []byte("not a response"))
+	}
+	test.AssertEquals(t, source.lookups, 4)
+}
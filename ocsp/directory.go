@@ -0,0 +1,208 @@
+package ocsp
+
+import (
+	"crypto"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// ErrTryLater indicates that the responder should reply with
+// tryLaterErrorResponse, because a response may exist but can't be provided
+// right now.
+var ErrTryLater = errors.New("OCSP response not available, try later")
+
+// IssuerKeyHash returns the SHA-1 hash of the issuer's public key as used in
+// the CertID of OCSP requests. Per RFC 6960 it is computed over the DER
+// encoding of the public key (defined in RFC 4055 for RSA and RFC 5480 for
+// ECDSA). MarshalPKIXPublicKey can't be used for this since it encodes keys
+// using the SPKI structure itself, and only the contents of the
+// subjectPublicKey are hashed, so it is extracted here.
+func IssuerKeyHash(issuer *x509.Certificate) ([]byte, error) {
+	var spki struct {
+		Algo      pkix.AlgorithmIdentifier
+		BitString asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return nil, err
+	}
+	keyHash := sha1.Sum(spki.BitString.Bytes)
+	return keyHash[:], nil
+}
+
+// directoryKey identifies the certificate a response in a DirectorySource is
+// for.
+func directoryKey(issuerKeyHash []byte, serial *big.Int) string {
+	return fmt.Sprintf("%x:%x", issuerKeyHash, serial)
+}
+
+// fileState is what a DirectorySource remembers about a file to notice when
+// it changes.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// DirectorySource is a Source which serves precomputed OCSP responses from a
+// directory, e.g. to keep serving OCSP while the database is unavailable.
+// Every regular file in the directory must contain one DER encoded OCSP
+// response signed by (or by a responder delegated by) one of the configured
+// issuers; other files are logged and skipped. Responses are looked up by
+// issuer key hash and serial, and the directory is reloaded whenever a file
+// in it is added, removed or modified.
+type DirectorySource struct {
+	sync.RWMutex
+	dir       string
+	issuers   map[string]*x509.Certificate
+	tryLater  bool
+	log       blog.Logger
+	responses map[string][]byte
+	files     map[string]fileState
+
+	loaded prometheus.Gauge
+}
+
+// NewDirectorySource loads the responses in dir for the given issuers and, if
+// reloadInterval is non-zero, checks the directory for changes that often.
+// Requests for serials without a response are answered with ErrTryLater if
+// tryLater is true, and ErrNotFound otherwise. Requests for other issuers are
+// always answered with ErrNotFound.
+func NewDirectorySource(
+	dir string,
+	issuers []*x509.Certificate,
+	reloadInterval time.Duration,
+	tryLater bool,
+	logger blog.Logger,
+	stats prometheus.Registerer,
+) (*DirectorySource, error) {
+	src := &DirectorySource{
+		dir:      dir,
+		issuers:  make(map[string]*x509.Certificate, len(issuers)),
+		tryLater: tryLater,
+		log:      logger,
+		loaded: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ocsp_responder_directory_responses",
+			Help: "Number of OCSP responses loaded from the response directory",
+		}),
+	}
+	stats.MustRegister(src.loaded)
+	for _, issuer := range issuers {
+		keyHash, err := IssuerKeyHash(issuer)
+		if err != nil {
+			return nil, err
+		}
+		src.issuers[string(keyHash)] = issuer
+	}
+	err := src.reload()
+	if err != nil {
+		return nil, err
+	}
+	if reloadInterval > 0 {
+		go func() {
+			for range time.Tick(reloadInterval) {
+				if err := src.reload(); err != nil {
+					src.log.Errf("Reloading OCSP responses from %s: %s", src.dir, err)
+				}
+			}
+		}()
+	}
+	return src, nil
+}
+
+// reload reads every response in the directory if any file in it has changed
+// since it was last read. If the directory can't be read the previously
+// loaded responses continue to be served.
+func (src *DirectorySource) reload() error {
+	infos, err := ioutil.ReadDir(src.dir)
+	if err != nil {
+		return err
+	}
+	files := make(map[string]fileState, len(infos))
+	for _, info := range infos {
+		if info.Mode().IsRegular() {
+			files[info.Name()] = fileState{size: info.Size(), modTime: info.ModTime()}
+		}
+	}
+	src.RLock()
+	unchanged := src.files != nil && len(files) == len(src.files)
+	if unchanged {
+		for name, state := range files {
+			if old, ok := src.files[name]; !ok || old != state {
+				unchanged = false
+				break
+			}
+		}
+	}
+	src.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	responses := make(map[string][]byte, len(files))
+	for name := range files {
+		der, key, err := src.readResponse(filepath.Join(src.dir, name))
+		if err != nil {
+			src.log.Errf("Skipping OCSP response file %s: %s", name, err)
+			continue
+		}
+		responses[key] = der
+	}
+	src.Lock()
+	src.responses = responses
+	src.files = files
+	src.Unlock()
+	src.loaded.Set(float64(len(responses)))
+	src.log.Infof("Loaded %d OCSP responses from %s", len(responses), src.dir)
+	return nil
+}
+
+// readResponse reads the response in the named file and returns it with its
+// lookup key, after checking it was signed for one of the issuers.
+func (src *DirectorySource) readResponse(filename string) ([]byte, string, error) {
+	der, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, "", err
+	}
+	for keyHash, issuer := range src.issuers {
+		response, err := ocsp.ParseResponse(der, issuer)
+		if err == nil {
+			return der, directoryKey([]byte(keyHash), response.SerialNumber), nil
+		}
+	}
+	return nil, "", fmt.Errorf("not a valid OCSP response from a configured issuer")
+}
+
+// Response looks up the response for the request's issuer and serial.
+func (src *DirectorySource) Response(req *ocsp.Request) ([]byte, http.Header, error) {
+	if req.HashAlgorithm != crypto.SHA1 {
+		// Issuer key hashes are only computed with SHA1
+		return nil, nil, ErrNotFound
+	}
+	if _, ok := src.issuers[string(req.IssuerKeyHash)]; !ok {
+		return nil, nil, ErrNotFound
+	}
+	src.RLock()
+	response, ok := src.responses[directoryKey(req.IssuerKeyHash, req.SerialNumber)]
+	src.RUnlock()
+	if !ok {
+		if src.tryLater {
+			return nil, nil, ErrTryLater
+		}
+		return nil, nil, ErrNotFound
+	}
+	return response, nil, nil
+}
@@ -0,0 +1,140 @@
+package ocsp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	goocsp "golang.org/x/crypto/ocsp"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// testIssuer returns a self-signed issuer certificate and its key.
+func testIssuer(t *testing.T, name string) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating issuer key")
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating issuer certificate")
+	cert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing issuer certificate")
+	return cert, key
+}
+
+// writeResponse signs a good response for serial and writes it to dir.
+func writeResponse(t *testing.T, dir string, issuer *x509.Certificate, key crypto.Signer, serial int64) []byte {
+	t.Helper()
+	der, err := goocsp.CreateResponse(issuer, issuer, goocsp.Response{
+		Status:       goocsp.Good,
+		SerialNumber: big.NewInt(serial),
+		ThisUpdate:   time.Now().Add(-time.Hour),
+		NextUpdate:   time.Now().Add(time.Hour),
+	}, key)
+	test.AssertNotError(t, err, "creating OCSP response")
+	filename := filepath.Join(dir, big.NewInt(serial).String()+".der")
+	test.AssertNotError(t, ioutil.WriteFile(filename, der, 0644), "writing OCSP response")
+	return der
+}
+
+func directoryRequest(t *testing.T, issuer *x509.Certificate, serial int64) *goocsp.Request {
+	t.Helper()
+	keyHash, err := IssuerKeyHash(issuer)
+	test.AssertNotError(t, err, "hashing issuer key")
+	return &goocsp.Request{
+		HashAlgorithm: crypto.SHA1,
+		IssuerKeyHash: keyHash,
+		SerialNumber:  big.NewInt(serial),
+	}
+}
+
+func TestDirectorySource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ocsp-responses")
+	test.AssertNotError(t, err, "creating response directory")
+	defer os.RemoveAll(dir)
+
+	issuer, key := testIssuer(t, "issuer")
+	other, otherKey := testIssuer(t, "other issuer")
+	first := writeResponse(t, dir, issuer, key, 1)
+	// Responses that aren't from the configured issuer, and files which aren't
+	// responses at all, are skipped.
+	writeResponse(t, dir, other, otherKey, 2)
+	test.AssertNotError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("hi"), 0644), "writing README")
+
+	src, err := NewDirectorySource(dir, []*x509.Certificate{issuer}, 0, true, blog.NewMock(), metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "NewDirectorySource failed")
+
+	resp, _, err := src.Response(directoryRequest(t, issuer, 1))
+	test.AssertNotError(t, err, "looking up response")
+	test.Assert(t, bytes.Equal(resp, first), "wrong response returned")
+
+	// Missing serials for the issuer are answered with tryLater, and requests
+	// for other issuers are unauthorized.
+	_, _, err = src.Response(directoryRequest(t, issuer, 2))
+	test.AssertEquals(t, err, ErrTryLater)
+	_, _, err = src.Response(directoryRequest(t, other, 2))
+	test.AssertEquals(t, err, ErrNotFound)
+
+	// Added responses are served once the directory is reloaded, and removed
+	// ones no longer are.
+	second := writeResponse(t, dir, issuer, key, 2)
+	test.AssertNotError(t, os.Remove(filepath.Join(dir, "1.der")), "removing response")
+	test.AssertNotError(t, src.reload(), "reload failed")
+	resp, _, err = src.Response(directoryRequest(t, issuer, 2))
+	test.AssertNotError(t, err, "looking up added response")
+	test.Assert(t, bytes.Equal(resp, second), "wrong response returned")
+	_, _, err = src.Response(directoryRequest(t, issuer, 1))
+	test.AssertEquals(t, err, ErrTryLater)
+
+	// Without tryLater, missing serials are unauthorized.
+	src, err = NewDirectorySource(dir, []*x509.Certificate{issuer}, 0, false, blog.NewMock(), metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "NewDirectorySource failed")
+	_, _, err = src.Response(directoryRequest(t, issuer, 1))
+	test.AssertEquals(t, err, ErrNotFound)
+
+	_, err = NewDirectorySource(filepath.Join(dir, "missing"), []*x509.Certificate{issuer}, 0, false, blog.NewMock(), metrics.NoopRegisterer)
+	test.AssertError(t, err, "NewDirectorySource didn't fail for a missing directory")
+}
+
+type tryLaterSource struct{}
+
+func (tryLaterSource) Response(*goocsp.Request) ([]byte, http.Header, error) {
+	return nil, nil, ErrTryLater
+}
+
+func TestResponderTryLater(t *testing.T) {
+	responder := NewResponder(tryLaterSource{}, 0, 0, metrics.NoopRegisterer)
+
+	issuer, _ := testIssuer(t, "issuer")
+	req, err := goocsp.CreateRequest(&x509.Certificate{SerialNumber: big.NewInt(1)}, issuer, nil)
+	test.AssertNotError(t, err, "creating OCSP request")
+
+	rw := httptest.NewRecorder()
+	responder.ServeHTTP(rw, httptest.NewRequest("POST", "/", bytes.NewReader(req)))
+	test.AssertEquals(t, rw.Code, http.StatusOK)
+	test.Assert(t, bytes.Equal(rw.Body.Bytes(), goocsp.TryLaterErrorResponse), "expected a tryLater response")
+	test.AssertEquals(t, test.CountCounterVec("type", "TryLater", responder.responseTypes), 1)
+}
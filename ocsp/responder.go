@@ -33,6 +33,8 @@ package ocsp
 import (
 	"crypto"
 	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -59,7 +61,8 @@ var ErrNotFound = errors.New("Request OCSP Response not found")
 // the logic that actually chooses a response based on a request.  In
 // order to create an actual responder, wrap one of these in a Responder
 // object and pass it to http.Handle. By default the Responder will set
-// the headers Cache-Control to "max-age=(response.NextUpdate-now), public, no-transform, must-revalidate",
+// the headers Cache-Control to "max-age=(response.NextUpdate-now), public, no-transform, must-revalidate"
+// (with the max-age scaled and clamped as configured in NewResponder),
 // Last-Modified to response.ThisUpdate, Expires to response.NextUpdate,
 // ETag to the SHA256 hash of the response, and Content-Type to
 // application/ocsp-response. If you want to override these headers,
@@ -70,6 +73,13 @@ type Source interface {
 	Response(*ocsp.Request) ([]byte, http.Header, error)
 }
 
+// cacheReportingSource is implemented by Sources, i.e. CachingSource, which can
+// report whether a response was served from a cache. The Responder uses it to
+// label the statuses of the responses it serves.
+type cacheReportingSource interface {
+	cachedResponse(*ocsp.Request) ([]byte, http.Header, bool, error)
+}
+
 // An InMemorySource is a map from serialNumber -> der(response)
 type InMemorySource struct {
 	responses map[string][]byte
@@ -132,6 +142,14 @@ func NewMemorySourceFromFile(responseFile string, logger blog.Logger) (Source, e
 	return NewMemorySource(responses, logger), nil
 }
 
+// certStatusToString maps the certificate statuses of OCSP responses to the
+// labels of the ocsp_response_statuses metric.
+var certStatusToString = map[int]string{
+	ocsp.Good:    "good",
+	ocsp.Revoked: "revoked",
+	ocsp.Unknown: "unknown",
+}
+
 var responseTypeToString = map[ocsp.ResponseStatus]string{
 	ocsp.Success:           "Success",
 	ocsp.Malformed:         "Malformed",
@@ -144,14 +162,23 @@ var responseTypeToString = map[ocsp.ResponseStatus]string{
 // A Responder object provides the HTTP logic to expose a
 // Source of OCSP responses.
 type Responder struct {
-	Source        Source
-	responseTypes *prometheus.CounterVec
-	requestSizes  prometheus.Histogram
-	clk           clock.Clock
+	Source         Source
+	maxAge         time.Duration
+	maxAgeFraction float64
+	responseTypes  *prometheus.CounterVec
+	// responseStatuses counts the successful responses served by the
+	// certificate status they give and whether they were a cache hit.
+	responseStatuses *prometheus.CounterVec
+	requestSizes     prometheus.Histogram
+	clk              clock.Clock
 }
 
-// NewResponder instantiates a Responder with the give Source.
-func NewResponder(source Source, stats prometheus.Registerer) *Responder {
+// NewResponder instantiates a Responder with the give Source. The max-age in
+// the Cache-Control header of a response is maxAgeFraction of the time left
+// until the response's nextUpdate, clamped to maxAge, so that caches don't
+// keep a response until just before it is superseded. A zero maxAgeFraction
+// uses all of the time left and a zero maxAge doesn't clamp.
+func NewResponder(source Source, maxAge time.Duration, maxAgeFraction float64, stats prometheus.Registerer) *Responder {
 	requestSizes := prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "ocsp_request_sizes",
@@ -168,13 +195,42 @@ func NewResponder(source Source, stats prometheus.Registerer) *Responder {
 		[]string{"type"},
 	)
 	stats.MustRegister(responseTypes)
+	responseStatuses := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ocsp_response_statuses",
+			Help: "Number of successful OCSP responses returned by certificate status and whether they were a cache hit",
+		},
+		[]string{"status", "cache"},
+	)
+	stats.MustRegister(responseStatuses)
 
 	return &Responder{
-		Source:        source,
-		responseTypes: responseTypes,
-		requestSizes:  requestSizes,
-		clk:           clock.New(),
+		Source:           source,
+		maxAge:           maxAge,
+		maxAgeFraction:   maxAgeFraction,
+		responseTypes:    responseTypes,
+		responseStatuses: responseStatuses,
+		requestSizes:     requestSizes,
+		clk:              clock.New(),
+	}
+}
+
+// cacheMaxAge returns the max-age, in seconds, to send with a response with
+// the given nextUpdate.
+func (rs Responder) cacheMaxAge(nextUpdate time.Time) int {
+	remaining := nextUpdate.Sub(rs.clk.Now())
+	if remaining <= 0 {
+		// TODO(#530): we want max-age=0 but this is technically an authorized OCSP response
+		//             (despite being stale) and 5019 forbids attaching no-cache
+		return 0
+	}
+	if rs.maxAgeFraction > 0 {
+		remaining = time.Duration(float64(remaining) * rs.maxAgeFraction)
+	}
+	if rs.maxAge > 0 && remaining > rs.maxAge {
+		remaining = rs.maxAge
 	}
+	return int(remaining / time.Second)
 }
 
 func overrideHeaders(response http.ResponseWriter, headers http.Header) {
@@ -208,6 +264,51 @@ type logEvent struct {
 
 // hashToString contains mappings for the only hash functions
 // x/crypto/ocsp supports
+// maxNonceLength is the longest nonce, in bytes, accepted in a request's nonce
+// extension, as RFC 8954 section 2.1 requires.
+const maxNonceLength = 32
+
+// idPKIXOCSPNonce is the OID of the OCSP nonce extension.
+var idPKIXOCSPNonce = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 2}
+
+// requestExtensions is enough of an OCSPRequest, from RFC 6960 section 4.1.1,
+// to read the request extensions which ocsp.ParseRequest discards.
+type requestExtensions struct {
+	TBSRequest struct {
+		Version       int           `asn1:"explicit,tag:0,default:0,optional"`
+		RequestorName asn1.RawValue `asn1:"explicit,tag:1,optional"`
+		RequestList   asn1.RawValue
+		Extensions    []pkix.Extension `asn1:"explicit,tag:2,optional"`
+	}
+	OptionalSignature asn1.RawValue `asn1:"explicit,tag:0,optional"`
+}
+
+// checkNonce returns an error if the DER encoded OCSP request carries a nonce
+// longer than maxNonceLength. The nonce isn't otherwise used, because the
+// responses served are presigned and can't echo it back.
+func checkNonce(der []byte) error {
+	var req requestExtensions
+	if _, err := asn1.Unmarshal(der, &req); err != nil {
+		return err
+	}
+	for _, ext := range req.TBSRequest.Extensions {
+		if !ext.Id.Equal(idPKIXOCSPNonce) {
+			continue
+		}
+		// The nonce should be an OCTET STRING, but some clients put the
+		// raw nonce in the extension value instead.
+		nonce := ext.Value
+		var octets []byte
+		if rest, err := asn1.Unmarshal(ext.Value, &octets); err == nil && len(rest) == 0 {
+			nonce = octets
+		}
+		if len(nonce) > maxNonceLength {
+			return fmt.Errorf("nonce is %d bytes, longer than the maximum of %d", len(nonce), maxNonceLength)
+		}
+	}
+	return nil
+}
+
 var hashToString = map[crypto.Hash]string{
 	crypto.SHA1:   "SHA1",
 	crypto.SHA256: "SHA256",
@@ -311,10 +412,14 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 	response.Header().Add("Content-Type", "application/ocsp-response")
 
 	// Parse response as an OCSP request
-	// XXX: This fails if the request contains the nonce extension.
-	//      We don't intend to support nonces anyway, but maybe we
-	//      should return unauthorizedRequest instead of malformed.
+	// Request extensions are ignored, except that overly long nonces are
+	// rejected. The responses served are presigned, so a nonce can't be
+	// echoed back and clients get the same response as they would without
+	// one.
 	ocspRequest, err := ocsp.ParseRequest(requestBody)
+	if err == nil {
+		err = checkNonce(requestBody)
+	}
 	if err != nil {
 		log.Debugf("Error decoding request body: %s", b64Body)
 		response.WriteHeader(http.StatusBadRequest)
@@ -328,7 +433,14 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 	le.HashAlg = hashToString[ocspRequest.HashAlgorithm]
 
 	// Look up OCSP response from source
-	ocspResponse, headers, err := rs.Source.Response(ocspRequest)
+	var ocspResponse []byte
+	var headers http.Header
+	cacheHit := false
+	if cachingSource, ok := rs.Source.(cacheReportingSource); ok {
+		ocspResponse, headers, cacheHit, err = cachingSource.cachedResponse(ocspRequest)
+	} else {
+		ocspResponse, headers, err = rs.Source.Response(ocspRequest)
+	}
 	if err != nil {
 		if err == ErrNotFound {
 			log.Infof("No response found for request: serial %x, request body %s",
@@ -337,6 +449,13 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 			rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.Unauthorized]}).Inc()
 			return
 		}
+		if err == ErrTryLater {
+			log.Infof("Response not available for request: serial %x, request body %s",
+				ocspRequest.SerialNumber, b64Body)
+			response.Write(ocsp.TryLaterErrorResponse)
+			rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.TryLater]}).Inc()
+			return
+		}
 		log.Infof("Error retrieving response for request: serial %x, request body %s, error: %s",
 			ocspRequest.SerialNumber, b64Body, err)
 		response.WriteHeader(http.StatusInternalServerError)
@@ -354,24 +473,23 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 		rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.InternalError]}).Inc()
 		return
 	}
+	cacheResult := "miss"
+	if cacheHit {
+		cacheResult = "hit"
+	}
+	rs.responseStatuses.With(prometheus.Labels{
+		"status": certStatusToString[parsedResponse.Status],
+		"cache":  cacheResult,
+	}).Inc()
 
 	// Write OCSP response
 	response.Header().Add("Last-Modified", parsedResponse.ThisUpdate.Format(time.RFC1123))
 	response.Header().Add("Expires", parsedResponse.NextUpdate.Format(time.RFC1123))
-	now := rs.clk.Now()
-	maxAge := 0
-	if now.Before(parsedResponse.NextUpdate) {
-		maxAge = int(parsedResponse.NextUpdate.Sub(now) / time.Second)
-	} else {
-		// TODO(#530): we want max-age=0 but this is technically an authorized OCSP response
-		//             (despite being stale) and 5019 forbids attaching no-cache
-		maxAge = 0
-	}
 	response.Header().Set(
 		"Cache-Control",
 		fmt.Sprintf(
 			"max-age=%d, public, no-transform, must-revalidate",
-			maxAge,
+			rs.cacheMaxAge(parsedResponse.NextUpdate),
 		),
 	)
 	responseHash := sha256.Sum256(ocspResponse)
@@ -31,6 +31,9 @@ package ocsp
 
 import (
 	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"net/http"
@@ -97,6 +100,12 @@ func TestOCSP(t *testing.T) {
 			},
 			[]string{"type"},
 		),
+		responseStatuses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponseStatuses-test",
+			},
+			[]string{"status", "cache"},
+		),
 		clk: clock.NewFake(),
 	}
 
@@ -132,6 +141,12 @@ func TestRequestTooBig(t *testing.T) {
 			},
 			[]string{"type"},
 		),
+		responseStatuses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponseStatuses-test",
+			},
+			[]string{"status", "cache"},
+		),
 		clk: clock.NewFake(),
 	}
 
@@ -145,6 +160,111 @@ func TestRequestTooBig(t *testing.T) {
 	}
 }
 
+// nonceRequest returns the base64 encoded OCSP request b64Req with a nonce
+// extension added to it.
+func nonceRequest(t *testing.T, b64Req string, nonce []byte) string {
+	t.Helper()
+	der, err := base64.StdEncoding.DecodeString(b64Req)
+	test.AssertNotError(t, err, "decoding request")
+	var req struct {
+		TBSRequest struct {
+			Version     int `asn1:"explicit,tag:0,default:0,optional"`
+			RequestList asn1.RawValue
+			Extensions  []pkix.Extension `asn1:"explicit,tag:2,optional"`
+		}
+	}
+	_, err = asn1.Unmarshal(der, &req)
+	test.AssertNotError(t, err, "parsing request")
+	value, err := asn1.Marshal(nonce)
+	test.AssertNotError(t, err, "marshaling nonce")
+	req.TBSRequest.Extensions = append(req.TBSRequest.Extensions, pkix.Extension{
+		Id:    asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 2},
+		Value: value,
+	})
+	der, err = asn1.Marshal(req)
+	test.AssertNotError(t, err, "marshaling request")
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func TestRequestWithNonce(t *testing.T) {
+	responder := Responder{
+		Source: testSource{},
+		responseTypes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponses-test",
+			},
+			[]string{"type"},
+		),
+		responseStatuses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponseStatuses-test",
+			},
+			[]string{"status", "cache"},
+		),
+		clk: clock.NewFake(),
+	}
+
+	// Requests with a nonce get the same presigned response as requests
+	// without one, rather than being rejected as malformed.
+	req := nonceRequest(t, "MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx/o6OXOHa+Yfe32YhgQU+3hPEvlgFYMsnxd/NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI//xsd4=", bytes.Repeat([]byte{1}, 32))
+	rw := httptest.NewRecorder()
+	responder.ServeHTTP(rw, &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Path: url.QueryEscape(req)},
+	})
+	test.AssertEquals(t, rw.Code, http.StatusOK)
+	expected, _, err := testSource{}.Response(nil)
+	test.AssertNotError(t, err, "getting test response")
+	test.Assert(t, bytes.Equal(rw.Body.Bytes(), expected), "wrong response returned")
+	test.AssertEquals(t, test.CountCounterVec("type", "Success", responder.responseTypes), 1)
+
+	// Nonces longer than RFC 8954 allows are rejected as malformed.
+	req = nonceRequest(t, "MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx/o6OXOHa+Yfe32YhgQU+3hPEvlgFYMsnxd/NBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI//xsd4=", bytes.Repeat([]byte{1}, maxNonceLength+1))
+	rw = httptest.NewRecorder()
+	responder.ServeHTTP(rw, &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Path: url.QueryEscape(req)},
+	})
+	test.AssertEquals(t, rw.Code, http.StatusBadRequest)
+	test.AssertEquals(t, test.CountCounterVec("type", "Malformed", responder.responseTypes), 1)
+}
+
+func TestResponseStatuses(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Date(2020, 6, 19, 1, 0, 0, 0, time.UTC))
+	stats := prometheus.NewRegistry()
+	responder := NewResponder(NewCachingSource(testSource{}, 10, time.Hour, fc, stats), 0, 0, stats)
+	serve := func() {
+		t.Helper()
+		rw := httptest.NewRecorder()
+		responder.ServeHTTP(rw, &http.Request{
+			Method: "GET",
+			URL:    &url.URL{Path: "MFQwUjBQME4wTDAJBgUrDgMCGgUABBQ55F6w46hhx%2Fo6OXOHa%2BYfe32YhgQU%2B3hPEvlgFYMsnxd%2FNBmzLjbqQYkCEwD6Wh0MaVKu9gJ3By9DI%2F%2Fxsd4%3D"},
+		})
+		test.AssertEquals(t, rw.Code, http.StatusOK)
+	}
+	count := func(status, cache string) int {
+		return test.CountCounter(responder.responseStatuses.With(prometheus.Labels{"status": status, "cache": cache}))
+	}
+
+	// The test response is for a revoked certificate. The first request for
+	// it misses the cache and the second hits it.
+	serve()
+	test.AssertEquals(t, count("revoked", "miss"), 1)
+	test.AssertEquals(t, count("revoked", "hit"), 0)
+	serve()
+	test.AssertEquals(t, count("revoked", "miss"), 1)
+	test.AssertEquals(t, count("revoked", "hit"), 1)
+	test.AssertEquals(t, count("good", "miss")+count("good", "hit"), 0)
+
+	// Without a cache every response is a miss.
+	responder = NewResponder(testSource{}, 0, 0, prometheus.NewRegistry())
+	serve()
+	serve()
+	test.AssertEquals(t, count("revoked", "miss"), 2)
+	test.AssertEquals(t, count("revoked", "hit"), 0)
+}
+
 var testResp = `308204f90a0100a08204f2308204ee06092b0601050507300101048204df308204db3081a7a003020100a121301f311d301b06035504030c146861707079206861636b65722066616b65204341180f32303135303932333231303630305a306c306a3042300906052b0e03021a0500041439e45eb0e3a861c7fa3a3973876be61f7b7d98860414fb784f12f96015832c9f177f3419b32e36ea41890209009cf1912ea8d509088000180f32303135303932333030303030305aa011180f32303330303832363030303030305a300d06092a864886f70d01010b05000382010100c17ed5f12c408d214092c86cb2d6ba9881637a9d5cafb8ddc05aed85806a554c37abdd83c2e00a4bb25b2d0dda1e1c0be65144377471bca53f14616f379ee0c0b436c697b400b7eba9513c5be6d92fbc817586d568156293cfa0099d64585146def907dee36eb650c424a00207b01813aa7ae90e65045339482eeef12b6fa8656315da8f8bb1375caa29ac3858f891adb85066c35b5176e154726ae746016e42e0d6016668ff10a8aa9637417d29be387a1bdba9268b13558034ab5f3e498a47fb096f2e1b39236b22956545884fbbed1884f1bc9686b834d8def4802bac8f79924a36867af87412f808977abaf6457f3cda9e7eccbd0731bcd04865b899ee41a08203193082031530820311308201f9a0030201020209009cf1912ea8d50908300d06092a864886f70d01010b0500301f311d301b06035504030c146861707079206861636b65722066616b65204341301e170d3135303430373233353033385a170d3235303430343233353033385a301f311d301b06035504030c146861707079206861636b65722066616b6520434130820122300d06092a864886f70d01010105000382010f003082010a0282010100c20a47799a05c512b27717633413d770f936bf99de62f130c8774d476deac0029aa6c9d1bb519605df32d34b336394d48e9adc9bbeb48652767dafdb5241c2fc54ce9650e33cb672298888c403642407270cc2f46667f07696d3dd62cfd1f41a8dc0ed60d7c18366b1d2cd462d34a35e148e8695a9a3ec62b656bd129a211a9a534847992d005b0412bcdffdde23085eeca2c32c2693029b5a79f1090fe0b1cb4a154b5c36bc04c7d5a08fa2a58700d3c88d5059205bc5560dc9480f1732b1ad29b030ed3235f7fb868f904fdc79f98ffb5c4e7d4b831ce195f171729ec3f81294df54e66bd3f83d81843b640aea5d7ec64d0905a9dbb03e6ff0e6ac523d36ab0203010001a350304e301d0603551d0e04160414fb784f12f96015832c9f177f3419b32e36ea4189301f0603551d23041830168014fb784f12f96015832c9f177f3419b32e36ea4189300c0603551d13040530030101ff300d06092a864886f70d01010b050003820101001df436be66ff938ccbfb353026962aa758763a777531119377845109e7c2105476c165565d5bbce1464b41bd1d392b079a7341c978af754ca9b3bd7976d485cbbe1d2070d2d4feec1e0f79e8fec9df741e0ea05a26a658d3866825cc1aa2a96a0a04942b2c203cc39501f917a899161dfc461717fe9301fce6ea1afffd7b7998f8941cf76f62def994c028bd1c4b49b17c4d243a6fb058c484968cf80501234da89347108b56b2640cb408e3c336fd72cd355c7f690a15405a7f4ba1e30a6be4a51d262b586f77f8472b207fdd194efab8d3a2683cc148abda7a11b9de1db9307b8ed5a9cd20226f668bd6ac5a3852fd449e42899b7bc915ee747891a110a971`
 
 type testHeaderSource struct {
@@ -173,6 +293,12 @@ func TestOverrideHeaders(t *testing.T) {
 			},
 			[]string{"type"},
 		),
+		responseStatuses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponseStatuses-test",
+			},
+			[]string{"status", "cache"},
+		),
 		clk: clock.NewFake(),
 	}
 
@@ -203,6 +329,12 @@ func TestCacheHeaders(t *testing.T) {
 			},
 			[]string{"type"},
 		),
+		responseStatuses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponseStatuses-test",
+			},
+			[]string{"status", "cache"},
+		),
 		clk: fc,
 	}
 
@@ -256,6 +388,77 @@ func TestCacheHeaders(t *testing.T) {
 	}
 }
 
+func TestCacheMaxAge(t *testing.T) {
+	source, err := NewMemorySourceFromFile(responseFile, blog.NewMock())
+	if err != nil {
+		t.Fatalf("Error constructing source: %s", err)
+	}
+
+	fc := clock.NewFake()
+	fc.Set(time.Date(2015, 11, 12, 0, 0, 0, 0, time.UTC))
+	responder := Responder{
+		Source:         source,
+		maxAge:         24 * time.Hour,
+		maxAgeFraction: 0.5,
+		responseTypes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponses-test",
+			},
+			[]string{"type"},
+		),
+		responseStatuses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponseStatuses-test",
+			},
+			[]string{"status", "cache"},
+		),
+		clk: fc,
+	}
+
+	testCases := []struct {
+		name       string
+		nextUpdate time.Time
+		expected   int
+	}{
+		{"far future", fc.Now().Add(30 * 24 * time.Hour), 86400},
+		{"just past the clamp", fc.Now().Add(48*time.Hour + 2*time.Second), 86400},
+		{"within the clamp", fc.Now().Add(12 * time.Hour), 21600},
+		{"about to expire", fc.Now().Add(3 * time.Second), 1},
+		{"now", fc.Now(), 0},
+		{"stale", fc.Now().Add(-time.Hour), 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := responder.cacheMaxAge(tc.nextUpdate); got != tc.expected {
+				t.Errorf("Got max-age %d, expected %d", got, tc.expected)
+			}
+		})
+	}
+
+	// The test response's nextUpdate is in 2030, so its max-age is clamped.
+	rw := httptest.NewRecorder()
+	responder.ServeHTTP(rw, &http.Request{
+		Method: "GET",
+		URL: &url.URL{
+			Path: "MEMwQTA/MD0wOzAJBgUrDgMCGgUABBSwLsMRhyg1dJUwnXWk++D57lvgagQU6aQ/7p6l5vLV13lgPJOmLiSOl6oCAhJN",
+		},
+	})
+	if rw.Code != http.StatusOK {
+		t.Errorf("Unexpected HTTP status code %d", rw.Code)
+	}
+	expected := "max-age=86400, public, no-transform, must-revalidate"
+	if actual := rw.Header().Get("Cache-Control"); actual != expected {
+		t.Errorf("Got header Cache-Control: %s. Expected %s", actual, expected)
+	}
+
+	// Without a clamp or fraction, all of the remaining validity is used.
+	responder.maxAge = 0
+	responder.maxAgeFraction = 0
+	if got := responder.cacheMaxAge(fc.Now().Add(30 * 24 * time.Hour)); got != 2592000 {
+		t.Errorf("Got max-age %d, expected %d", got, 2592000)
+	}
+}
+
 func TestNewSourceFromFile(t *testing.T) {
 	logger := blog.NewMock()
 	_, err := NewMemorySourceFromFile("", logger)
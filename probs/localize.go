@@ -0,0 +1,161 @@
+package probs
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// catalog holds translations of a summary of each problem type, indexed by
+// lowercase language tag. Problem types missing from a language's catalog are
+// left in English.
+var catalog = map[string]map[ProblemType]string{
+	"de": {
+		ConnectionProblem:            "Verbindung zum Server fehlgeschlagen",
+		MalformedProblem:             "Die Anfrage war fehlerhaft",
+		ServerInternalProblem:        "Interner Serverfehler",
+		TLSProblem:                   "TLS-Fehler",
+		UnauthorizedProblem:          "Nicht berechtigt",
+		RateLimitedProblem:           "Ratenbegrenzung überschritten",
+		BadNonceProblem:              "Ungültige Nonce",
+		InvalidEmailProblem:          "Ungültige Kontaktadresse",
+		RejectedIdentifierProblem:    "Der Bezeichner wurde abgelehnt",
+		AccountDoesNotExistProblem:   "Das Konto existiert nicht",
+		CAAProblem:                   "CAA-Einträge verbieten die Ausstellung",
+		DNSProblem:                   "DNS-Fehler",
+		AlreadyRevokedProblem:        "Das Zertifikat wurde bereits widerrufen",
+		OrderNotReadyProblem:         "Der Auftrag ist nicht bereit",
+		BadSignatureAlgorithmProblem: "Nicht unterstützter Signaturalgorithmus",
+		BadPublicKeyProblem:          "Ungültiger öffentlicher Schlüssel",
+		BadRevocationReasonProblem:   "Ungültiger Widerrufsgrund",
+		BadCSRProblem:                "Ungültige Zertifikatsanforderung (CSR)",
+	},
+	"es": {
+		ConnectionProblem:            "No se pudo conectar con el servidor",
+		MalformedProblem:             "La solicitud está mal formada",
+		ServerInternalProblem:        "Error interno del servidor",
+		TLSProblem:                   "Error de TLS",
+		UnauthorizedProblem:          "No autorizado",
+		RateLimitedProblem:           "Se superó el límite de solicitudes",
+		BadNonceProblem:              "Nonce no válido",
+		InvalidEmailProblem:          "Dirección de contacto no válida",
+		RejectedIdentifierProblem:    "El identificador fue rechazado",
+		AccountDoesNotExistProblem:   "La cuenta no existe",
+		CAAProblem:                   "Los registros CAA prohíben la emisión",
+		DNSProblem:                   "Error de DNS",
+		AlreadyRevokedProblem:        "El certificado ya fue revocado",
+		OrderNotReadyProblem:         "El pedido no está listo",
+		BadSignatureAlgorithmProblem: "Algoritmo de firma no admitido",
+		BadPublicKeyProblem:          "Clave pública no válida",
+		BadRevocationReasonProblem:   "Motivo de revocación no válido",
+		BadCSRProblem:                "Solicitud de certificado (CSR) no válida",
+	},
+	"fr": {
+		ConnectionProblem:            "Impossible de se connecter au serveur",
+		MalformedProblem:             "La requête est mal formée",
+		ServerInternalProblem:        "Erreur interne du serveur",
+		TLSProblem:                   "Erreur TLS",
+		UnauthorizedProblem:          "Non autorisé",
+		RateLimitedProblem:           "Limite de requêtes dépassée",
+		BadNonceProblem:              "Nonce invalide",
+		InvalidEmailProblem:          "Adresse de contact invalide",
+		RejectedIdentifierProblem:    "L'identifiant a été refusé",
+		AccountDoesNotExistProblem:   "Le compte n'existe pas",
+		CAAProblem:                   "Les enregistrements CAA interdisent l'émission",
+		DNSProblem:                   "Erreur DNS",
+		AlreadyRevokedProblem:        "Le certificat a déjà été révoqué",
+		OrderNotReadyProblem:         "La commande n'est pas prête",
+		BadSignatureAlgorithmProblem: "Algorithme de signature non pris en charge",
+		BadPublicKeyProblem:          "Clé publique invalide",
+		BadRevocationReasonProblem:   "Motif de révocation invalide",
+		BadCSRProblem:                "Demande de certificat (CSR) invalide",
+	},
+}
+
+// Localize returns a copy of prob whose detail, and that of its sub-problems,
+// is prefixed with a translated summary of the problem type in the language
+// most preferred by acceptLanguage (the value of an Accept-Language header),
+// along with that language's tag. The original English detail is kept since
+// it carries specifics, like names, which the catalog can't. If no supported
+// language is acceptable, English is preferred to all of them, or the catalog
+// has no translation for the problem, prob is returned unchanged with an empty
+// tag. Problem types are never changed.
+func Localize(prob *ProblemDetails, acceptLanguage string) (*ProblemDetails, string) {
+	lang := preferredLanguage(acceptLanguage)
+	if lang == "" {
+		return prob, ""
+	}
+	messages := catalog[lang]
+	localized := *prob
+	var translated bool
+	localized.Detail, translated = localizeDetail(messages, prob.Type, prob.Detail)
+	if len(prob.SubProblems) > 0 {
+		localized.SubProblems = make([]SubProblemDetails, len(prob.SubProblems))
+		for i, sub := range prob.SubProblems {
+			var ok bool
+			sub.Detail, ok = localizeDetail(messages, sub.Type, sub.Detail)
+			translated = translated || ok
+			localized.SubProblems[i] = sub
+		}
+	}
+	if !translated {
+		return prob, ""
+	}
+	return &localized, lang
+}
+
+func localizeDetail(messages map[ProblemType]string, typ ProblemType, detail string) (string, bool) {
+	summary, ok := messages[typ]
+	if !ok {
+		return detail, false
+	}
+	if detail == "" {
+		return summary, true
+	}
+	return summary + ": " + detail, true
+}
+
+// preferredLanguage returns the catalog language most preferred by the
+// Accept-Language header value, or "" if there is none or English is
+// preferred to it. Language ranges are matched by their primary subtag, so
+// "fr-CA" selects "fr".
+func preferredLanguage(acceptLanguage string) string {
+	type languageRange struct {
+		tag string
+		q   float64
+	}
+	var ranges []languageRange
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				parsed, err := strconv.ParseFloat(param[2:], 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		ranges = append(ranges, languageRange{tag, q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+	for _, r := range ranges {
+		primary := strings.SplitN(r.tag, "-", 2)[0]
+		if primary == "en" {
+			return ""
+		}
+		if _, ok := catalog[primary]; ok {
+			return primary
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package probs
+
+import (
+	"testing"
+
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestPreferredLanguage(t *testing.T) {
+	testCases := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"fr", "fr"},
+		{"fr-CA, en;q=0.8", "fr"},
+		{"en-US, fr;q=0.9", ""},
+		{"DE", "de"},
+		{"ja, es;q=0.5", "es"},
+		{"en;q=0.5, es;q=0.7", "es"},
+		{"fr;q=0, de;q=0.1", "de"},
+		{"ja, *", ""},
+		{"fr;q=bogus", ""},
+	}
+	for _, tc := range testCases {
+		test.AssertEquals(t, preferredLanguage(tc.header), tc.want)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	prob := RejectedIdentifier("Policy forbids issuing for name").WithSubProblems([]SubProblemDetails{
+		{
+			ProblemDetails: ProblemDetails{Type: RejectedIdentifierProblem, Detail: "blocked"},
+			Identifier:     identifier.DNSIdentifier("example.com"),
+		},
+	})
+
+	localized, lang := Localize(prob, "fr-FR,fr;q=0.9,en;q=0.8")
+	test.AssertEquals(t, lang, "fr")
+	test.AssertEquals(t, localized.Type, RejectedIdentifierProblem)
+	test.AssertEquals(t, localized.Detail, "L'identifiant a été refusé: Policy forbids issuing for name")
+	test.AssertEquals(t, localized.SubProblems[0].Detail, "L'identifiant a été refusé: blocked")
+	// The original problem is left alone.
+	test.AssertEquals(t, prob.Detail, "Policy forbids issuing for name")
+	test.AssertEquals(t, prob.SubProblems[0].Detail, "blocked")
+
+	unchanged, lang := Localize(prob, "ja")
+	test.AssertEquals(t, lang, "")
+	test.AssertEquals(t, unchanged, prob)
+
+	// Types missing from the catalog keep their English detail.
+	other := &ProblemDetails{Type: "unsupportedIdentifier", Detail: "IP addresses aren't supported"}
+	localized, lang = Localize(other, "de")
+	test.AssertEquals(t, lang, "")
+	test.AssertEquals(t, localized, other)
+}
@@ -3,6 +3,7 @@ package probs
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/letsencrypt/boulder/identifier"
 )
@@ -46,6 +47,10 @@ type ProblemDetails struct {
 	// SubProblems are optional additional per-identifier problems. See
 	// RFC 8555 Section 6.7.1: https://tools.ietf.org/html/rfc8555#section-6.7.1
 	SubProblems []SubProblemDetails `json:"subproblems,omitempty"`
+	// RetryAfter is how long the client should wait before retrying, sent as
+	// a Retry-After header rather than in the problem document. Zero if
+	// unknown.
+	RetryAfter time.Duration `json:"-"`
 }
 
 // SubProblemDetails represents sub-problems specific to an identifier that are
@@ -68,6 +73,7 @@ func (pd *ProblemDetails) WithSubProblems(subProbs []SubProblemDetails) *Problem
 		Detail:      pd.Detail,
 		HTTPStatus:  pd.HTTPStatus,
 		SubProblems: append(pd.SubProblems, subProbs...),
+		RetryAfter:  pd.RetryAfter,
 	}
 }
 
@@ -231,6 +237,16 @@ func ContentLengthRequired() *ProblemDetails {
 	}
 }
 
+// RequestTooLarge returns a ProblemDetails representing a request body that
+// exceeds the maximum size accepted by an endpoint
+func RequestTooLarge(detail string) *ProblemDetails {
+	return &ProblemDetails{
+		Type:       MalformedProblem,
+		Detail:     detail,
+		HTTPStatus: http.StatusRequestEntityTooLarge,
+	}
+}
+
 // InvalidContentType returns a ProblemDetails suitable for a missing
 // ContentType header, or an incorrect ContentType header
 func InvalidContentType(detail string) *ProblemDetails {
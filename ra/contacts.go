@@ -0,0 +1,125 @@
+package ra
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/mail"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/letsencrypt/boulder/bdns"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// maxLocalPartLength is the longest local part, before the @, that RFC 5321
+// section 4.5.3.1.1 allows a mailbox to have.
+const maxLocalPartLength = 64
+
+// LoadDisposableMailDomains reads a YAML file listing, under
+// disposableDomains, the domains of disposable email providers, for use as an
+// RA's DisposableMailDomains.
+func LoadDisposableMailDomains(filename string) (map[string]bool, error) {
+	yamlBytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		DisposableDomains []string `yaml:"disposableDomains"`
+	}
+	if err := yaml.Unmarshal(yamlBytes, &list); err != nil {
+		return nil, err
+	}
+	if len(list.DisposableDomains) == 0 {
+		return nil, fmt.Errorf("no disposable domains listed in %q", filename)
+	}
+	domains := make(map[string]bool, len(list.DisposableDomains))
+	for _, domain := range list.DisposableDomains {
+		domains[strings.ToLower(strings.TrimSuffix(domain, "."))] = true
+	}
+	return domains, nil
+}
+
+// checkContactEmail makes the optional checks of a contact email, which has
+// already passed policy.ValidEmail, that the RA is configured for. It returns
+// an error explaining why the address is rejected, and the reason to count
+// the rejection under, if the address:
+//
+//   - Isn't a bare RFC 5322 addr-spec, e.g. because it has a display name or
+//     comments, or its local part is too long, when StrictContactSyntax is set.
+//   - Is at one of the DisposableMailDomains, or a subdomain of one.
+//   - Is at a domain which, according to the MXResolver, doesn't exist or
+//     doesn't accept mail.
+func (ra *RegistrationAuthorityImpl) checkContactEmail(ctx context.Context, address string) (string, error) {
+	email, err := mail.ParseAddress(address)
+	if err != nil {
+		return "invalid_email", berrors.InvalidEmailError("%q is not a valid e-mail address", address)
+	}
+	at := strings.LastIndex(email.Address, "@")
+	domain := strings.ToLower(email.Address[at+1:])
+
+	if ra.StrictContactSyntax {
+		if email.Name != "" || email.Address != address {
+			return "syntax", berrors.InvalidEmailError(
+				"contact email %q must be a bare address, without a display name or comments", address)
+		}
+		if at > maxLocalPartLength {
+			return "syntax", berrors.InvalidEmailError(
+				"contact email %q has a local part longer than %d characters", address, maxLocalPartLength)
+		}
+	}
+
+	if len(ra.DisposableMailDomains) > 0 {
+		for d := domain; d != ""; {
+			if ra.DisposableMailDomains[d] {
+				return "disposable", berrors.InvalidEmailError(
+					"contact email domain %q belongs to a disposable email provider", domain)
+			}
+			dot := strings.Index(d, ".")
+			if dot < 0 {
+				break
+			}
+			d = d[dot+1:]
+		}
+	}
+
+	if ra.MXResolver != nil {
+		return ra.checkContactMX(ctx, domain)
+	}
+	return "", nil
+}
+
+// checkContactMX returns an error if domain doesn't exist or doesn't accept
+// mail: it has a null MX record (RFC 7505), or it has neither MX records nor
+// the address records that RFC 5321 section 5.1 falls back to. Lookup failures
+// other than NXDOMAIN may be the fault of our resolvers rather than the
+// domain, so they're logged and the address is accepted.
+func (ra *RegistrationAuthorityImpl) checkContactMX(ctx context.Context, domain string) (string, error) {
+	mx, err := ra.MXResolver.LookupMX(ctx, domain)
+	if err != nil {
+		if dnsErr, ok := err.(*bdns.DNSError); ok && dnsErr.NXDomain() {
+			return "mx_nxdomain", berrors.InvalidEmailError("contact email domain %q doesn't exist", domain)
+		}
+		blog.ForContext(ctx, ra.log).Warningf("MX lookup for contact email domain %q failed: %s", domain, err)
+		return "", nil
+	}
+	if len(mx) == 1 && mx[0] == "." {
+		return "mx_null", berrors.InvalidEmailError(
+			"contact email domain %q has a null MX record and doesn't accept mail", domain)
+	}
+	if len(mx) > 0 {
+		return "", nil
+	}
+	addrs, err := ra.MXResolver.LookupHost(ctx, domain)
+	if err != nil {
+		blog.ForContext(ctx, ra.log).Warningf("Address lookup for contact email domain %q failed: %s", domain, err)
+		return "", nil
+	}
+	if len(addrs) == 0 {
+		return "mx_missing", berrors.InvalidEmailError(
+			"contact email domain %q has no MX or address records and can't receive mail", domain)
+	}
+	return "", nil
+}
@@ -11,11 +11,13 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jmhodges/clock"
 	"github.com/letsencrypt/boulder/akamai"
 	akamaipb "github.com/letsencrypt/boulder/akamai/proto"
+	"github.com/letsencrypt/boulder/bdns"
 	caPB "github.com/letsencrypt/boulder/ca/proto"
 	"github.com/letsencrypt/boulder/core"
 	corepb "github.com/letsencrypt/boulder/core/proto"
@@ -63,6 +65,17 @@ type RegistrationAuthorityImpl struct {
 	publisher core.Publisher
 	caa       caaChecker
 
+	// DNSSECCAAProfiles are the names of the issuance profiles which require
+	// the CAA lookups for every name to have been DNSSEC validated.
+	DNSSECCAAProfiles map[string]bool
+
+	// StrictContactSyntax, DisposableMailDomains and MXResolver enable the
+	// optional checks of contact emails made by checkContactEmail. An unset
+	// field disables its check.
+	StrictContactSyntax   bool
+	DisposableMailDomains map[string]bool
+	MXResolver            bdns.DNSClient
+
 	clk       clock.Clock
 	log       blog.Logger
 	keyPolicy goodkey.KeyPolicy
@@ -88,6 +101,19 @@ type RegistrationAuthorityImpl struct {
 	reusedValidAuthzCounter prometheus.Counter
 	recheckCAACounter       prometheus.Counter
 	newCertCounter          prometheus.Counter
+	contactRejections       *prometheus.CounterVec
+
+	// finalizing holds the FinalizeOrder calls in progress, keyed by order ID.
+	finalizingMu sync.Mutex
+	finalizing   map[int64]*finalizeCall
+}
+
+// finalizeCall is a FinalizeOrder call in progress. Concurrent calls to
+// finalize the same order wait for it to be done and share its result.
+type finalizeCall struct {
+	done  chan struct{}
+	order *corepb.Order
+	err   error
 }
 
 // NewRegistrationAuthorityImpl constructs a new RA object.
@@ -162,6 +188,12 @@ func NewRegistrationAuthorityImpl(
 	})
 	stats.MustRegister(newCertCounter)
 
+	contactRejections := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "contact_rejections",
+		Help: "A counter of rejected account contacts labelled by the reason they were rejected",
+	}, []string{"reason"})
+	stats.MustRegister(contactRejections)
+
 	ra := &RegistrationAuthorityImpl{
 		clk:                          clk,
 		log:                          logger,
@@ -186,6 +218,8 @@ func NewRegistrationAuthorityImpl(
 		reusedValidAuthzCounter:      reusedValidAuthzCounter,
 		recheckCAACounter:            recheckCAACounter,
 		newCertCounter:               newCertCounter,
+		contactRejections:            contactRejections,
+		finalizing:                   make(map[int64]*finalizeCall),
 	}
 	return ra
 }
@@ -221,6 +255,9 @@ type certificateRequestEvent struct {
 	Requester int64 `json:",omitempty"`
 	// OrderID is the associated order ID (may be empty for an ACME v1 issuance)
 	OrderID int64 `json:",omitempty"`
+	// ProfileName is the issuance profile requested for the order (empty for
+	// the CA's default profile)
+	ProfileName string `json:",omitempty"`
 	// SerialNumber is the string representation of the issued certificate's
 	// serial number
 	SerialNumber string `json:",omitempty"`
@@ -250,6 +287,55 @@ type certificateRequestEvent struct {
 // registration-based overrides are necessary.
 const noRegistrationID = -1
 
+// applyStoredOverrides returns limit with the unexpired overrides of the
+// named limit which are stored in the rateLimitOverrides table, for regID or
+// any of keys, added to the overrides from the rate limit policy file. A stored
+// override replaces the policy file's override for the same registration or
+// key. If the RateLimitOverridesTable feature or the limit isn't enabled the
+// limit is returned as it is.
+func (ra *RegistrationAuthorityImpl) applyStoredOverrides(
+	ctx context.Context,
+	name string,
+	limit ratelimit.RateLimitPolicy,
+	regID int64,
+	keys ...string) (ratelimit.RateLimitPolicy, error) {
+
+	if !features.Enabled(features.RateLimitOverridesTable) || !limit.Enabled() {
+		return limit, nil
+	}
+	now := ra.clk.Now().UnixNano()
+	req := &sapb.GetRateLimitOverridesRequest{
+		Now:       &now,
+		LimitName: &name,
+		Keys:      keys,
+	}
+	if regID > 0 {
+		req.RegistrationID = &regID
+	}
+	if req.RegistrationID == nil && len(req.Keys) == 0 {
+		// Without either the SA would return the overrides for every
+		// registration and key.
+		return limit, nil
+	}
+	resp, err := ra.SA.GetRateLimitOverrides(ctx, req)
+	if err != nil {
+		return limit, fmt.Errorf("getting %s rate limit overrides: %s", name, err)
+	}
+	if len(resp.Overrides) == 0 {
+		return limit, nil
+	}
+	keyOverrides := make(map[string]int)
+	regOverrides := make(map[int64]int)
+	for _, override := range resp.Overrides {
+		if override.RegistrationID != nil && *override.RegistrationID != 0 {
+			regOverrides[*override.RegistrationID] = int(*override.Threshold)
+		} else if override.Key != nil {
+			keyOverrides[*override.Key] = int(*override.Threshold)
+		}
+	}
+	return limit.WithOverrides(keyOverrides, regOverrides), nil
+}
+
 // registrationCounter is a type to abstract the use of
 // ra.SA.CountRegistrationsByIP or ra.SA.CountRegistrationsByIPRange
 type registrationCounter func(context.Context, net.IP, time.Time, time.Time) (int, error)
@@ -275,7 +361,7 @@ func (ra *RegistrationAuthorityImpl) checkRegistrationIPLimit(
 	}
 
 	if count >= limit.GetThreshold(ip.String(), noRegistrationID) {
-		return berrors.RateLimitError("too many registrations for this IP")
+		return berrors.RateLimitError(limit.Window.Duration, "too many registrations for this IP")
 	}
 
 	return nil
@@ -286,11 +372,14 @@ func (ra *RegistrationAuthorityImpl) checkRegistrationIPLimit(
 func (ra *RegistrationAuthorityImpl) checkRegistrationLimits(ctx context.Context, ip net.IP) error {
 	// Check the registrations per IP limit using the CountRegistrationsByIP SA
 	// function that matches IP addresses exactly
-	exactRegLimit := ra.rlPolicies.RegistrationsPerIP()
-	err := ra.checkRegistrationIPLimit(ctx, exactRegLimit, ip, ra.SA.CountRegistrationsByIP)
+	exactRegLimit, err := ra.applyStoredOverrides(ctx, ratelimit.RegistrationsPerIPLimit, ra.rlPolicies.RegistrationsPerIP(), noRegistrationID, ip.String())
+	if err != nil {
+		return err
+	}
+	err = ra.checkRegistrationIPLimit(ctx, exactRegLimit, ip, ra.SA.CountRegistrationsByIP)
 	if err != nil {
 		ra.rateLimitCounter.WithLabelValues("registrations_by_ip", "exceeded").Inc()
-		ra.log.Infof("Rate limit exceeded, RegistrationsByIP, IP: %s", ip)
+		blog.ForContext(ctx, ra.log).Infof("Rate limit exceeded, RegistrationsByIP, IP: %s", ip)
 		return err
 	}
 	ra.rateLimitCounter.WithLabelValues("registrations_by_ip", "pass").Inc()
@@ -305,14 +394,17 @@ func (ra *RegistrationAuthorityImpl) checkRegistrationLimits(ctx context.Context
 	// Check the registrations per IP range limit using the
 	// CountRegistrationsByIPRange SA function that fuzzy-matches IPv6 addresses
 	// within a larger address range
-	fuzzyRegLimit := ra.rlPolicies.RegistrationsPerIPRange()
+	fuzzyRegLimit, err := ra.applyStoredOverrides(ctx, ratelimit.RegistrationsPerIPRangeLimit, ra.rlPolicies.RegistrationsPerIPRange(), noRegistrationID, ip.String())
+	if err != nil {
+		return err
+	}
 	err = ra.checkRegistrationIPLimit(ctx, fuzzyRegLimit, ip, ra.SA.CountRegistrationsByIPRange)
 	if err != nil {
 		ra.rateLimitCounter.WithLabelValues("registrations_by_ip_range", "exceeded").Inc()
-		ra.log.Infof("Rate limit exceeded, RegistrationsByIPRange, IP: %s", ip)
+		blog.ForContext(ctx, ra.log).Infof("Rate limit exceeded, RegistrationsByIPRange, IP: %s", ip)
 		// For the fuzzyRegLimit we use a new error message that specifically
 		// mentions that the limit being exceeded is applied to a *range* of IPs
-		return berrors.RateLimitError("too many registrations for this IP range")
+		return berrors.RateLimitError(fuzzyRegLimit.Window.Duration, "too many registrations for this IP range")
 	}
 	ra.rateLimitCounter.WithLabelValues("registrations_by_ip_range", "pass").Inc()
 
@@ -362,11 +454,17 @@ func (ra *RegistrationAuthorityImpl) NewRegistration(ctx context.Context, init c
 // * A list containing a mailto contact that contains hfields
 // * A list containing a contact that has non-ascii characters
 // * A list containing a contact that doesn't pass `policy.ValidEmail`
+// * A list containing a contact that fails one of the optional checks made by
+//   `checkContactEmail`
+//
+// Every rejection is counted by the contact_rejections metric, labelled with
+// the reason for it.
 func (ra *RegistrationAuthorityImpl) validateContacts(ctx context.Context, contacts *[]string) error {
 	if contacts == nil || len(*contacts) == 0 {
 		return nil // Nothing to validate
 	}
 	if ra.maxContactsPerReg > 0 && len(*contacts) > ra.maxContactsPerReg {
+		ra.contactRejections.WithLabelValues("too_many").Inc()
 		return berrors.MalformedError(
 			"too many contacts provided: %d > %d",
 			len(*contacts),
@@ -375,26 +473,8 @@ func (ra *RegistrationAuthorityImpl) validateContacts(ctx context.Context, conta
 	}
 
 	for _, contact := range *contacts {
-		if contact == "" {
-			return berrors.InvalidEmailError("empty contact")
-		}
-		parsed, err := url.Parse(contact)
-		if err != nil {
-			return berrors.InvalidEmailError("invalid contact")
-		}
-		if parsed.Scheme != "mailto" {
-			return berrors.InvalidEmailError("contact method %q is not supported", parsed.Scheme)
-		}
-		if parsed.RawQuery != "" {
-			return berrors.InvalidEmailError("contact email [%q] contains hfields", contact)
-		}
-		if !core.IsASCII(contact) {
-			return berrors.InvalidEmailError(
-				"contact email [%q] contains non-ASCII characters",
-				contact,
-			)
-		}
-		if err := policy.ValidEmail(parsed.Opaque); err != nil {
+		if reason, err := ra.validateContact(ctx, contact); err != nil {
+			ra.contactRejections.WithLabelValues(reason).Inc()
 			return err
 		}
 	}
@@ -410,6 +490,7 @@ func (ra *RegistrationAuthorityImpl) validateContacts(ctx context.Context, conta
 		// return a bare error and not a berror here.
 		return fmt.Errorf("failed to marshal reg.Contact to JSON: %#v", *contacts)
 	} else if len(jsonBytes) >= maxContactBytes {
+		ra.contactRejections.WithLabelValues("too_long").Inc()
 		return berrors.InvalidEmailError(
 			"too many/too long contact(s). Please use shorter or fewer email addresses")
 	}
@@ -417,8 +498,39 @@ func (ra *RegistrationAuthorityImpl) validateContacts(ctx context.Context, conta
 	return nil
 }
 
+// validateContact checks a single contact for validateContacts, returning an
+// error and the reason for it if it isn't acceptable.
+func (ra *RegistrationAuthorityImpl) validateContact(ctx context.Context, contact string) (string, error) {
+	if contact == "" {
+		return "empty", berrors.InvalidEmailError("empty contact")
+	}
+	parsed, err := url.Parse(contact)
+	if err != nil {
+		return "unparseable", berrors.InvalidEmailError("invalid contact")
+	}
+	if parsed.Scheme != "mailto" {
+		return "unsupported_scheme", berrors.InvalidEmailError("contact method %q is not supported", parsed.Scheme)
+	}
+	if parsed.RawQuery != "" {
+		return "hfields", berrors.InvalidEmailError("contact email [%q] contains hfields", contact)
+	}
+	if !core.IsASCII(contact) {
+		return "non_ascii", berrors.InvalidEmailError(
+			"contact email [%q] contains non-ASCII characters",
+			contact,
+		)
+	}
+	if err := policy.ValidEmail(parsed.Opaque); err != nil {
+		return "invalid_email", err
+	}
+	return ra.checkContactEmail(ctx, parsed.Opaque)
+}
+
 func (ra *RegistrationAuthorityImpl) checkPendingAuthorizationLimit(ctx context.Context, regID int64) error {
-	limit := ra.rlPolicies.PendingAuthorizationsPerAccount()
+	limit, err := ra.applyStoredOverrides(ctx, ratelimit.PendingAuthorizationsPerAccountLimit, ra.rlPolicies.PendingAuthorizationsPerAccount(), regID)
+	if err != nil {
+		return err
+	}
 	if limit.Enabled() {
 		countPB, err := ra.SA.CountPendingAuthorizations2(ctx, &sapb.RegistrationID{
 			Id: &regID,
@@ -431,8 +543,11 @@ func (ra *RegistrationAuthorityImpl) checkPendingAuthorizationLimit(ctx context.
 		noKey := ""
 		if int(*countPB.Count) >= limit.GetThreshold(noKey, regID) {
 			ra.rateLimitCounter.WithLabelValues("pending_authorizations_by_registration_id", "exceeded").Inc()
-			ra.log.Infof("Rate limit exceeded, PendingAuthorizationsByRegID, regID: %d", regID)
-			return berrors.RateLimitError("too many currently pending authorizations")
+			blog.ForContext(ctx, ra.log).Infof("Rate limit exceeded, PendingAuthorizationsByRegID, regID: %d", regID)
+			// Pending authorizations stop counting as soon as they're
+			// completed or deactivated, so there's no time at which the limit
+			// is known to reset.
+			return berrors.RateLimitError(0, "too many currently pending authorizations")
 		}
 		ra.rateLimitCounter.WithLabelValues("pending_authorizations_by_registration_id", "pass").Inc()
 	}
@@ -460,7 +575,10 @@ func (ra *RegistrationAuthorityImpl) checkInvalidAuthorizationLimits(ctx context
 }
 
 func (ra *RegistrationAuthorityImpl) checkInvalidAuthorizationLimit(ctx context.Context, regID int64, hostname string) error {
-	limit := ra.rlPolicies.InvalidAuthorizationsPerAccount()
+	limit, err := ra.applyStoredOverrides(ctx, ratelimit.InvalidAuthorizationsPerAccountLimit, ra.rlPolicies.InvalidAuthorizationsPerAccount(), regID)
+	if err != nil {
+		return err
+	}
 	if !limit.Enabled() {
 		return nil
 	}
@@ -484,8 +602,8 @@ func (ra *RegistrationAuthorityImpl) checkInvalidAuthorizationLimit(ctx context.
 	// here.
 	noKey := ""
 	if *count.Count >= int64(limit.GetThreshold(noKey, regID)) {
-		ra.log.Infof("Rate limit exceeded, InvalidAuthorizationsByRegID, regID: %d", regID)
-		return berrors.RateLimitError("too many failed authorizations recently")
+		blog.ForContext(ctx, ra.log).Infof("Rate limit exceeded, InvalidAuthorizationsByRegID, regID: %d", regID)
+		return berrors.RateLimitError(limit.Window.Duration, "too many failed authorizations recently")
 	}
 	return nil
 }
@@ -494,7 +612,10 @@ func (ra *RegistrationAuthorityImpl) checkInvalidAuthorizationLimit(ctx context.
 // rate limit. This rate limit ensures a client can not create more than the
 // specified threshold of new orders within the specified time window.
 func (ra *RegistrationAuthorityImpl) checkNewOrdersPerAccountLimit(ctx context.Context, acctID int64) error {
-	limit := ra.rlPolicies.NewOrdersPerAccount()
+	limit, err := ra.applyStoredOverrides(ctx, ratelimit.NewOrdersPerAccountLimit, ra.rlPolicies.NewOrdersPerAccount(), acctID)
+	if err != nil {
+		return err
+	}
 	if !limit.Enabled() {
 		return nil
 	}
@@ -508,7 +629,7 @@ func (ra *RegistrationAuthorityImpl) checkNewOrdersPerAccountLimit(ctx context.C
 	noKey := ""
 	if count >= limit.GetThreshold(noKey, acctID) {
 		ra.rateLimitCounter.WithLabelValues("new_order_by_registration_id", "exceeded").Inc()
-		return berrors.RateLimitError("too many new orders recently")
+		return berrors.RateLimitError(limit.Window.Duration, "too many new orders recently")
 	}
 	ra.rateLimitCounter.WithLabelValues("new_order_by_registration_id", "pass").Inc()
 	return nil
@@ -547,7 +668,7 @@ func (ra *RegistrationAuthorityImpl) NewAuthorization(ctx context.Context, reque
 				identifier.Value,
 				err,
 			)
-			ra.log.Warning(outErr.Error())
+			blog.ForContext(ctx, ra.log).Warning(outErr.Error())
 			return core.Authorization{}, outErr
 		}
 		auths, err := bgrpc.PBToAuthzMap(authzMapPB)
@@ -782,13 +903,13 @@ func (ra *RegistrationAuthorityImpl) checkAuthorizationsCAA(
 		} else if authz.Expires.Before(now) {
 			badNames = append(badNames, name)
 		} else if authz.Expires.Before(caaRecheckTime) {
-			// Ensure that CAA is rechecked for this name
-			recheckAuthzs = append(recheckAuthzs, authz)
+			// Ensure that CAA is rechecked for this name.
+			recheckAuthzs = append(recheckAuthzs, caaRecheckAuthz(name, authz))
 		}
 	}
 
 	if len(recheckAuthzs) > 0 {
-		if err := ra.recheckCAA(ctx, recheckAuthzs); err != nil {
+		if err := ra.recheckCAA(ctx, recheckAuthzs, false); err != nil {
 			return err
 		}
 	}
@@ -803,11 +924,41 @@ func (ra *RegistrationAuthorityImpl) checkAuthorizationsCAA(
 	return nil
 }
 
+// caaRecheckAuthz returns the authorization to recheck CAA for when it's used
+// for name. An authorization for a base domain that satisfies a wildcard name
+// is rechecked as the wildcard so that issuewild records are honoured, just as
+// they were when a wildcard authorization was validated.
+func caaRecheckAuthz(name string, authz *core.Authorization) *core.Authorization {
+	if strings.HasPrefix(name, "*.") && !strings.HasPrefix(authz.Identifier.Value, "*.") {
+		wildcardAuthz := *authz
+		wildcardAuthz.Identifier.Value = name
+		return &wildcardAuthz
+	}
+	return authz
+}
+
+// recheckDNSSECCAA rechecks CAA, requiring the lookups to have been DNSSEC
+// validated, for each of the names using the authorization that satisfied it.
+// Unlike the recheck of old authorizations, this is done however recently the
+// authorizations were validated since CAA checked at that time didn't require
+// DNSSEC.
+func (ra *RegistrationAuthorityImpl) recheckDNSSECCAA(ctx context.Context, names []string, authzs map[string]*core.Authorization) error {
+	recheckAuthzs := make([]*core.Authorization, 0, len(names))
+	for _, name := range names {
+		authz := authzs[name]
+		if authz == nil {
+			return berrors.InternalServerError("no authorization for %q to recheck CAA with", name)
+		}
+		recheckAuthzs = append(recheckAuthzs, caaRecheckAuthz(name, authz))
+	}
+	return ra.recheckCAA(ctx, recheckAuthzs, true)
+}
+
 // recheckCAA accepts a list of of names that need to have their CAA records
-// rechecked because their associated authorizations are sufficiently old and
-// performs the CAA checks required for each. If any of the rechecks fail an
-// error is returned.
-func (ra *RegistrationAuthorityImpl) recheckCAA(ctx context.Context, authzs []*core.Authorization) error {
+// rechecked because their associated authorizations are sufficiently old, or
+// because DNSSEC validated CAA is required, and performs the CAA checks
+// required for each. If any of the rechecks fail an error is returned.
+func (ra *RegistrationAuthorityImpl) recheckCAA(ctx context.Context, authzs []*core.Authorization, requireDNSSEC bool) error {
 	ra.recheckCAACounter.Add(float64(len(authzs)))
 
 	type authzCAAResult struct {
@@ -839,18 +990,24 @@ func (ra *RegistrationAuthorityImpl) recheckCAA(ctx context.Context, authzs []*c
 				return
 			}
 
-			resp, err := ra.caa.IsCAAValid(ctx, &vaPB.IsCAAValidRequest{
+			req := &vaPB.IsCAAValidRequest{
 				Domain:           &name,
 				ValidationMethod: &method,
 				AccountURIID:     &authz.RegistrationID,
-			})
+			}
+			if requireDNSSEC {
+				req.RequireDNSSEC = &requireDNSSEC
+			}
+			resp, err := ra.caa.IsCAAValid(ctx, req)
 			if err != nil {
-				ra.log.AuditErrf("Rechecking CAA: %s", err)
+				blog.ForContext(ctx, ra.log).AuditErrf("Rechecking CAA: %s", err)
 				err = berrors.InternalServerError(
 					"Internal error rechecking CAA for authorization ID %v (%v)",
 					authz.ID, name,
 				)
 			} else if resp.Problem != nil {
+				blog.ForContext(ctx, ra.log).AuditInfof("CAA recheck failed: authzID=[%s] regID=[%d] name=[%s] method=[%s] detail=[%s]",
+					authz.ID, authz.RegistrationID, name, method, *resp.Problem.Detail)
 				err = berrors.CAAError(*resp.Problem.Detail)
 			}
 			ch <- authzCAAResult{
@@ -907,18 +1064,44 @@ func (ra *RegistrationAuthorityImpl) failOrder(
 	// Convert the problem to a protobuf problem for the *corepb.Order field
 	pbProb, err := bgrpc.ProblemDetailsToPB(prob)
 	if err != nil {
-		ra.log.AuditErrf("Could not convert order error problem to PB: %q", err)
+		blog.ForContext(ctx, ra.log).AuditErrf("Could not convert order error problem to PB: %q", err)
 		return order
 	}
 
 	// Assign the protobuf problem to the field and save it via the SA
 	order.Error = pbProb
-	if err := ra.SA.SetOrderError(ctx, order); err != nil {
-		ra.log.AuditErrf("Could not persist order error: %q", err)
+	err = ra.SA.SetOrderError(ctx, order)
+	if berrors.Is(err, berrors.Conflict) {
+		// The order was updated since it was read. Unless that update finished
+		// the order, retry with the current version.
+		var current *corepb.Order
+		current, err = ra.SA.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+		if err == nil {
+			if current.Error != nil || current.GetCertificateSerial() != "" {
+				blog.ForContext(ctx, ra.log).Warningf("Not failing order %d, which was concurrently finished", *order.Id)
+				return order
+			}
+			order.Version = current.Version
+			err = ra.SA.SetOrderError(ctx, order)
+		}
 	}
+	if err != nil {
+		blog.ForContext(ctx, ra.log).AuditErrf("Could not persist order error: %q", err)
+		return order
+	}
+	bumpOrderVersion(order)
 	return order
 }
 
+// bumpOrderVersion increments the version of an order, if it has one, to
+// match the SA's after the RA has successfully updated the order.
+func bumpOrderVersion(order *corepb.Order) {
+	if order.Version != nil {
+		version := *order.Version + 1
+		order.Version = &version
+	}
+}
+
 // FinalizeOrder accepts a request to finalize an order object and, if possible,
 // issues a certificate to satisfy the order. If an order does not have valid,
 // unexpired authorizations for all of its associated names an error is
@@ -927,6 +1110,40 @@ func (ra *RegistrationAuthorityImpl) failOrder(
 // If successful the order will be returned in processing status for the client
 // to poll while awaiting finalization to occur.
 func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rapb.FinalizeOrderRequest) (*corepb.Order, error) {
+	// Buggy clients sometimes send several finalize requests for an order at
+	// once. Only the first is processed; the others wait for it and return the
+	// same order or error, instead of each attempting issuance.
+	id := *req.Order.Id
+	ra.finalizingMu.Lock()
+	if call, ok := ra.finalizing[id]; ok {
+		ra.finalizingMu.Unlock()
+		select {
+		case <-call.done:
+			return call.order, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &finalizeCall{
+		done: make(chan struct{}),
+		// Waiting calls see this error if finalizeOrder panics.
+		err: berrors.InternalServerError("error finalizing order"),
+	}
+	ra.finalizing[id] = call
+	ra.finalizingMu.Unlock()
+	defer func() {
+		ra.finalizingMu.Lock()
+		delete(ra.finalizing, id)
+		ra.finalizingMu.Unlock()
+		close(call.done)
+	}()
+
+	call.order, call.err = ra.finalizeOrder(ctx, req)
+	return call.order, call.err
+}
+
+// finalizeOrder does the work of FinalizeOrder.
+func (ra *RegistrationAuthorityImpl) finalizeOrder(ctx context.Context, req *rapb.FinalizeOrderRequest) (*corepb.Order, error) {
 	order := req.Order
 
 	if *order.Status != string(core.StatusReady) {
@@ -981,11 +1198,18 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 	// finalized because it isn't pending, but we aren't going to process it
 	// further because we already did and encountered an error.
 	if err := ra.SA.SetOrderProcessing(ctx, order); err != nil {
+		if berrors.Is(err, berrors.Conflict) {
+			// The order changed after it was read, so whether it's still ready is
+			// unknown. Leave it to whatever changed it rather than failing it.
+			return nil, berrors.OrderNotReadyError(
+				"Order was modified while being finalized. Fetch the order and retry finalization if it's still ready.")
+		}
 		// Fail the order with a server internal error - we weren't able to set the
 		// status to processing and that's unexpected & weird.
 		ra.failOrder(ctx, order, probs.ServerInternal("Error setting order processing"))
 		return nil, err
 	}
+	bumpOrderVersion(order)
 
 	// Attempt issuance for the order. If the order isn't fully authorized this
 	// will return an error.
@@ -993,7 +1217,7 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 		Bytes: req.Csr,
 		CSR:   csrOb,
 	}
-	cert, err := ra.issueCertificate(ctx, issueReq, accountID(*order.RegistrationID), orderID(*order.Id))
+	cert, err := ra.issueCertificate(ctx, issueReq, accountID(*order.RegistrationID), orderID(*order.Id), order.GetCertificateProfileName())
 	if err != nil {
 		// Fail the order. The problem is computed using
 		// `web.ProblemDetailsForError`, the same function the WFE uses to convert
@@ -1023,6 +1247,7 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 		ra.failOrder(ctx, order, probs.ServerInternal("Error persisting finalized order"))
 		return nil, err
 	}
+	bumpOrderVersion(order)
 
 	// Note how many names were in this finalized certificate order.
 	ra.namesPerCert.With(
@@ -1045,7 +1270,7 @@ func (ra *RegistrationAuthorityImpl) NewCertificate(ctx context.Context, req cor
 	// NewCertificate provides an order ID of 0, indicating this is a classic ACME
 	// v1 issuance request from the new certificate endpoint that is not
 	// associated with an ACME v2 order.
-	return ra.issueCertificate(ctx, req, accountID(regID), orderID(0))
+	return ra.issueCertificate(ctx, req, accountID(regID), orderID(0), "")
 }
 
 // To help minimize the chance that an accountID would be used as an order ID
@@ -1055,21 +1280,24 @@ type accountID int64
 type orderID int64
 
 // issueCertificate sets up a log event structure and captures any errors
-// encountered during issuance, then calls issueCertificateInner.
+// encountered during issuance, then calls issueCertificateInner. An empty
+// profileName issues under the CA's default issuance profile.
 func (ra *RegistrationAuthorityImpl) issueCertificate(
 	ctx context.Context,
 	req core.CertificateRequest,
 	acctID accountID,
-	oID orderID) (core.Certificate, error) {
+	oID orderID,
+	profileName string) (core.Certificate, error) {
 	// Construct the log event
 	logEvent := certificateRequestEvent{
 		ID:          core.NewToken(),
 		OrderID:     int64(oID),
 		Requester:   int64(acctID),
+		ProfileName: profileName,
 		RequestTime: ra.clk.Now(),
 	}
 	var result string
-	cert, err := ra.issueCertificateInner(ctx, req, acctID, oID, &logEvent)
+	cert, err := ra.issueCertificateInner(ctx, req, acctID, oID, profileName, &logEvent)
 	if err != nil {
 		logEvent.Error = err.Error()
 		result = "error"
@@ -1077,7 +1305,7 @@ func (ra *RegistrationAuthorityImpl) issueCertificate(
 		result = "successful"
 	}
 	logEvent.ResponseTime = ra.clk.Now()
-	ra.log.AuditObject(fmt.Sprintf("Certificate request - %s", result), logEvent)
+	blog.ForContext(ctx, ra.log).AuditObject(fmt.Sprintf("Certificate request - %s", result), logEvent)
 	return cert, err
 }
 
@@ -1100,6 +1328,7 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 	req core.CertificateRequest,
 	acctID accountID,
 	oID orderID,
+	profileName string,
 	logEvent *certificateRequestEvent) (core.Certificate, error) {
 	emptyCert := core.Certificate{}
 	if acctID <= 0 {
@@ -1153,6 +1382,12 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 		// return BoulderError and we don't want to lose the type.
 		return emptyCert, err
 	}
+	if ra.DNSSECCAAProfiles[profileName] {
+		err = ra.recheckDNSSECCAA(ctx, core.UniqueLowerNames(names), authzs)
+		if err != nil {
+			return emptyCert, err
+		}
+	}
 
 	// Collect up a certificateRequestAuthz that stores the ID and challenge type
 	// of each of the valid authorizations we used for this issuance.
@@ -1163,7 +1398,7 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 		// consistency violation worth logging a warning about. In this case the
 		// solvedByChallengeType will be logged as the empty string.
 		if solvedByChallengeType = authz.SolvedBy(); solvedByChallengeType == "" {
-			ra.log.Warningf("Authz %q has status %q but empty SolvedBy()", authz.ID, authz.Status)
+			blog.ForContext(ctx, ra.log).Warningf("Authz %q has status %q but empty SolvedBy()", authz.ID, authz.Status)
 		}
 		logEventAuthzs[name] = certificateRequestAuthz{
 			ID:            authz.ID,
@@ -1183,6 +1418,9 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 		RegistrationID: &acctIDInt,
 		OrderID:        &orderIDInt,
 	}
+	if profileName != "" {
+		issueReq.CertificateProfileName = &profileName
+	}
 
 	// wrapError adds a prefix to an error. If the error is a boulder error then
 	// the problem detail is updated with the prefix. Otherwise a new error is
@@ -1207,12 +1445,22 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 	if err != nil {
 		return emptyCert, wrapError(err, "getting SCTs")
 	}
-	cert, err := ra.CA.IssueCertificateForPrecertificate(ctx, &caPB.IssueCertificateForPrecertificateRequest{
+	// The precertificate is logged even if the issuance profile omits SCTs
+	// from final certificates, so that the final certificate is never issued
+	// without the CT policy having been met.
+	if precert.GetOmitSCTs() {
+		scts = nil
+	}
+	finalReq := &caPB.IssueCertificateForPrecertificateRequest{
 		DER:            precert.DER,
 		SCTs:           scts,
 		RegistrationID: &acctIDInt,
 		OrderID:        &orderIDInt,
-	})
+	}
+	if profileName != "" {
+		finalReq.CertificateProfileName = &profileName
+	}
+	cert, err := ra.CA.IssueCertificateForPrecertificate(ctx, finalReq)
 	if err != nil {
 		return emptyCert, wrapError(err, "issuing certificate for precertificate")
 	}
@@ -1256,7 +1504,7 @@ func (ra *RegistrationAuthorityImpl) getSCTs(ctx context.Context, cert []byte, e
 			// otherwise it will be a generic serverInternalError
 			err = berrors.MissingSCTsError(err.Error())
 		}
-		ra.log.Warningf("ctpolicy.GetSCTs failed: %s", err)
+		blog.ForContext(ctx, ra.log).Warningf("ctpolicy.GetSCTs failed: %s", err)
 		ra.ctpolicyResults.With(prometheus.Labels{"result": state}).Observe(took.Seconds())
 		return nil, err
 	}
@@ -1331,6 +1579,10 @@ func (ra *RegistrationAuthorityImpl) checkCertificatesPerNameLimit(ctx context.C
 	if err != nil {
 		return err
 	}
+	limit, err = ra.applyStoredOverrides(ctx, ratelimit.CertificatesPerNameLimit, limit, regID, tldNames...)
+	if err != nil {
+		return err
+	}
 
 	namesOutOfLimit, err := ra.enforceNameCounts(ctx, tldNames, limit, regID)
 	if err != nil {
@@ -1351,19 +1603,19 @@ func (ra *RegistrationAuthorityImpl) checkCertificatesPerNameLimit(ctx context.C
 			return nil
 		}
 
-		ra.log.Infof("Rate limit exceeded, CertificatesForDomain, regID: %d, domains: %s", regID, strings.Join(namesOutOfLimit, ", "))
+		blog.ForContext(ctx, ra.log).Infof("Rate limit exceeded, CertificatesForDomain, regID: %d, domains: %s", regID, strings.Join(namesOutOfLimit, ", "))
 		ra.rateLimitCounter.WithLabelValues("certificates_for_domain", "exceeded").Inc()
 		if len(namesOutOfLimit) > 1 {
 			var subErrors []berrors.SubBoulderError
 			for _, name := range namesOutOfLimit {
 				subErrors = append(subErrors, berrors.SubBoulderError{
 					Identifier:   identifier.DNSIdentifier(name),
-					BoulderError: berrors.RateLimitError("too many certificates already issued").(*berrors.BoulderError),
+					BoulderError: berrors.RateLimitError(limit.Window.Duration, "too many certificates already issued").(*berrors.BoulderError),
 				})
 			}
-			return berrors.RateLimitError("too many certificates already issued for multiple names (%s and %d others)", namesOutOfLimit[0], len(namesOutOfLimit)).(*berrors.BoulderError).WithSubErrors(subErrors)
+			return berrors.RateLimitError(limit.Window.Duration, "too many certificates already issued for multiple names (%s and %d others)", namesOutOfLimit[0], len(namesOutOfLimit)).(*berrors.BoulderError).WithSubErrors(subErrors)
 		}
-		return berrors.RateLimitError("too many certificates already issued for: %s", namesOutOfLimit[0])
+		return berrors.RateLimitError(limit.Window.Duration, "too many certificates already issued for: %s", namesOutOfLimit[0])
 	}
 	ra.rateLimitCounter.WithLabelValues("certificates_for_domain", "pass").Inc()
 
@@ -1376,8 +1628,13 @@ func (ra *RegistrationAuthorityImpl) checkCertificatesPerFQDNSetLimit(ctx contex
 		return fmt.Errorf("checking duplicate certificate limit for %q: %s", names, err)
 	}
 	names = core.UniqueLowerNames(names)
+	limit, err = ra.applyStoredOverrides(ctx, ratelimit.CertificatesPerFQDNSetLimit, limit, regID, strings.Join(names, ","))
+	if err != nil {
+		return err
+	}
 	if int(count) >= limit.GetThreshold(strings.Join(names, ","), regID) {
 		return berrors.RateLimitError(
+			limit.Window.Duration,
 			"too many certificates already issued for exact set of domains: %s",
 			strings.Join(names, ","),
 		)
@@ -1404,6 +1661,106 @@ func (ra *RegistrationAuthorityImpl) checkLimits(ctx context.Context, names []st
 	return nil
 }
 
+// GetRateLimits reports the account's current usage of the new orders per
+// account limit and, for each of the requested names, the failed validations
+// per account and certificates per domain limits. It makes the same SA count
+// queries as the enforcement path but only reads them, so calling it doesn't
+// count against any limit. Limits that aren't enabled are omitted.
+func (ra *RegistrationAuthorityImpl) GetRateLimits(ctx context.Context, req *rapb.GetRateLimitsRequest) (*rapb.RateLimits, error) {
+	regID := *req.RegistrationID
+	names := core.UniqueLowerNames(req.Names)
+	now := ra.clk.Now()
+	var limits []*rapb.RateLimitStatus
+
+	if limit := ra.rlPolicies.NewOrdersPerAccount(); limit.Enabled() {
+		limit, err := ra.applyStoredOverrides(ctx, ratelimit.NewOrdersPerAccountLimit, limit, regID)
+		if err != nil {
+			return nil, err
+		}
+		count, err := ra.SA.CountOrders(ctx, regID, limit.WindowBegin(now), now)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, rateLimitStatus("newOrdersPerAccount", "", limit, "", regID, int64(count), now))
+	}
+
+	if limit := ra.rlPolicies.InvalidAuthorizationsPerAccount(); limit.Enabled() {
+		limit, err := ra.applyStoredOverrides(ctx, ratelimit.InvalidAuthorizationsPerAccountLimit, limit, regID)
+		if err != nil {
+			return nil, err
+		}
+		// Invalid authorizations are counted by expiry, matching
+		// checkInvalidAuthorizationLimit.
+		latest := now.Add(ra.pendingAuthorizationLifetime)
+		earliestNanos := limit.WindowBegin(latest).UnixNano()
+		latestNanos := latest.UnixNano()
+		for _, name := range names {
+			hostname := name
+			count, err := ra.SA.CountInvalidAuthorizations2(ctx, &sapb.CountInvalidAuthorizationsRequest{
+				RegistrationID: &regID,
+				Hostname:       &hostname,
+				Range: &sapb.Range{
+					Earliest: &earliestNanos,
+					Latest:   &latestNanos,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			limits = append(limits, rateLimitStatus("invalidAuthorizationsPerAccount", name, limit, "", regID, *count.Count, now))
+		}
+	}
+
+	if limit := ra.rlPolicies.CertificatesPerName(); limit.Enabled() && len(names) > 0 {
+		domains, err := domainsForRateLimiting(names)
+		if err != nil {
+			return nil, err
+		}
+		limit, err := ra.applyStoredOverrides(ctx, ratelimit.CertificatesPerNameLimit, limit, regID, domains...)
+		if err != nil {
+			return nil, err
+		}
+		counts, err := ra.SA.CountCertificatesByNames(ctx, domains, limit.WindowBegin(now), now)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range counts {
+			if entry.Count == nil || entry.Name == nil {
+				return nil, fmt.Errorf("CountByNames_MapElement had nil Count or Name")
+			}
+			limits = append(limits, rateLimitStatus("certificatesPerName", *entry.Name, limit, *entry.Name, regID, *entry.Count, now))
+		}
+	}
+
+	return &rapb.RateLimits{Limits: limits}, nil
+}
+
+// rateLimitStatus builds the RateLimitStatus for a limit counted for key, using
+// overrideKey to look up the threshold that applies to the account.
+func rateLimitStatus(
+	name string,
+	key string,
+	limit ratelimit.RateLimitPolicy,
+	overrideKey string,
+	regID int64,
+	count int64,
+	now time.Time) *rapb.RateLimitStatus {
+	threshold := int64(limit.GetThreshold(overrideKey, regID))
+	window := int64(limit.Window.Duration)
+	resetBy := now.Add(limit.Window.Duration).UnixNano()
+	status := &rapb.RateLimitStatus{
+		Limit:     &name,
+		Threshold: &threshold,
+		Count:     &count,
+		Window:    &window,
+		ResetBy:   &resetBy,
+	}
+	if key != "" {
+		status.Key = &key
+	}
+	return status
+}
+
 // UpdateRegistration updates an existing Registration with new values. Caller
 // is responsible for making sure that update.Key is only different from base.Key
 // if it is being called from the WFE key change endpoint.
@@ -1610,7 +1967,7 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 			prob = p
 		} else if err != nil {
 			prob = probs.ServerInternal("Could not communicate with VA")
-			ra.log.AuditErrf("Could not communicate with VA: %s", err)
+			blog.ForContext(ctx, ra.log).AuditErrf("Could not communicate with VA: %s", err)
 		}
 
 		// Save the updated records
@@ -1630,7 +1987,7 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 		authz.Challenges[challIndex] = *challenge
 
 		if err := ra.recordValidation(vaCtx, authz.ID, authz.Expires, challenge); err != nil {
-			ra.log.AuditErrf("Could not record updated validation: err=[%s] regID=[%d] authzID=[%s]",
+			blog.ForContext(ctx, ra.log).AuditErrf("Could not record updated validation: err=[%s] regID=[%d] authzID=[%s]",
 				err, authz.RegistrationID, authz.ID)
 		}
 	}(authz)
@@ -1722,7 +2079,7 @@ func (ra *RegistrationAuthorityImpl) RevokeCertificateWithReg(ctx context.Contex
 		//   Revocation reason
 		//   Registration ID of requester
 		//   Error (if there was one)
-		ra.log.AuditInfof("%s, Request by registration ID: %d",
+		blog.ForContext(ctx, ra.log).AuditInfof("%s, Request by registration ID: %d",
 			revokeEvent(state, serialString, cert.Subject.CommonName, cert.DNSNames, revocationCode),
 			regID)
 	}()
@@ -1754,7 +2111,7 @@ func (ra *RegistrationAuthorityImpl) AdministrativelyRevokeCertificate(ctx conte
 		//   Revocation reason
 		//   Name of admin-revoker user
 		//   Error (if there was one)
-		ra.log.AuditInfof("%s, admin-revoker user: %s",
+		blog.ForContext(ctx, ra.log).AuditInfof("%s, admin-revoker user: %s",
 			revokeEvent(state, serialString, cert.Subject.CommonName, cert.DNSNames, revocationCode),
 			user)
 	}()
@@ -1768,12 +2125,16 @@ func (ra *RegistrationAuthorityImpl) AdministrativelyRevokeCertificate(ctx conte
 	return nil
 }
 
+// subscriberDeactivationReason is recorded by the SA as the reason for
+// deactivations requested by subscribers through the WFE.
+const subscriberDeactivationReason = "deactivated at the request of the subscriber"
+
 // DeactivateRegistration deactivates a valid registration
 func (ra *RegistrationAuthorityImpl) DeactivateRegistration(ctx context.Context, reg core.Registration) error {
 	if reg.Status != core.StatusValid {
 		return berrors.MalformedError("only valid registrations can be deactivated")
 	}
-	err := ra.SA.DeactivateRegistration(ctx, reg.ID)
+	err := ra.SA.DeactivateRegistration(ctx, reg.ID, subscriberDeactivationReason)
 	if err != nil {
 		return berrors.InternalServerError(err.Error())
 	}
@@ -1810,12 +2171,59 @@ func (ra *RegistrationAuthorityImpl) checkOrderNames(names []string) error {
 	return nil
 }
 
+// blockedDomainDetail is the problem detail for names which are rejected
+// because they match an entry in the SA's blocked domains list.
+const blockedDomainDetail = "The ACME server refuses to issue a certificate for this domain name, because it is blocked by policy"
+
+// checkBlockedDomains returns a rejectedIdentifier error, with suberrors for
+// each blocked identifier, if any of the names match an entry in the SA's
+// blocked domains list. Entries match the name itself and all of its
+// subdomains.
+func (ra *RegistrationAuthorityImpl) checkBlockedDomains(ctx context.Context, names []string) error {
+	if !features.Enabled(features.BlockedDomainsTable) {
+		return nil
+	}
+	resp, err := ra.SA.DomainsBlocked(ctx, &sapb.DomainsBlockedRequest{Domains: names})
+	if err != nil {
+		return err
+	}
+	if len(resp.Domains) == 0 {
+		return nil
+	}
+	if len(resp.Domains) == 1 {
+		return berrors.RejectedIdentifierError("Cannot issue for %q: %s", resp.Domains[0], blockedDomainDetail)
+	}
+	subErrors := make([]berrors.SubBoulderError, len(resp.Domains))
+	for i, name := range resp.Domains {
+		subErrors[i] = berrors.SubBoulderError{
+			Identifier: identifier.DNSIdentifier(name),
+			BoulderError: &berrors.BoulderError{
+				Type:   berrors.RejectedIdentifier,
+				Detail: blockedDomainDetail,
+			},
+		}
+	}
+	detail := fmt.Sprintf(
+		"Cannot issue for %q: %s (and %d more problems. Refer to sub-problems for more information.)",
+		resp.Domains[0],
+		blockedDomainDetail,
+		len(resp.Domains)-1,
+	)
+	return (&berrors.BoulderError{
+		Type:   berrors.RejectedIdentifier,
+		Detail: detail,
+	}).WithSubErrors(subErrors)
+}
+
 // NewOrder creates a new order object
 func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.NewOrderRequest) (*corepb.Order, error) {
 	order := &corepb.Order{
 		RegistrationID: req.RegistrationID,
 		Names:          core.UniqueLowerNames(req.Names),
 	}
+	if req.CertificateProfileName != nil && *req.CertificateProfileName != "" {
+		order.CertificateProfileName = req.CertificateProfileName
+	}
 
 	if len(order.Names) > ra.maxNames {
 		return nil, berrors.MalformedError(
@@ -1827,6 +2235,11 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 		return nil, err
 	}
 
+	// Reject any names which have been added to the blocked domains list
+	if err := ra.checkBlockedDomains(ctx, order.Names); err != nil {
+		return nil, err
+	}
+
 	if err := wildcardOverlap(order.Names); err != nil {
 		return nil, err
 	}
@@ -1844,8 +2257,8 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 	if err != nil && !berrors.Is(err, berrors.NotFound) {
 		return nil, err
 	}
-	// If there was an order, return it
-	if existingOrder != nil {
+	// If there was an order for the same issuance profile, return it
+	if existingOrder != nil && existingOrder.GetCertificateProfileName() == order.GetCertificateProfileName() {
 		return existingOrder, nil
 	}
 
@@ -2015,6 +2428,134 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 	return storedOrder, nil
 }
 
+// DryRunIssuance runs the policy, rate limit, and authorization checks (including
+// any required CAA rechecks) that an order for the requested names would be
+// subject to, without creating an order or issuing a certificate. It returns
+// whether the order would be accepted along with a reason for every check that
+// failed. Nothing is written to the SA and no nonces are involved, so it is safe
+// to call against production data when testing a policy change. Errors are only
+// returned when a check could not be performed at all.
+func (ra *RegistrationAuthorityImpl) DryRunIssuance(ctx context.Context, req *rapb.DryRunIssuanceRequest) (*rapb.DryRunIssuanceResponse, error) {
+	names := core.UniqueLowerNames(req.Names)
+	if len(names) == 0 {
+		return nil, berrors.MalformedError("dry run issuance requires at least one name")
+	}
+	regID := *req.RegistrationID
+
+	var reasons []string
+	// record adds a reason for a check that rejected the order. Errors that
+	// aren't a rejection (e.g. an SA failure) are returned as-is so that they
+	// aren't mistaken for a policy decision.
+	record := func(check string, err error) error {
+		if err == nil {
+			return nil
+		}
+		if !dryRunRejection(err) {
+			return err
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %s", check, err))
+		return nil
+	}
+
+	if len(names) > ra.maxNames {
+		reasons = append(reasons, fmt.Sprintf(
+			"names: order cannot contain more than %d DNS names", ra.maxNames))
+	}
+	if err := record("policy", ra.checkOrderNames(names)); err != nil {
+		return nil, err
+	}
+	if err := record("policy", ra.checkBlockedDomains(ctx, names)); err != nil {
+		return nil, err
+	}
+	if err := record("policy", wildcardOverlap(names)); err != nil {
+		return nil, err
+	}
+	if err := record("rate limit", ra.checkNewOrdersPerAccountLimit(ctx, regID)); err != nil {
+		return nil, err
+	}
+	if err := record("rate limit", ra.checkLimits(ctx, names, regID)); err != nil {
+		return nil, err
+	}
+	if err := record("authorizations", ra.checkDryRunAuthorizations(ctx, names, regID)); err != nil {
+		return nil, err
+	}
+
+	allowed := len(reasons) == 0
+	blog.ForContext(ctx, ra.log).Infof("Dry run issuance: regID=[%d] names=[%s] allowed=[%t] reasons=[%s]",
+		regID, strings.Join(names, ", "), allowed, strings.Join(reasons, "; "))
+	return &rapb.DryRunIssuanceResponse{
+		Allowed: &allowed,
+		Reasons: reasons,
+	}, nil
+}
+
+// checkDryRunAuthorizations checks that the account holds a valid authorization
+// for each of the names, rechecking CAA where finalization would. Wildcard names
+// are satisfied by a valid DNS-01 authorization for their base domain, matching
+// the authorizations NewOrder would create for them.
+func (ra *RegistrationAuthorityImpl) checkDryRunAuthorizations(ctx context.Context, names []string, regID int64) error {
+	now := ra.clk.Now()
+	nowUnix := now.UnixNano()
+	domains := make([]string, len(names))
+	for i, name := range names {
+		domains[i] = strings.TrimPrefix(name, "*.")
+	}
+	authzMapPB, err := ra.SA.GetValidAuthorizations2(ctx, &sapb.GetValidAuthorizationsRequest{
+		RegistrationID: &regID,
+		Domains:        core.UniqueLowerNames(domains),
+		Now:            &nowUnix,
+	})
+	if err != nil {
+		return err
+	}
+	byDomain, err := bgrpc.PBToAuthzMap(authzMapPB)
+	if err != nil {
+		return err
+	}
+
+	authzs := make(map[string]*core.Authorization, len(names))
+	for i, name := range names {
+		authz, present := byDomain[domains[i]]
+		if !present {
+			continue
+		}
+		if strings.HasPrefix(name, "*.") && !validatedWithDNS01(authz) {
+			continue
+		}
+		authzs[name] = authz
+	}
+	return ra.checkAuthorizationsCAA(ctx, names, authzs, regID, now)
+}
+
+// validatedWithDNS01 returns true if the authorization has a valid DNS-01
+// challenge.
+func validatedWithDNS01(authz *core.Authorization) bool {
+	for _, chall := range authz.Challenges {
+		if chall.Type == core.ChallengeTypeDNS01 && chall.Status == core.StatusValid {
+			return true
+		}
+	}
+	return false
+}
+
+// dryRunRejection returns true if the error is one that NewOrder or
+// FinalizeOrder would return to a subscriber as a reason for refusing the
+// order, as opposed to an internal failure.
+func dryRunRejection(err error) bool {
+	for _, errType := range []berrors.ErrorType{
+		berrors.Malformed,
+		berrors.RejectedIdentifier,
+		berrors.Unauthorized,
+		berrors.RateLimit,
+		berrors.CAA,
+	} {
+		if berrors.Is(err, errType) {
+			return true
+		}
+	}
+	return false
+}
+
 // createPendingAuthz checks that a name is allowed for issuance and creates the
 // necessary challenges for it and puts this and all of the relevant information
 // into a corepb.Authorization for transmission to the SA to be stored
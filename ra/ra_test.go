@@ -30,6 +30,7 @@ import (
 	ctpkix "github.com/google/certificate-transparency-go/x509/pkix"
 	"github.com/jmhodges/clock"
 	akamaipb "github.com/letsencrypt/boulder/akamai/proto"
+	"github.com/letsencrypt/boulder/bdns"
 	capb "github.com/letsencrypt/boulder/ca/proto"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/core"
@@ -325,7 +326,7 @@ func initAuthorities(t *testing.T) (*DummyValidationAuthority, *sa.SQLStorageAut
 		Status:    core.StatusValid,
 	})
 
-	ctp := ctpolicy.New(&mocks.Publisher{}, nil, nil, log, metrics.NoopRegisterer)
+	ctp := ctpolicy.New(&mocks.Publisher{}, nil, nil, 0, log, metrics.NoopRegisterer, fc)
 
 	ra := NewRegistrationAuthorityImpl(fc,
 		log,
@@ -425,6 +426,78 @@ func TestValidateContacts(t *testing.T) {
 	test.AssertError(t, err, "Too long contacts")
 }
 
+func TestValidateContactsOptionalChecks(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	testCases := []struct {
+		name    string
+		contact string
+		reason  string
+	}{
+		{"valid", "mailto:admin@email.com", ""},
+		{"display name", "mailto:Admin <admin@email.com>", "syntax"},
+		{"comment", "mailto:admin@email.com(admin)", "syntax"},
+		{"long local part", "mailto:" + strings.Repeat("a", maxLocalPartLength+1) + "@email.com", "syntax"},
+		{"disposable", "mailto:admin@throwaway.com", "disposable"},
+		{"disposable subdomain", "mailto:admin@mail.Throwaway.com", "disposable"},
+		{"similar to disposable", "mailto:admin@notthrowaway.com", ""},
+		{"nxdomain", "mailto:admin@nxdomain.com", "mx_nxdomain"},
+		{"null MX", "mailto:admin@null-mx.com", "mx_null"},
+		{"MX lookup failure", "mailto:admin@servfail.com", ""},
+		{"no MX, with address", "mailto:admin@no-mx.com", ""},
+		{"no MX or address", "mailto:admin@no-mx-or-address.com", "mx_missing"},
+		{"invalid", "mailto:admin.com", "invalid_email"},
+	}
+
+	// Without the optional checks configured, only invalid addresses are
+	// rejected.
+	for _, tc := range testCases {
+		err := ra.validateContacts(context.Background(), &[]string{tc.contact})
+		if tc.reason == "invalid_email" {
+			test.AssertError(t, err, tc.name)
+		} else {
+			test.AssertNotError(t, err, tc.name)
+		}
+	}
+
+	ra.StrictContactSyntax = true
+	ra.DisposableMailDomains = map[string]bool{"throwaway.com": true}
+	ra.MXResolver = &bdns.MockDNSClient{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := 0
+			if tc.reason != "" {
+				before = test.CountCounterVec("reason", tc.reason, ra.contactRejections)
+			}
+			err := ra.validateContacts(context.Background(), &[]string{tc.contact})
+			if tc.reason == "" {
+				test.AssertNotError(t, err, "contact was rejected")
+				return
+			}
+			test.AssertError(t, err, "contact was accepted")
+			test.Assert(t, berrors.Is(err, berrors.InvalidEmail), "error wasn't InvalidEmail")
+			test.AssertEquals(t, test.CountCounterVec("reason", tc.reason, ra.contactRejections), before+1)
+		})
+	}
+}
+
+func TestLoadDisposableMailDomains(t *testing.T) {
+	f, err := ioutil.TempFile("", "disposable-domains")
+	test.AssertNotError(t, err, "failed to create temp file")
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("disposableDomains:\n  - Throwaway.com\n  - burner.example.\n")
+	test.AssertNotError(t, err, "failed to write temp file")
+	test.AssertNotError(t, f.Close(), "failed to close temp file")
+
+	domains, err := LoadDisposableMailDomains(f.Name())
+	test.AssertNotError(t, err, "LoadDisposableMailDomains failed")
+	test.AssertDeepEquals(t, domains, map[string]bool{"throwaway.com": true, "burner.example": true})
+
+	_, err = LoadDisposableMailDomains("/does/not/exist")
+	test.AssertError(t, err, "LoadDisposableMailDomains succeeded for a missing file")
+}
+
 func TestNewRegistration(t *testing.T) {
 	_, sa, ra, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -1366,6 +1439,8 @@ func TestCheckCertificatesPerNameLimit(t *testing.T) {
 	// Verify it has no sub errors as there is only one bad name
 	test.AssertEquals(t, err.Error(), "too many certificates already issued for: example.com: see https://letsencrypt.org/docs/rate-limits/")
 	test.AssertEquals(t, len(err.(*berrors.BoulderError).SubErrors), 0)
+	// The client is told to retry once the limit's window has passed.
+	test.AssertEquals(t, err.(*berrors.BoulderError).RetryAfter, rlp.Window.Duration)
 
 	// Three base domains, two above threshold, one below
 	mockSA.nameCounts["example.com"] = nameCount("example.com", 10)
@@ -1379,6 +1454,7 @@ func TestCheckCertificatesPerNameLimit(t *testing.T) {
 	// Verify it has two sub errors as there are two bad names
 	test.AssertEquals(t, err.Error(), "too many certificates already issued for multiple names (example.com and 2 others): see https://letsencrypt.org/docs/rate-limits/")
 	test.AssertEquals(t, len(err.(*berrors.BoulderError).SubErrors), 2)
+	test.AssertEquals(t, err.(*berrors.BoulderError).RetryAfter, rlp.Window.Duration)
 
 	// SA misbehaved and didn't send back a count for every input name
 	err = ra.checkCertificatesPerNameLimit(ctx, []string{"zombo.com", "www.example.com", "example.com"}, rlp, 99)
@@ -1917,7 +1993,7 @@ func (cf *caaFailer) IsCAAValid(
 func TestRecheckCAAEmpty(t *testing.T) {
 	_, _, ra, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
-	if err := ra.recheckCAA(context.Background(), nil); err != nil {
+	if err := ra.recheckCAA(context.Background(), nil, false); err != nil {
 		t.Errorf("expected nil err, got %s", err)
 	}
 }
@@ -1937,11 +2013,57 @@ func TestRecheckCAASuccess(t *testing.T) {
 		makeHTTP01Authorization("b.com"),
 		makeHTTP01Authorization("c.com"),
 	}
-	if err := ra.recheckCAA(context.Background(), authzs); err != nil {
+	if err := ra.recheckCAA(context.Background(), authzs, false); err != nil {
 		t.Errorf("expected nil err, got %s", err)
 	}
 }
 
+// dnssecCAARecorder implements caaChecker, always returning nil, but recording
+// whether DNSSEC validated CAA was required for each name it was called for.
+type dnssecCAARecorder struct {
+	sync.Mutex
+	requireDNSSEC map[string]bool
+}
+
+func (cr *dnssecCAARecorder) IsCAAValid(
+	ctx context.Context,
+	in *vaPB.IsCAAValidRequest,
+	opts ...grpc.CallOption,
+) (*vaPB.IsCAAValidResponse, error) {
+	cr.Lock()
+	defer cr.Unlock()
+	cr.requireDNSSEC[*in.Domain] = in.GetRequireDNSSEC()
+	return &vaPB.IsCAAValidResponse{}, nil
+}
+
+func TestRecheckDNSSECCAA(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+	recorder := &dnssecCAARecorder{requireDNSSEC: make(map[string]bool)}
+	ra.caa = recorder
+	baseAuthz := makeHTTP01Authorization("b.com")
+	authzs := map[string]*core.Authorization{
+		"a.com":   makeHTTP01Authorization("a.com"),
+		"b.com":   baseAuthz,
+		"*.b.com": baseAuthz,
+	}
+	err := ra.recheckDNSSECCAA(context.Background(), []string{"a.com", "b.com", "*.b.com"}, authzs)
+	test.AssertNotError(t, err, "recheckDNSSECCAA failed")
+	// The base domain's authorization is rechecked as the wildcard name it
+	// satisfies, and DNSSEC validation is required for every name.
+	test.AssertDeepEquals(t, recorder.requireDNSSEC, map[string]bool{
+		"a.com":   true,
+		"b.com":   true,
+		"*.b.com": true,
+	})
+	test.AssertEquals(t, baseAuthz.Identifier.Value, "b.com")
+
+	// A name without an authorization is an error rather than going unchecked.
+	err = ra.recheckDNSSECCAA(context.Background(), []string{"c.com"}, authzs)
+	test.AssertError(t, err, "recheckDNSSECCAA succeeded for a name without an authorization")
+	test.AssertEquals(t, recorder.requireDNSSEC["c.com"], false)
+}
+
 func TestRecheckCAAFail(t *testing.T) {
 	_, _, ra, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -1951,7 +2073,7 @@ func TestRecheckCAAFail(t *testing.T) {
 		makeHTTP01Authorization("b.com"),
 		makeHTTP01Authorization("c.com"),
 	}
-	err := ra.recheckCAA(context.Background(), authzs)
+	err := ra.recheckCAA(context.Background(), authzs, false)
 
 	if err == nil {
 		t.Fatalf("expected err, got nil")
@@ -1988,10 +2110,12 @@ func TestRecheckCAAFail(t *testing.T) {
 	test.AssertEquals(t, subErrB.Type, berrors.CAA)
 
 	// Recheck CAA with just one bad authz
+	mockLog := ra.log.(*blog.Mock)
+	mockLog.Clear()
 	authzs = []*core.Authorization{
 		makeHTTP01Authorization("a.com"),
 	}
-	err = ra.recheckCAA(context.Background(), authzs)
+	err = ra.recheckCAA(context.Background(), authzs, false)
 	// It should error
 	test.AssertError(t, err, "expected err from recheckCAA")
 	// It should be a berror
@@ -1999,6 +2123,35 @@ func TestRecheckCAAFail(t *testing.T) {
 	test.AssertEquals(t, ok, true)
 	// There should be *no* suberrors because there was only one overall error
 	test.AssertEquals(t, len(berr.SubErrors), 0)
+	// The VA's detail should be passed on to the subscriber and audit logged
+	test.AssertEquals(t, berr.Detail, "CAA invalid for a.com")
+	test.AssertEquals(t, len(mockLog.GetAllMatching(
+		`INFO: \[AUDIT\] CAA recheck failed: .* name=\[a\.com\] method=\[http-01\] detail=\[CAA invalid for a\.com\]`)), 1)
+}
+
+func TestRecheckCAAWildcard(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+	recorder := &caaRecorder{names: make(map[string]bool)}
+	ra.caa = recorder
+
+	// An old authorization for the base domain used to satisfy both the exact
+	// and wildcard name should be rechecked as each of them.
+	older := fc.Now().Add(ra.authorizationLifetime).Add(-24 * time.Hour)
+	authz := makeHTTP01Authorization("example.com")
+	authz.Expires = &older
+	authzs := map[string]*core.Authorization{
+		"example.com":   authz,
+		"*.example.com": authz,
+	}
+	err := ra.checkAuthorizationsCAA(context.Background(), []string{"example.com", "*.example.com"}, authzs, 1, fc.Now())
+	test.AssertNotError(t, err, "checkAuthorizationsCAA failed")
+	test.AssertDeepEquals(t, recorder.names, map[string]bool{
+		"example.com":   true,
+		"*.example.com": true,
+	})
+	// The shared authorization must not have been modified.
+	test.AssertEquals(t, authz.Identifier.Value, "example.com")
 }
 
 func TestRecheckCAAInternalServerError(t *testing.T) {
@@ -2010,7 +2163,7 @@ func TestRecheckCAAInternalServerError(t *testing.T) {
 		makeHTTP01Authorization("b.com"),
 		makeHTTP01Authorization("d.com"),
 	}
-	if err := ra.recheckCAA(context.Background(), authzs); err == nil {
+	if err := ra.recheckCAA(context.Background(), authzs, false); err == nil {
 		t.Errorf("expected err, got nil")
 	} else if !berrors.Is(err, berrors.InternalServer) {
 		t.Errorf("expected InternalServer error, got %T", err)
@@ -3163,6 +3316,238 @@ func TestFinalizeOrderWildcard(t *testing.T) {
 		"wildcard order")
 }
 
+// mockSABlockingFinalize is a mock SA whose SetOrderProcessing blocks until
+// release is closed and then fails, so that concurrent finalizations of the
+// same order can be observed.
+type mockSABlockingFinalize struct {
+	mocks.StorageAuthority
+	sync.Mutex
+	calls   int
+	started chan struct{}
+	release chan struct{}
+}
+
+func (sa *mockSABlockingFinalize) SetOrderProcessing(_ context.Context, _ *corepb.Order) error {
+	sa.Lock()
+	sa.calls++
+	if sa.calls == 1 {
+		close(sa.started)
+	}
+	sa.Unlock()
+	<-sa.release
+	return berrors.InternalServerError("processing failed")
+}
+
+func TestFinalizeOrderConcurrent(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+	mockSA := &mockSABlockingFinalize{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	ra.SA = mockSA
+
+	testKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.AssertNotError(t, err, "error generating test key")
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		PublicKey:          testKey.PublicKey,
+		SignatureAlgorithm: x509.SHA256WithRSA,
+		DNSNames:           []string{"not-example.com"},
+	}, testKey)
+	test.AssertNotError(t, err, "Error creating CSR")
+
+	id := int64(1)
+	readyStatus := string(core.StatusReady)
+	finalize := func(ctx context.Context) error {
+		_, err := ra.FinalizeOrder(ctx, &rapb.FinalizeOrderRequest{
+			Order: &corepb.Order{
+				Id:             &id,
+				RegistrationID: &Registration.ID,
+				Status:         &readyStatus,
+				Names:          []string{"not-example.com"},
+			},
+			Csr: csr,
+		})
+		return err
+	}
+
+	const callers = 5
+	errs := make(chan error, callers)
+	go func() { errs <- finalize(ctx) }()
+	<-mockSA.started
+	for i := 1; i < callers; i++ {
+		go func() { errs <- finalize(ctx) }()
+	}
+
+	// A caller that gives up waiting gets its context's error.
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	test.AssertEquals(t, finalize(canceledCtx), context.Canceled)
+
+	// Give the other callers time to start waiting for the first.
+	time.Sleep(100 * time.Millisecond)
+	close(mockSA.release)
+	var first error
+	for i := 0; i < callers; i++ {
+		err := <-errs
+		test.AssertError(t, err, "FinalizeOrder didn't fail")
+		if first == nil {
+			first = err
+		}
+		test.AssertEquals(t, err, first)
+	}
+	test.AssertEquals(t, mockSA.calls, 1)
+
+	// Once the first finalization is done, another is processed on its own.
+	test.AssertError(t, finalize(ctx), "FinalizeOrder didn't fail")
+	test.AssertEquals(t, mockSA.calls, 2)
+}
+
+// mockSAOrderVersions is a mock SA storing a single order whose updates are
+// compare-and-swapped on its version, like the SA's with the OrderVersions
+// feature enabled.
+type mockSAOrderVersions struct {
+	mocks.StorageAuthority
+	sync.Mutex
+	version         int64
+	beganProcessing bool
+	err             *corepb.ProblemDetails
+	serial          string
+}
+
+// update applies f to the order if version is current.
+func (sa *mockSAOrderVersions) update(version *int64, f func() error) error {
+	sa.Lock()
+	defer sa.Unlock()
+	if version != nil && *version != sa.version {
+		return berrors.ConflictError("stale order version %d", *version)
+	}
+	if err := f(); err != nil {
+		return err
+	}
+	sa.version++
+	return nil
+}
+
+func (sa *mockSAOrderVersions) SetOrderProcessing(_ context.Context, order *corepb.Order) error {
+	return sa.update(order.Version, func() error {
+		if sa.beganProcessing {
+			return berrors.OrderNotReadyError("already processing")
+		}
+		sa.beganProcessing = true
+		return nil
+	})
+}
+
+func (sa *mockSAOrderVersions) SetOrderError(_ context.Context, order *corepb.Order) error {
+	return sa.update(order.Version, func() error {
+		sa.err = order.Error
+		return nil
+	})
+}
+
+func (sa *mockSAOrderVersions) FinalizeOrder(_ context.Context, order *corepb.Order) error {
+	return sa.update(order.Version, func() error {
+		sa.serial = *order.CertificateSerial
+		return nil
+	})
+}
+
+func (sa *mockSAOrderVersions) GetOrder(_ context.Context, req *sapb.OrderRequest) (*corepb.Order, error) {
+	sa.Lock()
+	defer sa.Unlock()
+	version := sa.version
+	beganProcessing := sa.beganProcessing
+	serial := sa.serial
+	return &corepb.Order{
+		Id:                req.Id,
+		Version:           &version,
+		BeganProcessing:   &beganProcessing,
+		Error:             sa.err,
+		CertificateSerial: &serial,
+	}, nil
+}
+
+func TestFinalizeOrderStaleVersion(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+	mockSA := &mockSAOrderVersions{}
+	ra.SA = mockSA
+
+	testKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	test.AssertNotError(t, err, "error generating test key")
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		PublicKey:          testKey.PublicKey,
+		SignatureAlgorithm: x509.SHA256WithRSA,
+		DNSNames:           []string{"not-example.com"},
+	}, testKey)
+	test.AssertNotError(t, err, "Error creating CSR")
+
+	id := int64(1)
+	readyStatus := string(core.StatusReady)
+	finalize := func() error {
+		var version int64
+		_, err := ra.finalizeOrder(ctx, &rapb.FinalizeOrderRequest{
+			Order: &corepb.Order{
+				Id:             &id,
+				RegistrationID: &Registration.ID,
+				Status:         &readyStatus,
+				Names:          []string{"not-example.com"},
+				Version:        &version,
+			},
+			Csr: csr,
+		})
+		return err
+	}
+
+	// Concurrent finalizations of the same version of the order, bypassing
+	// the RA's deduplication as if they were handled by different RAs, and
+	// whatever the outcome of the one which sets the order processing, the
+	// others are rejected without failing the order themselves.
+	const callers = 5
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() { errs <- finalize() }()
+	}
+	var conflicts int
+	for i := 0; i < callers; i++ {
+		err := <-errs
+		if berrors.Is(err, berrors.OrderNotReady) && strings.Contains(err.Error(), "modified while being finalized") {
+			conflicts++
+		}
+	}
+	test.AssertEquals(t, conflicts, callers-1)
+	test.Assert(t, mockSA.beganProcessing, "order wasn't set processing")
+	// The winner set the order processing, then either failed or finalized it,
+	// using the version it had itself incremented.
+	test.AssertEquals(t, mockSA.version, int64(2))
+	test.Assert(t, (mockSA.err != nil) != (mockSA.serial != ""), "order wasn't failed or finalized exactly once")
+}
+
+func TestFailOrderStaleVersion(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	// An order updated since it was read is still failed, using its current
+	// version.
+	mockSA := &mockSAOrderVersions{version: 3, beganProcessing: true}
+	ra.SA = mockSA
+	id := int64(1)
+	version := int64(1)
+	order := ra.failOrder(ctx, &corepb.Order{Id: &id, Version: &version}, probs.ServerInternal("oops"))
+	test.Assert(t, mockSA.err != nil, "order wasn't failed")
+	test.AssertEquals(t, mockSA.version, int64(4))
+	test.AssertEquals(t, *order.Version, int64(4))
+
+	// An order which was finished concurrently is left alone.
+	mockSA = &mockSAOrderVersions{version: 3, beganProcessing: true, serial: "1234"}
+	ra.SA = mockSA
+	version = 1
+	ra.failOrder(ctx, &corepb.Order{Id: &id, Version: &version}, probs.ServerInternal("oops"))
+	test.Assert(t, mockSA.err == nil, "finalized order was failed")
+	test.AssertEquals(t, mockSA.version, int64(3))
+}
+
 func TestIssueCertificateAuditLog(t *testing.T) {
 	_, sa, ra, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -3422,7 +3807,7 @@ func TestCTPolicyMeasurements(t *testing.T) {
 		PEM: eeCertPEM,
 	}
 
-	ctp := ctpolicy.New(&timeoutPub{}, []ctconfig.CTGroup{{}}, nil, log, metrics.NoopRegisterer)
+	ctp := ctpolicy.New(&timeoutPub{}, []ctconfig.CTGroup{{}}, nil, 0, log, metrics.NoopRegisterer, fc)
 	ra := NewRegistrationAuthorityImpl(fc,
 		log,
 		stats,
@@ -3440,11 +3825,41 @@ func TestCTPolicyMeasurements(t *testing.T) {
 
 	_, err := ra.issueCertificate(ctx, core.CertificateRequest{
 		CSR: ExampleCSR,
-	}, accountID(Registration.ID), 0)
+	}, accountID(Registration.ID), 0, "")
 	test.AssertError(t, err, "ra.issueCertificate didn't fail when CTPolicy.GetSCTs timed out")
 	test.AssertEquals(t, test.CountHistogramSamples(ra.ctpolicyResults.With(prometheus.Labels{"result": "failure"})), 1)
 }
 
+func TestOmitSCTsStillLogsPrecertificate(t *testing.T) {
+	_, ssa, _, fc, cleanup := initAuthorities(t)
+	defer cleanup()
+
+	ctp := ctpolicy.New(&timeoutPub{}, []ctconfig.CTGroup{{}}, nil, 0, log, metrics.NoopRegisterer, fc)
+	ra := NewRegistrationAuthorityImpl(fc,
+		log,
+		metrics.NoopRegisterer,
+		1, testKeyPolicy, 0, true, false, 300*24*time.Hour, 7*24*time.Hour, nil, noopCAA{}, 0, ctp, nil, nil)
+	ra.SA = ssa
+	ra.CA = &mockCAOmitSCTs{
+		mockCAFailCertForPrecert{err: fmt.Errorf("final certificate issued")},
+	}
+
+	exp := ra.clk.Now().Add(365 * 24 * time.Hour)
+	_ = createFinalizedAuthorization(t, ssa, "not-example.com", exp, "valid")
+	_ = createFinalizedAuthorization(t, ssa, "www.not-example.com", exp, "valid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Even though the final certificate won't embed them, it isn't issued
+	// until SCTs for the precertificate have been obtained.
+	_, err := ra.issueCertificate(ctx, core.CertificateRequest{
+		CSR: ExampleCSR,
+	}, accountID(Registration.ID), 0, "")
+	test.AssertError(t, err, "ra.issueCertificate didn't fail when CTPolicy.GetSCTs timed out")
+	test.Assert(t, strings.HasPrefix(err.Error(), "getting SCTs: "), fmt.Sprintf("unexpected error: %s", err))
+}
+
 func TestWildcardOverlap(t *testing.T) {
 	err := wildcardOverlap([]string{
 		"*.example.com",
@@ -3528,6 +3943,32 @@ func (ca *mockCAFailCertForPrecert) IssueCertificateForPrecertificate(
 	return core.Certificate{}, ca.err
 }
 
+// mockCAOmitSCTs is a mock CA that issues precertificates for a profile which
+// omits SCTs, and returns an error from `IssueCertificateForPrecertificate`
+// saying whether it was given any.
+type mockCAOmitSCTs struct {
+	mockCAFailCertForPrecert
+}
+
+func (ca *mockCAOmitSCTs) IssuePrecertificate(ctx context.Context, req *capb.IssueCertificateRequest) (*capb.IssuePrecertificateResponse, error) {
+	resp, err := ca.mockCAFailCertForPrecert.IssuePrecertificate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	omitSCTs := true
+	resp.OmitSCTs = &omitSCTs
+	return resp, nil
+}
+
+func (ca *mockCAOmitSCTs) IssueCertificateForPrecertificate(
+	_ context.Context,
+	req *capb.IssueCertificateForPrecertificateRequest) (core.Certificate, error) {
+	if len(req.SCTs) != 0 {
+		return core.Certificate{}, fmt.Errorf("got %d SCTs", len(req.SCTs))
+	}
+	return core.Certificate{}, ca.err
+}
+
 // TestIssueCertificateInnerErrs tests that errors from the CA caught during
 // `ra.issueCertificateInner` are propagated correctly, with the part of the
 // issuance process that failed prefixed on the error message.
@@ -3652,6 +4093,13 @@ func TestIssueCertificateInnerErrs(t *testing.T) {
 				Type:   berrors.Malformed,
 			},
 		},
+		{
+			Name: "no SCTs for a profile omitting them",
+			Mock: &mockCAOmitSCTs{
+				mockCAFailCertForPrecert{err: fmt.Errorf("got no SCTs")},
+			},
+			ExpectedErr: fmt.Errorf("issuing certificate for precertificate: got no SCTs"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3659,7 +4107,7 @@ func TestIssueCertificateInnerErrs(t *testing.T) {
 			// Mock the CA
 			ra.CA = tc.Mock
 			// Attempt issuance
-			_, err = ra.issueCertificateInner(ctx, req, accountID(Registration.ID), orderID(*order.Id), logEvent)
+			_, err = ra.issueCertificateInner(ctx, req, accountID(Registration.ID), orderID(*order.Id), "", logEvent)
 			// We expect all of the testcases to fail because all use mocked CAs that deliberately error
 			test.AssertError(t, err, "issueCertificateInner with failing mock CA did not fail")
 			// If there is an expected `error` then match the error message
@@ -3752,6 +4200,192 @@ func TestNewOrderMaxNames(t *testing.T) {
 	test.AssertEquals(t, berrors.Is(err, berrors.Malformed), true)
 }
 
+// mockSARateLimitCounts is a mock SA that returns fixed counts for the rate
+// limit count queries.
+type mockSARateLimitCounts struct {
+	mocks.StorageAuthority
+}
+
+func (m *mockSARateLimitCounts) CountOrders(_ context.Context, _ int64, _, _ time.Time) (int, error) {
+	return 3, nil
+}
+
+func (m *mockSARateLimitCounts) CountInvalidAuthorizations2(_ context.Context, req *sapb.CountInvalidAuthorizationsRequest) (*sapb.Count, error) {
+	count := int64(len(*req.Hostname))
+	return &sapb.Count{Count: &count}, nil
+}
+
+func (m *mockSARateLimitCounts) CountCertificatesByNames(_ context.Context, names []string, _, _ time.Time) ([]*sapb.CountByNames_MapElement, error) {
+	var results []*sapb.CountByNames_MapElement
+	for i, name := range names {
+		results = append(results, nameCount(name, 10*(i+1)))
+	}
+	return results, nil
+}
+
+func TestGetRateLimits(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.SA = &mockSARateLimitCounts{}
+	ra.rlPolicies = &dummyRateLimitConfig{
+		NewOrdersPerAccountPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 300,
+			Window:    cmd.ConfigDuration{Duration: 3 * time.Hour},
+		},
+		InvalidAuthorizationsPerAccountPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 5,
+			Window:    cmd.ConfigDuration{Duration: time.Hour},
+		},
+		CertificatesPerNamePolicy: ratelimit.RateLimitPolicy{
+			Threshold: 50,
+			Window:    cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+			Overrides: map[string]int{
+				"example.org": 100,
+			},
+		},
+	}
+
+	regID := int64(1)
+	resp, err := ra.GetRateLimits(context.Background(), &rapb.GetRateLimitsRequest{
+		RegistrationID: &regID,
+		Names:          []string{"www.example.com", "example.org", "WWW.example.com"},
+	})
+	test.AssertNotError(t, err, "GetRateLimits failed")
+
+	type status struct {
+		limit     string
+		key       string
+		threshold int64
+		count     int64
+		window    time.Duration
+	}
+	var got []status
+	for _, limit := range resp.Limits {
+		var key string
+		if limit.Key != nil {
+			key = *limit.Key
+		}
+		got = append(got, status{*limit.Limit, key, *limit.Threshold, *limit.Count, time.Duration(*limit.Window)})
+		test.AssertEquals(t, time.Unix(0, *limit.ResetBy).Equal(fc.Now().Add(time.Duration(*limit.Window))), true)
+	}
+	test.AssertDeepEquals(t, got, []status{
+		{"newOrdersPerAccount", "", 300, 3, 3 * time.Hour},
+		{"invalidAuthorizationsPerAccount", "example.org", 5, 11, time.Hour},
+		{"invalidAuthorizationsPerAccount", "www.example.com", 5, 15, time.Hour},
+		{"certificatesPerName", "example.com", 50, 10, 7 * 24 * time.Hour},
+		{"certificatesPerName", "example.org", 100, 20, 7 * 24 * time.Hour},
+	})
+
+	// Limits that aren't enabled shouldn't be reported.
+	ra.rlPolicies = &dummyRateLimitConfig{}
+	resp, err = ra.GetRateLimits(context.Background(), &rapb.GetRateLimitsRequest{
+		RegistrationID: &regID,
+		Names:          []string{"www.example.com"},
+	})
+	test.AssertNotError(t, err, "GetRateLimits failed")
+	test.AssertEquals(t, len(resp.Limits), 0)
+}
+
+// mockSANoOrderWrites is a mock SA that fails the test if an order or
+// authorization is created.
+type mockSANoOrderWrites struct {
+	mocks.StorageAuthority
+	t *testing.T
+}
+
+func (sa *mockSANoOrderWrites) NewOrder(_ context.Context, _ *corepb.Order) (*corepb.Order, error) {
+	sa.t.Fatal("unexpected call to NewOrder")
+	return nil, nil
+}
+
+func (sa *mockSANoOrderWrites) NewAuthorizations2(_ context.Context, _ *sapb.AddPendingAuthorizationsRequest) (*sapb.Authorization2IDs, error) {
+	sa.t.Fatal("unexpected call to NewAuthorizations2")
+	return nil, nil
+}
+
+func TestDryRunIssuance(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.SA = &mockSANoOrderWrites{StorageAuthority: *mocks.NewStorageAuthority(fc), t: t}
+	ra.maxNames = 2
+
+	testCases := []struct {
+		name            string
+		regID           int64
+		names           []string
+		expectedAllowed bool
+		expectedReasons []string
+	}{
+		{
+			name:            "valid authorization",
+			regID:           1,
+			names:           []string{"not-an-example.com"},
+			expectedAllowed: true,
+		},
+		{
+			name:            "wildcard satisfied by DNS-01 authorization",
+			regID:           1,
+			names:           []string{"*.not-an-example.com"},
+			expectedAllowed: true,
+		},
+		{
+			name:  "missing authorization",
+			regID: 1,
+			names: []string{"not-an-example.com", "unvalidated.com"},
+			expectedReasons: []string{
+				"authorizations: authorizations for these names not found or expired: unvalidated.com",
+			},
+		},
+		{
+			name:  "no authorizations for account",
+			regID: 2,
+			names: []string{"not-an-example.com"},
+			expectedReasons: []string{
+				"authorizations: authorizations for these names not found or expired: not-an-example.com",
+			},
+		},
+		{
+			name:  "policy forbids name",
+			regID: 1,
+			names: []string{"exactblacklist.letsencrypt.org"},
+			expectedReasons: []string{
+				"policy: Cannot issue for \"exactblacklist.letsencrypt.org\": The ACME server refuses to issue a certificate for this domain name, because it is forbidden by policy",
+				"authorizations: authorizations for these names not found or expired: exactblacklist.letsencrypt.org",
+			},
+		},
+		{
+			name:  "too many names",
+			regID: 1,
+			names: []string{"a.not-an-example.com", "b.not-an-example.com", "not-an-example.com"},
+			expectedReasons: []string{
+				"names: order cannot contain more than 2 DNS names",
+				"authorizations: authorizations for these names not found or expired: a.not-an-example.com, b.not-an-example.com",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := ra.DryRunIssuance(context.Background(), &rapb.DryRunIssuanceRequest{
+				RegistrationID: &tc.regID,
+				Names:          tc.names,
+			})
+			test.AssertNotError(t, err, "DryRunIssuance failed")
+			test.AssertEquals(t, *resp.Allowed, tc.expectedAllowed)
+			test.AssertDeepEquals(t, resp.Reasons, tc.expectedReasons)
+		})
+	}
+
+	regID := int64(1)
+	_, err := ra.DryRunIssuance(context.Background(), &rapb.DryRunIssuanceRequest{
+		RegistrationID: &regID,
+	})
+	test.AssertError(t, err, "DryRunIssuance didn't fail with no names")
+	test.AssertEquals(t, berrors.Is(err, berrors.Malformed), true)
+}
+
 // CSR generated by Go:
 // * Random public key
 // * CN = not-example.com
@@ -3872,3 +4506,210 @@ func TestRevocationAddBlockedKey(t *testing.T) {
 	test.Assert(t, mockSA.added.Comment != nil, "Comment is nil")
 	test.AssertEquals(t, *mockSA.added.Comment, "revoked by root")
 }
+
+// mockSABlockedDomains is a mock SA that blocks a fixed set of names.
+type mockSABlockedDomains struct {
+	mocks.StorageAuthority
+	blocked map[string]bool
+}
+
+func (m *mockSABlockedDomains) DomainsBlocked(_ context.Context, req *sapb.DomainsBlockedRequest) (*sapb.BlockedDomains, error) {
+	resp := &sapb.BlockedDomains{}
+	for _, name := range req.Domains {
+		if m.blocked[name] {
+			resp.Domains = append(resp.Domains, name)
+		}
+	}
+	return resp, nil
+}
+
+func TestNewOrderBlockedDomains(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.SA = &mockSABlockedDomains{blocked: map[string]bool{
+		"blocked.com":   true,
+		"a.blocked.com": true,
+	}}
+
+	// Without the feature the blocked domains list isn't consulted
+	err := ra.checkBlockedDomains(ctx, []string{"blocked.com"})
+	test.AssertNotError(t, err, "checkBlockedDomains failed with the feature disabled")
+
+	err = features.Set(map[string]bool{"BlockedDomainsTable": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	err = ra.checkBlockedDomains(ctx, []string{"ok.com"})
+	test.AssertNotError(t, err, "checkBlockedDomains failed for an unblocked name")
+
+	_, err = ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: &Registration.ID,
+		Names:          []string{"ok.com", "Blocked.com"},
+	})
+	test.AssertError(t, err, "NewOrder didn't fail for a blocked name")
+	test.AssertEquals(t, berrors.Is(err, berrors.RejectedIdentifier), true)
+	test.AssertEquals(t, err.Error(), fmt.Sprintf("Cannot issue for %q: %s", "blocked.com", blockedDomainDetail))
+
+	// A dry run reports the blocked name as a policy rejection
+	resp, err := ra.DryRunIssuance(ctx, &rapb.DryRunIssuanceRequest{
+		RegistrationID: &Registration.ID,
+		Names:          []string{"ok.com", "Blocked.com"},
+	})
+	test.AssertNotError(t, err, "DryRunIssuance failed")
+	test.AssertEquals(t, resp.GetAllowed(), false)
+	test.AssertEquals(t, resp.Reasons[0], fmt.Sprintf("policy: Cannot issue for %q: %s", "blocked.com", blockedDomainDetail))
+
+	err = ra.checkBlockedDomains(ctx, []string{"a.blocked.com", "blocked.com", "ok.com"})
+	test.AssertError(t, err, "checkBlockedDomains didn't fail for blocked names")
+	bErr, ok := err.(*berrors.BoulderError)
+	test.AssertEquals(t, ok, true)
+	test.AssertEquals(t, bErr.Type, berrors.RejectedIdentifier)
+	test.AssertEquals(t, len(bErr.SubErrors), 2)
+	test.AssertEquals(t, bErr.SubErrors[0].Identifier.Value, "a.blocked.com")
+	test.AssertEquals(t, bErr.SubErrors[1].Identifier.Value, "blocked.com")
+}
+
+// mockSAOrderProfiles is a mock SA that has an existing order, requested
+// under profileName, for any names and which records the last order created.
+type mockSAOrderProfiles struct {
+	mocks.StorageAuthority
+	profileName string
+	newOrder    *corepb.Order
+}
+
+func (m *mockSAOrderProfiles) GetOrderForNames(_ context.Context, req *sapb.GetOrderForNamesRequest) (*corepb.Order, error) {
+	id := int64(1)
+	order := &corepb.Order{Id: &id, RegistrationID: req.AcctID, Names: req.Names}
+	if m.profileName != "" {
+		order.CertificateProfileName = &m.profileName
+	}
+	return order, nil
+}
+
+func (m *mockSAOrderProfiles) GetAuthorizations2(_ context.Context, _ *sapb.GetAuthorizationsRequest) (*sapb.Authorizations, error) {
+	return &sapb.Authorizations{}, nil
+}
+
+func (m *mockSAOrderProfiles) CountPendingAuthorizations2(_ context.Context, _ *sapb.RegistrationID) (*sapb.Count, error) {
+	zero := int64(0)
+	return &sapb.Count{Count: &zero}, nil
+}
+
+func (m *mockSAOrderProfiles) NewAuthorizations2(_ context.Context, req *sapb.AddPendingAuthorizationsRequest) (*sapb.Authorization2IDs, error) {
+	ids := make([]int64, len(req.Authz))
+	for i := range ids {
+		ids[i] = int64(i + 2)
+	}
+	return &sapb.Authorization2IDs{Ids: ids}, nil
+}
+
+func (m *mockSAOrderProfiles) NewOrder(_ context.Context, order *corepb.Order) (*corepb.Order, error) {
+	id := int64(2)
+	order.Id = &id
+	m.newOrder = order
+	return order, nil
+}
+
+func TestNewOrderProfile(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	shortLived := "shortlived"
+	mockSA := &mockSAOrderProfiles{}
+	ra.SA = mockSA
+
+	// An existing order without a profile isn't reused for an order requesting
+	// one.
+	order, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID:         &Registration.ID,
+		Names:                  []string{"example.com"},
+		CertificateProfileName: &shortLived,
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, *order.Id, int64(2))
+	test.AssertEquals(t, order.GetCertificateProfileName(), shortLived)
+	test.AssertEquals(t, mockSA.newOrder.GetCertificateProfileName(), shortLived)
+
+	// An existing order with the same profile is reused.
+	mockSA.profileName = shortLived
+	order, err = ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID:         &Registration.ID,
+		Names:                  []string{"example.com"},
+		CertificateProfileName: &shortLived,
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, *order.Id, int64(1))
+
+	// And an existing order with a profile isn't reused for an order that
+	// doesn't request one.
+	mockSA.newOrder = nil
+	order, err = ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: &Registration.ID,
+		Names:          []string{"example.com"},
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, *order.Id, int64(2))
+	test.AssertEquals(t, mockSA.newOrder.CertificateProfileName == nil, true)
+}
+
+// mockSARateLimitOverrides is a mock SA which returns fixed rate limit
+// overrides and records the last request for them.
+type mockSARateLimitOverrides struct {
+	mocks.StorageAuthority
+	overrides []*sapb.RateLimitOverride
+	req       *sapb.GetRateLimitOverridesRequest
+}
+
+func (m *mockSARateLimitOverrides) GetRateLimitOverrides(_ context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	m.req = req
+	return &sapb.RateLimitOverrides{Overrides: m.overrides}, nil
+}
+
+func TestApplyStoredOverrides(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	regID := int64(5)
+	key := "example.com"
+	regThreshold := int64(50)
+	keyThreshold := int64(20)
+	limitName := ratelimit.CertificatesPerNameLimit
+	mockSA := &mockSARateLimitOverrides{overrides: []*sapb.RateLimitOverride{
+		{LimitName: &limitName, RegistrationID: &regID, Threshold: &regThreshold},
+		{LimitName: &limitName, Key: &key, Threshold: &keyThreshold},
+	}}
+	ra.SA = mockSA
+	policy := ratelimit.RateLimitPolicy{
+		Threshold: 2,
+		Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		Overrides: map[string]int{"example.com": 10, "example.net": 10},
+	}
+
+	// Without the feature the stored overrides aren't consulted
+	limit, err := ra.applyStoredOverrides(ctx, ratelimit.CertificatesPerNameLimit, policy, regID, "example.com")
+	test.AssertNotError(t, err, "applyStoredOverrides failed with the feature disabled")
+	test.AssertEquals(t, limit.GetThreshold("example.com", regID), 10)
+	test.Assert(t, mockSA.req == nil, "SA was asked for overrides with the feature disabled")
+
+	err = features.Set(map[string]bool{"RateLimitOverridesTable": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	limit, err = ra.applyStoredOverrides(ctx, ratelimit.CertificatesPerNameLimit, policy, regID, "example.com", "example.net")
+	test.AssertNotError(t, err, "applyStoredOverrides failed")
+	test.AssertEquals(t, *mockSA.req.LimitName, ratelimit.CertificatesPerNameLimit)
+	test.AssertEquals(t, *mockSA.req.RegistrationID, regID)
+	test.AssertDeepEquals(t, mockSA.req.Keys, []string{"example.com", "example.net"})
+	test.AssertEquals(t, limit.GetThreshold("example.net", noRegistrationID), 10)
+	test.AssertEquals(t, limit.GetThreshold("example.com", noRegistrationID), 20)
+	test.AssertEquals(t, limit.GetThreshold("example.org", regID), 50)
+	// The policy file's overrides are left alone
+	test.AssertEquals(t, policy.GetThreshold("example.com", noRegistrationID), 10)
+
+	// Without a registration or keys there's nothing to look up
+	mockSA.req = nil
+	_, err = ra.applyStoredOverrides(ctx, ratelimit.RegistrationsPerIPLimit, policy, noRegistrationID)
+	test.AssertNotError(t, err, "applyStoredOverrides failed")
+	test.Assert(t, mockSA.req == nil, "SA was asked for every override")
+}
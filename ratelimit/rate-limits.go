@@ -9,6 +9,35 @@ import (
 	"github.com/letsencrypt/boulder/cmd"
 )
 
+// Names of the rate limits, matching their keys in the policy file. Rate limit
+// overrides stored by the SA refer to the limit they apply to by these names.
+const (
+	CertificatesPerNameLimit             = "certificatesPerName"
+	RegistrationsPerIPLimit              = "registrationsPerIP"
+	RegistrationsPerIPRangeLimit         = "registrationsPerIPRange"
+	PendingAuthorizationsPerAccountLimit = "pendingAuthorizationsPerAccount"
+	InvalidAuthorizationsPerAccountLimit = "invalidAuthorizationsPerAccount"
+	PendingOrdersPerAccountLimit         = "pendingOrdersPerAccount"
+	NewOrdersPerAccountLimit             = "newOrdersPerAccount"
+	CertificatesPerFQDNSetLimit          = "certificatesPerFQDNSet"
+)
+
+var limitNames = map[string]bool{
+	CertificatesPerNameLimit:             true,
+	RegistrationsPerIPLimit:              true,
+	RegistrationsPerIPRangeLimit:         true,
+	PendingAuthorizationsPerAccountLimit: true,
+	InvalidAuthorizationsPerAccountLimit: true,
+	PendingOrdersPerAccountLimit:         true,
+	NewOrdersPerAccountLimit:             true,
+	CertificatesPerFQDNSetLimit:          true,
+}
+
+// IsLimitName returns true if name is the name of a rate limit.
+func IsLimitName(name string) bool {
+	return limitNames[name]
+}
+
 // Limits is defined to allow mock implementations be provided during unit
 // testing
 type Limits interface {
@@ -213,6 +242,34 @@ func (rlp *RateLimitPolicy) GetThreshold(key string, regID int64) int {
 	return rlp.Threshold
 }
 
+// WithOverrides returns a copy of the RateLimitPolicy with keyOverrides and
+// regOverrides added to its Overrides and RegistrationOverrides, replacing any
+// of the policy's own overrides for the same keys and registrations. The
+// policy itself isn't modified.
+func (rlp RateLimitPolicy) WithOverrides(keyOverrides map[string]int, regOverrides map[int64]int) RateLimitPolicy {
+	if len(keyOverrides) > 0 {
+		overrides := make(map[string]int, len(rlp.Overrides)+len(keyOverrides))
+		for k, v := range rlp.Overrides {
+			overrides[k] = v
+		}
+		for k, v := range keyOverrides {
+			overrides[k] = v
+		}
+		rlp.Overrides = overrides
+	}
+	if len(regOverrides) > 0 {
+		overrides := make(map[int64]int, len(rlp.RegistrationOverrides)+len(regOverrides))
+		for k, v := range rlp.RegistrationOverrides {
+			overrides[k] = v
+		}
+		for k, v := range regOverrides {
+			overrides[k] = v
+		}
+		rlp.RegistrationOverrides = overrides
+	}
+	return rlp
+}
+
 // WindowBegin returns the time that a RateLimitPolicy's window begins, given a
 // particular end time (typically the current time).
 func (rlp *RateLimitPolicy) WindowBegin(windowEnd time.Time) time.Time {
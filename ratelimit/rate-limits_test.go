@@ -87,6 +87,32 @@ func TestGetThreshold(t *testing.T) {
 	}
 }
 
+func TestWithOverrides(t *testing.T) {
+	policy := RateLimitPolicy{
+		Threshold:             1,
+		Overrides:             map[string]int{"key": 2, "other": 4},
+		RegistrationOverrides: map[int64]int{101: 3},
+	}
+	overridden := policy.WithOverrides(map[string]int{"key": 20, "new": 30}, map[int64]int{102: 40})
+	test.AssertEquals(t, overridden.GetThreshold("key", 11), 20)
+	test.AssertEquals(t, overridden.GetThreshold("new", 11), 30)
+	test.AssertEquals(t, overridden.GetThreshold("other", 11), 4)
+	test.AssertEquals(t, overridden.GetThreshold("foo", 101), 3)
+	test.AssertEquals(t, overridden.GetThreshold("foo", 102), 40)
+	test.AssertEquals(t, overridden.GetThreshold("foo", 11), 1)
+
+	// The original policy is unchanged.
+	test.AssertEquals(t, policy.GetThreshold("key", 11), 2)
+	test.AssertEquals(t, policy.GetThreshold("new", 11), 1)
+	test.AssertEquals(t, policy.GetThreshold("foo", 102), 1)
+}
+
+func TestIsLimitName(t *testing.T) {
+	test.Assert(t, IsLimitName(CertificatesPerNameLimit), "certificatesPerName isn't a limit name")
+	test.Assert(t, IsLimitName("newOrdersPerAccount"), "newOrdersPerAccount isn't a limit name")
+	test.Assert(t, !IsLimitName("certificatesPerDay"), "certificatesPerDay is a limit name")
+}
+
 func TestWindowBegin(t *testing.T) {
 	policy := RateLimitPolicy{
 		Window: cmd.ConfigDuration{Duration: 24 * time.Hour},
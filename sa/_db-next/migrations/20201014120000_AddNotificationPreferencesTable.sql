@@ -0,0 +1,15 @@
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `notificationPreferences` (
+    `registrationID` BIGINT(20) NOT NULL,
+    `disabled` BOOLEAN NOT NULL DEFAULT false,
+    `nagTimes` VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (`registrationID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE `notificationPreferences`;
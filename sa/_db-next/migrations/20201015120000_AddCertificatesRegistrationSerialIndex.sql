@@ -0,0 +1,10 @@
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+ALTER TABLE `certificates` ADD INDEX `regId_serial_certificates_idx` (`registrationID`, `serial`);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+ALTER TABLE `certificates` DROP INDEX `regId_serial_certificates_idx`;
@@ -0,0 +1,16 @@
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `deactivatedRegistrations` (
+    `registrationID` BIGINT(20) NOT NULL,
+    `deactivatedAt` DATETIME NOT NULL,
+    `reason` VARCHAR(255) NOT NULL,
+    PRIMARY KEY (`registrationID`),
+    KEY `deactivatedAt_idx` (`deactivatedAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE `deactivatedRegistrations`;
@@ -0,0 +1,16 @@
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `blockedDomains` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `domain` VARCHAR(255) NOT NULL UNIQUE,
+    `added` DATETIME NOT NULL,
+    `comment` VARCHAR(255) DEFAULT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE `blockedDomains`;
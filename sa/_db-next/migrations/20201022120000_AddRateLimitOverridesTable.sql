@@ -0,0 +1,22 @@
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `rateLimitOverrides` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `limitName` VARCHAR(255) NOT NULL,
+    `registrationID` BIGINT(20) NOT NULL DEFAULT 0,
+    `overrideKey` VARCHAR(255) NOT NULL DEFAULT '',
+    `threshold` INT(11) NOT NULL,
+    `expires` DATETIME NOT NULL,
+    `added` DATETIME NOT NULL,
+    `comment` VARCHAR(255) DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `limitName_registrationID_overrideKey` (`limitName`, `registrationID`, `overrideKey`),
+    KEY `expires_idx` (`expires`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE `rateLimitOverrides`;
@@ -140,4 +140,9 @@ func initTables(dbMap *gorp.DbMap) {
 	dbMap.AddTableWithName(recordedSerialModel{}, "serials").SetKeys(true, "ID")
 	dbMap.AddTableWithName(precertificateModel{}, "precertificates").SetKeys(true, "ID")
 	dbMap.AddTableWithName(keyHashModel{}, "keyHashToSerial").SetKeys(true, "ID")
+	dbMap.AddTableWithName(certificateProfileModel{}, "certificateProfiles").SetKeys(false, "Serial")
+	dbMap.AddTableWithName(certificateIssuerModel{}, "certificateIssuers").SetKeys(false, "Serial")
+	dbMap.AddTableWithName(deactivatedRegistrationModel{}, "deactivatedRegistrations").SetKeys(false, "RegistrationID")
+	dbMap.AddTableWithName(orderProfileModel{}, "orderProfiles").SetKeys(false, "OrderID")
+	dbMap.AddTableWithName(rateLimitOverrideModel{}, "rateLimitOverrides").SetKeys(true, "ID")
 }
@@ -618,6 +618,52 @@ type keyHashModel struct {
 	CertSerial   string
 }
 
+// certificateProfileModel records the issuance profile a certificate was
+// issued under. It's keyed by serial so that a precertificate and its final
+// certificate share one row.
+type certificateProfileModel struct {
+	Serial      string
+	ProfileName string
+}
+
+// certificateIssuerModel records the SHA-256 fingerprint of the issuer
+// certificate a certificate was signed by. Like certificateProfileModel it's
+// keyed by serial.
+type certificateIssuerModel struct {
+	Serial            string
+	IssuerFingerprint string
+}
+
+// orderProfileModel records the issuance profile requested for an order.
+type orderProfileModel struct {
+	OrderID     int64
+	ProfileName string
+}
+
+// deactivatedRegistrationModel records when and why a registration was
+// deactivated.
+type deactivatedRegistrationModel struct {
+	RegistrationID int64
+	DeactivatedAt  time.Time
+	Reason         string
+}
+
+// rateLimitOverrideModel overrides the threshold of a rate limit, until it
+// expires, for either a registration or a key such as a registered domain. The
+// other of RegistrationID and OverrideKey is left as its zero value, so that
+// the unique key over (limitName, registrationID, overrideKey) holds one
+// override per account or key.
+type rateLimitOverrideModel struct {
+	ID             int64
+	LimitName      string
+	RegistrationID int64
+	OverrideKey    string
+	Threshold      int64
+	Expires        time.Time
+	Added          time.Time
+	Comment        *string
+}
+
 var stringToSourceInt = map[string]int{
 	"API":           1,
 	"admin-revoker": 2,
@@ -116,6 +116,24 @@ func (ssa *SQLStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb
 				return nil, err
 			}
 		}
+		if features.Enabled(features.StoreCertificateProfiles) && req.ProfileName != nil && *req.ProfileName != "" {
+			err := txWithCtx.Insert(&certificateProfileModel{
+				Serial:      serialHex,
+				ProfileName: *req.ProfileName,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if features.Enabled(features.StoreIssuerFingerprints) && req.IssuerFingerprint != nil && *req.IssuerFingerprint != "" {
+			err := txWithCtx.Insert(&certificateIssuerModel{
+				Serial:            serialHex,
+				IssuerFingerprint: *req.IssuerFingerprint,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		return nil, nil
 	})
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -131,3 +133,101 @@ func TestAddPrecertificateKeyHash(t *testing.T) {
 	spkiHash := sha256.Sum256(testCert.RawSubjectPublicKeyInfo)
 	test.Assert(t, bytes.Compare(keyHashes[0].KeyHash, spkiHash[:]) == 0, "spki hash mismatch")
 }
+
+func TestAddPrecertificateProfile(t *testing.T) {
+	if !strings.HasSuffix(os.Getenv("BOULDER_CONFIG_DIR"), "config-next") {
+		return
+	}
+
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+	reg := satest.CreateWorkingRegistration(t, sa)
+	err := features.Set(map[string]bool{"StoreCertificateProfiles": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	serial, testCert := test.ThrowAwayCert(t, 1)
+	issued := testCert.NotBefore.UnixNano()
+	profileName := "shortlived"
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:         testCert.Raw,
+		RegID:       &reg.ID,
+		Ocsp:        []byte{1, 2, 3},
+		Issued:      &issued,
+		ProfileName: &profileName,
+	})
+	test.AssertNotError(t, err, "failed to add precert")
+
+	profile, err := sa.GetCertificateProfile(ctx, &sapb.Serial{Serial: &serial})
+	test.AssertNotError(t, err, "GetCertificateProfile failed")
+	test.AssertEquals(t, *profile.Name, profileName)
+
+	// A precertificate stored without a profile, like those issued before
+	// profiles were stored, has an empty profile name rather than an error.
+	unknownSerial, unknownCert := test.ThrowAwayCert(t, 1)
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:    unknownCert.Raw,
+		RegID:  &reg.ID,
+		Ocsp:   []byte{1, 2, 3},
+		Issued: &issued,
+	})
+	test.AssertNotError(t, err, "failed to add precert")
+
+	profile, err = sa.GetCertificateProfile(ctx, &sapb.Serial{Serial: &unknownSerial})
+	test.AssertNotError(t, err, "GetCertificateProfile failed")
+	test.AssertEquals(t, *profile.Name, "")
+
+	badSerial := "not-a-serial"
+	_, err = sa.GetCertificateProfile(ctx, &sapb.Serial{Serial: &badSerial})
+	test.AssertError(t, err, "GetCertificateProfile accepted an invalid serial")
+	test.Assert(t, berrors.Is(err, berrors.Malformed), "GetCertificateProfile didn't return Malformed")
+}
+
+func TestAddPrecertificateIssuerFingerprint(t *testing.T) {
+	if !strings.HasSuffix(os.Getenv("BOULDER_CONFIG_DIR"), "config-next") {
+		return
+	}
+
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+	reg := satest.CreateWorkingRegistration(t, sa)
+	err := features.Set(map[string]bool{"StoreIssuerFingerprints": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	serial, testCert := test.ThrowAwayCert(t, 1)
+	issued := testCert.NotBefore.UnixNano()
+	fingerprint := strings.Repeat("ab", sha256.Size)
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:               testCert.Raw,
+		RegID:             &reg.ID,
+		Ocsp:              []byte{1, 2, 3},
+		Issued:            &issued,
+		IssuerFingerprint: &fingerprint,
+	})
+	test.AssertNotError(t, err, "failed to add precert")
+
+	issuer, err := sa.GetCertificateIssuer(ctx, &sapb.Serial{Serial: &serial})
+	test.AssertNotError(t, err, "GetCertificateIssuer failed")
+	test.AssertEquals(t, *issuer.Fingerprint, fingerprint)
+
+	// A precertificate stored without a fingerprint, like those issued before
+	// fingerprints were stored, has an empty one rather than an error.
+	unknownSerial, unknownCert := test.ThrowAwayCert(t, 1)
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:    unknownCert.Raw,
+		RegID:  &reg.ID,
+		Ocsp:   []byte{1, 2, 3},
+		Issued: &issued,
+	})
+	test.AssertNotError(t, err, "failed to add precert")
+
+	issuer, err = sa.GetCertificateIssuer(ctx, &sapb.Serial{Serial: &unknownSerial})
+	test.AssertNotError(t, err, "GetCertificateIssuer failed")
+	test.AssertEquals(t, *issuer.Fingerprint, "")
+
+	badSerial := "not-a-serial"
+	_, err = sa.GetCertificateIssuer(ctx, &sapb.Serial{Serial: &badSerial})
+	test.AssertError(t, err, "GetCertificateIssuer accepted an invalid serial")
+	test.Assert(t, berrors.Is(err, berrors.Malformed), "GetCertificateIssuer didn't return Malformed")
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -25,6 +26,7 @@ import (
 	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/identifier"
 	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/ratelimit"
 	"github.com/letsencrypt/boulder/revocation"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 )
@@ -827,15 +829,46 @@ func (ssa *SQLStorageAuthority) PreviousCertificateExists(
 	return notExists, nil
 }
 
-// DeactivateRegistration deactivates a currently valid registration
-func (ssa *SQLStorageAuthority) DeactivateRegistration(ctx context.Context, id int64) error {
-	_, err := ssa.dbMap.WithContext(ctx).Exec(
-		"UPDATE registrations SET status = ? WHERE status = ? AND id = ?",
-		string(core.StatusDeactivated),
-		string(core.StatusValid),
-		id,
-	)
-	return err
+// DeactivateRegistration deactivates a currently valid registration. The
+// registration's row is kept, and if the StoreDeactivationInfo feature is
+// enabled the time of and reason for the deactivation are recorded in the
+// deactivatedRegistrations table.
+func (ssa *SQLStorageAuthority) DeactivateRegistration(ctx context.Context, id int64, reason string) error {
+	if !features.Enabled(features.StoreDeactivationInfo) {
+		_, err := ssa.dbMap.WithContext(ctx).Exec(
+			"UPDATE registrations SET status = ? WHERE status = ? AND id = ?",
+			string(core.StatusDeactivated),
+			string(core.StatusValid),
+			id,
+		)
+		return err
+	}
+	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(txWithCtx db.Executor) (interface{}, error) {
+		result, err := txWithCtx.Exec(
+			"UPDATE registrations SET status = ? WHERE status = ? AND id = ?",
+			string(core.StatusDeactivated),
+			string(core.StatusValid),
+			id,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rows == 0 {
+			// The registration doesn't exist or was already deactivated, so
+			// there's no new deactivation to record.
+			return nil, nil
+		}
+		return nil, txWithCtx.Insert(&deactivatedRegistrationModel{
+			RegistrationID: id,
+			DeactivatedAt:  ssa.clk.Now(),
+			Reason:         reason,
+		})
+	})
+	return overallError
 }
 
 // DeactivateAuthorization2 deactivates a currently valid or pending authorization.
@@ -858,6 +891,12 @@ func (ssa *SQLStorageAuthority) DeactivateAuthorization2(ctx context.Context, re
 
 // NewOrder adds a new v2 style order to the database
 func (ssa *SQLStorageAuthority) NewOrder(ctx context.Context, req *corepb.Order) (*corepb.Order, error) {
+	profileName := req.GetCertificateProfileName()
+	if profileName != "" && !features.Enabled(features.StoreOrderProfiles) {
+		// The profile couldn't be looked up when the order is finalized, so
+		// the certificate would silently be issued with the default profile.
+		return nil, berrors.InternalServerError("order requests profile %q, but order profiles aren't stored", profileName)
+	}
 	order := &orderModel{
 		RegistrationID: *req.RegistrationID,
 		Expires:        time.Unix(0, *req.Expires),
@@ -895,6 +934,16 @@ func (ssa *SQLStorageAuthority) NewOrder(ctx context.Context, req *corepb.Order)
 			return nil, err
 		}
 
+		if profileName != "" {
+			err := txWithCtx.Insert(&orderProfileModel{
+				OrderID:     order.ID,
+				ProfileName: profileName,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if features.Enabled(features.FasterNewOrdersRateLimit) {
 			// Increment the order creation count
 			if err := addNewOrdersRateLimit(ctx, txWithCtx, *req.RegistrationID, ssa.clk.Now().Truncate(time.Hour)); err != nil {
@@ -920,6 +969,10 @@ func (ssa *SQLStorageAuthority) NewOrder(ctx context.Context, req *corepb.Order)
 	// A new order is never processing because it can't have been finalized yet
 	processingStatus := false
 	outputOrder.BeganProcessing = &processingStatus
+	if features.Enabled(features.OrderVersions) {
+		var version int64
+		outputOrder.Version = &version
+	}
 
 	// Calculate the order status before returning it. Since it may have reused all
 	// valid authorizations the order may be "born" in a ready status.
@@ -936,20 +989,22 @@ func (ssa *SQLStorageAuthority) NewOrder(ctx context.Context, req *corepb.Order)
 // corresponding Order table row in the DB.
 func (ssa *SQLStorageAuthority) SetOrderProcessing(ctx context.Context, req *corepb.Order) error {
 	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(txWithCtx db.Executor) (interface{}, error) {
-		result, err := txWithCtx.Exec(`
+		set, where, versionArgs := orderVersionSQL(req)
+		result, err := txWithCtx.Exec(fmt.Sprintf(`
 		UPDATE orders
-		SET beganProcessing = ?
+		SET beganProcessing = ?%s
 		WHERE id = ?
-		AND beganProcessing = ?`,
-			true,
-			*req.Id,
-			false)
+		AND beganProcessing = ?%s`, set, where),
+			append([]interface{}{true, *req.Id, false}, versionArgs...)...)
 		if err != nil {
 			return nil, berrors.InternalServerError("error updating order to beganProcessing status")
 		}
 
 		n, err := result.RowsAffected()
 		if err != nil || n == 0 {
+			if err := checkOrderVersion(txWithCtx, req); err != nil {
+				return nil, err
+			}
 			return nil, berrors.OrderNotReadyError("Order was already processing. This may indicate your client finalized the same order multiple times, possibly due to a client bug.")
 		}
 
@@ -966,18 +1021,21 @@ func (ssa *SQLStorageAuthority) SetOrderError(ctx context.Context, order *corepb
 			return nil, err
 		}
 
-		result, err := txWithCtx.Exec(`
+		set, where, versionArgs := orderVersionSQL(order)
+		result, err := txWithCtx.Exec(fmt.Sprintf(`
 		UPDATE orders
-		SET error = ?
-		WHERE id = ?`,
-			om.Error,
-			om.ID)
+		SET error = ?%s
+		WHERE id = ?%s`, set, where),
+			append([]interface{}{om.Error, om.ID}, versionArgs...)...)
 		if err != nil {
 			return nil, berrors.InternalServerError("error updating order error field")
 		}
 
 		n, err := result.RowsAffected()
 		if err != nil || n == 0 {
+			if err := checkOrderVersion(txWithCtx, order); err != nil {
+				return nil, err
+			}
 			return nil, berrors.InternalServerError("no order updated with new error field")
 		}
 
@@ -992,19 +1050,22 @@ func (ssa *SQLStorageAuthority) SetOrderError(ctx context.Context, order *corepb
 // this is not a generic update RPC).
 func (ssa *SQLStorageAuthority) FinalizeOrder(ctx context.Context, req *corepb.Order) error {
 	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(txWithCtx db.Executor) (interface{}, error) {
-		result, err := txWithCtx.Exec(`
+		set, where, versionArgs := orderVersionSQL(req)
+		result, err := txWithCtx.Exec(fmt.Sprintf(`
 		UPDATE orders
-		SET certificateSerial = ?
+		SET certificateSerial = ?%s
 		WHERE id = ? AND
-		beganProcessing = true`,
-			*req.CertificateSerial,
-			*req.Id)
+		beganProcessing = true%s`, set, where),
+			append([]interface{}{*req.CertificateSerial, *req.Id}, versionArgs...)...)
 		if err != nil {
 			return nil, berrors.InternalServerError("error updating order for finalization")
 		}
 
 		n, err := result.RowsAffected()
 		if err != nil || n == 0 {
+			if err := checkOrderVersion(txWithCtx, req); err != nil {
+				return nil, err
+			}
 			return nil, berrors.InternalServerError("no order updated for finalization")
 		}
 
@@ -1019,6 +1080,43 @@ func (ssa *SQLStorageAuthority) FinalizeOrder(ctx context.Context, req *corepb.O
 	return overallError
 }
 
+// orderVersionSQL returns the SET and WHERE clause fragments, and the WHERE
+// clause's arguments, with which UPDATEs of an order's row maintain its
+// version when the OrderVersions feature is enabled. The version is always
+// incremented, but only checked against the order's if it has one, since
+// orders read before the feature was enabled don't.
+func orderVersionSQL(order *corepb.Order) (string, string, []interface{}) {
+	if !features.Enabled(features.OrderVersions) {
+		return "", "", nil
+	}
+	if order.Version == nil {
+		return ", version = version + 1", "", nil
+	}
+	return ", version = version + 1", " AND version = ?", []interface{}{*order.Version}
+}
+
+// checkOrderVersion returns a Conflict error if the OrderVersions feature is
+// enabled and the order's version is stale, explaining why an UPDATE made
+// with orderVersionSQL didn't change its row.
+func checkOrderVersion(tx db.Executor, order *corepb.Order) error {
+	if !features.Enabled(features.OrderVersions) || order.Version == nil {
+		return nil
+	}
+	var version int64
+	err := tx.SelectOne(&version, "SELECT version FROM orders WHERE id = ?", *order.Id)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return nil
+		}
+		return err
+	}
+	if version != *order.Version {
+		return berrors.ConflictError("order %d was modified concurrently (version %d, expected %d)",
+			*order.Id, version, *order.Version)
+	}
+	return nil
+}
+
 // authzForOrder retrieves the authorization IDs for an order. It returns these
 // IDs in two slices: one for v1 style authorizations, and another for
 // v2 style authorizations.
@@ -1050,23 +1148,29 @@ func (ssa *SQLStorageAuthority) namesForOrder(ctx context.Context, orderID int64
 
 // GetOrder is used to retrieve an already existing order object
 func (ssa *SQLStorageAuthority) GetOrder(ctx context.Context, req *sapb.OrderRequest) (*corepb.Order, error) {
-	omObj, err := ssa.dbMap.WithContext(ctx).Get(orderModel{}, *req.Id)
+	return ssa.getOrder(ctx, *req.Id, false)
+}
+
+// getOrder retrieves the order with the given ID. Expired orders are only
+// returned if includeExpired is true.
+func (ssa *SQLStorageAuthority) getOrder(ctx context.Context, id int64, includeExpired bool) (*corepb.Order, error) {
+	omObj, err := ssa.dbMap.WithContext(ctx).Get(orderModel{}, id)
 	if err != nil {
 		if db.IsNoRows(err) {
-			return nil, berrors.NotFoundError("no order found for ID %d", *req.Id)
+			return nil, berrors.NotFoundError("no order found for ID %d", id)
 		}
 		return nil, err
 	}
 	if omObj == nil {
-		return nil, berrors.NotFoundError("no order found for ID %d", *req.Id)
+		return nil, berrors.NotFoundError("no order found for ID %d", id)
 	}
 	order, err := modelToOrder(omObj.(*orderModel))
 	if err != nil {
 		return nil, err
 	}
 	orderExp := time.Unix(0, *order.Expires)
-	if orderExp.Before(ssa.clk.Now()) {
-		return nil, berrors.NotFoundError("no order found for ID %d", *req.Id)
+	if !includeExpired && orderExp.Before(ssa.clk.Now()) {
+		return nil, berrors.NotFoundError("no order found for ID %d", id)
 	}
 
 	v2AuthzIDs, err := ssa.authzForOrder(ctx, *order.Id)
@@ -1088,6 +1192,34 @@ func (ssa *SQLStorageAuthority) GetOrder(ctx context.Context, req *sapb.OrderReq
 	}
 	order.Names = reversedNames
 
+	if features.Enabled(features.OrderVersions) {
+		var version int64
+		err := ssa.dbMap.WithContext(ctx).SelectOne(
+			&version,
+			"SELECT version FROM orders WHERE id = ?",
+			*order.Id,
+		)
+		if err != nil {
+			return nil, err
+		}
+		order.Version = &version
+	}
+
+	if features.Enabled(features.StoreOrderProfiles) {
+		var profileName string
+		err := ssa.dbMap.WithContext(ctx).SelectOne(
+			&profileName,
+			"SELECT profileName FROM orderProfiles WHERE orderID = ?",
+			*order.Id,
+		)
+		if err != nil && !db.IsNoRows(err) {
+			return nil, err
+		}
+		if profileName != "" {
+			order.CertificateProfileName = &profileName
+		}
+	}
+
 	// Calculate the status for the order
 	status, err := ssa.statusForOrder(ctx, order)
 	if err != nil {
@@ -1710,7 +1842,9 @@ func (ssa *SQLStorageAuthority) CountInvalidAuthorizations2(ctx context.Context,
 // GetValidAuthorizations2 returns the latest authorization for all
 // domain names that the account has authorizations for. This method is
 // intended to deprecate GetValidAuthorizations. This method only supports
-// DNS identifier types.
+// DNS identifier types. All of the requested names are looked up with a single
+// query, and names without an unexpired valid authorization are omitted from
+// the result rather than returned as an error.
 func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error) {
 	var authzModels []authzModel
 	params := []interface{}{
@@ -1839,3 +1973,957 @@ func (ssa *SQLStorageAuthority) KeyBlocked(ctx context.Context, req *sapb.KeyBlo
 	exists = true
 	return &sapb.Exists{Exists: &exists}, nil
 }
+
+// normalizeBlockedDomain lowercases a blockedDomains entry and strips any
+// trailing dot, returning an error if it isn't a domain name or a wildcard
+// whose only "*" is the leftmost label.
+func normalizeBlockedDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	base := strings.TrimPrefix(domain, "*.")
+	if base == "" || strings.ContainsAny(base, "* ") {
+		return "", berrors.MalformedError("invalid blocked domain %q", domain)
+	}
+	return domain, nil
+}
+
+// blockedDomainCandidates returns the blockedDomains entries which would block
+// name: the name itself and each of its parent domains, which block their
+// subdomains, and wildcards of the parent domains, which block only
+// subdomains. A wildcard name is blocked by the same entries as its base
+// domain plus a wildcard of the base domain itself, since it covers that
+// domain's subdomains.
+func blockedDomainCandidates(name string) []string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	var candidates []string
+	if strings.HasPrefix(name, "*.") {
+		name = strings.TrimPrefix(name, "*.")
+		candidates = append(candidates, "*."+name)
+	}
+	labels := strings.Split(name, ".")
+	for i := range labels {
+		domain := strings.Join(labels[i:], ".")
+		candidates = append(candidates, domain)
+		if i > 0 {
+			candidates = append(candidates, "*."+domain)
+		}
+	}
+	return candidates
+}
+
+// AddBlockedDomain adds a domain, or a wildcard, to the blockedDomains table.
+// Adding an entry that's already present isn't an error.
+func (ssa *SQLStorageAuthority) AddBlockedDomain(ctx context.Context, req *sapb.AddBlockedDomainRequest) (*corepb.Empty, error) {
+	if req == nil || req.Domain == nil || req.Added == nil {
+		return nil, errIncompleteRequest
+	}
+	domain, err := normalizeBlockedDomain(*req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	_, err = ssa.dbMap.WithContext(ctx).Exec(
+		"INSERT INTO blockedDomains (domain, added, comment) VALUES (?, ?, ?)",
+		domain,
+		time.Unix(0, *req.Added),
+		req.Comment,
+	)
+	if err != nil && !db.IsDuplicate(err) {
+		return nil, err
+	}
+	return &corepb.Empty{}, nil
+}
+
+// RemoveBlockedDomain removes an entry from the blockedDomains table. A
+// NotFound error is returned if there is no such entry.
+func (ssa *SQLStorageAuthority) RemoveBlockedDomain(ctx context.Context, req *sapb.RemoveBlockedDomainRequest) (*corepb.Empty, error) {
+	if req == nil || req.Domain == nil {
+		return nil, errIncompleteRequest
+	}
+	domain, err := normalizeBlockedDomain(*req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	result, err := ssa.dbMap.WithContext(ctx).Exec("DELETE FROM blockedDomains WHERE domain = ?", domain)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, berrors.NotFoundError("no blocked domain entry for %q", domain)
+	}
+	return &corepb.Empty{}, nil
+}
+
+// DomainsBlocked returns the requested domains which are blocked by an entry
+// in the blockedDomains table, in the order they were requested.
+func (ssa *SQLStorageAuthority) DomainsBlocked(ctx context.Context, req *sapb.DomainsBlockedRequest) (*sapb.BlockedDomains, error) {
+	if req == nil {
+		return nil, errIncompleteRequest
+	}
+	resp := &sapb.BlockedDomains{}
+	if len(req.Domains) == 0 {
+		return resp, nil
+	}
+	candidates := make(map[string][]string, len(req.Domains))
+	var params []interface{}
+	var qmarks []string
+	for _, domain := range req.Domains {
+		candidates[domain] = blockedDomainCandidates(domain)
+		for _, candidate := range candidates[domain] {
+			params = append(params, candidate)
+			qmarks = append(qmarks, "?")
+		}
+	}
+	var entries []string
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&entries,
+		"SELECT domain FROM blockedDomains WHERE domain IN ("+strings.Join(qmarks, ",")+")",
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return resp, nil
+	}
+	blocked := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		blocked[entry] = true
+	}
+	for _, domain := range req.Domains {
+		for _, candidate := range candidates[domain] {
+			if blocked[candidate] {
+				resp.Domains = append(resp.Domains, domain)
+				break
+			}
+		}
+	}
+	return resp, nil
+}
+
+// rateLimitOverrideTarget returns the registrationID and overrideKey columns
+// identifying the account or key of a rate limit override, returning an error
+// unless exactly one of registrationID and key is set.
+func rateLimitOverrideTarget(registrationID *int64, key *string) (int64, string, error) {
+	var regID int64
+	var overrideKey string
+	if registrationID != nil {
+		regID = *registrationID
+	}
+	if key != nil {
+		overrideKey = *key
+	}
+	if (regID == 0) == (overrideKey == "") {
+		return 0, "", berrors.MalformedError("exactly one of registration ID and key must be set")
+	}
+	return regID, overrideKey, nil
+}
+
+// AddRateLimitOverride adds an override of a rate limit's threshold for a
+// registration or a key, which applies until it expires. An existing override
+// of the same limit for the same registration or key is replaced.
+func (ssa *SQLStorageAuthority) AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*corepb.Empty, error) {
+	if req == nil || req.LimitName == nil || req.Threshold == nil || req.Expires == nil || req.Added == nil {
+		return nil, errIncompleteRequest
+	}
+	if !ratelimit.IsLimitName(*req.LimitName) {
+		return nil, berrors.MalformedError("unknown rate limit %q", *req.LimitName)
+	}
+	regID, key, err := rateLimitOverrideTarget(req.RegistrationID, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if *req.Threshold < 0 {
+		return nil, berrors.MalformedError("rate limit override threshold must not be negative, got %d", *req.Threshold)
+	}
+	if *req.Expires <= *req.Added {
+		return nil, berrors.MalformedError("rate limit override must expire after it's added")
+	}
+	_, err = ssa.dbMap.WithContext(ctx).Exec(
+		`INSERT INTO rateLimitOverrides
+		(limitName, registrationID, overrideKey, threshold, expires, added, comment)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+		threshold = VALUES(threshold), expires = VALUES(expires), added = VALUES(added), comment = VALUES(comment)`,
+		*req.LimitName,
+		regID,
+		key,
+		*req.Threshold,
+		time.Unix(0, *req.Expires),
+		time.Unix(0, *req.Added),
+		req.Comment,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &corepb.Empty{}, nil
+}
+
+// RemoveRateLimitOverride removes the override of a rate limit for a
+// registration or a key. A NotFound error is returned if there is no such
+// override.
+func (ssa *SQLStorageAuthority) RemoveRateLimitOverride(ctx context.Context, req *sapb.RemoveRateLimitOverrideRequest) (*corepb.Empty, error) {
+	if req == nil || req.LimitName == nil {
+		return nil, errIncompleteRequest
+	}
+	regID, key, err := rateLimitOverrideTarget(req.RegistrationID, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	result, err := ssa.dbMap.WithContext(ctx).Exec(
+		"DELETE FROM rateLimitOverrides WHERE limitName = ? AND registrationID = ? AND overrideKey = ?",
+		*req.LimitName,
+		regID,
+		key,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, berrors.NotFoundError("no %q rate limit override for registration ID %d or key %q", *req.LimitName, regID, key)
+	}
+	return &corepb.Empty{}, nil
+}
+
+// GetRateLimitOverrides returns the rate limit overrides which haven't expired
+// at req.Now, ordered by limit name. If req.LimitName is set only overrides of
+// that limit are returned, and if req.RegistrationID or req.Keys are set only
+// the overrides for that registration or those keys are returned.
+func (ssa *SQLStorageAuthority) GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	if req == nil || req.Now == nil {
+		return nil, errIncompleteRequest
+	}
+	query := `SELECT id, limitName, registrationID, overrideKey, threshold, expires, added, comment
+		FROM rateLimitOverrides WHERE expires > ?`
+	params := []interface{}{time.Unix(0, *req.Now)}
+	if req.LimitName != nil && *req.LimitName != "" {
+		query += " AND limitName = ?"
+		params = append(params, *req.LimitName)
+	}
+	var targets []string
+	if req.RegistrationID != nil && *req.RegistrationID != 0 {
+		targets = append(targets, "registrationID = ?")
+		params = append(params, *req.RegistrationID)
+	}
+	if len(req.Keys) > 0 {
+		qmarks := make([]string, len(req.Keys))
+		for i, key := range req.Keys {
+			qmarks[i] = "?"
+			params = append(params, key)
+		}
+		targets = append(targets, "overrideKey IN ("+strings.Join(qmarks, ",")+")")
+	}
+	if len(targets) > 0 {
+		query += " AND (" + strings.Join(targets, " OR ") + ")"
+	}
+	query += " ORDER BY limitName, id"
+
+	var models []rateLimitOverrideModel
+	_, err := ssa.dbMap.WithContext(ctx).Select(&models, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	resp := &sapb.RateLimitOverrides{}
+	for i := range models {
+		m := &models[i]
+		expires := m.Expires.UnixNano()
+		added := m.Added.UnixNano()
+		override := &sapb.RateLimitOverride{
+			LimitName: &m.LimitName,
+			Threshold: &m.Threshold,
+			Expires:   &expires,
+			Added:     &added,
+			Comment:   m.Comment,
+		}
+		if m.RegistrationID != 0 {
+			override.RegistrationID = &m.RegistrationID
+		} else {
+			override.Key = &m.OverrideKey
+		}
+		resp.Overrides = append(resp.Overrides, override)
+	}
+	return resp, nil
+}
+
+// ReplacementCertificateExists returns true if the registration has been issued
+// a certificate after req.IssuedAfter which is neither revoked nor expired at
+// req.ValidAt and whose names are a superset of req.Domains, i.e. a
+// certificate that replaces one for those names.
+func (ssa *SQLStorageAuthority) ReplacementCertificateExists(ctx context.Context, req *sapb.ReplacementCertificateExistsRequest) (*sapb.Exists, error) {
+	if req == nil || req.RegistrationID == nil || len(req.Domains) == 0 || req.IssuedAfter == nil || req.ValidAt == nil {
+		return nil, errIncompleteRequest
+	}
+	var candidates []struct {
+		Serial string
+		DER    []byte
+	}
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&candidates,
+		`SELECT c.serial, c.der FROM certificates AS c
+		JOIN certificateStatus AS cs ON cs.serial = c.serial
+		WHERE c.registrationID = ?
+		AND c.issued > ?
+		AND c.expires > ?
+		AND cs.status != ?`,
+		*req.RegistrationID,
+		time.Unix(0, *req.IssuedAfter),
+		time.Unix(0, *req.ValidAt),
+		string(core.OCSPStatusRevoked),
+	)
+	if err != nil {
+		return nil, err
+	}
+	exists := false
+	for _, candidate := range candidates {
+		cert, err := x509.ParseCertificate(candidate.DER)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate %s: %s", candidate.Serial, err)
+		}
+		names := make(map[string]bool, len(cert.DNSNames))
+		for _, name := range cert.DNSNames {
+			names[strings.ToLower(name)] = true
+		}
+		covered := true
+		for _, domain := range req.Domains {
+			if !names[strings.ToLower(domain)] {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			exists = true
+			break
+		}
+	}
+	return &sapb.Exists{Exists: &exists}, nil
+}
+
+// GetNotificationPreferences returns the expiration notification preferences
+// of a registration from the notificationPreferences table. If the registration
+// has no preferences a NotFound error is returned.
+func (ssa *SQLStorageAuthority) GetNotificationPreferences(ctx context.Context, req *sapb.RegistrationID) (*sapb.NotificationPreferences, error) {
+	if req == nil || req.Id == nil {
+		return nil, errIncompleteRequest
+	}
+	var model struct {
+		Disabled bool
+		NagTimes string
+	}
+	err := ssa.dbMap.WithContext(ctx).SelectOne(
+		&model,
+		"SELECT disabled, nagTimes FROM notificationPreferences WHERE registrationID = ?",
+		*req.Id,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return nil, berrors.NotFoundError("no notification preferences for registration ID %d", *req.Id)
+		}
+		return nil, err
+	}
+	// nagTimes is stored as a comma separated list of Go duration strings,
+	// e.g. "720h,168h,24h"
+	var nagTimes []int64
+	for _, s := range strings.Split(model.NagTimes, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nag time %q for registration ID %d: %s", s, *req.Id, err)
+		}
+		nagTimes = append(nagTimes, int64(d))
+	}
+	return &sapb.NotificationPreferences{
+		Disabled: &model.Disabled,
+		NagTimes: nagTimes,
+	}, nil
+}
+
+const (
+	// defaultCertificatesPageSize is the number of certificates returned by
+	// GetCertificatesByRegistration and GetCertificatesExpiring when the
+	// request doesn't specify a limit.
+	defaultCertificatesPageSize = 100
+	// maxCertificatesPageSize is the most certificates returned by a single
+	// GetCertificatesByRegistration or GetCertificatesExpiring call regardless
+	// of the requested limit.
+	maxCertificatesPageSize = 1000
+)
+
+// GetCertificatesByRegistration returns a page of the certificates issued to a
+// registration, ordered by serial. If there may be more certificates the
+// response's NextCursor is set and should be passed as the cursor of the next
+// request. Because serials are unique and each page starts after the last
+// serial of the previous one, pages never overlap or skip certificates that
+// existed when paging started, even if more certificates are issued meanwhile.
+func (ssa *SQLStorageAuthority) GetCertificatesByRegistration(ctx context.Context, req *sapb.GetCertificatesByRegistrationRequest) (*sapb.Certificates, error) {
+	if req == nil || req.RegistrationID == nil {
+		return nil, errIncompleteRequest
+	}
+	var cursor string
+	if req.Cursor != nil {
+		cursor = *req.Cursor
+	}
+	if cursor != "" && !core.ValidSerial(cursor) {
+		return nil, berrors.MalformedError("invalid certificates cursor %q", cursor)
+	}
+	limit := int64(defaultCertificatesPageSize)
+	if req.Limit != nil && *req.Limit > 0 {
+		limit = *req.Limit
+	}
+	if limit > maxCertificatesPageSize {
+		limit = maxCertificatesPageSize
+	}
+
+	// Select one more certificate than the limit to find out if there is
+	// another page without a separate count query.
+	var certs []core.Certificate
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&certs,
+		"SELECT "+certFields+" FROM certificates WHERE registrationID = ? AND serial > ? ORDER BY serial LIMIT ?",
+		*req.RegistrationID,
+		cursor,
+		limit+1,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sapb.Certificates{}
+	if int64(len(certs)) > limit {
+		certs = certs[:limit]
+		nextCursor := certs[len(certs)-1].Serial
+		resp.NextCursor = &nextCursor
+	}
+	for _, cert := range certs {
+		resp.Certificates = append(resp.Certificates, bgrpc.CertToPB(cert))
+	}
+	return resp, nil
+}
+
+// parseExpiringCursor splits a GetCertificatesExpiring cursor, as built by
+// GetCertificatesExpiring, into the notAfter and serial of the last row of the
+// previous page.
+func parseExpiringCursor(cursor string) (time.Time, string, error) {
+	parts := strings.SplitN(cursor, ":", 2)
+	if len(parts) != 2 || !core.ValidSerial(parts[1]) {
+		return time.Time{}, "", berrors.MalformedError("invalid certificates cursor %q", cursor)
+	}
+	notAfter, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, "", berrors.MalformedError("invalid certificates cursor %q", cursor)
+	}
+	return time.Unix(0, notAfter), parts[1], nil
+}
+
+// GetCertificatesExpiring returns a page of the unrevoked certificates whose
+// notAfter is at or after req.NotAfterStart and before req.NotAfterEnd,
+// ordered by notAfter and then serial. If there may be more certificates the
+// response's NextCursor is set and should be passed as the cursor of the next
+// request. Serials found in the certificateStatus table that have no final
+// certificate (i.e. precertificates that were never completed) are skipped, so
+// a page may hold fewer certificates than the limit, or none at all, and still
+// have a NextCursor.
+func (ssa *SQLStorageAuthority) GetCertificatesExpiring(ctx context.Context, req *sapb.GetCertificatesExpiringRequest) (*sapb.Certificates, error) {
+	if req == nil || req.NotAfterStart == nil || req.NotAfterEnd == nil {
+		return nil, errIncompleteRequest
+	}
+	// Without a cursor the page starts at the beginning of the window, which
+	// the "notAfter > ? OR serial > ?" clause below then doesn't narrow since
+	// every serial sorts after the empty string.
+	afterNotAfter := time.Unix(0, *req.NotAfterStart)
+	afterSerial := ""
+	if req.Cursor != nil && *req.Cursor != "" {
+		var err error
+		afterNotAfter, afterSerial, err = parseExpiringCursor(*req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if afterNotAfter.Before(time.Unix(0, *req.NotAfterStart)) {
+			afterNotAfter = time.Unix(0, *req.NotAfterStart)
+			afterSerial = ""
+		}
+	}
+	limit := int64(defaultCertificatesPageSize)
+	if req.Limit != nil && *req.Limit > 0 {
+		limit = *req.Limit
+	}
+	if limit > maxCertificatesPageSize {
+		limit = maxCertificatesPageSize
+	}
+
+	// Find the serials in the certificateStatus table first, where the range
+	// over notAfter is served by notAfter_idx, and fetch the certificates
+	// afterwards rather than with an expensive JOIN. As in
+	// GetCertificatesByRegistration one more row than the limit is selected to
+	// find out if there is another page.
+	var statuses []struct {
+		Serial   string
+		NotAfter time.Time
+	}
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&statuses,
+		`SELECT serial, notAfter FROM certificateStatus
+		WHERE notAfter >= ? AND notAfter < ?
+		AND (notAfter > ? OR serial > ?)
+		AND status != ?
+		ORDER BY notAfter, serial
+		LIMIT ?`,
+		afterNotAfter,
+		time.Unix(0, *req.NotAfterEnd),
+		afterNotAfter,
+		afterSerial,
+		string(core.OCSPStatusRevoked),
+		limit+1,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sapb.Certificates{}
+	if int64(len(statuses)) > limit {
+		statuses = statuses[:limit]
+		last := statuses[len(statuses)-1]
+		nextCursor := fmt.Sprintf("%d:%s", last.NotAfter.UnixNano(), last.Serial)
+		resp.NextCursor = &nextCursor
+	}
+	if len(statuses) == 0 {
+		return resp, nil
+	}
+
+	qmarks := make([]string, len(statuses))
+	params := make([]interface{}, len(statuses))
+	for i, status := range statuses {
+		qmarks[i] = "?"
+		params[i] = status.Serial
+	}
+	var certs []core.Certificate
+	_, err = ssa.dbMap.WithContext(ctx).Select(
+		&certs,
+		"SELECT "+certFields+" FROM certificates WHERE serial IN ("+strings.Join(qmarks, ",")+")",
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	bySerial := make(map[string]core.Certificate, len(certs))
+	for _, cert := range certs {
+		bySerial[cert.Serial] = cert
+	}
+	for _, status := range statuses {
+		cert, ok := bySerial[status.Serial]
+		if !ok {
+			continue
+		}
+		resp.Certificates = append(resp.Certificates, bgrpc.CertToPB(cert))
+	}
+	return resp, nil
+}
+
+// GetCertificateProfile returns the name of the issuance profile the
+// certificate or precertificate with the given serial was issued under.
+// Certificates issued before profile names were stored have no row in the
+// certificateProfiles table; for those, and whenever the
+// StoreCertificateProfiles feature is disabled, an empty name is returned
+// rather than an error.
+func (ssa *SQLStorageAuthority) GetCertificateProfile(ctx context.Context, req *sapb.Serial) (*sapb.CertificateProfile, error) {
+	if req == nil || req.Serial == nil {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(*req.Serial) {
+		return nil, berrors.MalformedError("invalid serial %q", *req.Serial)
+	}
+	var name string
+	if features.Enabled(features.StoreCertificateProfiles) {
+		err := ssa.dbMap.WithContext(ctx).SelectOne(
+			&name,
+			"SELECT profileName FROM certificateProfiles WHERE serial = ?",
+			*req.Serial,
+		)
+		if err != nil && !db.IsNoRows(err) {
+			return nil, err
+		}
+	}
+	return &sapb.CertificateProfile{Name: &name}, nil
+}
+
+// GetCertificateIssuer returns the hex SHA-256 fingerprint of the issuer
+// certificate which signed the certificate or precertificate with the given
+// serial. As with GetCertificateProfile, an empty fingerprint is returned for
+// certificates with no row in the certificateIssuers table and whenever the
+// StoreIssuerFingerprints feature is disabled.
+func (ssa *SQLStorageAuthority) GetCertificateIssuer(ctx context.Context, req *sapb.Serial) (*sapb.CertificateIssuer, error) {
+	if req == nil || req.Serial == nil {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(*req.Serial) {
+		return nil, berrors.MalformedError("invalid serial %q", *req.Serial)
+	}
+	var fingerprint string
+	if features.Enabled(features.StoreIssuerFingerprints) {
+		err := ssa.dbMap.WithContext(ctx).SelectOne(
+			&fingerprint,
+			"SELECT issuerFingerprint FROM certificateIssuers WHERE serial = ?",
+			*req.Serial,
+		)
+		if err != nil && !db.IsNoRows(err) {
+			return nil, err
+		}
+	}
+	return &sapb.CertificateIssuer{Fingerprint: &fingerprint}, nil
+}
+
+// likeEscaper escapes the characters which are special in the pattern of a
+// LIKE clause.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// SearchCertificatesByName returns a page of the names of certificates which
+// match req.Name, either exactly or, if req.IncludeSubdomains is set, as a
+// subdomain. Names are found in the issuedNames table, which holds the
+// reversed names of every certificate as they were issued, and the
+// registration they were issued to in the serials table. Matches are ordered by
+// their issuedNames row, i.e. roughly when they were issued. If there may be
+// more matches the response's NextCursor is set and should be passed as the
+// cursor of the next request.
+func (ssa *SQLStorageAuthority) SearchCertificatesByName(ctx context.Context, req *sapb.SearchCertificatesByNameRequest) (*sapb.CertificateNameMatches, error) {
+	if req == nil || req.Name == nil {
+		return nil, errIncompleteRequest
+	}
+	name := strings.TrimSuffix(strings.ToLower(*req.Name), ".")
+	base := strings.TrimPrefix(name, "*.")
+	if base == "" || strings.Contains(base, "*") {
+		return nil, berrors.MalformedError("invalid name %q", *req.Name)
+	}
+	var afterID int64
+	if req.Cursor != nil && *req.Cursor != "" {
+		var err error
+		afterID, err = strconv.ParseInt(*req.Cursor, 10, 64)
+		if err != nil || afterID < 0 {
+			return nil, berrors.MalformedError("invalid name search cursor %q", *req.Cursor)
+		}
+	}
+	limit := int64(defaultCertificatesPageSize)
+	if req.Limit != nil && *req.Limit > 0 {
+		limit = *req.Limit
+	}
+	if limit > maxCertificatesPageSize {
+		limit = maxCertificatesPageSize
+	}
+
+	// A search for subdomains matches every reversed name starting with the
+	// reversed base domain and a dot, which includes wildcards of the base
+	// domain and of its subdomains.
+	nameClause := "n.reversedName = ?"
+	params := []interface{}{ReverseName(name)}
+	if req.IncludeSubdomains != nil && *req.IncludeSubdomains {
+		nameClause = "(n.reversedName = ? OR n.reversedName LIKE ?)"
+		params = append(params, likeEscaper.Replace(ReverseName(base))+".%")
+	}
+	params = append(params, afterID, limit+1)
+
+	// Select one more match than the limit to find out if there is another
+	// page without a separate count query.
+	var rows []struct {
+		ID             int64
+		ReversedName   string
+		Serial         string
+		NotBefore      time.Time
+		RegistrationID int64
+	}
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&rows,
+		`SELECT n.id, n.reversedName, n.serial, n.notBefore, COALESCE(s.registrationID, 0) AS registrationID
+		FROM issuedNames AS n
+		LEFT JOIN serials AS s ON s.serial = n.serial
+		WHERE `+nameClause+` AND n.id > ?
+		ORDER BY n.id
+		LIMIT ?`,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sapb.CertificateNameMatches{}
+	if int64(len(rows)) > limit {
+		rows = rows[:limit]
+		nextCursor := strconv.FormatInt(rows[len(rows)-1].ID, 10)
+		resp.NextCursor = &nextCursor
+	}
+	for _, row := range rows {
+		row := row
+		matchName := ReverseName(row.ReversedName)
+		notBefore := row.NotBefore.UnixNano()
+		resp.Matches = append(resp.Matches, &sapb.CertificateNameMatches_Match{
+			Name:           &matchName,
+			Serial:         &row.Serial,
+			RegistrationID: &row.RegistrationID,
+			NotBefore:      &notBefore,
+		})
+	}
+	return resp, nil
+}
+
+// GetOrderForSerial returns the IDs of the order the certificate with the
+// given serial was issued for and of the account which created that order. A
+// NotFound error is returned if no order has the serial, e.g. for
+// certificates issued through the ACME v1 new-cert endpoint, which has no
+// orders.
+func (ssa *SQLStorageAuthority) GetOrderForSerial(ctx context.Context, req *sapb.Serial) (*sapb.OrderForSerial, error) {
+	if req == nil || req.Serial == nil {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(*req.Serial) {
+		return nil, berrors.MalformedError("invalid serial %q", *req.Serial)
+	}
+	var result struct {
+		ID             int64
+		RegistrationID int64
+	}
+	err := ssa.dbMap.WithContext(ctx).SelectOne(
+		&result,
+		"SELECT id, registrationID FROM orders WHERE certificateSerial = ? LIMIT 1",
+		*req.Serial,
+	)
+	if db.IsNoRows(err) {
+		return nil, berrors.NotFoundError("no order found for serial %q", *req.Serial)
+	} else if err != nil {
+		return nil, err
+	}
+	return &sapb.OrderForSerial{
+		OrderID:        &result.ID,
+		RegistrationID: &result.RegistrationID,
+	}, nil
+}
+
+// GetCertificateHistory gathers everything stored about the certificate with
+// the given serial, for exporting to audit logs: the certificate and
+// precertificate, the certificate's status and issuance profile, and the
+// order it was issued for along with that order's authorizations. Unlike
+// GetOrder, the order is returned even if it has expired. A NotFound error is
+// returned if there is neither a certificate nor a precertificate with the
+// serial.
+func (ssa *SQLStorageAuthority) GetCertificateHistory(ctx context.Context, req *sapb.Serial) (*sapb.CertificateHistory, error) {
+	if req == nil || req.Serial == nil {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(*req.Serial) {
+		return nil, berrors.MalformedError("invalid serial %q", *req.Serial)
+	}
+	history := &sapb.CertificateHistory{Serial: req.Serial}
+
+	precert, err := ssa.GetPrecertificate(ctx, req)
+	if err != nil && !berrors.Is(err, berrors.NotFound) {
+		return nil, err
+	}
+	history.Precertificate = precert
+
+	cert, err := ssa.GetCertificate(ctx, *req.Serial)
+	if err == nil {
+		history.Certificate = bgrpc.CertToPB(cert)
+	} else if !berrors.Is(err, berrors.NotFound) {
+		return nil, err
+	}
+	if history.Precertificate == nil && history.Certificate == nil {
+		return nil, berrors.NotFoundError("no certificate or precertificate found for serial %q", *req.Serial)
+	}
+
+	status, err := ssa.GetCertificateStatus(ctx, *req.Serial)
+	if err == nil {
+		history.Status = bgrpc.CertStatusToPB(status)
+	} else if !db.IsNoRows(err) {
+		return nil, err
+	}
+
+	profile, err := ssa.GetCertificateProfile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	history.ProfileName = profile.Name
+
+	orderForSerial, err := ssa.GetOrderForSerial(ctx, req)
+	if berrors.Is(err, berrors.NotFound) {
+		return history, nil
+	} else if err != nil {
+		return nil, err
+	}
+	order, err := ssa.getOrder(ctx, *orderForSerial.OrderID, true)
+	if err != nil {
+		return nil, err
+	}
+	history.Order = order
+
+	for _, authzID := range order.V2Authorizations {
+		id := authzID
+		authz, err := ssa.GetAuthorization2(ctx, &sapb.AuthorizationID2{Id: &id})
+		if berrors.Is(err, berrors.NotFound) {
+			// The janitor may have deleted the authorization.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		history.Authorizations = append(history.Authorizations, authz)
+	}
+	return history, nil
+}
+
+const (
+	// defaultDeactivatedRegistrationsLimit is the number of registrations
+	// returned by GetDeactivatedRegistrations when the request doesn't specify
+	// a limit.
+	defaultDeactivatedRegistrationsLimit = 100
+	// maxDeactivatedRegistrationsLimit is the most registrations returned by a
+	// single GetDeactivatedRegistrations call regardless of the requested
+	// limit.
+	maxDeactivatedRegistrationsLimit = 1000
+)
+
+// GetDeactivatedRegistrations returns the registrations deactivated at or
+// after the requested time, most recently deactivated first, along with when
+// and why they were deactivated. Only deactivations recorded while the
+// StoreDeactivationInfo feature was enabled are returned.
+func (ssa *SQLStorageAuthority) GetDeactivatedRegistrations(ctx context.Context, req *sapb.GetDeactivatedRegistrationsRequest) (*sapb.DeactivatedRegistrations, error) {
+	if req == nil || req.Since == nil {
+		return nil, errIncompleteRequest
+	}
+	limit := int64(defaultDeactivatedRegistrationsLimit)
+	if req.Limit != nil && *req.Limit > 0 {
+		limit = *req.Limit
+	}
+	if limit > maxDeactivatedRegistrationsLimit {
+		limit = maxDeactivatedRegistrationsLimit
+	}
+	resp := &sapb.DeactivatedRegistrations{}
+	if !features.Enabled(features.StoreDeactivationInfo) {
+		return resp, nil
+	}
+	var models []deactivatedRegistrationModel
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&models,
+		`SELECT registrationID, deactivatedAt, reason FROM deactivatedRegistrations
+		WHERE deactivatedAt >= ?
+		ORDER BY deactivatedAt DESC
+		LIMIT ?`,
+		time.Unix(0, *req.Since),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range models {
+		regID := m.RegistrationID
+		deactivatedAt := m.DeactivatedAt.UnixNano()
+		reason := m.Reason
+		resp.Registrations = append(resp.Registrations, &sapb.DeactivatedRegistration{
+			RegistrationID: &regID,
+			DeactivatedAt:  &deactivatedAt,
+			Reason:         &reason,
+		})
+	}
+	return resp, nil
+}
+
+// activeCertificatesQuery selects the certificates issued to registrations
+// which haven't expired or been revoked. It's a single statement, so the
+// certificates counted are those of one consistent snapshot: a certificate
+// being issued is counted once its certificates row is committed, and its
+// certificateStatus row is committed in the same transaction, while one being
+// revoked stops being counted once the status update is committed.
+const activeCertificatesQuery = `FROM certificates AS c
+	JOIN certificateStatus AS cs ON cs.serial = c.serial
+	WHERE c.expires > ?
+	AND cs.status != ?`
+
+// CountActiveCertificates returns the number of certificates issued to a
+// registration which haven't expired or been revoked.
+func (ssa *SQLStorageAuthority) CountActiveCertificates(ctx context.Context, req *sapb.RegistrationID) (*sapb.Count, error) {
+	if req == nil || req.Id == nil {
+		return nil, errIncompleteRequest
+	}
+	var count int64
+	err := ssa.dbMap.WithContext(ctx).SelectOne(
+		&count,
+		"SELECT COUNT(1) "+activeCertificatesQuery+" AND c.registrationID = ?",
+		ssa.clk.Now(),
+		string(core.OCSPStatusRevoked),
+		*req.Id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &sapb.Count{Count: &count}, nil
+}
+
+// maxActiveCertificatesRegistrations is the most registrations whose active
+// certificates CountActiveCertificatesByRegistrations counts in one query.
+const maxActiveCertificatesRegistrations = 100
+
+// CountActiveCertificatesByRegistrations returns, like CountActiveCertificates,
+// the number of certificates issued to each of the registrations which haven't
+// expired or been revoked. Every registration requested is included in the
+// response, with a count of zero if it has no active certificates. The
+// registrations are counted in batches, each of which is consistent but may be
+// counted at a slightly different time to the others.
+func (ssa *SQLStorageAuthority) CountActiveCertificatesByRegistrations(ctx context.Context, req *sapb.CountActiveCertificatesByRegistrationsRequest) (*sapb.CountByRegistrations, error) {
+	if req == nil {
+		return nil, errIncompleteRequest
+	}
+	counts := make(map[int64]int64, len(req.RegistrationIDs))
+	var regIDs []int64
+	for _, regID := range req.RegistrationIDs {
+		if _, present := counts[regID]; !present {
+			counts[regID] = 0
+			regIDs = append(regIDs, regID)
+		}
+	}
+
+	now := ssa.clk.Now()
+	for pending := regIDs; len(pending) > 0; {
+		batch := pending
+		if len(batch) > maxActiveCertificatesRegistrations {
+			batch = batch[:maxActiveCertificatesRegistrations]
+		}
+		pending = pending[len(batch):]
+
+		qmarks := make([]string, len(batch))
+		args := []interface{}{now, string(core.OCSPStatusRevoked)}
+		for i, regID := range batch {
+			qmarks[i] = "?"
+			args = append(args, regID)
+		}
+		var rows []struct {
+			RegistrationID int64
+			Count          int64
+		}
+		_, err := ssa.dbMap.WithContext(ctx).Select(
+			&rows,
+			"SELECT c.registrationID AS registrationID, COUNT(1) AS count "+activeCertificatesQuery+
+				" AND c.registrationID IN ("+strings.Join(qmarks, ", ")+") GROUP BY c.registrationID",
+			args...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			counts[row.RegistrationID] = row.Count
+		}
+	}
+
+	resp := &sapb.CountByRegistrations{}
+	for _, regID := range regIDs {
+		regID := regID
+		count := counts[regID]
+		resp.CountByRegistrations = append(resp.CountByRegistrations, &sapb.CountByRegistrations_MapElement{
+			RegistrationID: &regID,
+			Count:          &count,
+		})
+	}
+	return resp, nil
+}
@@ -12,7 +12,9 @@ import (
 	"math/big"
 	"math/bits"
 	"net"
+	"os"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -28,6 +30,7 @@ import (
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/ratelimit"
 	"github.com/letsencrypt/boulder/revocation"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 	"github.com/letsencrypt/boulder/sa/satest"
@@ -679,6 +682,79 @@ func TestPreviousCertificateExists(t *testing.T) {
 	}
 }
 
+func TestReplacementCertificateExists(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+
+	// An example cert taken from EFF's website
+	certDER, err := ioutil.ReadFile("www.eff.org.der")
+	test.AssertNotError(t, err, "reading cert DER")
+	cert, err := x509.ParseCertificate(certDER)
+	test.AssertNotError(t, err, "parsing cert DER")
+
+	issued := sa.clk.Now()
+	issuedUnix := issued.UnixNano()
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:    certDER,
+		Issued: &issuedUnix,
+		RegID:  &reg.ID,
+	})
+	test.AssertNotError(t, err, "Failed to add precertificate")
+	_, err = sa.AddCertificate(ctx, certDER, reg.ID, nil, &issued)
+	test.AssertNotError(t, err, "calling AddCertificate")
+
+	before := issued.Add(-time.Hour).UnixNano()
+	valid := cert.NotAfter.Add(-time.Hour).UnixNano()
+	expired := cert.NotAfter.Add(time.Hour).UnixNano()
+	cases := []struct {
+		name        string
+		domains     []string
+		regID       int64
+		issuedAfter int64
+		validAt     int64
+		expected    bool
+	}{
+		{"subset of names", cert.DNSNames[:1], reg.ID, before, valid, true},
+		{"same names", cert.DNSNames, reg.ID, before, valid, true},
+		{"missing name", append([]string{"wwoof.org"}, cert.DNSNames...), reg.ID, before, valid, false},
+		{"wrong account", cert.DNSNames, 3333, before, valid, false},
+		{"not newer", cert.DNSNames, reg.ID, issuedUnix, valid, false},
+		{"expired", cert.DNSNames, reg.ID, before, expired, false},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			exists, err := sa.ReplacementCertificateExists(context.Background(),
+				&sapb.ReplacementCertificateExistsRequest{
+					RegistrationID: &testCase.regID,
+					Domains:        testCase.domains,
+					IssuedAfter:    &testCase.issuedAfter,
+					ValidAt:        &testCase.validAt,
+				})
+			test.AssertNotError(t, err, "calling ReplacementCertificateExists")
+			if *exists.Exists != testCase.expected {
+				t.Errorf("wanted %v got %v", testCase.expected, *exists.Exists)
+			}
+		})
+	}
+
+	// A revoked certificate isn't a replacement.
+	_, err = sa.dbMap.Exec("UPDATE certificateStatus SET status = ? WHERE serial = ?",
+		string(core.OCSPStatusRevoked), core.SerialToString(cert.SerialNumber))
+	test.AssertNotError(t, err, "revoking certificate")
+	exists, err := sa.ReplacementCertificateExists(context.Background(),
+		&sapb.ReplacementCertificateExistsRequest{
+			RegistrationID: &reg.ID,
+			Domains:        cert.DNSNames,
+			IssuedAfter:    &before,
+			ValidAt:        &valid,
+		})
+	test.AssertNotError(t, err, "calling ReplacementCertificateExists")
+	test.Assert(t, !*exists.Exists, "revoked certificate is a replacement")
+}
+
 func TestDeactivateAuthorization2(t *testing.T) {
 	sa, fc, cleanUp := initSA(t)
 	defer cleanUp()
@@ -701,7 +777,7 @@ func TestDeactivateAccount(t *testing.T) {
 
 	reg := satest.CreateWorkingRegistration(t, sa)
 
-	err := sa.DeactivateRegistration(context.Background(), reg.ID)
+	err := sa.DeactivateRegistration(context.Background(), reg.ID, "testing")
 	test.AssertNotError(t, err, "DeactivateRegistration failed")
 
 	dbReg, err := sa.GetRegistration(context.Background(), reg.ID)
@@ -709,6 +785,70 @@ func TestDeactivateAccount(t *testing.T) {
 	test.AssertEquals(t, dbReg.Status, core.StatusDeactivated)
 }
 
+func TestGetDeactivatedRegistrations(t *testing.T) {
+	if !strings.HasSuffix(os.Getenv("BOULDER_CONFIG_DIR"), "config-next") {
+		return
+	}
+
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+	err := features.Set(map[string]bool{"StoreDeactivationInfo": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	regA := satest.CreateWorkingRegistration(t, sa)
+	regB, err := sa.NewRegistration(context.Background(), core.Registration{
+		Key:       &jose.JSONWebKey{Key: &rsa.PublicKey{N: big.NewInt(1), E: 3}},
+		InitialIP: net.ParseIP("43.34.43.34"),
+	})
+	test.AssertNotError(t, err, "NewRegistration failed")
+
+	start := fc.Now()
+	err = sa.DeactivateRegistration(context.Background(), regA.ID, "first")
+	test.AssertNotError(t, err, "DeactivateRegistration failed")
+	fc.Add(time.Hour)
+	err = sa.DeactivateRegistration(context.Background(), regB.ID, "second")
+	test.AssertNotError(t, err, "DeactivateRegistration failed")
+	// Deactivating an already deactivated registration doesn't overwrite the
+	// recorded deactivation.
+	fc.Add(time.Hour)
+	err = sa.DeactivateRegistration(context.Background(), regA.ID, "again")
+	test.AssertNotError(t, err, "DeactivateRegistration failed")
+
+	// The registrations are kept, deactivated.
+	dbReg, err := sa.GetRegistration(context.Background(), regA.ID)
+	test.AssertNotError(t, err, "GetRegistration failed")
+	test.AssertEquals(t, dbReg.Status, core.StatusDeactivated)
+
+	since := start.UnixNano()
+	resp, err := sa.GetDeactivatedRegistrations(context.Background(), &sapb.GetDeactivatedRegistrationsRequest{Since: &since})
+	test.AssertNotError(t, err, "GetDeactivatedRegistrations failed")
+	test.AssertEquals(t, len(resp.Registrations), 2)
+	test.AssertEquals(t, *resp.Registrations[0].RegistrationID, regB.ID)
+	test.AssertEquals(t, *resp.Registrations[0].Reason, "second")
+	test.AssertEquals(t, *resp.Registrations[0].DeactivatedAt, start.Add(time.Hour).UnixNano())
+	test.AssertEquals(t, *resp.Registrations[1].RegistrationID, regA.ID)
+	test.AssertEquals(t, *resp.Registrations[1].Reason, "first")
+	test.AssertEquals(t, *resp.Registrations[1].DeactivatedAt, start.UnixNano())
+
+	// Deactivations before since aren't returned, nor are those past the
+	// limit.
+	since = start.Add(time.Minute).UnixNano()
+	resp, err = sa.GetDeactivatedRegistrations(context.Background(), &sapb.GetDeactivatedRegistrationsRequest{Since: &since})
+	test.AssertNotError(t, err, "GetDeactivatedRegistrations failed")
+	test.AssertEquals(t, len(resp.Registrations), 1)
+	test.AssertEquals(t, *resp.Registrations[0].RegistrationID, regB.ID)
+	since = start.UnixNano()
+	limit := int64(1)
+	resp, err = sa.GetDeactivatedRegistrations(context.Background(), &sapb.GetDeactivatedRegistrationsRequest{Since: &since, Limit: &limit})
+	test.AssertNotError(t, err, "GetDeactivatedRegistrations failed")
+	test.AssertEquals(t, len(resp.Registrations), 1)
+	test.AssertEquals(t, *resp.Registrations[0].RegistrationID, regB.ID)
+
+	_, err = sa.GetDeactivatedRegistrations(context.Background(), &sapb.GetDeactivatedRegistrationsRequest{})
+	test.AssertError(t, err, "GetDeactivatedRegistrations didn't fail without since")
+}
+
 func TestReverseName(t *testing.T) {
 	testCases := []struct {
 		inputDomain   string
@@ -1049,6 +1189,248 @@ func TestFinalizeOrder(t *testing.T) {
 	test.AssertEquals(t, *updatedOrder.Status, string(core.StatusValid))
 }
 
+func TestGetOrderForSerial(t *testing.T) {
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	reg, err := sa.NewRegistration(ctx, core.Registration{
+		Key:       &jose.JSONWebKey{Key: &rsa.PublicKey{N: big.NewInt(1), E: 1}},
+		InitialIP: net.ParseIP("42.42.42.42"),
+	})
+	test.AssertNotError(t, err, "Couldn't create test registration")
+
+	authzID := createFinalizedAuthorization(t, sa, "example.com", fc.Now().Add(time.Hour), "valid")
+	orderExpiry := fc.Now().Add(365 * 24 * time.Hour).UnixNano()
+	order, err := sa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:   &reg.ID,
+		Expires:          &orderExpiry,
+		Names:            []string{"example.com"},
+		V2Authorizations: []int64{authzID},
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	err = sa.SetOrderProcessing(ctx, order)
+	test.AssertNotError(t, err, "SetOrderProcessing failed")
+	serial := "0000000000000000000000000000000000b2"
+	order.CertificateSerial = &serial
+	err = sa.FinalizeOrder(ctx, order)
+	test.AssertNotError(t, err, "FinalizeOrder failed")
+
+	result, err := sa.GetOrderForSerial(ctx, &sapb.Serial{Serial: &serial})
+	test.AssertNotError(t, err, "GetOrderForSerial failed")
+	test.AssertEquals(t, *result.OrderID, *order.Id)
+	test.AssertEquals(t, *result.RegistrationID, reg.ID)
+
+	// A serial no order was finalized with isn't found.
+	other := "0000000000000000000000000000000000b3"
+	_, err = sa.GetOrderForSerial(ctx, &sapb.Serial{Serial: &other})
+	test.AssertError(t, err, "GetOrderForSerial didn't fail for a serial with no order")
+	test.Assert(t, berrors.Is(err, berrors.NotFound), "GetOrderForSerial error wasn't NotFound")
+
+	// An invalid serial is rejected.
+	invalid := "not a serial"
+	_, err = sa.GetOrderForSerial(ctx, &sapb.Serial{Serial: &invalid})
+	test.Assert(t, berrors.Is(err, berrors.Malformed), "GetOrderForSerial error wasn't Malformed")
+}
+
+func TestGetCertificateHistory(t *testing.T) {
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+
+	// An example cert taken from EFF's website
+	certDER, err := ioutil.ReadFile("www.eff.org.der")
+	test.AssertNotError(t, err, "reading cert DER")
+	cert, err := x509.ParseCertificate(certDER)
+	test.AssertNotError(t, err, "parsing cert DER")
+	serial := core.SerialToString(cert.SerialNumber)
+
+	// Nothing is found before the certificate is added.
+	_, err = sa.GetCertificateHistory(ctx, &sapb.Serial{Serial: &serial})
+	test.AssertError(t, err, "GetCertificateHistory didn't fail for an unknown serial")
+	test.Assert(t, berrors.Is(err, berrors.NotFound), "GetCertificateHistory error wasn't NotFound")
+
+	issued := fc.Now()
+	issuedUnix := issued.UnixNano()
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:    certDER,
+		Issued: &issuedUnix,
+		RegID:  &reg.ID,
+	})
+	test.AssertNotError(t, err, "Failed to add precertificate")
+
+	// A precertificate without a final certificate or order is found alone.
+	history, err := sa.GetCertificateHistory(ctx, &sapb.Serial{Serial: &serial})
+	test.AssertNotError(t, err, "GetCertificateHistory failed")
+	test.AssertEquals(t, *history.Serial, serial)
+	test.AssertByteEquals(t, history.Precertificate.Der, certDER)
+	test.Assert(t, history.Certificate == nil, "unexpected certificate")
+	test.AssertEquals(t, *history.Status.Status, string(core.OCSPStatusGood))
+	test.Assert(t, history.Order == nil, "unexpected order")
+
+	_, err = sa.AddCertificate(ctx, certDER, reg.ID, nil, &issued)
+	test.AssertNotError(t, err, "calling AddCertificate")
+
+	authzID := createFinalizedAuthorization(t, sa, "www.eff.org", fc.Now().Add(time.Hour), "valid")
+	orderExpiry := fc.Now().Add(2 * time.Hour).UnixNano()
+	order, err := sa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:   &reg.ID,
+		Expires:          &orderExpiry,
+		Names:            []string{"www.eff.org"},
+		V2Authorizations: []int64{authzID},
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	err = sa.SetOrderProcessing(ctx, order)
+	test.AssertNotError(t, err, "SetOrderProcessing failed")
+	order.CertificateSerial = &serial
+	err = sa.FinalizeOrder(ctx, order)
+	test.AssertNotError(t, err, "FinalizeOrder failed")
+
+	// The order and its authorization are included even once they've expired.
+	fc.Add(3 * time.Hour)
+	history, err = sa.GetCertificateHistory(ctx, &sapb.Serial{Serial: &serial})
+	test.AssertNotError(t, err, "GetCertificateHistory failed")
+	test.AssertByteEquals(t, history.Certificate.Der, certDER)
+	test.AssertByteEquals(t, history.Precertificate.Der, certDER)
+	test.AssertEquals(t, *history.Order.Id, *order.Id)
+	test.AssertEquals(t, *history.Order.CertificateSerial, serial)
+	test.AssertEquals(t, len(history.Authorizations), 1)
+	test.AssertEquals(t, *history.Authorizations[0].Id, fmt.Sprintf("%d", authzID))
+
+	// An invalid serial is rejected.
+	invalid := "not a serial"
+	_, err = sa.GetCertificateHistory(ctx, &sapb.Serial{Serial: &invalid})
+	test.Assert(t, berrors.Is(err, berrors.Malformed), "GetCertificateHistory error wasn't Malformed")
+}
+
+func TestOrderProfile(t *testing.T) {
+	if !strings.HasSuffix(os.Getenv("BOULDER_CONFIG_DIR"), "config-next") {
+		return
+	}
+
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+	err := features.Set(map[string]bool{"StoreOrderProfiles": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	expires := fc.Now().Add(time.Hour).UnixNano()
+	status := string(core.StatusPending)
+	newOrder := func(profileName *string) *corepb.Order {
+		order, err := sa.NewOrder(ctx, &corepb.Order{
+			RegistrationID:         &reg.ID,
+			Expires:                &expires,
+			Names:                  []string{"example.com"},
+			V2Authorizations:       []int64{1},
+			Status:                 &status,
+			CertificateProfileName: profileName,
+		})
+		test.AssertNotError(t, err, "sa.NewOrder failed")
+		order, err = sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+		test.AssertNotError(t, err, "sa.GetOrder failed")
+		return order
+	}
+
+	shortLived := "shortlived"
+	order := newOrder(&shortLived)
+	test.AssertEquals(t, order.GetCertificateProfileName(), shortLived)
+	order = newOrder(nil)
+	test.AssertEquals(t, order.CertificateProfileName == nil, true)
+}
+
+func TestOrderProfileWithoutStorage(t *testing.T) {
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	// Without StoreOrderProfiles the profile would be dropped, so an order
+	// requesting one is refused rather than created.
+	reg := satest.CreateWorkingRegistration(t, sa)
+	expires := fc.Now().Add(time.Hour).UnixNano()
+	shortLived := "shortlived"
+	_, err := sa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:         &reg.ID,
+		Expires:                &expires,
+		Names:                  []string{"example.com"},
+		V2Authorizations:       []int64{1},
+		CertificateProfileName: &shortLived,
+	})
+	test.AssertError(t, err, "sa.NewOrder accepted a profile it can't store")
+	test.Assert(t, berrors.Is(err, berrors.InternalServer), "sa.NewOrder error wasn't InternalServer")
+}
+
+func TestOrderVersions(t *testing.T) {
+	if !strings.HasSuffix(os.Getenv("BOULDER_CONFIG_DIR"), "config-next") {
+		return
+	}
+
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+	err := features.Set(map[string]bool{"OrderVersions": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	authzID := createFinalizedAuthorization(t, sa, "example.com", fc.Now().Add(time.Hour), "valid")
+	expires := fc.Now().Add(time.Hour).UnixNano()
+	order, err := sa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:   &reg.ID,
+		Expires:          &expires,
+		Names:            []string{"example.com"},
+		V2Authorizations: []int64{authzID},
+	})
+	test.AssertNotError(t, err, "sa.NewOrder failed")
+	test.AssertEquals(t, *order.Version, int64(0))
+	order, err = sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "sa.GetOrder failed")
+	test.AssertEquals(t, *order.Version, int64(0))
+
+	// Concurrent attempts to set the same version of the order processing
+	// race, and only one wins.
+	const callers = 5
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() { errs <- sa.SetOrderProcessing(ctx, order) }()
+	}
+	var succeeded int
+	for i := 0; i < callers; i++ {
+		err := <-errs
+		if err == nil {
+			succeeded++
+		} else if !berrors.Is(err, berrors.Conflict) && !berrors.Is(err, berrors.OrderNotReady) {
+			t.Errorf("unexpected error setting order processing: %s", err)
+		}
+	}
+	test.AssertEquals(t, succeeded, 1)
+
+	// Updates carrying the stale version are rejected as conflicts.
+	problem := &corepb.ProblemDetails{}
+	order.Error = problem
+	err = sa.SetOrderError(ctx, order)
+	test.AssertEquals(t, berrors.Is(err, berrors.Conflict), true)
+	serial := "serial"
+	order.CertificateSerial = &serial
+	err = sa.FinalizeOrder(ctx, order)
+	test.AssertEquals(t, berrors.Is(err, berrors.Conflict), true)
+
+	// Updates carrying the current version succeed and increment it.
+	current, err := sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "sa.GetOrder failed")
+	test.AssertEquals(t, *current.Version, int64(1))
+	current.CertificateSerial = &serial
+	err = sa.FinalizeOrder(ctx, current)
+	test.AssertNotError(t, err, "FinalizeOrder failed with the current version")
+	current, err = sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "sa.GetOrder failed")
+	test.AssertEquals(t, *current.Version, int64(2))
+
+	// Updates without a version aren't checked.
+	current.Version = nil
+	current.Error = problem
+	err = sa.SetOrderError(ctx, current)
+	test.AssertNotError(t, err, "SetOrderError failed without a version")
+}
+
 func TestOrder(t *testing.T) {
 	sa, fc, cleanup := initSA(t)
 	defer cleanup()
@@ -2202,6 +2584,26 @@ func TestGetValidAuthorizations2(t *testing.T) {
 	test.AssertEquals(t, len(authzs.Authz), 1)
 	test.AssertEquals(t, *authzs.Authz[0].Domain, ident)
 	test.AssertEquals(t, *authzs.Authz[0].Authz.Id, fmt.Sprintf("%d", authzID))
+
+	// Create a newer valid authorization for the same name, plus an invalid and
+	// an expired authorization for names that should be omitted from the result.
+	newerAuthzID := createFinalizedAuthorization(t, sa, ident, expires.Add(time.Hour), "valid")
+	_ = createFinalizedAuthorization(t, sa, "bbb", expires, "invalid")
+	_ = createFinalizedAuthorization(t, sa, "ccc", fc.Now().Add(-time.Hour).UTC(), "valid")
+
+	authzs, err = sa.GetValidAuthorizations2(context.Background(), &sapb.GetValidAuthorizationsRequest{
+		Domains: []string{
+			"aaa",
+			"bbb",
+			"ccc",
+		},
+		RegistrationID: &regID,
+		Now:            &now,
+	})
+	test.AssertNotError(t, err, "sa.GetValidAuthorizations2 failed")
+	test.AssertEquals(t, len(authzs.Authz), 1)
+	test.AssertEquals(t, *authzs.Authz[0].Domain, ident)
+	test.AssertEquals(t, *authzs.Authz[0].Authz.Id, fmt.Sprintf("%d", newerAuthzID))
 }
 
 func TestGetOrderExpired(t *testing.T) {
@@ -2341,3 +2743,527 @@ func TestBlockedKeyRevokedBy(t *testing.T) {
 	})
 	test.AssertNotError(t, err, "AddBlockedKey failed")
 }
+
+func TestGetNotificationPreferences(t *testing.T) {
+	if !strings.HasSuffix(os.Getenv("BOULDER_CONFIG_DIR"), "config-next") {
+		return
+	}
+
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+
+	regA := satest.CreateWorkingRegistration(t, sa)
+	regB := satest.CreateWorkingRegistration(t, sa)
+	_, err := sa.dbMap.Exec(
+		"INSERT INTO notificationPreferences (registrationID, disabled, nagTimes) VALUES (?, ?, ?), (?, ?, ?)",
+		regA.ID, false, "720h, 168h,24h",
+		regB.ID, true, "",
+	)
+	test.AssertNotError(t, err, "failed to insert notificationPreferences rows")
+
+	prefs, err := sa.GetNotificationPreferences(context.Background(), &sapb.RegistrationID{Id: &regA.ID})
+	test.AssertNotError(t, err, "GetNotificationPreferences failed")
+	test.AssertEquals(t, *prefs.Disabled, false)
+	test.AssertDeepEquals(t, prefs.NagTimes, []int64{int64(720 * time.Hour), int64(168 * time.Hour), int64(24 * time.Hour)})
+
+	prefs, err = sa.GetNotificationPreferences(context.Background(), &sapb.RegistrationID{Id: &regB.ID})
+	test.AssertNotError(t, err, "GetNotificationPreferences failed")
+	test.AssertEquals(t, *prefs.Disabled, true)
+	test.AssertEquals(t, len(prefs.NagTimes), 0)
+
+	missing := int64(404)
+	_, err = sa.GetNotificationPreferences(context.Background(), &sapb.RegistrationID{Id: &missing})
+	test.AssertError(t, err, "GetNotificationPreferences didn't fail for a registration without preferences")
+	test.Assert(t, berrors.Is(err, berrors.NotFound), "GetNotificationPreferences didn't return NotFound")
+}
+
+func TestGetCertificatesByRegistration(t *testing.T) {
+	sa, clk, cleanUp := initSA(t)
+	defer cleanUp()
+
+	regA := satest.CreateWorkingRegistration(t, sa)
+	regB := satest.CreateWorkingRegistration(t, sa)
+
+	addCert := func(regID int64, serial string) {
+		_, err := sa.dbMap.Exec(
+			"INSERT INTO certificates (registrationID, serial, digest, der, issued, expires) VALUES (?, ?, ?, ?, ?, ?)",
+			regID, serial, "digest", []byte{1, 2, 3}, clk.Now(), clk.Now().Add(time.Hour))
+		test.AssertNotError(t, err, "Failed to insert certificate")
+	}
+	serial := func(i int) string {
+		return fmt.Sprintf("%036x", i)
+	}
+	// Insert out of order to check that pages are ordered by serial.
+	for _, i := range []int{3, 1, 5, 2, 4} {
+		addCert(regA.ID, serial(i))
+	}
+	addCert(regB.ID, serial(6))
+
+	getPage := func(cursor string) ([]string, string) {
+		limit := int64(2)
+		page, err := sa.GetCertificatesByRegistration(ctx, &sapb.GetCertificatesByRegistrationRequest{
+			RegistrationID: &regA.ID,
+			Cursor:         &cursor,
+			Limit:          &limit,
+		})
+		test.AssertNotError(t, err, "GetCertificatesByRegistration failed")
+		var serials []string
+		for _, cert := range page.Certificates {
+			test.AssertEquals(t, *cert.RegistrationID, regA.ID)
+			serials = append(serials, *cert.Serial)
+		}
+		var next string
+		if page.NextCursor != nil {
+			next = *page.NextCursor
+		}
+		return serials, next
+	}
+
+	serials, next := getPage("")
+	test.AssertDeepEquals(t, serials, []string{serial(1), serial(2)})
+	test.AssertEquals(t, next, serial(2))
+
+	// A certificate issued while paging that sorts before the cursor must not
+	// cause later pages to repeat or skip certificates.
+	addCert(regA.ID, serial(0))
+
+	serials, next = getPage(next)
+	test.AssertDeepEquals(t, serials, []string{serial(3), serial(4)})
+	test.AssertEquals(t, next, serial(4))
+
+	serials, next = getPage(next)
+	test.AssertDeepEquals(t, serials, []string{serial(5)})
+	test.AssertEquals(t, next, "")
+
+	// Without a limit the default page size applies.
+	page, err := sa.GetCertificatesByRegistration(ctx, &sapb.GetCertificatesByRegistrationRequest{
+		RegistrationID: &regA.ID,
+	})
+	test.AssertNotError(t, err, "GetCertificatesByRegistration failed")
+	test.AssertEquals(t, len(page.Certificates), 6)
+	test.Assert(t, page.NextCursor == nil, "Expected no next cursor")
+
+	badCursor := "not a serial"
+	_, err = sa.GetCertificatesByRegistration(ctx, &sapb.GetCertificatesByRegistrationRequest{
+		RegistrationID: &regA.ID,
+		Cursor:         &badCursor,
+	})
+	test.AssertError(t, err, "GetCertificatesByRegistration accepted an invalid cursor")
+	test.Assert(t, berrors.Is(err, berrors.Malformed), "Expected a Malformed error")
+
+	_, err = sa.GetCertificatesByRegistration(ctx, &sapb.GetCertificatesByRegistrationRequest{})
+	test.AssertError(t, err, "GetCertificatesByRegistration accepted an incomplete request")
+}
+
+func TestGetCertificatesExpiring(t *testing.T) {
+	sa, clk, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+
+	serial := func(i int) string {
+		return fmt.Sprintf("%036x", i)
+	}
+	addStatus := func(i int, notAfter time.Time, status core.OCSPStatus) {
+		_, err := sa.dbMap.Exec(
+			"INSERT INTO certificateStatus (serial, status, notAfter, ocspLastUpdated, revokedDate, revokedReason, lastExpirationNagSent) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			serial(i), string(status), notAfter, time.Time{}, time.Time{}, 0, time.Time{})
+		test.AssertNotError(t, err, "Failed to insert certificate status")
+	}
+	addCert := func(i int, notAfter time.Time, status core.OCSPStatus) {
+		addStatus(i, notAfter, status)
+		_, err := sa.dbMap.Exec(
+			"INSERT INTO certificates (registrationID, serial, digest, der, issued, expires) VALUES (?, ?, ?, ?, ?, ?)",
+			reg.ID, serial(i), "digest", []byte{1, 2, 3}, clk.Now(), notAfter)
+		test.AssertNotError(t, err, "Failed to insert certificate")
+	}
+
+	start := clk.Now().Add(24 * time.Hour)
+	end := start.Add(24 * time.Hour)
+	addCert(1, start.Add(-time.Second), core.OCSPStatusGood)
+	addCert(2, start, core.OCSPStatusGood)
+	addCert(3, start.Add(2*time.Hour), core.OCSPStatusGood)
+	addCert(4, start.Add(time.Hour), core.OCSPStatusGood)
+	addCert(5, start.Add(time.Hour), core.OCSPStatusGood)
+	addCert(6, start.Add(time.Hour), core.OCSPStatusRevoked)
+	// A precertificate without a final certificate is skipped.
+	addStatus(7, start.Add(3*time.Hour), core.OCSPStatusGood)
+	addCert(8, start.Add(4*time.Hour), core.OCSPStatusGood)
+	addCert(9, end, core.OCSPStatusGood)
+
+	startNS, endNS := start.UnixNano(), end.UnixNano()
+	getPage := func(cursor string) ([]string, string) {
+		limit := int64(2)
+		page, err := sa.GetCertificatesExpiring(ctx, &sapb.GetCertificatesExpiringRequest{
+			NotAfterStart: &startNS,
+			NotAfterEnd:   &endNS,
+			Cursor:        &cursor,
+			Limit:         &limit,
+		})
+		test.AssertNotError(t, err, "GetCertificatesExpiring failed")
+		var serials []string
+		for _, cert := range page.Certificates {
+			serials = append(serials, *cert.Serial)
+		}
+		var next string
+		if page.NextCursor != nil {
+			next = *page.NextCursor
+		}
+		return serials, next
+	}
+
+	// Pages are ordered by notAfter and then serial.
+	serials, next := getPage("")
+	test.AssertDeepEquals(t, serials, []string{serial(2), serial(4)})
+	test.Assert(t, next != "", "Expected a next cursor")
+
+	serials, next = getPage(next)
+	test.AssertDeepEquals(t, serials, []string{serial(5), serial(3)})
+	test.Assert(t, next != "", "Expected a next cursor")
+
+	serials, next = getPage(next)
+	test.AssertDeepEquals(t, serials, []string{serial(8)})
+	test.AssertEquals(t, next, "")
+
+	// Without a limit the default page size applies.
+	page, err := sa.GetCertificatesExpiring(ctx, &sapb.GetCertificatesExpiringRequest{
+		NotAfterStart: &startNS,
+		NotAfterEnd:   &endNS,
+	})
+	test.AssertNotError(t, err, "GetCertificatesExpiring failed")
+	test.AssertEquals(t, len(page.Certificates), 5)
+	test.Assert(t, page.NextCursor == nil, "Expected no next cursor")
+
+	for _, badCursor := range []string{"not a cursor", "1:not a serial", "x:" + serial(1)} {
+		_, err = sa.GetCertificatesExpiring(ctx, &sapb.GetCertificatesExpiringRequest{
+			NotAfterStart: &startNS,
+			NotAfterEnd:   &endNS,
+			Cursor:        &badCursor,
+		})
+		test.AssertError(t, err, "GetCertificatesExpiring accepted an invalid cursor")
+		test.Assert(t, berrors.Is(err, berrors.Malformed), "Expected a Malformed error")
+	}
+
+	_, err = sa.GetCertificatesExpiring(ctx, &sapb.GetCertificatesExpiringRequest{})
+	test.AssertError(t, err, "GetCertificatesExpiring accepted an incomplete request")
+}
+
+func TestBlockedDomainCandidates(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected []string
+	}{
+		{"com", []string{"com"}},
+		{"Example.com.", []string{"example.com", "com", "*.com"}},
+		{"a.example.com", []string{"a.example.com", "example.com", "*.example.com", "com", "*.com"}},
+		{"*.example.com", []string{"*.example.com", "example.com", "com", "*.com"}},
+	}
+	for _, tc := range testCases {
+		test.AssertDeepEquals(t, blockedDomainCandidates(tc.name), tc.expected)
+	}
+
+	for _, domain := range []string{"", ".", "*.", "a.*.example.com", "*.*.example.com", "exa mple.com"} {
+		_, err := normalizeBlockedDomain(domain)
+		test.AssertError(t, err, fmt.Sprintf("normalizeBlockedDomain(%q) didn't fail", domain))
+		test.AssertEquals(t, berrors.Is(err, berrors.Malformed), true)
+	}
+	domain, err := normalizeBlockedDomain("*.Example.COM.")
+	test.AssertNotError(t, err, "normalizeBlockedDomain failed")
+	test.AssertEquals(t, domain, "*.example.com")
+}
+
+func TestBlockedDomains(t *testing.T) {
+	if !strings.HasSuffix(os.Getenv("BOULDER_CONFIG_DIR"), "config-next") {
+		return
+	}
+
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	blocked := func(names ...string) []string {
+		resp, err := sa.DomainsBlocked(ctx, &sapb.DomainsBlockedRequest{Domains: names})
+		test.AssertNotError(t, err, "DomainsBlocked failed")
+		return resp.Domains
+	}
+	add := func(domain string) {
+		added := fc.Now().UnixNano()
+		_, err := sa.AddBlockedDomain(ctx, &sapb.AddBlockedDomainRequest{Domain: &domain, Added: &added})
+		test.AssertNotError(t, err, "AddBlockedDomain failed")
+	}
+
+	test.AssertEquals(t, len(blocked("example.com", "a.example.net")), 0)
+
+	add("Example.com")
+	add("*.example.net")
+	// Adding an entry twice isn't an error
+	add("example.com")
+
+	names := []string{
+		"example.com",
+		"a.b.example.com",
+		"*.example.com",
+		"example.net",
+		"a.example.net",
+		"*.example.net",
+		"notexample.com",
+	}
+	test.AssertDeepEquals(t, blocked(names...), []string{
+		"example.com",
+		"a.b.example.com",
+		"*.example.com",
+		"a.example.net",
+		"*.example.net",
+	})
+
+	domain := "EXAMPLE.com"
+	_, err := sa.RemoveBlockedDomain(ctx, &sapb.RemoveBlockedDomainRequest{Domain: &domain})
+	test.AssertNotError(t, err, "RemoveBlockedDomain failed")
+	_, err = sa.RemoveBlockedDomain(ctx, &sapb.RemoveBlockedDomainRequest{Domain: &domain})
+	test.AssertError(t, err, "RemoveBlockedDomain didn't fail for a missing entry")
+	test.AssertEquals(t, berrors.Is(err, berrors.NotFound), true)
+	test.AssertDeepEquals(t, blocked(names...), []string{"a.example.net", "*.example.net"})
+}
+
+func TestRateLimitOverrides(t *testing.T) {
+	if !strings.HasSuffix(os.Getenv("BOULDER_CONFIG_DIR"), "config-next") {
+		return
+	}
+
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	add := func(limitName string, regID int64, key string, threshold int64, lifetime time.Duration) error {
+		added := fc.Now().UnixNano()
+		expires := fc.Now().Add(lifetime).UnixNano()
+		req := &sapb.RateLimitOverride{
+			LimitName: &limitName,
+			Threshold: &threshold,
+			Expires:   &expires,
+			Added:     &added,
+		}
+		if regID != 0 {
+			req.RegistrationID = &regID
+		}
+		if key != "" {
+			req.Key = &key
+		}
+		_, err := sa.AddRateLimitOverride(ctx, req)
+		return err
+	}
+	get := func(req *sapb.GetRateLimitOverridesRequest) []*sapb.RateLimitOverride {
+		now := fc.Now().UnixNano()
+		req.Now = &now
+		resp, err := sa.GetRateLimitOverrides(ctx, req)
+		test.AssertNotError(t, err, "GetRateLimitOverrides failed")
+		return resp.Overrides
+	}
+
+	test.AssertEquals(t, len(get(&sapb.GetRateLimitOverridesRequest{})), 0)
+
+	test.AssertError(t, add("bogusLimit", 1, "", 10, time.Hour), "unknown limit was accepted")
+	test.AssertError(t, add(ratelimit.CertificatesPerNameLimit, 1, "example.com", 10, time.Hour), "override for both a registration and a key was accepted")
+	test.AssertError(t, add(ratelimit.CertificatesPerNameLimit, 0, "", 10, time.Hour), "override for neither a registration nor a key was accepted")
+	test.AssertError(t, add(ratelimit.CertificatesPerNameLimit, 0, "example.com", -1, time.Hour), "negative threshold was accepted")
+	test.AssertError(t, add(ratelimit.CertificatesPerNameLimit, 0, "example.com", 10, -time.Hour), "expired override was accepted")
+
+	test.AssertNotError(t, add(ratelimit.CertificatesPerNameLimit, 0, "example.com", 10, time.Hour), "AddRateLimitOverride failed")
+	test.AssertNotError(t, add(ratelimit.CertificatesPerNameLimit, 0, "example.net", 10, time.Hour), "AddRateLimitOverride failed")
+	test.AssertNotError(t, add(ratelimit.NewOrdersPerAccountLimit, 1, "", 100, time.Hour), "AddRateLimitOverride failed")
+	// Adding an override again replaces it
+	test.AssertNotError(t, add(ratelimit.CertificatesPerNameLimit, 0, "example.com", 20, 2*time.Hour), "AddRateLimitOverride failed")
+
+	test.AssertEquals(t, len(get(&sapb.GetRateLimitOverridesRequest{})), 3)
+	limitName := ratelimit.CertificatesPerNameLimit
+	overrides := get(&sapb.GetRateLimitOverridesRequest{LimitName: &limitName, Keys: []string{"example.com", "example.org"}})
+	test.AssertEquals(t, len(overrides), 1)
+	test.AssertEquals(t, *overrides[0].Key, "example.com")
+	test.AssertEquals(t, *overrides[0].Threshold, int64(20))
+	test.Assert(t, overrides[0].RegistrationID == nil, "key override has a registration ID")
+	regID := int64(1)
+	overrides = get(&sapb.GetRateLimitOverridesRequest{RegistrationID: &regID})
+	test.AssertEquals(t, len(overrides), 1)
+	test.AssertEquals(t, *overrides[0].LimitName, ratelimit.NewOrdersPerAccountLimit)
+	test.AssertEquals(t, *overrides[0].RegistrationID, regID)
+
+	// Expired overrides aren't returned
+	fc.Add(90 * time.Minute)
+	overrides = get(&sapb.GetRateLimitOverridesRequest{})
+	test.AssertEquals(t, len(overrides), 1)
+	test.AssertEquals(t, *overrides[0].Key, "example.com")
+
+	key := "example.com"
+	req := &sapb.RemoveRateLimitOverrideRequest{LimitName: &limitName, Key: &key}
+	_, err := sa.RemoveRateLimitOverride(ctx, req)
+	test.AssertNotError(t, err, "RemoveRateLimitOverride failed")
+	_, err = sa.RemoveRateLimitOverride(ctx, req)
+	test.AssertError(t, err, "RemoveRateLimitOverride didn't fail for a missing override")
+	test.AssertEquals(t, berrors.Is(err, berrors.NotFound), true)
+	test.AssertEquals(t, len(get(&sapb.GetRateLimitOverridesRequest{})), 0)
+}
+
+func TestCountActiveCertificates(t *testing.T) {
+	sa, clk, cleanUp := initSA(t)
+	defer cleanUp()
+
+	regA := satest.CreateWorkingRegistration(t, sa)
+	regB := satest.CreateWorkingRegistration(t, sa)
+	regC := satest.CreateWorkingRegistration(t, sa)
+
+	var serials int
+	addCert := func(regID int64, expires time.Time, status core.OCSPStatus) string {
+		serials++
+		serial := fmt.Sprintf("%036x", serials)
+		_, err := sa.dbMap.Exec(
+			"INSERT INTO certificateStatus (serial, status, notAfter, ocspLastUpdated, revokedDate, revokedReason, lastExpirationNagSent) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			serial, string(status), expires, time.Time{}, time.Time{}, 0, time.Time{})
+		test.AssertNotError(t, err, "Failed to insert certificate status")
+		_, err = sa.dbMap.Exec(
+			"INSERT INTO certificates (registrationID, serial, digest, der, issued, expires) VALUES (?, ?, ?, ?, ?, ?)",
+			regID, serial, "digest", []byte{1, 2, 3}, clk.Now(), expires)
+		test.AssertNotError(t, err, "Failed to insert certificate")
+		return serial
+	}
+	count := func(regID int64) int64 {
+		resp, err := sa.CountActiveCertificates(ctx, &sapb.RegistrationID{Id: &regID})
+		test.AssertNotError(t, err, "CountActiveCertificates failed")
+		return *resp.Count
+	}
+
+	later := clk.Now().Add(time.Hour)
+	addCert(regA.ID, later, core.OCSPStatusGood)
+	addCert(regA.ID, later, core.OCSPStatusGood)
+	revoking := addCert(regA.ID, later, core.OCSPStatusGood)
+	// Expired and revoked certificates aren't counted.
+	addCert(regA.ID, clk.Now().Add(-time.Hour), core.OCSPStatusGood)
+	addCert(regA.ID, later, core.OCSPStatusRevoked)
+	addCert(regB.ID, later, core.OCSPStatusGood)
+
+	test.AssertEquals(t, count(regA.ID), int64(3))
+	test.AssertEquals(t, count(regB.ID), int64(1))
+	test.AssertEquals(t, count(regC.ID), int64(0))
+
+	_, err := sa.dbMap.Exec("UPDATE certificateStatus SET status = ? WHERE serial = ?",
+		string(core.OCSPStatusRevoked), revoking)
+	test.AssertNotError(t, err, "Failed to revoke certificate")
+	test.AssertEquals(t, count(regA.ID), int64(2))
+
+	// Every registration requested is included once, in the order requested,
+	// even if it has no active certificates.
+	resp, err := sa.CountActiveCertificatesByRegistrations(ctx, &sapb.CountActiveCertificatesByRegistrationsRequest{
+		RegistrationIDs: []int64{regC.ID, regA.ID, regB.ID, regA.ID},
+	})
+	test.AssertNotError(t, err, "CountActiveCertificatesByRegistrations failed")
+	var got [][2]int64
+	for _, element := range resp.CountByRegistrations {
+		got = append(got, [2]int64{*element.RegistrationID, *element.Count})
+	}
+	test.AssertDeepEquals(t, got, [][2]int64{{regC.ID, 0}, {regA.ID, 2}, {regB.ID, 1}})
+
+	_, err = sa.CountActiveCertificates(ctx, &sapb.RegistrationID{})
+	test.AssertError(t, err, "CountActiveCertificates accepted an incomplete request")
+}
+
+func TestSearchCertificatesByName(t *testing.T) {
+	sa, clk, cleanUp := initSA(t)
+	defer cleanUp()
+
+	regA := satest.CreateWorkingRegistration(t, sa)
+	regB := satest.CreateWorkingRegistration(t, sa)
+
+	var serials int
+	addCert := func(regID int64, names ...string) string {
+		serials++
+		serial := fmt.Sprintf("%036x", serials)
+		if regID != 0 {
+			_, err := sa.dbMap.Exec(
+				"INSERT INTO serials (registrationID, serial, created, expires) VALUES (?, ?, ?, ?)",
+				regID, serial, clk.Now(), clk.Now().Add(time.Hour))
+			test.AssertNotError(t, err, "Failed to insert serial")
+		}
+		for _, name := range names {
+			_, err := sa.dbMap.Exec(
+				"INSERT INTO issuedNames (reversedName, serial, notBefore) VALUES (?, ?, ?)",
+				ReverseName(name), serial, clk.Now())
+			test.AssertNotError(t, err, "Failed to insert issued name")
+		}
+		return serial
+	}
+	type match struct {
+		name   string
+		serial string
+		regID  int64
+	}
+	search := func(name string, includeSubdomains bool, limit int64) []match {
+		req := &sapb.SearchCertificatesByNameRequest{
+			Name:              &name,
+			IncludeSubdomains: &includeSubdomains,
+			Limit:             &limit,
+		}
+		var matches []match
+		for {
+			resp, err := sa.SearchCertificatesByName(ctx, req)
+			test.AssertNotError(t, err, "SearchCertificatesByName failed")
+			test.Assert(t, int64(len(resp.Matches)) <= limit, "page was larger than the limit")
+			for _, m := range resp.Matches {
+				test.AssertEquals(t, *m.NotBefore, clk.Now().UnixNano())
+				matches = append(matches, match{*m.Name, *m.Serial, *m.RegistrationID})
+			}
+			if resp.NextCursor == nil {
+				return matches
+			}
+			req.Cursor = resp.NextCursor
+		}
+	}
+
+	apex := addCert(regA.ID, "example.com", "www.example.com")
+	wildcard := addCert(regB.ID, "*.example.com")
+	deep := addCert(regA.ID, "a.b.example.com")
+	base := addCert(regB.ID, "b.example.com")
+	other := addCert(regB.ID, "notexample.com", "example.net")
+	// A certificate without a serials row, e.g. one issued before serials
+	// were recorded, matches with an unknown registration.
+	unknown := addCert(0, "example.com")
+
+	test.AssertDeepEquals(t, search("example.com", false, 10), []match{
+		{"example.com", apex, regA.ID},
+		{"example.com", unknown, 0},
+	})
+	// Names are matched case-insensitively and without a trailing dot.
+	test.AssertDeepEquals(t, search("WWW.Example.com.", false, 10), []match{
+		{"www.example.com", apex, regA.ID},
+	})
+	test.AssertDeepEquals(t, search("*.example.com", false, 10), []match{
+		{"*.example.com", wildcard, regB.ID},
+	})
+	// Every page is at most the limit, and together they hold every match.
+	for _, limit := range []int64{1, 2, 10} {
+		test.AssertDeepEquals(t, search("example.com", true, limit), []match{
+			{"example.com", apex, regA.ID},
+			{"www.example.com", apex, regA.ID},
+			{"*.example.com", wildcard, regB.ID},
+			{"a.b.example.com", deep, regA.ID},
+			{"b.example.com", base, regB.ID},
+			{"example.com", unknown, 0},
+		})
+	}
+	// The subdomains of a wildcard don't include its base domain.
+	test.AssertDeepEquals(t, search("*.b.example.com", true, 10), []match{
+		{"a.b.example.com", deep, regA.ID},
+	})
+	test.AssertDeepEquals(t, search("example.net", true, 10), []match{
+		{"example.net", other, regB.ID},
+	})
+	test.AssertEquals(t, len(search("nothing.example.org", true, 10)), 0)
+
+	for _, name := range []string{"", "*", "*.", "a.*.example.com"} {
+		name := name
+		_, err := sa.SearchCertificatesByName(ctx, &sapb.SearchCertificatesByNameRequest{Name: &name})
+		test.AssertError(t, err, fmt.Sprintf("SearchCertificatesByName accepted %q", name))
+		test.Assert(t, berrors.Is(err, berrors.Malformed), "SearchCertificatesByName didn't return Malformed")
+	}
+	name, cursor := "example.com", "bogus"
+	_, err := sa.SearchCertificatesByName(ctx, &sapb.SearchCertificatesByNameRequest{Name: &name, Cursor: &cursor})
+	test.AssertError(t, err, "SearchCertificatesByName accepted an invalid cursor")
+	_, err = sa.SearchCertificatesByName(ctx, &sapb.SearchCertificatesByNameRequest{})
+	test.AssertError(t, err, "SearchCertificatesByName accepted an incomplete request")
+}
@@ -33,6 +33,13 @@ GRANT SELECT,INSERT ON precertificates TO 'sa'@'localhost';
 GRANT SELECT,INSERT ON keyHashToSerial TO 'sa'@'localhost';
 GRANT SELECT,INSERT ON blockedKeys TO 'sa'@'localhost';
 GRANT SELECT,INSERT,UPDATE ON newOrdersRL TO 'sa'@'localhost';
+GRANT SELECT ON notificationPreferences TO 'sa'@'localhost';
+GRANT SELECT,INSERT ON certificateProfiles TO 'sa'@'localhost';
+GRANT SELECT,INSERT ON certificateIssuers TO 'sa'@'localhost';
+GRANT SELECT,INSERT ON deactivatedRegistrations TO 'sa'@'localhost';
+GRANT SELECT,INSERT,DELETE ON blockedDomains TO 'sa'@'localhost';
+GRANT SELECT,INSERT ON orderProfiles TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE,DELETE ON rateLimitOverrides TO 'sa'@'localhost';
 
 -- OCSP Responder
 GRANT SELECT ON certificateStatus TO 'ocsp_resp'@'localhost';
@@ -68,6 +75,7 @@ GRANT SELECT,DELETE ON orders TO 'janitor'@'localhost';
 GRANT SELECT,DELETE ON requestedNames TO 'janitor'@'localhost';
 GRANT SELECT,DELETE ON orderFqdnSets TO 'janitor'@'localhost';
 GRANT SELECT,DELETE ON orderToAuthz2 TO 'janitor'@'localhost';
+GRANT SELECT,DELETE ON authz2 TO 'janitor'@'localhost';
 
 -- Bad Key Revoker
 GRANT SELECT,UPDATE ON blockedKeys TO 'badkeyrevoker'@'localhost';
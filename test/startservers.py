@@ -45,6 +45,22 @@ def run(cmd, race_detection, fakeclock):
     p.cmd = cmd
     return p
 
+def validateConfigs(progs):
+    """Return True if the config of every Boulder binary in progs passes
+    --validate-config, so that misconfigurations are reported before anything
+    is started rather than as a failure to start.
+    """
+    for (_, prog) in progs:
+        args = prog.split()
+        binary = os.path.basename(args[0])
+        if '--config' not in args or not os.path.isdir(os.path.join("cmd", binary)):
+            continue
+        config = args[args.index('--config') + 1]
+        if subprocess.call([args[0], '--config', config, '--validate-config']) != 0:
+            print("%s config %s is invalid" % (binary, config))
+            return False
+    return True
+
 def start(race_detection, fakeclock):
     """Return True if everything builds and starts.
 
@@ -95,6 +111,8 @@ def start(race_detection, fakeclock):
         [4000, './bin/boulder-wfe --config %s' % os.path.join(config_dir, "wfe.json")],
         [8016, './bin/log-validator --config %s' % os.path.join(config_dir, "log-validator.json")],
     ])
+    if not validateConfigs(progs):
+        return False
     for (port, prog) in progs:
         try:
             global processes
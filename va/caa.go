@@ -18,6 +18,7 @@ import (
 type caaParams struct {
 	accountURIID     *int64
 	validationMethod *string
+	requireDNSSEC    bool
 }
 
 func (va *ValidationAuthorityImpl) IsCAAValid(ctx context.Context, req *vapb.IsCAAValidRequest) (*vapb.IsCAAValidResponse, error) {
@@ -28,6 +29,7 @@ func (va *ValidationAuthorityImpl) IsCAAValid(ctx context.Context, req *vapb.IsC
 	params := &caaParams{
 		accountURIID:     req.AccountURIID,
 		validationMethod: req.ValidationMethod,
+		requireDNSSEC:    req.GetRequireDNSSEC(),
 	}
 	if prob := va.checkCAA(ctx, acmeID, params); prob != nil {
 		typ := string(prob.Type)
@@ -48,7 +50,7 @@ func (va *ValidationAuthorityImpl) checkCAA(
 	ctx context.Context,
 	identifier identifier.ACMEIdentifier,
 	params *caaParams) *probs.ProblemDetails {
-	present, valid, records, err := va.checkCAARecords(ctx, identifier, params)
+	present, valid, dnssec, records, failure, err := va.checkCAARecords(ctx, identifier, params)
 	if err != nil {
 		return probs.DNS(err.Error())
 	}
@@ -66,14 +68,49 @@ func (va *ValidationAuthorityImpl) checkCAA(
 		challengeType = *params.validationMethod
 	}
 
-	va.log.AuditInfof("Checked CAA records for %s, [Present: %t, Account ID: %s, Challenge: %s, Valid for issuance: %t] Records=%s",
-		identifier.Value, present, accountID, challengeType, valid, recordsStr)
+	// Lookups which weren't DNSSEC validated, whether because the zone isn't
+	// signed or because the AD bit was lost on the way from the resolver, only
+	// prevent issuance if that was required.
+	dnssecMissing := params.requireDNSSEC && !dnssec
+	va.log.AuditInfof("Checked CAA records for %s, [Present: %t, Account ID: %s, Challenge: %s, DNSSEC validated: %t, Valid for issuance: %t] Records=%s",
+		identifier.Value, present, accountID, challengeType, dnssec, valid && !dnssecMissing, recordsStr)
 	if !valid {
-		return probs.CAA(fmt.Sprintf("CAA record for %s prevents issuance", identifier.Value))
+		return probs.CAA(fmt.Sprintf("CAA record for %s prevents issuance: %s", identifier.Value, failure))
+	}
+	if dnssecMissing {
+		return probs.CAA(fmt.Sprintf("CAA lookups for %s were not DNSSEC validated, which is required", identifier.Value))
 	}
 	return nil
 }
 
+// caaFailure describes why a set of CAA records prevents issuance, for
+// inclusion in the problem returned to the subscriber. Only the records that
+// the failed check considered are included, so e.g. iodef records never are.
+type caaFailure struct {
+	reason  string
+	records []*dns.CAA
+}
+
+func (f *caaFailure) String() string {
+	if f == nil {
+		return "unknown reason"
+	}
+	if len(f.records) == 0 {
+		return f.reason
+	}
+	// Records from the resolver carry the name they were found at, which may
+	// be a parent of the name being checked.
+	name := strings.TrimRight(f.records[0].Hdr.Name, ".")
+	formatted := make([]string, len(f.records))
+	for i, caa := range f.records {
+		formatted[i] = fmt.Sprintf("%d %s %q", caa.Flag, caa.Tag, caa.Value)
+	}
+	if name == "" {
+		return fmt.Sprintf("%s; relevant records: %s", f.reason, strings.Join(formatted, ", "))
+	}
+	return fmt.Sprintf("%s; relevant records at %s: %s", f.reason, name, strings.Join(formatted, ", "))
+}
+
 // CAASet consists of filtered CAA records
 type CAASet struct {
 	Issue     []*dns.CAA
@@ -124,6 +161,8 @@ func newCAASet(CAAs []*dns.CAA) *CAASet {
 type caaResult struct {
 	records []*dns.CAA
 	err     error
+	// authenticated is true if the resolver set the AD bit of its response.
+	authenticated bool
 }
 
 func parseResults(results []caaResult) (*CAASet, []*dns.CAA, error) {
@@ -139,6 +178,22 @@ func parseResults(results []caaResult) (*CAASet, []*dns.CAA, error) {
 	return nil, nil, nil
 }
 
+// dnssecValidated returns true if all of the lookups that parseResults relies
+// on were DNSSEC validated: those up to and including the first with records
+// or, if none had any, every lookup, since each of them showing there are no
+// records is what allows issuance.
+func dnssecValidated(results []caaResult) bool {
+	for _, res := range results {
+		if res.err != nil || !res.authenticated {
+			return false
+		}
+		if len(res.records) > 0 {
+			return true
+		}
+	}
+	return len(results) > 0
+}
+
 func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name string) []caaResult {
 	labels := strings.Split(name, ".")
 	results := make([]caaResult, len(labels))
@@ -148,7 +203,7 @@ func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name s
 		// Start the concurrent DNS lookup.
 		wg.Add(1)
 		go func(name string, r *caaResult) {
-			r.records, r.err = va.dnsClient.LookupCAA(ctx, name)
+			r.records, r.authenticated, r.err = va.dnsClient.LookupCAA(ctx, name)
 			wg.Done()
 		}(strings.Join(labels[i:], "."), &results[i])
 	}
@@ -157,7 +212,7 @@ func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name s
 	return results
 }
 
-func (va *ValidationAuthorityImpl) getCAASet(ctx context.Context, hostname string) (*CAASet, []*dns.CAA, error) {
+func (va *ValidationAuthorityImpl) getCAASet(ctx context.Context, hostname string) (*CAASet, []*dns.CAA, bool, error) {
 	hostname = strings.TrimRight(hostname, ".")
 
 	// See RFC 6844 "Certification Authority Processing" for pseudocode, as
@@ -170,36 +225,42 @@ func (va *ValidationAuthorityImpl) getCAASet(ctx context.Context, hostname strin
 	//
 	// We depend on our resolver to snap CNAME and DNAME records.
 	results := va.parallelCAALookup(ctx, hostname)
-	return parseResults(results)
+	caaSet, records, err := parseResults(results)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return caaSet, records, dnssecValidated(results), nil
 }
 
 // checkCAARecords fetches the CAA records for the given identifier and then
 // validates them. If the identifier argument's value has a wildcard prefix then
 // the prefix is stripped and validation will be performed against the base
 // domain, honouring any issueWild CAA records encountered as appropriate.
-// checkCAARecords returns four values: the first is a bool indicating whether
+// checkCAARecords returns six values: the first is a bool indicating whether
 // CAA records were present after filtering for known/supported CAA tags. The
 // second is a bool indicating whether issuance for the identifier is valid. The
-// unmodified *dns.CAA records that were processed/filtered are returned as the
-// third argument. Any  errors encountered are returned as the fourth return
-// value (or nil).
+// third is a bool indicating whether the lookups were DNSSEC validated, which
+// doesn't affect the second. The unmodified *dns.CAA records that were
+// processed/filtered are returned as the fourth argument. If issuance isn't
+// valid the fifth argument describes why. Any errors encountered are returned
+// as the sixth return value (or nil).
 func (va *ValidationAuthorityImpl) checkCAARecords(
 	ctx context.Context,
 	identifier identifier.ACMEIdentifier,
-	params *caaParams) (bool, bool, []*dns.CAA, error) {
+	params *caaParams) (bool, bool, bool, []*dns.CAA, *caaFailure, error) {
 	hostname := strings.ToLower(identifier.Value)
 	// If this is a wildcard name, remove the prefix
 	var wildcard bool
 	if strings.HasPrefix(hostname, `*.`) {
-		hostname = strings.TrimPrefix(identifier.Value, `*.`)
+		hostname = strings.TrimPrefix(hostname, `*.`)
 		wildcard = true
 	}
-	caaSet, records, err := va.getCAASet(ctx, hostname)
+	caaSet, records, dnssec, err := va.getCAASet(ctx, hostname)
 	if err != nil {
-		return false, false, nil, err
+		return false, false, false, nil, nil, err
 	}
-	present, valid := va.validateCAASet(caaSet, wildcard, params)
-	return present, valid, records, nil
+	present, failure := va.validateCAASet(caaSet, wildcard, params)
+	return present, failure == nil, dnssec, records, failure, nil
 }
 
 func containsMethod(commaSeparatedMethods, method string) bool {
@@ -213,20 +274,34 @@ func containsMethod(commaSeparatedMethods, method string) bool {
 
 // validateCAASet checks a provided *CAASet. When the wildcard argument is true
 // this means the CAASet's issueWild records must be validated as well. This
-// function returns two booleans: the first indicates whether the CAASet was
-// empty, the second indicates whether the CAASet is valid for issuance to
-// proceed.
-func (va *ValidationAuthorityImpl) validateCAASet(caaSet *CAASet, wildcard bool, params *caaParams) (present, valid bool) {
+// function returns a boolean indicating whether the CAASet was empty and, if
+// the CAASet doesn't allow issuance to proceed, a *caaFailure saying why.
+func (va *ValidationAuthorityImpl) validateCAASet(caaSet *CAASet, wildcard bool, params *caaParams) (present bool, failure *caaFailure) {
+	if params == nil {
+		// Without an account or validation method no record carrying an
+		// accounturi or validationmethods parameter can be satisfied.
+		params = &caaParams{}
+	}
+
 	if caaSet == nil {
 		// No CAA records found, can issue
 		va.metrics.caaCounter.WithLabelValues("no records").Inc()
-		return false, true
+		return false, nil
 	}
 
 	if caaSet.criticalUnknown() {
 		// Contains unknown critical directives
 		va.metrics.caaCounter.WithLabelValues("record with unknown critical directive").Inc()
-		return true, false
+		var critical []*dns.CAA
+		for _, caa := range caaSet.Unknown {
+			if (caa.Flag & (128 | 1)) != 0 {
+				critical = append(critical, caa)
+			}
+		}
+		return true, &caaFailure{
+			reason:  "a record with an unrecognized property is marked critical",
+			records: critical,
+		}
 	}
 
 	if len(caaSet.Issue) == 0 && !wildcard {
@@ -235,7 +310,7 @@ func (va *ValidationAuthorityImpl) validateCAASet(caaSet *CAASet, wildcard bool,
 		// non-wildcard identifier, or there is only an iodef or non-critical unknown
 		// directive.)
 		va.metrics.caaCounter.WithLabelValues("no relevant records").Inc()
-		return true, true
+		return true, nil
 	}
 
 	// Per RFC 6844 Section 5.3 "issueWild properties MUST be ignored when
@@ -244,15 +319,25 @@ func (va *ValidationAuthorityImpl) validateCAASet(caaSet *CAASet, wildcard bool,
 	// `caaSet.Issuewild` when `wildcard` is true and there is >0 `Issuewild`
 	// records.
 	records := caaSet.Issue
+	tag := "issue"
 	if wildcard && len(caaSet.Issuewild) > 0 {
 		records = caaSet.Issuewild
+		tag = "issuewild"
 	}
 
 	// There are CAA records pertaining to issuance in our case. Note that this
 	// includes the case of the unsatisfiable CAA record value ";", used to
 	// prevent issuance by any CA under any circumstance.
 	//
-	// Our CAA identity must be found in the chosen checkSet.
+	// Our CAA identity must be found in the chosen checkSet. The accounturi and
+	// validationmethods parameters of the chosen records (and only those) are
+	// then checked against the validating account and challenge type, so a
+	// wildcard name is bound by its issuewild records and an exact name by its
+	// issue records.
+	//
+	// If a record names us but one of its parameters isn't satisfied, that is
+	// reported rather than our identity being absent.
+	reason := fmt.Sprintf("no %s record authorizes %s", tag, va.issuerDomain)
 	for _, caa := range records {
 		caaIssuerDomain, caaParameters, caaValid := extractIssuerDomainAndParameters(caa)
 		if !caaValid || caaIssuerDomain != va.issuerDomain {
@@ -261,40 +346,40 @@ func (va *ValidationAuthorityImpl) validateCAASet(caaSet *CAASet, wildcard bool,
 
 		if features.Enabled(features.CAAAccountURI) {
 			// Check the accounturi CAA parameter as defined
-			// in section 3 of the draft CAA ACME RFC:
-			// https://tools.ietf.org/html/draft-ietf-acme-caa-04
+			// in section 3 of RFC 8657:
+			// https://tools.ietf.org/html/rfc8657#section-3
 			caaAccountURI, ok := caaParameters["accounturi"]
 			if ok {
-				if params.accountURIID == nil {
-					continue
-				}
-				if !checkAccountURI(caaAccountURI, va.accountURIPrefixes, *params.accountURIID) {
+				if params.accountURIID == nil || !checkAccountURI(caaAccountURI, va.accountURIPrefixes, *params.accountURIID) {
+					reason = fmt.Sprintf("the %s record's accounturi doesn't match the requesting account", tag)
 					continue
 				}
 			}
 		}
 		if features.Enabled(features.CAAValidationMethods) {
 			// Check the validationmethods CAA parameter as defined
-			// in section 4 of the draft CAA ACME RFC:
-			// https://tools.ietf.org/html/draft-ietf-acme-caa-04
+			// in section 4 of RFC 8657:
+			// https://tools.ietf.org/html/rfc8657#section-4
 			caaMethods, ok := caaParameters["validationmethods"]
 			if ok {
-				if params.validationMethod == nil {
-					continue
-				}
-				if !containsMethod(caaMethods, *params.validationMethod) {
+				if params.validationMethod == nil || !containsMethod(caaMethods, *params.validationMethod) {
+					method := "unknown"
+					if params.validationMethod != nil {
+						method = *params.validationMethod
+					}
+					reason = fmt.Sprintf("the %s record's validationmethods don't include %s", tag, method)
 					continue
 				}
 			}
 		}
 
 		va.metrics.caaCounter.WithLabelValues("authorized").Inc()
-		return true, true
+		return true, nil
 	}
 
 	// The list of authorized issuers is non-empty, but we are not in it. Fail.
 	va.metrics.caaCounter.WithLabelValues("unauthorized").Inc()
-	return true, false
+	return true, &caaFailure{reason: reason, records: records}
 }
 
 // checkAccountURI checks the specified full account URI against the
@@ -34,12 +34,16 @@ func (mock caaMockDNS) LookupHost(_ context.Context, hostname string) ([]net.IP,
 	return []net.IP{ip}, nil
 }
 
-func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, error) {
+func (mock caaMockDNS) LookupMX(_ context.Context, domain string) ([]string, error) {
+	return nil, nil
+}
+
+func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, bool, error) {
 	var results []*dns.CAA
 	var record dns.CAA
 	switch strings.TrimRight(domain, ".") {
 	case "caa-timeout.com":
-		return nil, fmt.Errorf("error")
+		return nil, false, fmt.Errorf("error")
 	case "reserved.com":
 		record.Tag = "issue"
 		record.Value = "ca.com"
@@ -59,9 +63,9 @@ func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA,
 		results = append(results, &record)
 	case "com":
 		// com has no CAA records.
-		return nil, nil
+		return nil, false, nil
 	case "servfail.com", "servfail.present.com":
-		return results, fmt.Errorf("SERVFAIL")
+		return results, false, fmt.Errorf("SERVFAIL")
 	case "multi-crit-present.com":
 		record.Flag = 1
 		record.Tag = "issue"
@@ -178,8 +182,53 @@ func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA,
 		record.Tag = "issuewild"
 		record.Value = "letsencrypt.org"
 		results = append(results, &record)
+	case "wildcard-dns-only.com":
+		// Exact names may be validated with http-01 while wildcard names may
+		// only be validated with dns-01.
+		record.Tag = "issue"
+		record.Value = "letsencrypt.org; validationmethods=http-01"
+		results = append(results, &record)
+		secondRecord := record
+		secondRecord.Tag = "issuewild"
+		secondRecord.Value = "letsencrypt.org; validationmethods=dns-01"
+		results = append(results, &secondRecord)
+	case "wildcard-accounturi.com":
+		// Any account may issue for exact names but only account 123 may
+		// issue for wildcard names.
+		record.Tag = "issue"
+		record.Value = "letsencrypt.org"
+		results = append(results, &record)
+		secondRecord := record
+		secondRecord.Tag = "issuewild"
+		secondRecord.Value = "letsencrypt.org; accounturi=https://letsencrypt.org/acct/reg/123"
+		results = append(results, &secondRecord)
+	case "issue-methods-no-issuewild.com":
+		// Without issuewild records the issue record binds wildcard names too.
+		record.Tag = "issue"
+		record.Value = "letsencrypt.org; validationmethods=dns-01,tls-alpn-01"
+		results = append(results, &record)
+	case "caa-at-parent.com":
+		// Unlike the other records here, these carry the name they were found
+		// at, as records from a real resolver do.
+		record.Hdr.Name = "caa-at-parent.com."
+		record.Tag = "issue"
+		record.Value = "ca.com"
+		results = append(results, &record)
+		secondRecord := record
+		secondRecord.Tag = "iodef"
+		secondRecord.Value = "mailto:security@caa-at-parent.com"
+		results = append(results, &secondRecord)
+	case "present-dnssec.com":
+		record.Tag = "issue"
+		record.Value = "letsencrypt.org"
+		results = append(results, &record)
+	case "unsatisfiable-dnssec.com":
+		record.Tag = "issue"
+		record.Value = ";"
+		results = append(results, &record)
 	}
-	return results, nil
+	// Names ending in "dnssec.com" are in a signed zone, "com" itself isn't.
+	return results, strings.HasSuffix(strings.TrimRight(domain, "."), "dnssec.com"), nil
 }
 
 func TestCAATimeout(t *testing.T) {
@@ -406,7 +455,7 @@ func TestCAAChecking(t *testing.T) {
 		mockLog.Clear()
 		t.Run(caaTest.Name, func(t *testing.T) {
 			ident := identifier.DNSIdentifier(caaTest.Domain)
-			present, valid, _, err := va.checkCAARecords(ctx, ident, params)
+			present, valid, _, _, _, err := va.checkCAARecords(ctx, ident, params)
 			if err != nil {
 				t.Errorf("checkCAARecords error for %s: %s", caaTest.Domain, err)
 			}
@@ -424,45 +473,158 @@ func TestCAAChecking(t *testing.T) {
 
 	// present-dns-only.com should now be valid even with http-01
 	ident := identifier.DNSIdentifier("present-dns-only.com")
-	present, valid, _, err := va.checkCAARecords(ctx, ident, params)
+	present, valid, _, _, _, err := va.checkCAARecords(ctx, ident, params)
 	test.AssertNotError(t, err, "present-dns-only.com")
 	test.Assert(t, present, "Present should be true")
 	test.Assert(t, valid, "Valid should be true")
 
 	// present-incorrect-accounturi.com should now be also be valid
 	ident = identifier.DNSIdentifier("present-incorrect-accounturi.com")
-	present, valid, _, err = va.checkCAARecords(ctx, ident, params)
+	present, valid, _, _, _, err = va.checkCAARecords(ctx, ident, params)
 	test.AssertNotError(t, err, "present-incorrect-accounturi.com")
 	test.Assert(t, present, "Present should be true")
 	test.Assert(t, valid, "Valid should be true")
 
 	// nil params should be valid, too
-	present, valid, _, err = va.checkCAARecords(ctx, ident, nil)
+	present, valid, _, _, _, err = va.checkCAARecords(ctx, ident, nil)
 	test.AssertNotError(t, err, "present-dns-only.com")
 	test.Assert(t, present, "Present should be true")
 	test.Assert(t, valid, "Valid should be true")
 
 	ident.Value = "servfail.com"
-	present, valid, _, err = va.checkCAARecords(ctx, ident, nil)
+	present, valid, _, _, _, err = va.checkCAARecords(ctx, ident, nil)
 	test.AssertError(t, err, "servfail.com")
 	test.Assert(t, !present, "Present should be false")
 	test.Assert(t, !valid, "Valid should be false")
 
-	if _, _, _, err := va.checkCAARecords(ctx, ident, nil); err == nil {
+	if _, _, _, _, _, err := va.checkCAARecords(ctx, ident, nil); err == nil {
 		t.Errorf("Should have returned error on CAA lookup, but did not: %s", ident.Value)
 	}
 
 	ident.Value = "servfail.present.com"
-	present, valid, _, err = va.checkCAARecords(ctx, ident, nil)
+	present, valid, _, _, _, err = va.checkCAARecords(ctx, ident, nil)
 	test.AssertError(t, err, "servfail.present.com")
 	test.Assert(t, !present, "Present should be false")
 	test.Assert(t, !valid, "Valid should be false")
 
-	if _, _, _, err := va.checkCAARecords(ctx, ident, nil); err == nil {
+	if _, _, _, _, _, err := va.checkCAARecords(ctx, ident, nil); err == nil {
 		t.Errorf("Should have returned error on CAA lookup, but did not: %s", ident.Value)
 	}
 }
 
+func TestCAAParamsWildcard(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Domain  string
+		Account int64
+		Method  string
+		Valid   bool
+	}{
+		{
+			Name:    "Good (exact name, method allowed by issue)",
+			Domain:  "wildcard-dns-only.com",
+			Account: 123,
+			Method:  core.ChallengeTypeHTTP01,
+			Valid:   true,
+		},
+		{
+			Name:    "Bad (exact name, method only allowed by issuewild)",
+			Domain:  "wildcard-dns-only.com",
+			Account: 123,
+			Method:  core.ChallengeTypeDNS01,
+			Valid:   false,
+		},
+		{
+			Name:    "Good (wildcard name, method allowed by issuewild)",
+			Domain:  "*.wildcard-dns-only.com",
+			Account: 123,
+			Method:  core.ChallengeTypeDNS01,
+			Valid:   true,
+		},
+		{
+			Name:    "Bad (wildcard name, method only allowed by issue)",
+			Domain:  "*.wildcard-dns-only.com",
+			Account: 123,
+			Method:  core.ChallengeTypeHTTP01,
+			Valid:   false,
+		},
+		{
+			Name:    "Bad (mixed case wildcard name, method only allowed by issue)",
+			Domain:  "*.Wildcard-DNS-Only.com",
+			Account: 123,
+			Method:  core.ChallengeTypeHTTP01,
+			Valid:   false,
+		},
+		{
+			Name:    "Good (exact name, issue has no accounturi)",
+			Domain:  "wildcard-accounturi.com",
+			Account: 321,
+			Method:  core.ChallengeTypeDNS01,
+			Valid:   true,
+		},
+		{
+			Name:    "Good (wildcard name, correct issuewild accounturi)",
+			Domain:  "*.wildcard-accounturi.com",
+			Account: 123,
+			Method:  core.ChallengeTypeDNS01,
+			Valid:   true,
+		},
+		{
+			Name:    "Bad (wildcard name, incorrect issuewild accounturi)",
+			Domain:  "*.wildcard-accounturi.com",
+			Account: 321,
+			Method:  core.ChallengeTypeDNS01,
+			Valid:   false,
+		},
+		{
+			Name:    "Good (wildcard name, method allowed by issue without issuewild)",
+			Domain:  "*.issue-methods-no-issuewild.com",
+			Account: 123,
+			Method:  core.ChallengeTypeDNS01,
+			Valid:   true,
+		},
+		{
+			Name:    "Good (exact name, second method allowed by issue)",
+			Domain:  "issue-methods-no-issuewild.com",
+			Account: 123,
+			Method:  core.ChallengeTypeTLSALPN01,
+			Valid:   true,
+		},
+		{
+			Name:    "Bad (exact name, method not allowed by issue)",
+			Domain:  "issue-methods-no-issuewild.com",
+			Account: 123,
+			Method:  core.ChallengeTypeHTTP01,
+			Valid:   false,
+		},
+	}
+
+	va, _ := setup(nil, 0, "", nil)
+	if err := features.Set(map[string]bool{"CAAValidationMethods": true, "CAAAccountURI": true}); err != nil {
+		t.Fatalf("Failed to enable feature: %v", err)
+	}
+	defer features.Reset()
+
+	va.dnsClient = caaMockDNS{}
+	va.accountURIPrefixes = []string{"https://letsencrypt.org/acct/reg/"}
+	for _, caaTest := range testCases {
+		t.Run(caaTest.Name, func(t *testing.T) {
+			params := &caaParams{accountURIID: &caaTest.Account, validationMethod: &caaTest.Method}
+			present, valid, _, _, _, err := va.checkCAARecords(ctx, identifier.DNSIdentifier(caaTest.Domain), params)
+			test.AssertNotError(t, err, "checkCAARecords failed")
+			test.Assert(t, present, "Present should be true")
+			test.AssertEquals(t, valid, caaTest.Valid)
+		})
+	}
+
+	// Without a validating account or method records carrying parameters
+	// can't be satisfied.
+	present, valid, _, _, _, err := va.checkCAARecords(ctx, identifier.DNSIdentifier("*.wildcard-accounturi.com"), nil)
+	test.AssertNotError(t, err, "checkCAARecords failed")
+	test.Assert(t, present, "Present should be true")
+	test.Assert(t, !valid, "Valid should be false")
+}
+
 func TestCAALogging(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 	va.dnsClient = caaMockDNS{}
@@ -481,55 +643,55 @@ func TestCAALogging(t *testing.T) {
 		{
 			Domain:          "reserved.com",
 			ChallengeType:   nil,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: unknown, Challenge: unknown, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"ca.com\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: unknown, Challenge: unknown, DNSSEC validated: false, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"ca.com\"}]",
 		},
 		{
 			Domain:          "reserved.com",
 			AccountURIID:    &acctID,
 			ChallengeType:   &httpChal,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"ca.com\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: 12345, Challenge: http-01, DNSSEC validated: false, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"ca.com\"}]",
 		},
 		{
 			Domain:          "reserved.com",
 			AccountURIID:    &acctID,
 			ChallengeType:   &dnsChal,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: 12345, Challenge: dns-01, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"ca.com\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: 12345, Challenge: dns-01, DNSSEC validated: false, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"ca.com\"}]",
 		},
 		{
 			Domain:          "mixedcase.com",
 			AccountURIID:    &acctID,
 			ChallengeType:   &httpChal,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for mixedcase.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"iSsUe\",\"Value\":\"ca.com\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for mixedcase.com, [Present: true, Account ID: 12345, Challenge: http-01, DNSSEC validated: false, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"iSsUe\",\"Value\":\"ca.com\"}]",
 		},
 		{
 			Domain:          "critical.com",
 			AccountURIID:    &acctID,
 			ChallengeType:   &httpChal,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for critical.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":1,\"Tag\":\"issue\",\"Value\":\"ca.com\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for critical.com, [Present: true, Account ID: 12345, Challenge: http-01, DNSSEC validated: false, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":1,\"Tag\":\"issue\",\"Value\":\"ca.com\"}]",
 		},
 		{
 			Domain:          "present.com",
 			AccountURIID:    &acctID,
 			ChallengeType:   &httpChal,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for present.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"letsencrypt.org\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for present.com, [Present: true, Account ID: 12345, Challenge: http-01, DNSSEC validated: false, Valid for issuance: true] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"letsencrypt.org\"}]",
 		},
 		{
 			Domain:          "multi-crit-present.com",
 			AccountURIID:    &acctID,
 			ChallengeType:   &httpChal,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for multi-crit-present.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":1,\"Tag\":\"issue\",\"Value\":\"ca.com\"},{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":1,\"Tag\":\"issue\",\"Value\":\"letsencrypt.org\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for multi-crit-present.com, [Present: true, Account ID: 12345, Challenge: http-01, DNSSEC validated: false, Valid for issuance: true] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":1,\"Tag\":\"issue\",\"Value\":\"ca.com\"},{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":1,\"Tag\":\"issue\",\"Value\":\"letsencrypt.org\"}]",
 		},
 		{
 			Domain:          "present-with-parameter.com",
 			AccountURIID:    &acctID,
 			ChallengeType:   &httpChal,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for present-with-parameter.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"  letsencrypt.org  ;foo=bar;baz=bar\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for present-with-parameter.com, [Present: true, Account ID: 12345, Challenge: http-01, DNSSEC validated: false, Valid for issuance: true] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"  letsencrypt.org  ;foo=bar;baz=bar\"}]",
 		},
 		{
 			Domain:          "satisfiable-wildcard-override.com",
 			AccountURIID:    &acctID,
 			ChallengeType:   &httpChal,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for satisfiable-wildcard-override.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"ca.com\"},{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issuewild\",\"Value\":\"letsencrypt.org\"}]",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for satisfiable-wildcard-override.com, [Present: true, Account ID: 12345, Challenge: http-01, DNSSEC validated: false, Valid for issuance: false] Records=[{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issue\",\"Value\":\"ca.com\"},{\"Hdr\":{\"Name\":\"\",\"Rrtype\":0,\"Class\":0,\"Ttl\":0,\"Rdlength\":0},\"Flag\":0,\"Tag\":\"issuewild\",\"Value\":\"letsencrypt.org\"}]",
 		},
 	}
 
@@ -578,6 +740,62 @@ func TestIsCAAValidErrMessage(t *testing.T) {
 	test.AssertEquals(t, *resp.Problem.Detail, fmt.Sprintf("While processing CAA for %s: error", domain))
 }
 
+// TestCAAFailureDetail tests that CAA problems say which records prevented
+// issuance and why.
+func TestCAAFailureDetail(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+	if err := features.Set(map[string]bool{"CAAValidationMethods": true, "CAAAccountURI": true}); err != nil {
+		t.Fatalf("Failed to enable feature: %v", err)
+	}
+	defer features.Reset()
+	va.dnsClient = caaMockDNS{}
+	va.accountURIPrefixes = []string{"https://letsencrypt.org/acct/reg/"}
+
+	testCases := []struct {
+		domain   string
+		expected string
+	}{
+		{
+			domain:   "reserved.com",
+			expected: `CAA record for reserved.com prevents issuance: no issue record authorizes letsencrypt.org; relevant records: 0 issue "ca.com"`,
+		},
+		{
+			domain:   "*.unsatisfiable-wildcard-override.com",
+			expected: `CAA record for *.unsatisfiable-wildcard-override.com prevents issuance: no issuewild record authorizes letsencrypt.org; relevant records: 0 issuewild "ca.com"`,
+		},
+		{
+			domain:   "unknown-critical.com",
+			expected: `CAA record for unknown-critical.com prevents issuance: a record with an unrecognized property is marked critical; relevant records: 128 foo "bar"`,
+		},
+		{
+			domain:   "present-incorrect-accounturi.com",
+			expected: `CAA record for present-incorrect-accounturi.com prevents issuance: the issue record's accounturi doesn't match the requesting account; relevant records: 0 issue "letsencrypt.org; accounturi=https://letsencrypt.org/acct/reg/321"`,
+		},
+		{
+			domain:   "present-dns-only.com",
+			expected: `CAA record for present-dns-only.com prevents issuance: the issue record's validationmethods don't include http-01; relevant records: 0 issue "letsencrypt.org; validationmethods=dns-01"`,
+		},
+		{
+			// The iodef record isn't relevant and so isn't included.
+			domain:   "www.caa-at-parent.com",
+			expected: `CAA record for www.caa-at-parent.com prevents issuance: no issue record authorizes letsencrypt.org; relevant records at caa-at-parent.com: 0 issue "ca.com"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.domain, func(t *testing.T) {
+			acctID := int64(123)
+			method := core.ChallengeTypeHTTP01
+			prob := va.checkCAA(ctx, identifier.DNSIdentifier(tc.domain), &caaParams{
+				accountURIID:     &acctID,
+				validationMethod: &method,
+			})
+			test.AssertNotNil(t, prob, "checkCAA allowed issuance")
+			test.AssertEquals(t, prob.Type, probs.CAAProblem)
+			test.AssertEquals(t, prob.Detail, tc.expected)
+		})
+	}
+}
+
 func TestCAAFailure(t *testing.T) {
 	chall := createChallenge(core.ChallengeTypeHTTP01)
 	hs := httpSrv(t, chall.Token)
@@ -600,13 +818,13 @@ func TestParseResults(t *testing.T) {
 	test.Assert(t, err == nil, "error is not nil")
 	test.Assert(t, records == nil, "records is not nil")
 	test.AssertNotError(t, err, "no error should be returned")
-	r = []caaResult{{nil, errors.New("")}, {[]*dns.CAA{{Value: "test"}}, nil}}
+	r = []caaResult{{nil, errors.New(""), false}, {[]*dns.CAA{{Value: "test"}}, nil, false}}
 	s, records, err = parseResults(r)
 	test.Assert(t, s == nil, "set is not nil")
 	test.AssertEquals(t, err.Error(), "")
 	expected := dns.CAA{Value: "other-test"}
 	test.AssertEquals(t, len(records), 0)
-	r = []caaResult{{[]*dns.CAA{&expected}, nil}, {[]*dns.CAA{{Value: "test"}}, nil}}
+	r = []caaResult{{[]*dns.CAA{&expected}, nil, false}, {[]*dns.CAA{{Value: "test"}}, nil, false}}
 	s, records, err = parseResults(r)
 	test.AssertEquals(t, len(s.Unknown), 1)
 	test.Assert(t, s.Unknown[0] == &expected, "Incorrect record returned")
@@ -825,3 +1043,47 @@ func TestExtractIssuerDomainAndParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestCAADNSSEC(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+	va.dnsClient = caaMockDNS{}
+
+	testCases := []struct {
+		domain          string
+		expectedDNSSEC  bool
+		expectedValid   bool
+		expectedProblem string
+	}{
+		// The records were found in a signed zone.
+		{"present-dnssec.com", true, true, ""},
+		// So were they for a subdomain, whose own lookup found none; the
+		// unsigned lookup for "com" wasn't relied on.
+		{"www.present-dnssec.com", true, true, ""},
+		// Showing there are no records relied on the unsigned lookup for "com".
+		{"absent-dnssec.com", false, true, "CAA lookups for absent-dnssec.com were not DNSSEC validated, which is required"},
+		// Records preventing issuance are reported as such.
+		{"unsatisfiable-dnssec.com", true, false, "CAA record for unsatisfiable-dnssec.com prevents issuance: no issue record authorizes letsencrypt.org; relevant records: 0 issue \";\""},
+		{"present.com", false, true, "CAA lookups for present.com were not DNSSEC validated, which is required"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.domain, func(t *testing.T) {
+			_, valid, dnssec, _, _, err := va.checkCAARecords(ctx, identifier.DNSIdentifier(tc.domain), nil)
+			test.AssertNotError(t, err, "checkCAARecords failed")
+			test.AssertEquals(t, dnssec, tc.expectedDNSSEC)
+			test.AssertEquals(t, valid, tc.expectedValid)
+
+			// Unvalidated lookups only prevent issuance if DNSSEC is
+			// required.
+			prob := va.checkCAA(ctx, identifier.DNSIdentifier(tc.domain), &caaParams{})
+			test.AssertEquals(t, prob == nil, tc.expectedValid)
+			prob = va.checkCAA(ctx, identifier.DNSIdentifier(tc.domain), &caaParams{requireDNSSEC: true})
+			if tc.expectedProblem == "" {
+				test.Assert(t, prob == nil, fmt.Sprintf("unexpected problem: %s", prob))
+			} else {
+				test.Assert(t, prob != nil, "expected a problem")
+				test.AssertEquals(t, prob.Type, probs.CAAProblem)
+				test.AssertEquals(t, prob.Detail, tc.expectedProblem)
+			}
+		})
+	}
+}
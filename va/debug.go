@@ -0,0 +1,53 @@
+package va
+
+import (
+	"context"
+	"net"
+
+	"github.com/letsencrypt/boulder/bdns"
+	berrors "github.com/letsencrypt/boulder/errors"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/identifier"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+// DebugServer implements the VADebug gRPC service, which performs a
+// validation the same way the VA does but resolves names using a resolver
+// chosen by the caller, e.g. to debug split-horizon DNS. It must only be
+// served to administrative clients, never on the VA's regular gRPC server.
+type DebugServer struct {
+	va          *ValidationAuthorityImpl
+	newResolver func(addr string) bdns.DNSClient
+}
+
+// NewDebugServer returns a DebugServer which validates like va, using
+// newResolver to construct a DNS client for each requested resolver address.
+func NewDebugServer(va *ValidationAuthorityImpl, newResolver func(addr string) bdns.DNSClient) *DebugServer {
+	return &DebugServer{va: va, newResolver: newResolver}
+}
+
+// DebugValidation performs the requested validation using the requested
+// resolver and returns the resulting validation records and problem, if any.
+// Remote VAs aren't consulted, and nothing about the authorization is
+// changed.
+func (ds *DebugServer) DebugValidation(ctx context.Context, req *vapb.DebugValidationRequest) (*vapb.ValidationResult, error) {
+	if req == nil || req.Resolver == nil {
+		return nil, bgrpc.ErrMissingParameters
+	}
+	if _, _, err := net.SplitHostPort(*req.Resolver); err != nil {
+		return nil, berrors.MalformedError("invalid resolver address %q: %s", *req.Resolver, err)
+	}
+	domain, challenge, authz, err := bgrpc.PerformValidationReqToArgs(req.Validation)
+	if err != nil {
+		return nil, err
+	}
+
+	va := *ds.va
+	va.dnsClient = ds.newResolver(*req.Resolver)
+	va.remoteVAs = nil
+	va.log.AuditInfof("Debug validation of %s (%s challenge for authz %s) using resolver %s",
+		domain, challenge.Type, authz.ID, *req.Resolver)
+
+	records, prob := va.validate(ctx, identifier.DNSIdentifier(domain), challenge, authz)
+	return bgrpc.ValidationResultToPB(records, prob)
+}
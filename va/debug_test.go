@@ -0,0 +1,52 @@
+package va
+
+import (
+	"context"
+	"testing"
+
+	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/core"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/test"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+func TestDebugValidation(t *testing.T) {
+	va, mockLog := setup(nil, 0, "", nil)
+	var resolvers []string
+	ds := NewDebugServer(va, func(addr string) bdns.DNSClient {
+		resolvers = append(resolvers, addr)
+		return &bdns.MockDNSClient{Log: blog.NewMock()}
+	})
+
+	chall := core.DNSChallenge01("")
+	chall.Token = expectedToken
+	chall.ProvidedKeyAuthorization = expectedKeyAuthorization
+	req, err := bgrpc.ArgsToPerformValidationRequest("good-dns01.com", chall, core.Authorization{ID: "1", RegistrationID: 1})
+	test.AssertNotError(t, err, "creating validation request")
+
+	resolver := "10.0.0.1:53"
+	res, err := ds.DebugValidation(context.Background(), &vapb.DebugValidationRequest{Validation: req, Resolver: &resolver})
+	test.AssertNotError(t, err, "DebugValidation failed")
+	test.Assert(t, res.Problems == nil, "debug validation failed")
+	test.AssertEquals(t, len(res.Records), 1)
+	test.AssertDeepEquals(t, resolvers, []string{resolver})
+	test.AssertEquals(t, len(mockLog.GetAllMatching("Debug validation of good-dns01.com.*using resolver 10.0.0.1:53")), 1)
+	// The VA's own resolver is left alone.
+	_, ok := va.dnsClient.(*bdns.MockDNSClient)
+	test.Assert(t, ok, "VA resolver was replaced")
+
+	// Failed validations are returned as problems, not errors.
+	bad := "bad-dns01.com"
+	req.Domain = &bad
+	res, err = ds.DebugValidation(context.Background(), &vapb.DebugValidationRequest{Validation: req, Resolver: &resolver})
+	test.AssertNotError(t, err, "DebugValidation failed")
+	test.Assert(t, res.Problems != nil, "expected a problem for a failed validation")
+
+	invalid := "10.0.0.1"
+	_, err = ds.DebugValidation(context.Background(), &vapb.DebugValidationRequest{Validation: req, Resolver: &invalid})
+	test.AssertError(t, err, "DebugValidation accepted a resolver without a port")
+	_, err = ds.DebugValidation(context.Background(), &vapb.DebugValidationRequest{Validation: req})
+	test.AssertError(t, err, "DebugValidation accepted a request without a resolver")
+}
@@ -11,19 +11,24 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/iana"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/http/httpguts"
 )
 
 const (
-	// maxRedirect is the maximum number of redirects the VA will follow
-	// processing an HTTP-01 challenge.
-	maxRedirect = 10
+	// defaultMaxRedirects is the maximum number of redirects the VA will
+	// follow processing an HTTP-01 challenge, unless it's configured with
+	// another.
+	defaultMaxRedirects = 10
 	// maxResponseSize holds the maximum number of bytes that will be read from an
 	// HTTP-01 challenge response. The expected payload should be ~87 bytes. Since
 	// it may be padded by whitespace which we previously allowed accept up to 128
@@ -36,8 +41,58 @@ const (
 	// whitespaceCutset is the set of characters trimmed from the right of an
 	// HTTP-01 key authorization response.
 	whitespaceCutset = "\n\r\t "
+	// defaultHappyEyeballsDelay is how long a connection attempt to the IPv6
+	// address of a dual-stacked HTTP-01 target is given before an attempt to
+	// its IPv4 address is started, the "Connection Attempt Delay" recommended
+	// by RFC 8305 Section 5.
+	defaultHappyEyeballsDelay = 250 * time.Millisecond
+	// maxHTTPHeaders is the most extra request headers which may be configured
+	// for HTTP-01 requests.
+	maxHTTPHeaders = 10
 )
 
+// reservedHTTPHeaders are the request headers which can't be configured as
+// extra HTTP-01 request headers, because the VA sets them itself or they
+// would change how the request is made.
+var reservedHTTPHeaders = map[string]bool{
+	"Accept":            true,
+	"Accept-Encoding":   true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Host":              true,
+	"Te":                true,
+	"Trailer":           true,
+	"Transfer-Encoding": true,
+	"Upgrade":           true,
+	"User-Agent":        true,
+}
+
+// makeHTTPHeaders checks the extra request headers configured for HTTP-01
+// requests and returns them in canonical form.
+func makeHTTPHeaders(headers map[string]string) (http.Header, error) {
+	if len(headers) > maxHTTPHeaders {
+		return nil, fmt.Errorf("at most %d HTTP headers may be configured, got %d", maxHTTPHeaders, len(headers))
+	}
+	result := make(http.Header, len(headers))
+	for name, value := range headers {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid HTTP header name %q", name)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("invalid value for HTTP header %q", name)
+		}
+		name = http.CanonicalHeaderKey(name)
+		if reservedHTTPHeaders[name] {
+			return nil, fmt.Errorf("HTTP header %q can't be configured", name)
+		}
+		if _, ok := result[name]; ok {
+			return nil, fmt.Errorf("HTTP header %q configured more than once", name)
+		}
+		result.Set(name, value)
+	}
+	return result, nil
+}
+
 // preresolvedDialer is a struct type that provides a DialContext function which
 // will connect to the provided IP and port instead of letting DNS resolve
 // The hostname of the preresolvedDialer is used to ensure the dial only completes
@@ -47,6 +102,18 @@ type preresolvedDialer struct {
 	port     int
 	hostname string
 	timeout  time.Duration
+
+	// fallbackIP, if set, is raced against ip: it is dialed if ip hasn't
+	// connected within fallbackDelay, or as soon as dialing ip fails, and
+	// whichever connects first is used.
+	fallbackIP    net.IP
+	fallbackDelay time.Duration
+
+	// mu protects connected and attempted, which record the outcome of
+	// DialContext for the validation record.
+	mu        sync.Mutex
+	connected net.IP
+	attempted []net.IP
 }
 
 // a dialerMismatchError is produced when a preresolvedDialer is used to dial
@@ -122,8 +189,21 @@ func (d *preresolvedDialer) DialContext(
 		}
 	}
 
+	if d.fallbackIP == nil {
+		return d.dial(ctx, network, d.ip)
+	}
+	return d.race(ctx, network)
+}
+
+// dial connects to the given pre-resolved IP and the dialer's port, noting
+// the attempt.
+func (d *preresolvedDialer) dial(ctx context.Context, network string, ip net.IP) (net.Conn, error) {
+	d.mu.Lock()
+	d.attempted = append(d.attempted, ip)
+	d.mu.Unlock()
+
 	// Make a new dial address using the pre-resolved IP and port.
-	targetAddr := net.JoinHostPort(d.ip.String(), strconv.Itoa(d.port))
+	targetAddr := net.JoinHostPort(ip.String(), strconv.Itoa(d.port))
 
 	// Create a throw-away dialer using default values and the dialer timeout
 	// (populated from the VA singleDialTimeout).
@@ -135,6 +215,126 @@ func (d *preresolvedDialer) DialContext(
 	return throwAwayDialer.DialContext(ctx, network, targetAddr)
 }
 
+// won records the IP address a race was won by and returns its connection.
+func (d *preresolvedDialer) won(ip net.IP, conn net.Conn) (net.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.connected = ip
+	return conn, nil
+}
+
+// race dials ip, and fallbackIP once fallbackDelay has passed or the dial to
+// ip has failed, in the style of RFC 8305 "Happy Eyeballs". The first
+// connection made is returned and the other attempt is abandoned. If both
+// attempts fail the error from dialing ip is returned.
+func (d *preresolvedDialer) race(ctx context.Context, network string) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	primary := make(chan dialResult, 1)
+	fallback := make(chan dialResult, 1)
+	start := func(ip net.IP, results chan<- dialResult) {
+		go func() {
+			conn, err := d.dial(ctx, network, ip)
+			results <- dialResult{conn, err}
+		}()
+	}
+	start(d.ip, primary)
+	timer := time.NewTimer(d.fallbackDelay)
+	defer timer.Stop()
+
+	var primaryErr error
+	fallbackStarted := false
+	for {
+		select {
+		case <-timer.C:
+			if !fallbackStarted {
+				fallbackStarted = true
+				start(d.fallbackIP, fallback)
+			}
+		case res := <-primary:
+			primary = nil
+			if res.err == nil {
+				if fallbackStarted && fallback != nil {
+					go closeLoser(fallback)
+				}
+				return d.won(d.ip, res.conn)
+			}
+			primaryErr = res.err
+			if !fallbackStarted {
+				fallbackStarted = true
+				start(d.fallbackIP, fallback)
+			} else if fallback == nil {
+				return nil, primaryErr
+			}
+		case res := <-fallback:
+			fallback = nil
+			if res.err == nil {
+				if primary != nil {
+					go closeLoser(primary)
+				}
+				return d.won(d.fallbackIP, res.conn)
+			}
+			if primary == nil {
+				return nil, primaryErr
+			}
+		}
+	}
+}
+
+// dialResult is the outcome of one connection attempt in a race.
+type dialResult struct {
+	conn net.Conn
+	err  error
+}
+
+// updateRecord sets the address used and the addresses tried in record from
+// the attempts made by a racing dialer. It returns false, leaving record
+// unchanged, if the dialer doesn't race or was never used.
+func (d *preresolvedDialer) updateRecord(record *core.ValidationRecord) bool {
+	if d.fallbackIP == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.attempted) == 0 {
+		return false
+	}
+	if d.connected != nil {
+		record.AddressUsed = d.connected
+	}
+	record.AddressesTried = nil
+	for _, ip := range d.attempted {
+		if !ip.Equal(record.AddressUsed) {
+			record.AddressesTried = append(record.AddressesTried, ip)
+		}
+	}
+	return true
+}
+
+// raceFamily returns the address family of the connection made by a racing
+// dialer, or "none" if it didn't connect.
+func (d *preresolvedDialer) raceFamily() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch {
+	case d.connected == nil:
+		return "none"
+	case d.connected.To4() != nil:
+		return "ipv4"
+	default:
+		return "ipv6"
+	}
+}
+
+// closeLoser closes the connection, if any, made by the attempt that lost a
+// race once it finishes. The attempt's context has already been cancelled.
+func closeLoser(results <-chan dialResult) {
+	if res := <-results; res.conn != nil {
+		_ = res.conn.Close()
+	}
+}
+
 // a dialerFunc meets the function signature requirements of
 // a http.Transport.DialContext handler.
 type dialerFunc func(ctx context.Context, network, addr string) (net.Conn, error)
@@ -387,6 +587,12 @@ func (va *ValidationAuthorityImpl) setupHTTPValidation(
 		hostname: target.host,
 		timeout:  va.singleDialTimeout,
 	}
+	// With Happy Eyeballs the fallback address is raced against the target IP
+	// by the dialer instead of being retried after the target IP fails.
+	if features.Enabled(features.HTTP01HappyEyeballs) && len(target.next) > 0 {
+		dialer.fallbackIP = target.next[0]
+		dialer.fallbackDelay = va.happyEyeballsDelay
+	}
 	return dialer, record, nil
 }
 
@@ -471,6 +677,11 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 	ctx, cancel := context.WithDeadline(ctx, deadline)
 	defer cancel()
 	initialReq = initialReq.WithContext(ctx)
+	// The extra headers are set first so that they can't override the headers
+	// the VA sets itself. net/http copies them to the requests for redirects.
+	for name, values := range va.httpHeaders {
+		initialReq.Header[name] = values
+	}
 	if va.userAgent != "" {
 		initialReq.Header.Set("User-Agent", va.userAgent)
 	}
@@ -495,19 +706,22 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 	// DialContext function
 	transport := httpTransport(dialer.DialContext)
 
-	va.log.AuditInfof("Attempting to validate HTTP-01 for %q with GET to %q",
-		initialReq.Host, initialReq.URL.String())
+	va.log.AuditInfof("Attempting to validate HTTP-01 for %q with GET to %q at %s with User-Agent %q",
+		initialReq.Host, initialReq.URL.String(), baseRecord.AddressUsed, initialReq.UserAgent())
 
 	// Create a closure around records & numRedirects we can use with a HTTP
 	// client to process redirects per our own policy (e.g. resolving IP
 	// addresses explicitly, not following redirects to ports != [80,443], etc)
 	records := []core.ValidationRecord{baseRecord}
+	// dialers holds the dialer used for each of the records, so that the
+	// records can be updated with the addresses raced dialers connected to.
+	dialers := []*preresolvedDialer{dialer}
 	numRedirects := 0
-	processRedirect := func(req *http.Request, via []*http.Request) error {
+	followRedirect := func(req *http.Request) error {
 		va.log.Debugf("processing a HTTP redirect from the server to %q", req.URL.String())
-		// Only process up to maxRedirect redirects
-		if numRedirects > maxRedirect {
-			return berrors.ConnectionFailureError("Too many redirects")
+		// Only process up to maxRedirects redirects
+		if numRedirects >= va.maxRedirects {
+			return berrors.ConnectionFailureError("Too many redirects, only %d are followed", va.maxRedirects)
 		}
 		numRedirects++
 		va.metrics.http01Redirects.Inc()
@@ -547,6 +761,7 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		// the IP address we selected.
 		redirDialer, redirRecord, err := va.setupHTTPValidation(ctx, req.URL.String(), redirTarget)
 		records = append(records, redirRecord)
+		dialers = append(dialers, redirDialer)
 		if err != nil {
 			return err
 		}
@@ -556,6 +771,21 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		transport.DialContext = redirDialer.DialContext
 		return nil
 	}
+	// processRedirect audit logs every hop of the redirect chain, along with
+	// the address it resolved to, or the reason it was rejected.
+	processRedirect := func(req *http.Request, via []*http.Request) error {
+		from := via[len(via)-1].URL.String()
+		err := followRedirect(req)
+		if err != nil {
+			va.log.AuditInfof("HTTP-01 redirect for %q from %q to %q rejected: %s",
+				initialReq.Host, from, req.URL.String(), err)
+			return err
+		}
+		last := records[len(records)-1]
+		va.log.AuditInfof("HTTP-01 redirect %d of at most %d for %q: following from %q to %q at %s",
+			numRedirects, va.maxRedirects, initialReq.Host, from, last.URL, last.AddressUsed)
+		return nil
+	}
 
 	// Create a new HTTP client configured to use the customized transport and
 	// to check HTTP redirects encountered with processRedirect
@@ -564,12 +794,28 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		CheckRedirect: processRedirect,
 	}
 
+	// noteRaces updates the records made by raced dialers with the addresses
+	// they connected to and logs which address family won each race.
+	noteRaces := func() {
+		for i, d := range dialers {
+			if d == nil || !d.updateRecord(&records[i]) {
+				continue
+			}
+			family := d.raceFamily()
+			va.metrics.http01HappyEyeballs.With(prometheus.Labels{"family": family}).Inc()
+			va.log.AuditInfof("HTTP-01 connection for %q raced %s against %s: connected over %s to %s",
+				records[i].URL, d.ip, d.fallbackIP, family, records[i].AddressUsed)
+		}
+	}
+
 	// Make the initial validation request. This may result in redirects being
 	// followed.
 	httpResponse, err := client.Do(initialReq)
+	noteRaces()
 	// If there was an error and its a kind of error we consider a fallback error,
-	// then try to fallback.
-	if err != nil && fallbackErr(err) {
+	// then try to fallback. A dialer which raced the fallback address has
+	// already tried it.
+	if err != nil && fallbackErr(err) && dialer.fallbackIP == nil {
 		// Try to advance to another IP. If there was an error advancing we don't
 		// have a fallback address to use and must return the original error.
 		if ipErr := target.nextIP(); ipErr != nil {
@@ -577,13 +823,18 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		}
 
 		// setup another validation to retry the target with the new IP and append
-		// the retry record.
+		// the retry record. The retry starts over from the initial URL, so the
+		// address that failed is that of the base record.
+		dialErr := err
 		retryDialer, retryRecord, err := va.setupHTTPValidation(ctx, initialReq.URL.String(), target)
+		retryRecord.AddressesTried = []net.IP{baseRecord.AddressUsed}
 		records = append(records, retryRecord)
 		if err != nil {
 			return nil, records, err
 		}
 		va.metrics.http01Fallbacks.Inc()
+		va.log.AuditInfof("Retrying HTTP-01 GET to %q at %s after failing at %s: %s",
+			initialReq.URL.String(), retryRecord.AddressUsed, baseRecord.AddressUsed, dialErr)
 		// Replace the transport's dialer with the preresolvedDialer for the retry
 		// host.
 		transport.DialContext = retryDialer.DialContext
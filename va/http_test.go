@@ -20,6 +20,7 @@ import (
 	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
 	"github.com/letsencrypt/boulder/test"
@@ -102,6 +103,92 @@ func TestPreresolvedDialerTimeout(t *testing.T) {
 	}
 }
 
+// TestPreresolvedDialerRace tests that a preresolvedDialer with a fallback IP
+// uses whichever of its addresses connects and records the attempts it made.
+func TestPreresolvedDialerRace(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "listening on 127.0.0.1")
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	port := listener.Addr().(*net.TCPAddr).Port
+	listening := net.ParseIP("127.0.0.1")
+	closed := net.ParseIP("127.0.0.2")
+
+	testCases := []struct {
+		Name              string
+		IP                net.IP
+		FallbackIP        net.IP
+		ExpectedErr       bool
+		ExpectedUsed      net.IP
+		ExpectedTried     []net.IP
+		ExpectedFamily    string
+		ExpectedAttempted int
+	}{
+		{
+			Name:              "Primary connects before the fallback delay",
+			IP:                listening,
+			FallbackIP:        closed,
+			ExpectedUsed:      listening,
+			ExpectedFamily:    "ipv4",
+			ExpectedAttempted: 1,
+		},
+		{
+			Name:              "Fallback connects after the primary fails",
+			IP:                closed,
+			FallbackIP:        listening,
+			ExpectedUsed:      listening,
+			ExpectedTried:     []net.IP{closed},
+			ExpectedFamily:    "ipv4",
+			ExpectedAttempted: 2,
+		},
+		{
+			Name:              "Both fail",
+			IP:                closed,
+			FallbackIP:        net.ParseIP("127.0.0.3"),
+			ExpectedErr:       true,
+			ExpectedUsed:      closed,
+			ExpectedTried:     []net.IP{net.ParseIP("127.0.0.3")},
+			ExpectedFamily:    "none",
+			ExpectedAttempted: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := &preresolvedDialer{
+				ip:            tc.IP,
+				port:          port,
+				hostname:      "example.com",
+				timeout:       time.Second,
+				fallbackIP:    tc.FallbackIP,
+				fallbackDelay: time.Minute,
+			}
+			conn, err := d.DialContext(context.Background(), "tcp", net.JoinHostPort("example.com", strconv.Itoa(port)))
+			if tc.ExpectedErr {
+				test.AssertError(t, err, "expected both dials to fail")
+			} else {
+				test.AssertNotError(t, err, "expected a dial to succeed")
+				conn.Close()
+			}
+
+			record := core.ValidationRecord{AddressUsed: tc.IP}
+			test.Assert(t, d.updateRecord(&record), "expected a raced dialer to update the record")
+			test.AssertEquals(t, record.AddressUsed.String(), tc.ExpectedUsed.String())
+			test.AssertDeepEquals(t, record.AddressesTried, tc.ExpectedTried)
+			test.AssertEquals(t, d.raceFamily(), tc.ExpectedFamily)
+			test.AssertEquals(t, len(d.attempted), tc.ExpectedAttempted)
+		})
+	}
+}
+
 func TestHTTPTransport(t *testing.T) {
 	dummyDialerFunc := func(_ context.Context, _, _ string) (net.Conn, error) {
 		return nil, nil
@@ -504,7 +591,7 @@ func httpTestSrv(t *testing.T) *httptest.Server {
 	})
 
 	// A path that always redirects to itself, creating a loop that will terminate
-	// after maxRedirect.
+	// after maxRedirects.
 	mux.HandleFunc("/loop", func(resp http.ResponseWriter, req *http.Request) {
 		http.Redirect(
 			resp,
@@ -706,10 +793,10 @@ func TestFetchHTTP(t *testing.T) {
 	httpPort := getPort(testSrv)
 
 	// For the looped test case we expect one validation record per redirect up to
-	// maxRedirect (inclusive). There is also +1 record for the base lookup,
-	// giving a termination criteria of > maxRedirect+1
+	// defaultMaxRedirects (inclusive). There is also +1 record for the base
+	// lookup.
 	expectedLoopRecords := []core.ValidationRecord{}
-	for i := 0; i <= maxRedirect+1; i++ {
+	for i := 0; i <= defaultMaxRedirects; i++ {
 		// The first request will not have a port # in the URL.
 		url := "http://example.com/loop"
 		if i != 0 {
@@ -770,7 +857,7 @@ func TestFetchHTTP(t *testing.T) {
 			Host: "example.com",
 			Path: "/loop",
 			ExpectedProblem: probs.ConnectionFailure(fmt.Sprintf(
-				"Fetching http://example.com:%d/loop: Too many redirects", httpPort)),
+				"Fetching http://example.com:%d/loop: Too many redirects, only 10 are followed", httpPort)),
 			ExpectedRecords: expectedLoopRecords,
 		},
 		{
@@ -912,7 +999,8 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://ipv4.and.ipv6.localhost/ok",
 					AddressesResolved: []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1")},
 					// The second validation record should have used the IPv4 addr as a fallback
-					AddressUsed: net.ParseIP("127.0.0.1"),
+					AddressUsed:    net.ParseIP("127.0.0.1"),
+					AddressesTried: []net.IP{net.ParseIP("::1")},
 				},
 			},
 		},
@@ -996,6 +1084,43 @@ func TestFetchHTTP(t *testing.T) {
 	}
 }
 
+// TestFetchHTTPMaxRedirects tests that a configured maximum number of
+// redirects is enforced and that every hop is audit logged.
+func TestFetchHTTPMaxRedirects(t *testing.T) {
+	testSrv := httpTestSrv(t)
+	defer testSrv.Close()
+
+	va, log := setup(testSrv, 0, "", nil)
+	va.maxRedirects = 2
+	httpPort := getPort(testSrv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*500)
+	defer cancel()
+	_, records, prob := va.fetchHTTP(ctx, "example.com", "/loop")
+	loopURL := fmt.Sprintf("http://example.com:%d/loop", httpPort)
+	test.AssertMarshaledEquals(t, prob, probs.ConnectionFailure(
+		"Fetching "+loopURL+": Too many redirects, only 2 are followed"))
+	// The base record and one for each redirect followed.
+	test.AssertEquals(t, len(records), 3)
+
+	hops := log.GetAllMatching(`\[AUDIT\] HTTP-01 redirect \d of at most 2 for "example.com": following from ".*" to "` +
+		regexp.QuoteMeta(loopURL) + `" at 127.0.0.1`)
+	test.AssertEquals(t, len(hops), 2)
+	rejected := log.GetAllMatching(`\[AUDIT\] HTTP-01 redirect for "example.com" from "` +
+		regexp.QuoteMeta(loopURL) + `" to "` + regexp.QuoteMeta(loopURL) + `" rejected: Too many redirects`)
+	test.AssertEquals(t, len(rejected), 1)
+
+	// Redirects to a disallowed scheme are rejected, and audit logged, with
+	// the reason why.
+	log.Clear()
+	_, _, prob = va.fetchHTTP(ctx, "example.com", "/redir-bad-proto")
+	if prob == nil {
+		t.Fatal("redirect to a bad scheme was followed")
+	}
+	rejected = log.GetAllMatching(`\[AUDIT\] HTTP-01 redirect for "example.com" from "http://example.com/redir-bad-proto" to "gopher://example.com" rejected: Invalid protocol scheme`)
+	test.AssertEquals(t, len(rejected), 1)
+}
+
 // All paths that get assigned to tokens MUST be valid tokens
 const expectedToken = "LoqXcYV8q5ONbJQxbmR7SCTNo3tiAXDfowyjxAjEuX0"
 const expectedKeyAuthorization = "LoqXcYV8q5ONbJQxbmR7SCTNo3tiAXDfowyjxAjEuX0.9jg46WB3rR_AHD-EBXdN7cBkH1WOu0tA3M9fm21mqTI"
@@ -1088,6 +1213,36 @@ func httpSrv(t *testing.T, token string) *httptest.Server {
 	return server
 }
 
+// TestFetchHTTPHappyEyeballs tests that with the HTTP01HappyEyeballs feature a
+// dual homed host with broken IPv6 is validated over IPv4 with the raced IPv6
+// address recorded as tried, instead of with a separate fallback record.
+func TestFetchHTTPHappyEyeballs(t *testing.T) {
+	testSrv := httpTestSrv(t)
+	defer testSrv.Close()
+	va, _ := setup(testSrv, 0, "", nil)
+	err := features.Set(map[string]bool{"HTTP01HappyEyeballs": true})
+	test.AssertNotError(t, err, "setting HTTP01HappyEyeballs feature")
+	defer features.Reset()
+	httpPort := getPort(testSrv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	body, records, prob := va.fetchHTTP(ctx, "ipv4.and.ipv6.localhost", "/ok")
+	test.Assert(t, prob == nil, fmt.Sprintf("unexpected problem: %v", prob))
+	test.AssertEquals(t, string(body), "ok")
+	test.AssertDeepEquals(t, records, []core.ValidationRecord{
+		{
+			Hostname:          "ipv4.and.ipv6.localhost",
+			Port:              strconv.Itoa(httpPort),
+			URL:               "http://ipv4.and.ipv6.localhost/ok",
+			AddressesResolved: []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1")},
+			AddressUsed:       net.ParseIP("127.0.0.1"),
+			AddressesTried:    []net.IP{net.ParseIP("::1")},
+		},
+	})
+	test.AssertEquals(t, test.CountCounterVec("family", "ipv4", va.metrics.http01HappyEyeballs), 1)
+}
+
 func TestHTTPBadPort(t *testing.T) {
 	chall := core.HTTPChallenge01("")
 	setChallengeToken(&chall, expectedToken)
@@ -1415,6 +1570,68 @@ func TestHTTPRedirectUserAgent(t *testing.T) {
 	}
 }
 
+// TestHTTPHeaders tests that the configured extra request headers and
+// User-Agent are sent with the initial and redirected HTTP-01 requests, and
+// that the User-Agent is audit logged.
+func TestHTTPHeaders(t *testing.T) {
+	var seen []http.Header
+	m := http.NewServeMux()
+	m.HandleFunc("/first", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header)
+		http.Redirect(w, r, "/second", http.StatusFound)
+	})
+	m.HandleFunc("/second", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header)
+		fmt.Fprint(w, "ok")
+	})
+	hs := httptest.NewServer(m)
+	defer hs.Close()
+
+	va, mockLog := setup(hs, 0, "validator 2.0", nil)
+	var err error
+	va.httpHeaders, err = makeHTTPHeaders(map[string]string{"x-validator-id": "perspective-1"})
+	test.AssertNotError(t, err, "makeHTTPHeaders failed")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	body, _, prob := va.fetchHTTP(ctx, "valid.com", "/first")
+	test.Assert(t, prob == nil, fmt.Sprintf("unexpected problem: %v", prob))
+	test.AssertEquals(t, string(body), "ok")
+	test.AssertEquals(t, len(seen), 2)
+	for _, header := range seen {
+		test.AssertEquals(t, header.Get("X-Validator-Id"), "perspective-1")
+		test.AssertEquals(t, header.Get("User-Agent"), "validator 2.0")
+		test.AssertEquals(t, header.Get("Accept"), "*/*")
+	}
+	test.AssertEquals(t, len(mockLog.GetAllMatching(`Attempting to validate HTTP-01 .* with User-Agent "validator 2\.0"`)), 1)
+}
+
+func TestMakeHTTPHeaders(t *testing.T) {
+	headers, err := makeHTTPHeaders(nil)
+	test.AssertNotError(t, err, "makeHTTPHeaders failed without headers")
+	test.AssertEquals(t, len(headers), 0)
+
+	tooMany := make(map[string]string)
+	for i := 0; i <= maxHTTPHeaders; i++ {
+		tooMany[fmt.Sprintf("X-Header-%d", i)] = "value"
+	}
+	for _, tc := range []struct {
+		headers  map[string]string
+		errorMsg string
+	}{
+		{map[string]string{"user-agent": "x"}, `HTTP header "User-Agent" can't be configured`},
+		{map[string]string{"Host": "example.com"}, `HTTP header "Host" can't be configured`},
+		{map[string]string{"X Header": "value"}, `invalid HTTP header name "X Header"`},
+		{map[string]string{"X-Header": "a\nb"}, `invalid value for HTTP header "X-Header"`},
+		{map[string]string{"X-Header": "a", "x-header": "b"}, `HTTP header "X-Header" configured more than once`},
+		{tooMany, fmt.Sprintf("at most %d HTTP headers may be configured, got %d", maxHTTPHeaders, maxHTTPHeaders+1)},
+	} {
+		_, err := makeHTTPHeaders(tc.headers)
+		test.AssertError(t, err, "makeHTTPHeaders didn't fail")
+		test.AssertEquals(t, err.Error(), tc.errorMsg)
+	}
+}
+
 func getPort(hs *httptest.Server) int {
 	url, err := url.Parse(hs.URL)
 	if err != nil {
@@ -16,6 +16,7 @@ import (
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
+	"github.com/miekg/dns"
 )
 
 const (
@@ -39,14 +40,17 @@ var (
 )
 
 // certNames collects up all of a certificate's subject names (Subject CN and
-// Subject Alternate Names) and reduces them to a unique, sorted set, typically for an
-// error message
+// Subject Alternate Names, including IP addresses) and reduces them to a unique,
+// sorted set, typically for an error message
 func certNames(cert *x509.Certificate) []string {
 	var names []string
 	if cert.Subject.CommonName != "" {
 		names = append(names, cert.Subject.CommonName)
 	}
 	names = append(names, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
 	names = core.UniqueLowerNames(names)
 	for i, n := range names {
 		names[i] = replaceInvalidUTF8([]byte(n))
@@ -55,13 +59,20 @@ func certNames(cert *x509.Certificate) []string {
 }
 
 func (va *ValidationAuthorityImpl) tryGetTLSCerts(ctx context.Context,
-	identifier identifier.ACMEIdentifier, challenge core.Challenge,
+	ident identifier.ACMEIdentifier, challenge core.Challenge,
 	tlsConfig *tls.Config) ([]*x509.Certificate, *tls.ConnectionState, []core.ValidationRecord, *probs.ProblemDetails) {
 
-	allAddrs, err := va.getAddrs(ctx, identifier.Value)
+	// IP identifiers are connected to directly, without any DNS lookups.
+	var allAddrs []net.IP
+	var err error
+	if ident.Type == identifier.IP {
+		allAddrs = []net.IP{net.ParseIP(ident.Value)}
+	} else {
+		allAddrs, err = va.getAddrs(ctx, ident.Value)
+	}
 	validationRecords := []core.ValidationRecord{
 		{
-			Hostname:          identifier.Value,
+			Hostname:          ident.Value,
 			AddressesResolved: allAddrs,
 			Port:              strconv.Itoa(va.tlsPort),
 		},
@@ -77,7 +88,7 @@ func (va *ValidationAuthorityImpl) tryGetTLSCerts(ctx context.Context,
 
 	// This shouldn't happen, but be defensive about it anyway
 	if len(addresses) < 1 {
-		return nil, nil, validationRecords, probs.Malformed("no IP addresses found for %q", identifier.Value)
+		return nil, nil, validationRecords, probs.Malformed("no IP addresses found for %q", ident.Value)
 	}
 
 	// If there is at least one IPv6 address then try it first
@@ -85,7 +96,7 @@ func (va *ValidationAuthorityImpl) tryGetTLSCerts(ctx context.Context,
 		address := net.JoinHostPort(v6[0].String(), thisRecord.Port)
 		thisRecord.AddressUsed = v6[0]
 
-		certs, cs, prob := va.getTLSCerts(ctx, address, identifier, challenge, tlsConfig)
+		certs, cs, prob := va.getTLSCerts(ctx, address, ident, challenge, tlsConfig)
 
 		// If there is no problem, return immediately
 		if err == nil {
@@ -112,7 +123,7 @@ func (va *ValidationAuthorityImpl) tryGetTLSCerts(ctx context.Context,
 	// talking to the first IPv6 address, try the first IPv4 address
 	thisRecord.AddressUsed = v4[0]
 	certs, cs, prob := va.getTLSCerts(ctx, net.JoinHostPort(v4[0].String(), thisRecord.Port),
-		identifier, challenge, tlsConfig)
+		ident, challenge, tlsConfig)
 	return certs, cs, validationRecords, prob
 }
 
@@ -174,15 +185,42 @@ func (va *ValidationAuthorityImpl) tlsDial(ctx context.Context, hostPort string,
 	return conn, nil
 }
 
-func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identifier identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
-	if identifier.Type != "dns" {
-		va.log.Info(fmt.Sprintf("Identifier type for TLS-ALPN-01 was not DNS: %s", identifier))
-		return nil, probs.Malformed("Identifier type for TLS-ALPN-01 was not DNS")
+// tlsALPN01ServerName returns the SNI to send when validating an IP
+// identifier: per RFC 8738 section 6, the reverse mapping name (in the
+// in-addr.arpa or ip6.arpa domain) of the address, since IP literals aren't
+// permitted in SNI.
+func tlsALPN01ServerName(ip net.IP) (string, error) {
+	arpa, err := dns.ReverseAddr(ip.String())
+	if err != nil {
+		return "", err
 	}
+	return strings.TrimSuffix(arpa, "."), nil
+}
 
-	certs, cs, validationRecords, problem := va.tryGetTLSCerts(ctx, identifier, challenge, &tls.Config{
+func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, ident identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
+	serverName := ident.Value
+	var ip net.IP
+	switch ident.Type {
+	case identifier.DNS:
+	case identifier.IP:
+		ip = net.ParseIP(ident.Value)
+		if ip == nil {
+			va.log.Info(fmt.Sprintf("Invalid IP identifier for TLS-ALPN-01: %s", ident))
+			return nil, probs.Malformed("Invalid IP address %q for TLS-ALPN-01", ident.Value)
+		}
+		var err error
+		serverName, err = tlsALPN01ServerName(ip)
+		if err != nil {
+			return nil, probs.Malformed("Invalid IP address %q for TLS-ALPN-01", ident.Value)
+		}
+	default:
+		va.log.Info(fmt.Sprintf("Identifier type for TLS-ALPN-01 was not DNS or IP: %s", ident))
+		return nil, probs.Malformed("Identifier type for TLS-ALPN-01 was not DNS or IP")
+	}
+
+	certs, cs, validationRecords, problem := va.tryGetTLSCerts(ctx, ident, challenge, &tls.Config{
 		NextProtos: []string{ACMETLS1Protocol},
-		ServerName: identifier.Value,
+		ServerName: serverName,
 	})
 	if problem != nil {
 		return validationRecords, problem
@@ -199,15 +237,22 @@ func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identi
 
 	leafCert := certs[0]
 
-	// Verify SNI - certificate returned must be issued only for the domain we are verifying.
-	if len(leafCert.DNSNames) != 1 || !strings.EqualFold(leafCert.DNSNames[0], identifier.Value) {
+	// Verify SNI - certificate returned must be issued only for the domain we are verifying,
+	// or for an IP identifier have only the address as an iPAddress subjectAltName.
+	var wrongNames bool
+	if ip != nil {
+		wrongNames = len(leafCert.DNSNames) != 0 || len(leafCert.IPAddresses) != 1 || !leafCert.IPAddresses[0].Equal(ip)
+	} else {
+		wrongNames = len(leafCert.DNSNames) != 1 || !strings.EqualFold(leafCert.DNSNames[0], ident.Value)
+	}
+	if wrongNames {
 		hostPort := net.JoinHostPort(validationRecords[0].AddressUsed.String(), validationRecords[0].Port)
 		names := certNames(leafCert)
 		errText := fmt.Sprintf(
 			"Incorrect validation certificate for %s challenge. "+
 				"Requested %s from %s. Received %d certificate(s), "+
 				"first certificate had names %q",
-			challenge.Type, identifier.Value, hostPort, len(certs), strings.Join(names, ", "))
+			challenge.Type, ident.Value, hostPort, len(certs), strings.Join(names, ", "))
 		return validationRecords, probs.Unauthorized(errText)
 	}
 
@@ -146,11 +146,108 @@ func TestTLSALPN01FailIP(t *testing.T) {
 		Value: net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
 	}, chall)
 	if prob == nil {
-		t.Fatalf("IdentifierType IP shouldn't have worked.")
+		t.Fatalf("IdentifierType IP with a port shouldn't have worked.")
 	}
 	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
 }
 
+// tlsalpn01SrvWithIP returns a TLS-ALPN-01 server listening on ip which, when
+// given the reverse mapping name of ip as SNI, presents a challenge
+// certificate with certIP as its only subjectAltName.
+func tlsalpn01SrvWithIP(t *testing.T, chall core.Challenge, ip net.IP, certIP net.IP) *httptest.Server {
+	t.Helper()
+	serverName, err := tlsALPN01ServerName(ip)
+	test.AssertNotError(t, err, "Failed to make the reverse mapping name")
+
+	template := tlsCertTemplate(nil)
+	template.IPAddresses = []net.IP{certIP}
+	shasum := sha256.Sum256([]byte(chall.ProvidedKeyAuthorization))
+	encHash, _ := asn1.Marshal(shasum[:])
+	template.ExtraExtensions = []pkix.Extension{{
+		Id:       IdPeAcmeIdentifier,
+		Critical: true,
+		Value:    encHash,
+	}}
+	certBytes, _ := x509.CreateCertificate(rand.Reader, template, template, &TheKey.PublicKey, &TheKey)
+	acmeCert := &tls.Certificate{
+		Certificate: [][]byte{certBytes},
+		PrivateKey:  &TheKey,
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(ip.String(), "0"))
+	test.AssertNotError(t, err, "Failed to listen")
+	hs := httptest.NewUnstartedServer(http.DefaultServeMux)
+	hs.Listener = listener
+	hs.TLS = &tls.Config{
+		GetCertificate: func(clientHello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			if clientHello.ServerName != serverName {
+				return nil, fmt.Errorf("unexpected SNI %q", clientHello.ServerName)
+			}
+			return acmeCert, nil
+		},
+		NextProtos: []string{ACMETLS1Protocol},
+	}
+	hs.Config.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){
+		ACMETLS1Protocol: func(_ *http.Server, conn *tls.Conn, _ http.Handler) {
+			_ = conn.Close()
+		},
+	}
+	hs.StartTLS()
+	return hs
+}
+
+func TestTLSALPN01ServerName(t *testing.T) {
+	name, err := tlsALPN01ServerName(net.ParseIP("192.0.2.1"))
+	test.AssertNotError(t, err, "tlsALPN01ServerName failed")
+	test.AssertEquals(t, name, "1.2.0.192.in-addr.arpa")
+
+	name, err = tlsALPN01ServerName(net.ParseIP("2001:db8::1"))
+	test.AssertNotError(t, err, "tlsALPN01ServerName failed")
+	test.AssertEquals(t, name, "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa")
+}
+
+func TestTLSALPN01IPSuccess(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "::1"} {
+		t.Run(addr, func(t *testing.T) {
+			ip := net.ParseIP(addr)
+			chall := createChallenge(core.ChallengeTypeTLSALPN01)
+			hs := tlsalpn01SrvWithIP(t, chall, ip, ip)
+			defer hs.Close()
+			va, _ := setup(hs, 0, "", nil)
+
+			// validate rather than validateChallenge, to check that CAA, which
+			// doesn't apply to IP addresses, isn't looked up.
+			records, prob := va.validate(ctx, identifier.IPIdentifier(ip), chall, core.Authorization{})
+			if prob != nil {
+				t.Fatalf("Validation failed: %v", prob)
+			}
+			test.AssertEquals(t, len(records), 1)
+			test.AssertEquals(t, records[0].Hostname, addr)
+			test.Assert(t, records[0].AddressUsed.Equal(ip), "Wrong address used")
+			test.AssertDeepEquals(t, records[0].AddressesResolved, []net.IP{ip})
+		})
+	}
+}
+
+func TestTLSALPN01IPWrongAddress(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "::1"} {
+		t.Run(addr, func(t *testing.T) {
+			ip := net.ParseIP(addr)
+			chall := createChallenge(core.ChallengeTypeTLSALPN01)
+			hs := tlsalpn01SrvWithIP(t, chall, ip, net.ParseIP("192.0.2.1"))
+			defer hs.Close()
+			va, _ := setup(hs, 0, "", nil)
+
+			_, prob := va.validateChallenge(ctx, identifier.IPIdentifier(ip), chall)
+			if prob == nil {
+				t.Fatalf("Validation succeeded with a certificate for the wrong address")
+			}
+			test.AssertEquals(t, prob.Type, probs.UnauthorizedProblem)
+			test.AssertContains(t, prob.Detail, "192.0.2.1")
+		})
+	}
+}
+
 func slowTLSSrv() *httptest.Server {
 	server := httptest.NewUnstartedServer(http.DefaultServeMux)
 	server.TLS = &tls.Config{
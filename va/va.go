@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"regexp"
@@ -83,6 +84,7 @@ type vaMetrics struct {
 	tlsALPNOIDCounter                   *prometheus.CounterVec
 	http01Fallbacks                     prometheus.Counter
 	http01Redirects                     prometheus.Counter
+	http01HappyEyeballs                 *prometheus.CounterVec
 	caaCounter                          *prometheus.CounterVec
 	ipv4FallbackCounter                 prometheus.Counter
 }
@@ -144,6 +146,13 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 			Help: "Number of HTTP-01 redirects followed",
 		})
 	stats.MustRegister(http01Redirects)
+	http01HappyEyeballs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http01_happy_eyeballs",
+			Help: "Number of raced HTTP-01 connection attempts sliced by the address family which connected first, or none",
+		},
+		[]string{"family"})
+	stats.MustRegister(http01HappyEyeballs)
 	caaCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "caa_sets_processed",
 		Help: "A counter of CAA sets processed labelled by result",
@@ -164,6 +173,7 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 		tlsALPNOIDCounter:                   tlsALPNOIDCounter,
 		http01Fallbacks:                     http01Fallbacks,
 		http01Redirects:                     http01Redirects,
+		http01HappyEyeballs:                 http01HappyEyeballs,
 		caaCounter:                          caaCounter,
 		ipv4FallbackCounter:                 ipv4FallbackCounter,
 	}
@@ -178,12 +188,18 @@ type ValidationAuthorityImpl struct {
 	httpsPort          int
 	tlsPort            int
 	userAgent          string
+	httpHeaders        http.Header
 	clk                clock.Clock
 	remoteVAs          []RemoteVA
 	maxRemoteFailures  int
+	remotePerspectives int
+	remoteQuorum       int
 	multiVAPolicy      *MultiVAPolicy
 	accountURIPrefixes []string
 	singleDialTimeout  time.Duration
+	challengeTimeouts  map[string]time.Duration
+	happyEyeballsDelay time.Duration
+	maxRedirects       int
 
 	metrics *vaMetrics
 }
@@ -195,12 +211,18 @@ func NewValidationAuthorityImpl(
 	remoteVAs []RemoteVA,
 	maxRemoteFailures int,
 	userAgent string,
+	httpHeaders map[string]string,
 	issuerDomain string,
 	stats prometheus.Registerer,
 	clk clock.Clock,
 	logger blog.Logger,
 	accountURIPrefixes []string,
 	multiVAPolicyFile string,
+	challengeTimeouts map[string]time.Duration,
+	remotePerspectives int,
+	remoteQuorum int,
+	happyEyeballsDelay time.Duration,
+	maxRedirects int,
 ) (*ValidationAuthorityImpl, error) {
 	if pc.HTTPPort == 0 {
 		pc.HTTPPort = 80
@@ -211,11 +233,54 @@ func NewValidationAuthorityImpl(
 	if pc.TLSPort == 0 {
 		pc.TLSPort = 443
 	}
+	if happyEyeballsDelay == 0 {
+		happyEyeballsDelay = defaultHappyEyeballsDelay
+	}
+	if happyEyeballsDelay < 0 {
+		return nil, fmt.Errorf("happy eyeballs delay must not be negative, got %s", happyEyeballsDelay)
+	}
+	if maxRedirects == 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+	if maxRedirects < 0 {
+		return nil, fmt.Errorf("max redirects must not be negative, got %d", maxRedirects)
+	}
+
+	headers, err := makeHTTPHeaders(httpHeaders)
+	if err != nil {
+		return nil, err
+	}
 
 	if features.Enabled(features.CAAAccountURI) && len(accountURIPrefixes) == 0 {
 		return nil, errors.New("no account URI prefixes configured")
 	}
 
+	for challType, timeout := range challengeTimeouts {
+		if !core.ValidChallenge(challType) {
+			return nil, fmt.Errorf("challenge timeout configured for unknown challenge type %q", challType)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("challenge timeout for %q must be positive, got %s", challType, timeout)
+		}
+	}
+
+	if remotePerspectives < 0 || remotePerspectives > len(remoteVAs) {
+		return nil, fmt.Errorf("remote perspectives must be between 0 and the number of remote VAs (%d), got %d",
+			len(remoteVAs), remotePerspectives)
+	}
+	if remoteQuorum < 0 {
+		return nil, fmt.Errorf("remote quorum must not be negative, got %d", remoteQuorum)
+	}
+	if perspectives := remotePerspectives; remoteQuorum > 0 {
+		if perspectives == 0 {
+			perspectives = len(remoteVAs)
+		}
+		if remoteQuorum > perspectives {
+			return nil, fmt.Errorf("remote quorum (%d) must not exceed the number of remote perspectives (%d)",
+				remoteQuorum, perspectives)
+		}
+	}
+
 	va := &ValidationAuthorityImpl{
 		log:                logger,
 		dnsClient:          resolver,
@@ -224,16 +289,22 @@ func NewValidationAuthorityImpl(
 		httpsPort:          pc.HTTPSPort,
 		tlsPort:            pc.TLSPort,
 		userAgent:          userAgent,
+		httpHeaders:        headers,
 		clk:                clk,
 		metrics:            initMetrics(stats),
 		remoteVAs:          remoteVAs,
 		maxRemoteFailures:  maxRemoteFailures,
+		remotePerspectives: remotePerspectives,
+		remoteQuorum:       remoteQuorum,
 		accountURIPrefixes: accountURIPrefixes,
 		// singleDialTimeout specifies how long an individual `DialContext` operation may take
 		// before timing out. This timeout ignores the base RPC timeout and is strictly
 		// used for the DialContext operations that take place during an
 		// HTTP-01 challenge validation.
-		singleDialTimeout: 10 * time.Second,
+		singleDialTimeout:  10 * time.Second,
+		challengeTimeouts:  challengeTimeouts,
+		happyEyeballsDelay: happyEyeballsDelay,
+		maxRedirects:       maxRedirects,
 	}
 
 	// if a multiVAPolicyFile was specified then set up a live reloader and
@@ -264,6 +335,44 @@ type verificationRequestEvent struct {
 	Challenge         core.Challenge `json:",omitempty"`
 	ValidationLatency float64
 	Error             string `json:",omitempty"`
+	// RemoteResults holds the result from each remote perspective that
+	// reported one before the overall result was decided.
+	RemoteResults []*remoteValidationResult `json:",omitempty"`
+}
+
+// multiVAQuorum returns the number of remote VAs that should be asked to
+// perform each validation and how many of them must agree with the primary VA
+// for the validation to succeed. A remotePerspectives value of zero means
+// every remote VA is asked and a remoteQuorum value of zero means the quorum is
+// derived from maxRemoteFailures.
+func (va *ValidationAuthorityImpl) multiVAQuorum() (perspectives, quorum int) {
+	perspectives = va.remotePerspectives
+	if perspectives == 0 || perspectives > len(va.remoteVAs) {
+		perspectives = len(va.remoteVAs)
+	}
+	quorum = va.remoteQuorum
+	if quorum == 0 {
+		quorum = perspectives - va.maxRemoteFailures
+	}
+	if quorum > perspectives {
+		quorum = perspectives
+	}
+	return perspectives, quorum
+}
+
+// timeoutError is implemented by errors that describe a timeout, such as the
+// errors returned by the gRPC client when a deadline is exceeded.
+type timeoutError interface {
+	Timeout() bool
+}
+
+// isTimeout returns true if err represents a remote VA RPC that timed out
+// rather than one that failed outright.
+func isTimeout(ctx context.Context, err error) bool {
+	if te, ok := err.(timeoutError); ok && te.Timeout() {
+		return true
+	}
+	return ctx.Err() == context.DeadlineExceeded
 }
 
 // detailedError returns a ProblemDetails corresponding to an error
@@ -329,7 +438,7 @@ func detailedError(err error) *probs.ProblemDetails {
 // validation attempt.
 func (va *ValidationAuthorityImpl) validate(
 	ctx context.Context,
-	identifier identifier.ACMEIdentifier,
+	ident identifier.ACMEIdentifier,
 	challenge core.Challenge,
 	authz core.Authorization,
 ) ([]core.ValidationRecord, *probs.ProblemDetails) {
@@ -337,22 +446,27 @@ func (va *ValidationAuthorityImpl) validate(
 	// If the identifier is a wildcard domain we need to validate the base
 	// domain by removing the "*." wildcard prefix. We create a separate
 	// `baseIdentifier` here before starting the `va.checkCAA` goroutine with the
-	// `identifier` to avoid a data race.
-	baseIdentifier := identifier
-	if strings.HasPrefix(identifier.Value, "*.") {
-		baseIdentifier.Value = strings.TrimPrefix(identifier.Value, "*.")
+	// `ident` to avoid a data race.
+	baseIdentifier := ident
+	if strings.HasPrefix(ident.Value, "*.") {
+		baseIdentifier.Value = strings.TrimPrefix(ident.Value, "*.")
 	}
 
 	// va.checkCAA accepts wildcard identifiers and handles them appropriately so
-	// we can dispatch `checkCAA` with the provided `identifier` instead of
+	// we can dispatch `checkCAA` with the provided `ident` instead of
 	// `baseIdentifier`
 	ch := make(chan *probs.ProblemDetails, 1)
 	go func() {
+		// CAA only applies to domain names (RFC 8738, section 7).
+		if ident.Type == identifier.IP {
+			ch <- nil
+			return
+		}
 		params := &caaParams{
 			accountURIID:     &authz.RegistrationID,
 			validationMethod: &challenge.Type,
 		}
-		ch <- va.checkCAA(ctx, identifier, params)
+		ch <- va.checkCAA(ctx, ident, params)
 	}()
 
 	// TODO(#1292): send into another goroutine
@@ -373,6 +487,30 @@ func (va *ValidationAuthorityImpl) validateChallenge(ctx context.Context, identi
 	if err := challenge.CheckConsistencyForValidation(); err != nil {
 		return nil, probs.Malformed("Challenge failed consistency check: %s", err)
 	}
+	// If the operator configured a timeout for this challenge type, bound the
+	// validation by it and note the effective timeout in each validation record
+	// so that slow or timed out validations are easier to debug.
+	timeout, ok := va.challengeTimeouts[challenge.Type]
+	if !ok {
+		return va.validateChallengeType(ctx, identifier, challenge)
+	}
+	if deadline, hasDeadline := ctx.Deadline(); hasDeadline {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	records, prob := va.validateChallengeType(ctx, identifier, challenge)
+	for i := range records {
+		records[i].Timeout = timeout
+	}
+	return records, prob
+}
+
+// validateChallengeType dispatches to the validation method for the
+// challenge's type.
+func (va *ValidationAuthorityImpl) validateChallengeType(ctx context.Context, identifier identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
 	switch challenge.Type {
 	case core.ChallengeTypeHTTP01:
 		return va.validateHTTP01(ctx, identifier, challenge)
@@ -384,24 +522,27 @@ func (va *ValidationAuthorityImpl) validateChallenge(ctx context.Context, identi
 	return nil, probs.Malformed("invalid challenge type %s", challenge.Type)
 }
 
-// performRemoteValidation calls `PerformValidation` for each of the configured
-// remoteVAs in a random order. The provided `results` chan should have an equal
-// size to the number of remote VAs. The validations will be performed in
-// separate go-routines. If the result `error` from a remote
+// performRemoteValidation calls `PerformValidation` for `perspectives` of the
+// configured remoteVAs chosen in a random order. The provided `results` chan
+// should have a size equal to `perspectives`. The validations will be performed
+// in separate go-routines. If the result `error` from a remote
 // `PerformValidation` RPC is nil or a nil `ProblemDetails` instance it is
 // written directly to the `results` chan. If the err is a cancelled error it is
-// treated as a nil error. Otherwise the error/problem is written to the results
-// channel as-is.
+// treated as a nil error. A timed out RPC is written to the results channel as
+// a failure marked as timed out. Otherwise the error/problem is written to the
+// results channel as-is.
 func (va *ValidationAuthorityImpl) performRemoteValidation(
 	ctx context.Context,
 	domain string,
 	challenge core.Challenge,
 	authz core.Authorization,
+	perspectives int,
 	results chan *remoteValidationResult) {
-	for _, i := range rand.Perm(len(va.remoteVAs)) {
+	for _, i := range rand.Perm(len(va.remoteVAs))[:perspectives] {
 		remoteVA := va.remoteVAs[i]
 		go func(rva RemoteVA, index int) {
 			_, err := rva.PerformValidation(ctx, domain, challenge, authz)
+			var timedOut bool
 			if err != nil {
 				// returned error can be a nil *probs.ProblemDetails which breaks the
 				// err != nil check so do a slightly more complicated unwrap check to
@@ -422,6 +563,12 @@ func (va *ValidationAuthorityImpl) performRemoteValidation(
 					// just means we cancelled the remote VA request before it was
 					// finished because we didn't care about its result.
 					err = nil
+				} else if isTimeout(ctx, err) {
+					// A timed out remote VA doesn't agree with the primary VA but
+					// it hasn't disagreed either. Count it as a failure and log
+					// it distinctly so timeouts can be told apart from failures.
+					timedOut = true
+					va.log.Warningf("Remote VA %q.PerformValidation timed out: %s", rva.Address, err)
 				} else if !ok {
 					// Otherwise, the non-nil err was *not* a *probs.ProblemDetails and
 					// was *not* a context cancelleded error and represents something that
@@ -433,12 +580,16 @@ func (va *ValidationAuthorityImpl) performRemoteValidation(
 			}
 			result := &remoteValidationResult{
 				VAHostname: rva.Address,
+				TimedOut:   timedOut,
 			}
 			if err == nil {
 				results <- result
 			} else if prob, ok := err.(*probs.ProblemDetails); ok {
 				result.Problem = prob
 				results <- result
+			} else if timedOut {
+				result.Problem = probs.ServerInternal("Remote PerformValidation RPC timed out")
+				results <- result
 			} else {
 				result.Problem = probs.ServerInternal("Remote PerformValidation RPC failed")
 				results <- result
@@ -450,7 +601,8 @@ func (va *ValidationAuthorityImpl) performRemoteValidation(
 // processRemoteResults evaluates a primary VA result, and a channel of remote
 // VA problems to produce a single overall validation result based on configured
 // feature flags. The overall result is calculated based on the VA's configured
-// `maxRemoteFailures` value.
+// quorum (see multiVAQuorum). The remote results read from the channel are
+// returned alongside the overall result.
 //
 // If the `MultiVAFullResults` feature is enabled then `processRemoteResults`
 // will expect to read a result from the `remoteErrors` channel for each VA and
@@ -469,7 +621,8 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 	challengeType string,
 	primaryResult *probs.ProblemDetails,
 	remoteResultsChan chan *remoteValidationResult,
-	numRemoteVAs int) *probs.ProblemDetails {
+	numRemoteVAs int,
+	required int) ([]*remoteValidationResult, *probs.ProblemDetails) {
 
 	state := "failure"
 	start := va.clk.Now()
@@ -481,7 +634,7 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 		}).Observe(va.clk.Since(start).Seconds())
 	}()
 
-	required := numRemoteVAs - va.maxRemoteFailures
+	maxFailures := numRemoteVAs - required
 	good := 0
 	bad := 0
 
@@ -510,11 +663,11 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 		if !features.Enabled(features.MultiVAFullResults) {
 			if good >= required {
 				state = "success"
-				return nil
-			} else if bad > va.maxRemoteFailures {
+				return remoteResults, nil
+			} else if bad > maxFailures {
 				modifiedProblem := *result.Problem
 				modifiedProblem.Detail = "During secondary validation: " + firstProb.Detail
-				return &modifiedProblem
+				return remoteResults, &modifiedProblem
 			}
 		}
 
@@ -533,21 +686,22 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 		acctID,
 		challengeType,
 		primaryResult,
-		remoteResults)
+		remoteResults,
+		maxFailures)
 
 	// Based on the threshold of good/bad return nil or a problem.
 	if good >= required {
 		state = "success"
-		return nil
-	} else if bad > va.maxRemoteFailures {
+		return remoteResults, nil
+	} else if bad > maxFailures {
 		modifiedProblem := *firstProb
 		modifiedProblem.Detail = "During secondary validation: " + firstProb.Detail
-		return &modifiedProblem
+		return remoteResults, &modifiedProblem
 	}
 
 	// This condition should not occur - it indicates the good/bad counts didn't
-	// meet either the required threshold or the maxRemoteFailures threshold.
-	return probs.ServerInternal("Too few remote PerformValidation RPC results")
+	// meet either the required threshold or the maxFailures threshold.
+	return remoteResults, probs.ServerInternal("Too few remote PerformValidation RPC results")
 }
 
 // logRemoteValidationDifferentials is called by `processRemoteResults` when the
@@ -558,7 +712,8 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 	acctID int64,
 	challengeType string,
 	primaryResult *probs.ProblemDetails,
-	remoteResults []*remoteValidationResult) {
+	remoteResults []*remoteValidationResult,
+	maxFailures int) {
 
 	var successes []*remoteValidationResult
 	var failures []*remoteValidationResult
@@ -583,7 +738,7 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 	// If the primary result was OK and there were more failures than the allowed
 	// threshold increment a stat that indicates this overall validation will have
 	// failed if features.EnforceMultiVA is enabled.
-	if primaryResult == nil && len(failures) > va.maxRemoteFailures {
+	if primaryResult == nil && len(failures) > maxFailures {
 		va.metrics.prospectiveRemoteValidationFailures.Inc()
 	}
 
@@ -617,10 +772,12 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 }
 
 // remoteValidationResult is a struct that combines a problem details instance
-// (that may be nil) with the remote VA hostname that produced it.
+// (that may be nil) with the remote VA hostname that produced it. TimedOut is
+// true when the problem is the result of the remote VA not responding in time.
 type remoteValidationResult struct {
 	VAHostname string
 	Problem    *probs.ProblemDetails
+	TimedOut   bool `json:",omitempty"`
 }
 
 // PerformValidation validates the given challenge. It always returns a list of
@@ -634,9 +791,10 @@ func (va *ValidationAuthorityImpl) PerformValidation(ctx context.Context, domain
 	vStart := va.clk.Now()
 
 	var remoteResults chan *remoteValidationResult
-	if remoteVACount := len(va.remoteVAs); remoteVACount > 0 {
-		remoteResults = make(chan *remoteValidationResult, remoteVACount)
-		go va.performRemoteValidation(ctx, domain, challenge, authz, remoteResults)
+	perspectives, quorum := va.multiVAQuorum()
+	if perspectives > 0 {
+		remoteResults = make(chan *remoteValidationResult, perspectives)
+		go va.performRemoteValidation(ctx, domain, challenge, authz, perspectives, remoteResults)
 	}
 
 	records, prob := va.validate(ctx, identifier.DNSIdentifier(domain), challenge, authz)
@@ -660,26 +818,29 @@ func (va *ValidationAuthorityImpl) PerformValidation(ctx context.Context, domain
 			// differentials then collect and log the remote results in a separate go
 			// routine to avoid blocking the primary VA.
 			go func() {
-				_ = va.processRemoteResults(
+				_, _ = va.processRemoteResults(
 					domain,
 					authz.RegistrationID,
 					string(challenge.Type),
 					prob,
 					remoteResults,
-					len(va.remoteVAs))
+					perspectives,
+					quorum)
 			}()
 			// Since prob was nil and we're not enforcing the results from
 			// `processRemoteResults` set the challenge status to valid so the
 			// validationTime metrics increment has the correct result label.
 			challenge.Status = core.StatusValid
 		} else if features.Enabled(features.EnforceMultiVA) {
-			remoteProb := va.processRemoteResults(
+			results, remoteProb := va.processRemoteResults(
 				domain,
 				authz.RegistrationID,
 				string(challenge.Type),
 				prob,
 				remoteResults,
-				len(va.remoteVAs))
+				perspectives,
+				quorum)
+			logEvent.RemoteResults = results
 
 			// We consider the multi VA result skippable even though we are enforcing
 			// multi VA if the domain or the account has multi-VA disabled by policy.
@@ -13,6 +13,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -137,6 +138,95 @@ func TestPerformValidationValid(t *testing.T) {
 	}
 }
 
+func TestChallengeTimeouts(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	chalDNS := core.DNSChallenge01("")
+	chalDNS.Token = expectedToken
+	chalDNS.ProvidedKeyAuthorization = expectedKeyAuthorization
+	ident := identifier.DNSIdentifier("good-dns01.com")
+
+	// Without a configured timeout the records shouldn't carry one.
+	records, prob := va.validateChallenge(context.Background(), ident, chalDNS)
+	test.Assert(t, prob == nil, fmt.Sprintf("validation failed: %#v", prob))
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].Timeout, time.Duration(0))
+
+	// With a configured timeout the records should carry the effective timeout.
+	va.challengeTimeouts = map[string]time.Duration{core.ChallengeTypeDNS01: 5 * time.Second}
+	records, prob = va.validateChallenge(context.Background(), ident, chalDNS)
+	test.Assert(t, prob == nil, fmt.Sprintf("validation failed: %#v", prob))
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].Timeout, 5*time.Second)
+
+	// A shorter RPC deadline should be reflected in the effective timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	records, prob = va.validateChallenge(ctx, ident, chalDNS)
+	test.Assert(t, prob == nil, fmt.Sprintf("validation failed: %#v", prob))
+	test.AssertEquals(t, len(records), 1)
+	test.Assert(t, records[0].Timeout > 0 && records[0].Timeout <= time.Second,
+		fmt.Sprintf("expected effective timeout of at most 1s, got %s", records[0].Timeout))
+
+	// Other challenge types should be unaffected.
+	va.challengeTimeouts = map[string]time.Duration{core.ChallengeTypeHTTP01: time.Second}
+	records, prob = va.validateChallenge(context.Background(), ident, chalDNS)
+	test.Assert(t, prob == nil, fmt.Sprintf("validation failed: %#v", prob))
+	test.AssertEquals(t, records[0].Timeout, time.Duration(0))
+}
+
+func TestNewValidationAuthorityImplChallengeTimeouts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		timeouts map[string]time.Duration
+		errorMsg string
+	}{
+		{
+			name:     "unknown challenge type",
+			timeouts: map[string]time.Duration{"tls-sni-01": time.Second},
+			errorMsg: `challenge timeout configured for unknown challenge type "tls-sni-01"`,
+		},
+		{
+			name:     "non-positive timeout",
+			timeouts: map[string]time.Duration{core.ChallengeTypeHTTP01: 0},
+			errorMsg: `challenge timeout for "http-01" must be positive, got 0s`,
+		},
+		{
+			name:     "valid timeouts",
+			timeouts: map[string]time.Duration{core.ChallengeTypeHTTP01: time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewValidationAuthorityImpl(
+				&cmd.PortConfig{},
+				&bdns.MockDNSClient{Log: blog.NewMock()},
+				nil,
+				0,
+				"user agent 1.0",
+				nil,
+				"letsencrypt.org",
+				metrics.NoopRegisterer,
+				clock.New(),
+				blog.NewMock(),
+				accountURIPrefixes,
+				"",
+				tc.timeouts,
+				0,
+				0,
+				0,
+				0)
+			if tc.errorMsg == "" {
+				test.AssertNotError(t, err, "unexpected error creating VA")
+			} else {
+				test.AssertError(t, err, "expected error creating VA")
+				test.AssertEquals(t, err.Error(), tc.errorMsg)
+			}
+		})
+	}
+}
+
 // TestPerformValidationWildcard tests that the VA properly strips the `*.`
 // prefix from a wildcard name provided to the PerformValidation function.
 func TestPerformValidationWildcard(t *testing.T) {
@@ -202,12 +292,18 @@ func setup(
 		nil,
 		maxRemoteFailures,
 		userAgent,
+		nil,
 		"letsencrypt.org",
 		metrics.NoopRegisterer,
 		clock.New(),
 		logger,
 		accountURIPrefixes,
-		"")
+		"",
+		nil,
+		0,
+		0,
+		0,
+		0)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to create validation authority: %v", err))
 	}
@@ -280,6 +376,30 @@ func (b *brokenRemoteVA) PerformValidation(
 	return nil, errBrokenRemoteVA
 }
 
+// timeoutRemoteVA is a mock for the core.ValidationAuthority interface that
+// always returns an error indicating the RPC timed out.
+type timeoutRemoteVA struct{}
+
+type errTimeout struct{}
+
+func (errTimeout) Error() string { return "PerformValidation timed out after 5000 ms" }
+func (errTimeout) Timeout() bool { return true }
+
+func (v timeoutRemoteVA) PerformValidation(_ context.Context, _ string, _ core.Challenge, _ core.Authorization) ([]core.ValidationRecord, error) {
+	return nil, errTimeout{}
+}
+
+// countingRemoteVA is a mock for the core.ValidationAuthority interface that
+// always succeeds and counts how many times it was called.
+type countingRemoteVA struct {
+	calls int32
+}
+
+func (v *countingRemoteVA) PerformValidation(_ context.Context, _ string, _ core.Challenge, _ core.Authorization) ([]core.ValidationRecord, error) {
+	atomic.AddInt32(&v.calls, 1)
+	return nil, nil
+}
+
 func TestMultiVA(t *testing.T) {
 	// Create a new challenge to use for the httpSrv
 	chall := core.HTTPChallenge01("")
@@ -491,6 +611,190 @@ func TestMultiVA(t *testing.T) {
 	}
 }
 
+func TestMultiVAQuorum(t *testing.T) {
+	chall := core.HTTPChallenge01("")
+	setChallengeToken(&chall, core.NewToken())
+
+	const localUA = "local 1"
+	ms := httpMultiSrv(t, chall.Token, map[string]bool{localUA: true})
+	defer ms.Close()
+
+	testCases := []struct {
+		Name         string
+		RemoteVAs    []RemoteVA
+		Perspectives int
+		Quorum       int
+		ExpectedProb *probs.ProblemDetails
+		ExpectedLogs []string
+	}{
+		{
+			Name: "Quorum met with one failure",
+			RemoteVAs: []RemoteVA{
+				{&countingRemoteVA{}, "good 1"},
+				{&countingRemoteVA{}, "good 2"},
+				{&brokenRemoteVA{}, "broken"},
+			},
+			Quorum: 2,
+			ExpectedLogs: []string{
+				`Validation result .*"RemoteResults":\[.*"VAHostname":"broken","Problem":{"type":"serverInternal","detail":"Remote PerformValidation RPC failed"`,
+			},
+		},
+		{
+			Name: "Quorum not met with one failure",
+			RemoteVAs: []RemoteVA{
+				{&countingRemoteVA{}, "good 1"},
+				{&countingRemoteVA{}, "good 2"},
+				{&brokenRemoteVA{}, "broken"},
+			},
+			Quorum:       3,
+			ExpectedProb: probs.ServerInternal("During secondary validation: Remote PerformValidation RPC failed"),
+		},
+		{
+			Name: "Timed out perspective doesn't agree",
+			RemoteVAs: []RemoteVA{
+				{&countingRemoteVA{}, "good 1"},
+				{timeoutRemoteVA{}, "slow"},
+				{&countingRemoteVA{}, "good 2"},
+			},
+			Quorum:       3,
+			ExpectedProb: probs.ServerInternal("During secondary validation: Remote PerformValidation RPC timed out"),
+			ExpectedLogs: []string{
+				`WARNING: Remote VA "slow".PerformValidation timed out`,
+				`Validation result .*"VAHostname":"slow","Problem":{"type":"serverInternal","detail":"Remote PerformValidation RPC timed out","status":500},"TimedOut":true`,
+			},
+		},
+		{
+			Name: "Quorum of a subset of perspectives",
+			RemoteVAs: []RemoteVA{
+				{&countingRemoteVA{}, "good 1"},
+				{&countingRemoteVA{}, "good 2"},
+				{&countingRemoteVA{}, "good 3"},
+			},
+			Perspectives: 2,
+			Quorum:       2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			localVA, mockLog := setup(ms.Server, 0, localUA, tc.RemoteVAs)
+			localVA.remotePerspectives = tc.Perspectives
+			localVA.remoteQuorum = tc.Quorum
+			err := features.Set(map[string]bool{
+				"EnforceMultiVA":     true,
+				"MultiVAFullResults": true,
+			})
+			test.AssertNotError(t, err, "Failed to set feature flags")
+			defer features.Reset()
+
+			_, prob := localVA.PerformValidation(ctx, "localhost", chall, core.Authorization{})
+			if tc.ExpectedProb == nil {
+				test.Assert(t, prob == nil, fmt.Sprintf("expected no prob, got %v", prob))
+			} else {
+				test.AssertDeepEquals(t, prob, tc.ExpectedProb)
+			}
+			for _, expected := range tc.ExpectedLogs {
+				test.AssertEquals(t, len(mockLog.GetAllMatching(expected)), 1)
+			}
+
+			// When only a subset of perspectives is configured only that many
+			// remote VAs should have been asked.
+			if tc.Perspectives != 0 {
+				var calls int32
+				for _, rva := range tc.RemoteVAs {
+					calls += atomic.LoadInt32(&rva.ValidationAuthority.(*countingRemoteVA).calls)
+				}
+				test.AssertEquals(t, int(calls), tc.Perspectives)
+			}
+		})
+	}
+}
+
+func TestMultiVAQuorumConfig(t *testing.T) {
+	remoteVAs := []RemoteVA{
+		{&countingRemoteVA{}, "remote 1"},
+		{&countingRemoteVA{}, "remote 2"},
+		{&countingRemoteVA{}, "remote 3"},
+	}
+
+	va, _ := setup(nil, 1, "", remoteVAs)
+	perspectives, quorum := va.multiVAQuorum()
+	test.AssertEquals(t, perspectives, 3)
+	test.AssertEquals(t, quorum, 2)
+
+	va.remotePerspectives = 2
+	perspectives, quorum = va.multiVAQuorum()
+	test.AssertEquals(t, perspectives, 2)
+	test.AssertEquals(t, quorum, 1)
+
+	va.remoteQuorum = 2
+	perspectives, quorum = va.multiVAQuorum()
+	test.AssertEquals(t, perspectives, 2)
+	test.AssertEquals(t, quorum, 2)
+
+	testCases := []struct {
+		name         string
+		perspectives int
+		quorum       int
+		errorMsg     string
+	}{
+		{
+			name:         "too many perspectives",
+			perspectives: 4,
+			errorMsg:     "remote perspectives must be between 0 and the number of remote VAs (3), got 4",
+		},
+		{
+			name:     "negative quorum",
+			quorum:   -1,
+			errorMsg: "remote quorum must not be negative, got -1",
+		},
+		{
+			name:     "quorum larger than all remote VAs",
+			quorum:   4,
+			errorMsg: "remote quorum (4) must not exceed the number of remote perspectives (3)",
+		},
+		{
+			name:         "quorum larger than perspectives",
+			perspectives: 2,
+			quorum:       3,
+			errorMsg:     "remote quorum (3) must not exceed the number of remote perspectives (2)",
+		},
+		{
+			name:         "valid",
+			perspectives: 2,
+			quorum:       2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewValidationAuthorityImpl(
+				&cmd.PortConfig{},
+				&bdns.MockDNSClient{Log: blog.NewMock()},
+				remoteVAs,
+				0,
+				"user agent 1.0",
+				nil,
+				"letsencrypt.org",
+				metrics.NoopRegisterer,
+				clock.New(),
+				blog.NewMock(),
+				accountURIPrefixes,
+				"",
+				nil,
+				tc.perspectives,
+				tc.quorum,
+				0,
+				0)
+			if tc.errorMsg == "" {
+				test.AssertNotError(t, err, "unexpected error creating VA")
+			} else {
+				test.AssertError(t, err, "expected error creating VA")
+				test.AssertEquals(t, err.Error(), tc.errorMsg)
+			}
+		})
+	}
+}
+
 func TestMultiVAEarlyReturn(t *testing.T) {
 	chall := core.HTTPChallenge01("")
 	setChallengeToken(&chall, core.NewToken())
@@ -760,7 +1064,7 @@ func TestLogRemoteValidationDifferentials(t *testing.T) {
 			mockLog.Clear()
 
 			localVA.logRemoteValidationDifferentials(
-				"example.com", 1999, "blorpus-01", tc.primaryResult, tc.remoteProbs)
+				"example.com", 1999, "blorpus-01", tc.primaryResult, tc.remoteProbs, 2)
 
 			lines := mockLog.GetAllMatching("remoteVADifferentials JSON=.*")
 			if tc.expectedLog != "" {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/rsa"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -38,6 +40,11 @@ type RequestEvent struct {
 	Payload string                 `json:",omitempty"`
 	Extra   map[string]interface{} `json:",omitempty"`
 
+	// RequestID uniquely identifies this request. It is attached to the
+	// context passed to WFE handlers so that log lines written while handling
+	// the request, by any service, can be correlated.
+	RequestID string `json:",omitempty"`
+
 	// For endpoints that create objects, the ID of the newly created object.
 	Created string `json:",omitempty"`
 
@@ -49,6 +56,10 @@ type RequestEvent struct {
 
 	// For challenge POSTs, the challenge type.
 	ChallengeType string `json:",omitempty"`
+
+	// AcceptLanguage is the request's Accept-Language header, used to localize
+	// problem documents. It isn't logged.
+	AcceptLanguage string `json:"-"`
 }
 
 func (e *RequestEvent) AddError(msg string, args ...interface{}) {
@@ -59,6 +70,9 @@ type WFEHandlerFunc func(context.Context, *RequestEvent, http.ResponseWriter, *h
 
 func (f WFEHandlerFunc) ServeHTTP(e *RequestEvent, w http.ResponseWriter, r *http.Request) {
 	ctx := context.TODO()
+	if e.RequestID != "" {
+		ctx = blog.WithRequestID(ctx, e.RequestID)
+	}
 	f(ctx, e, w, r)
 }
 
@@ -104,6 +118,9 @@ func (th *TopHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		UserAgent: r.Header.Get("User-Agent"),
 		Origin:    r.Header.Get("Origin"),
 		Extra:     make(map[string]interface{}),
+		RequestID: newRequestID(),
+
+		AcceptLanguage: r.Header.Get("Accept-Language"),
 	}
 
 	if features.Enabled(features.StripDefaultSchemePort) {
@@ -155,6 +172,16 @@ func (th *TopHandler) logEvent(logEvent *RequestEvent) {
 		int(logEvent.Latency*1000), logEvent.RealIP, jsonEvent)
 }
 
+// newRequestID returns a random identifier for an incoming request.
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(fmt.Sprintf("error reading random bytes: %s", err))
+	}
+	return hex.EncodeToString(b)
+}
+
 // Comma-separated list of HTTP clients involved in making this
 // request, starting with the original requestor and ending with the
 // remote end of our TCP connection (which is typically our own
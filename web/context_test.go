@@ -2,6 +2,7 @@ package web
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
@@ -30,7 +31,7 @@ func TestLogCode(t *testing.T) {
 		t.Fatal(err)
 	}
 	th.ServeHTTP(httptest.NewRecorder(), req)
-	expected := `INFO: GET /endpoint 0 201 0 0.0.0.0 JSON={}`
+	expected := `INFO: GET /endpoint 0 201 0 0.0.0.0 JSON={"RequestID":"[0-9a-f]{16}"}`
 	if len(mockLog.GetAllMatching(expected)) != 1 {
 		t.Errorf("Expected exactly one log line matching %q. Got \n%s",
 			expected, strings.Join(mockLog.GetAllMatching(".*"), "\n"))
@@ -52,7 +53,7 @@ func TestStatusCodeLogging(t *testing.T) {
 		t.Fatal(err)
 	}
 	th.ServeHTTP(httptest.NewRecorder(), req)
-	expected := `INFO: GET /endpoint 0 200 0 0.0.0.0 JSON={}`
+	expected := `INFO: GET /endpoint 0 200 0 0.0.0.0 JSON={"RequestID":"[0-9a-f]{16}"}`
 	if len(mockLog.GetAllMatching(expected)) != 1 {
 		t.Errorf("Expected exactly one log line matching %q. Got \n%s",
 			expected, strings.Join(mockLog.GetAllMatching(".*"), "\n"))
@@ -68,13 +69,29 @@ func TestOrigin(t *testing.T) {
 	}
 	req.Header.Add("Origin", "https://example.com")
 	th.ServeHTTP(httptest.NewRecorder(), req)
-	expected := `INFO: GET /endpoint 0 201 0 0.0.0.0 JSON={.*"Origin":"https://example.com"}`
+	expected := `INFO: GET /endpoint 0 201 0 0.0.0.0 JSON={.*"Origin":"https://example.com".*}`
 	if len(mockLog.GetAllMatching(expected)) != 1 {
 		t.Errorf("Expected exactly one log line matching %q. Got \n%s",
 			expected, strings.Join(mockLog.GetAllMatching(".*"), "\n"))
 	}
 }
 
+func TestRequestIDContext(t *testing.T) {
+	mockLog := blog.UseMock()
+	var requestID string
+	th := NewTopHandler(mockLog, WFEHandlerFunc(func(ctx context.Context, e *RequestEvent, w http.ResponseWriter, r *http.Request) {
+		requestID = blog.RequestIDFromContext(ctx)
+		test.AssertEquals(t, requestID, e.RequestID)
+		e.Endpoint = "/endpoint"
+	}))
+	req, err := http.NewRequest("GET", "/thisisignored", &bytes.Reader{})
+	test.AssertNotError(t, err, "failed to create request")
+	th.ServeHTTP(httptest.NewRecorder(), req)
+	test.AssertEquals(t, len(requestID), 16)
+	expected := fmt.Sprintf(`INFO: GET /endpoint 0 200 0 0.0.0.0 JSON={"RequestID":"%s"}`, requestID)
+	test.AssertEquals(t, len(mockLog.GetAllMatching(expected)), 1)
+}
+
 type hostHeaderHandler struct {
 	f func(*RequestEvent, http.ResponseWriter, *http.Request)
 }
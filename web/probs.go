@@ -19,6 +19,7 @@ func problemDetailsForBoulderError(err *berrors.BoulderError, msg string) *probs
 		outProb = probs.NotFound(fmt.Sprintf("%s :: %s", msg, err))
 	case berrors.RateLimit:
 		outProb = probs.RateLimited(fmt.Sprintf("%s :: %s", msg, err))
+		outProb.RetryAfter = err.RetryAfter
 	case berrors.InternalServer:
 		// Internal server error messages may include sensitive data, so we do
 		// not include it.
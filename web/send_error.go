@@ -4,21 +4,38 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/letsencrypt/boulder/features"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/probs"
 )
 
+const (
+	// rateLimitRetryAfter is the Retry-After sent with rate limited problems
+	// whose limit has no known reset time.
+	rateLimitRetryAfter = time.Hour
+	// internalRetryAfter is the Retry-After sent with internal server errors
+	// which retrying is likely to resolve, e.g. timeouts talking to backends.
+	internalRetryAfter = time.Minute
+)
+
 // SendError does a few things that we want for each error response:
 //  - Adds both the external and the internal error to a RequestEvent.
 //  - If the ProblemDetails provided is a ServerInternalProblem, audit logs the
 //    internal error.
+//  - If the LocalizeProblems feature is enabled, translates the Detail field
+//    of the ProblemDetails for the request's Accept-Language.
 //  - Prefixes the Type field of the ProblemDetails with a namespace.
+//  - Adds a Retry-After header to rate limited and retryable internal error
+//    responses.
 //  - Sends an HTTP response containing the error and an error code to the user.
 func SendError(
 	log blog.Logger,
@@ -57,6 +74,17 @@ func SendError(
 		}
 	}
 
+	// Decide how long the subscriber should wait before retrying while the
+	// problem's Type is still un-namespaced.
+	wait := retryAfter(prob, ierr)
+
+	// Translate the detail for the subscriber, after the English detail has
+	// been recorded in the log event.
+	var lang string
+	if features.Enabled(features.LocalizeProblems) {
+		prob, lang = probs.Localize(prob, logEvent.AcceptLanguage)
+	}
+
 	// Set the proper namespace for the problem and any
 	// sub-problems
 	prob.Type = probs.ProblemType(namespace) + prob.Type
@@ -70,7 +98,42 @@ func SendError(
 	}
 
 	// Write the JSON problem response
+	if wait > 0 {
+		response.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10))
+	}
 	response.Header().Set("Content-Type", "application/problem+json")
+	if lang != "" {
+		response.Header().Set("Content-Language", lang)
+	}
 	response.WriteHeader(code)
 	response.Write(problemDoc)
 }
+
+// retryAfter returns how long the client should wait before retrying a
+// request which failed with prob because of ierr, or zero if it shouldn't be
+// told to wait. Rate limited problems without a reset time from their limit,
+// and internal errors which are retryable, are given conservative defaults.
+func retryAfter(prob *probs.ProblemDetails, ierr error) time.Duration {
+	switch {
+	case prob.RetryAfter > 0:
+		return prob.RetryAfter
+	case prob.Type == probs.RateLimitedProblem:
+		return rateLimitRetryAfter
+	case prob.Type == probs.ServerInternalProblem && retryableError(ierr):
+		return internalRetryAfter
+	}
+	return 0
+}
+
+// retryableError returns true for errors which retrying is likely to resolve:
+// timeouts and backend services being unavailable or overloaded.
+func retryableError(err error) bool {
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	switch grpc.Code(err) {
+	case codes.DeadlineExceeded, codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
@@ -1,13 +1,20 @@
 package web
 
 import (
+	"context"
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/probs"
 	"github.com/letsencrypt/boulder/test"
 )
 
@@ -94,3 +101,68 @@ func TestSendErrorSubProbLogging(t *testing.T) {
 
 	test.AssertEquals(t, logEvent.Error, `400 :: malformed :: dfoop :: bad ["example.com :: malformed :: dfoop :: nop", "what about example.com :: malformed :: dfoop :: nah"]`)
 }
+
+func TestSendErrorLocalized(t *testing.T) {
+	_ = features.Set(map[string]bool{"LocalizeProblems": true})
+	defer features.Reset()
+
+	rw := httptest.NewRecorder()
+	logEvent := &RequestEvent{AcceptLanguage: "es-MX, en;q=0.5"}
+	SendError(log.NewMock(), "namespace:test:", rw, logEvent, probs.RateLimited("too many certificates"), nil)
+	test.AssertEquals(t, rw.Header().Get("Content-Language"), "es")
+	test.AssertUnmarshaledEquals(t, rw.Body.String(), `{
+		"type": "namespace:test:rateLimited",
+		"detail": "Se superó el límite de solicitudes: too many certificates",
+		"status": 429
+	}`)
+	// The log event keeps the English detail.
+	test.AssertEquals(t, logEvent.Error, "429 :: rateLimited :: too many certificates")
+}
+
+func TestSendErrorRetryAfter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prob     *probs.ProblemDetails
+		ierr     error
+		expected string
+	}{
+		{
+			name:     "rate limit with a reset time",
+			prob:     ProblemDetailsForError(berrors.RateLimitError(90*time.Second+time.Millisecond, "too many"), "limited"),
+			expected: "91",
+		},
+		{
+			name:     "rate limit without a reset time",
+			prob:     ProblemDetailsForError(berrors.RateLimitError(0, "too many"), "limited"),
+			expected: "3600",
+		},
+		{
+			name:     "deadline exceeded",
+			prob:     probs.ServerInternal("timed out"),
+			ierr:     context.DeadlineExceeded,
+			expected: "60",
+		},
+		{
+			name:     "backend unavailable",
+			prob:     probs.ServerInternal("unavailable"),
+			ierr:     status.Error(codes.Unavailable, "connection refused"),
+			expected: "60",
+		},
+		{
+			name: "internal error",
+			prob: probs.ServerInternal("broken"),
+			ierr: errors.New("broken"),
+		},
+		{
+			name: "malformed",
+			prob: probs.Malformed("bad"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			SendError(log.NewMock(), "namespace:test:", rw, &RequestEvent{}, tc.prob, tc.ierr)
+			test.AssertEquals(t, rw.Header().Get("Retry-After"), tc.expected)
+		})
+	}
+}
@@ -900,12 +900,12 @@ func (wfe *WebFrontEndImpl) RevokeCertificate(ctx context.Context, logEvent *web
 	if err != nil {
 		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Failed to revoke certificate"), err)
 	} else {
-		wfe.log.Debugf("Revoked %v", serial)
+		blog.ForContext(ctx, wfe.log).Debugf("Revoked %v", serial)
 		response.WriteHeader(http.StatusOK)
 	}
 }
 
-func (wfe *WebFrontEndImpl) logCsr(request *http.Request, cr core.CertificateRequest, registration core.Registration) {
+func (wfe *WebFrontEndImpl) logCsr(ctx context.Context, request *http.Request, cr core.CertificateRequest, registration core.Registration) {
 	var csrLog = struct {
 		ClientAddr string
 		CSR        string
@@ -915,7 +915,7 @@ func (wfe *WebFrontEndImpl) logCsr(request *http.Request, cr core.CertificateReq
 		CSR:        hex.EncodeToString(cr.Bytes),
 		Requester:  registration.ID,
 	}
-	wfe.log.AuditObject("Certificate request", csrLog)
+	blog.ForContext(ctx, wfe.log).AuditObject("Certificate request", csrLog)
 }
 
 // NewCertificate is used by clients to request the issuance of a cert for an
@@ -965,7 +965,7 @@ func (wfe *WebFrontEndImpl) NewCertificate(ctx context.Context, logEvent *web.Re
 		wfe.sendError(response, logEvent, probs.Malformed("Error parsing certificate request: %s", err), err)
 		return
 	}
-	wfe.logCsr(request, certificateRequest, reg)
+	wfe.logCsr(ctx, request, certificateRequest, reg)
 	// Check that the key in the CSR is good. This will also be checked in the CA
 	// component, but we want to discard CSRs with bad keys as early as possible
 	// because (a) it's an easy check and we can save unnecessary requests and
@@ -1015,7 +1015,7 @@ func (wfe *WebFrontEndImpl) NewCertificate(ctx context.Context, logEvent *web.Re
 	response.Header().Set("Content-Type", "application/pkix-cert")
 	response.WriteHeader(http.StatusCreated)
 	if _, err = response.Write(cert.DER); err != nil {
-		wfe.log.Warningf("Could not write response: %s", err)
+		blog.ForContext(ctx, wfe.log).Warningf("Could not write response: %s", err)
 	}
 }
 
@@ -1459,7 +1459,7 @@ func (wfe *WebFrontEndImpl) Certificate(ctx context.Context, logEvent *web.Reque
 	response.Header().Add("Link", link(relativeIssuerPath, "up"))
 	response.WriteHeader(http.StatusOK)
 	if _, err = response.Write(cert.DER); err != nil {
-		wfe.log.Warningf("Could not write response: %s", err)
+		blog.ForContext(ctx, wfe.log).Warningf("Could not write response: %s", err)
 	}
 }
 
@@ -1475,7 +1475,7 @@ func (wfe *WebFrontEndImpl) Issuer(ctx context.Context, logEvent *web.RequestEve
 	response.Header().Set("Content-Type", "application/pkix-cert")
 	response.WriteHeader(http.StatusOK)
 	if _, err := response.Write(wfe.IssuerCert); err != nil {
-		wfe.log.Warningf("Could not write response: %s", err)
+		blog.ForContext(ctx, wfe.log).Warningf("Could not write response: %s", err)
 	}
 }
 
@@ -1484,7 +1484,7 @@ func (wfe *WebFrontEndImpl) BuildID(ctx context.Context, logEvent *web.RequestEv
 	response.Header().Set("Content-Type", "text/plain")
 	response.WriteHeader(http.StatusOK)
 	if _, err := fmt.Fprintf(response, "Boulder=(%s %s)\n", core.GetBuildID(), core.GetBuildTime()); err != nil {
-		wfe.log.Warningf("Could not write response: %s", err)
+		blog.ForContext(ctx, wfe.log).Warningf("Could not write response: %s", err)
 	}
 }
 
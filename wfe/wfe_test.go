@@ -254,6 +254,14 @@ func (ra *MockRegistrationAuthority) FinalizeOrder(ctx context.Context, _ *rapb.
 	return nil, nil
 }
 
+func (ra *MockRegistrationAuthority) GetRateLimits(ctx context.Context, _ *rapb.GetRateLimitsRequest) (*rapb.RateLimits, error) {
+	return nil, nil
+}
+
+func (ra *MockRegistrationAuthority) DryRunIssuance(ctx context.Context, _ *rapb.DryRunIssuanceRequest) (*rapb.DryRunIssuanceResponse, error) {
+	return nil, nil
+}
+
 type mockPA struct{}
 
 func (pa *mockPA) ChallengesFor(identifier identifier.ACMEIdentifier) (challenges []core.Challenge, err error) {
@@ -896,7 +904,7 @@ func TestIssueCertificate(t *testing.T) {
 	// authorized, etc.
 	stats := metrics.NoopRegisterer
 
-	ctp := ctpolicy.New(&mocks.Publisher{}, nil, nil, wfe.log, metrics.NoopRegisterer)
+	ctp := ctpolicy.New(&mocks.Publisher{}, nil, nil, 0, wfe.log, metrics.NoopRegisterer, fc)
 	ra := ra.NewRegistrationAuthorityImpl(
 		fc,
 		wfe.log,
@@ -2175,9 +2183,16 @@ func TestLogCsrPem(t *testing.T) {
 	mockLog := wfe.log.(*blog.Mock)
 	mockLog.Clear()
 
-	wfe.logCsr(req, certificateRequest, reg)
+	wfe.logCsr(ctx, req, certificateRequest, reg)
 
 	assertCsrLogged(t, mockLog)
+
+	// The request ID attached to the context by the WFE's handlers prefixes
+	// the log line.
+	mockLog.Clear()
+	wfe.logCsr(blog.WithRequestID(ctx, "abc123"), req, certificateRequest, reg)
+	matches := mockLog.GetAllMatching(`^INFO: \[AUDIT\] \[abc123\] Certificate request JSON=`)
+	test.AssertEquals(t, len(matches), 1)
 }
 
 func TestLengthRequired(t *testing.T) {
@@ -0,0 +1,151 @@
+package wfe2
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// compressionMinSize is the smallest response body, in bytes, which is
+	// compressed. Below it gzip's framing overhead eats most of the saving.
+	compressionMinSize = 256
+)
+
+// compressibleContentTypes are the media types of responses which are
+// compressed. DER encoded responses, e.g. the issuer certificate served as
+// application/pkix-cert, hardly shrink and aren't included.
+var compressibleContentTypes = map[string]bool{
+	"application/json":                  true,
+	"application/problem+json":          true,
+	"application/pem-certificate-chain": true,
+	"text/plain":                        true,
+}
+
+// acceptsGzip returns true if the request's Accept-Encoding header allows a
+// gzip encoded response, either by name or with "*", and doesn't give gzip a
+// q-value of zero.
+func acceptsGzip(request *http.Request) bool {
+	accepted := false
+	for _, header := range request.Header["Accept-Encoding"] {
+		for _, coding := range strings.Split(header, ",") {
+			params := strings.Split(coding, ";")
+			name := strings.ToLower(strings.TrimSpace(params[0]))
+			if name != "gzip" && name != "*" {
+				continue
+			}
+			allowed := true
+			for _, param := range params[1:] {
+				param = strings.TrimSpace(param)
+				if !strings.HasPrefix(param, "q=") {
+					continue
+				}
+				q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+				allowed = err == nil && q > 0
+			}
+			if name == "gzip" {
+				// An explicit entry for gzip takes precedence over "*".
+				return allowed
+			}
+			accepted = allowed
+		}
+	}
+	return accepted
+}
+
+// compressingResponseWriter buffers the response written by a handler so that,
+// once the handler is done, the body can be gzip compressed if the client
+// accepts that and the response is worth compressing. WFE handlers all write
+// their response in one go, so buffering it holds nothing back from clients.
+// Since the whole body is known, Content-Length is always set, for compressed
+// responses to the compressed length, and Go's HTTP server never resorts to a
+// chunked Transfer-Encoding.
+type compressingResponseWriter struct {
+	http.ResponseWriter
+	acceptsGzip bool
+	status      int
+	body        bytes.Buffer
+	ratio       prometheus.Observer
+}
+
+func newCompressingResponseWriter(response http.ResponseWriter, request *http.Request, ratio prometheus.Observer) *compressingResponseWriter {
+	return &compressingResponseWriter{
+		ResponseWriter: response,
+		acceptsGzip:    acceptsGzip(request),
+		ratio:          ratio,
+	}
+}
+
+// WriteHeader records the status code, which is written by finish.
+func (cw *compressingResponseWriter) WriteHeader(status int) {
+	if cw.status == 0 {
+		cw.status = status
+	}
+}
+
+// Write buffers body, which is written by finish.
+func (cw *compressingResponseWriter) Write(body []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	return cw.body.Write(body)
+}
+
+// finish writes the buffered response to the underlying ResponseWriter,
+// compressing its body if it's eligible.
+func (cw *compressingResponseWriter) finish() error {
+	if cw.status == 0 {
+		// Nothing was written. Leave it to the server to send its default
+		// empty 200 response.
+		return nil
+	}
+	header := cw.Header()
+	if !bodyAllowed(cw.status) {
+		cw.ResponseWriter.WriteHeader(cw.status)
+		return nil
+	}
+
+	body := cw.body.Bytes()
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if compressibleContentTypes[mediaType] && header.Get("Content-Encoding") == "" {
+		// Whether the response is compressed depends on Accept-Encoding, so
+		// caches must take it into account even if this one isn't.
+		header.Add("Vary", "Accept-Encoding")
+		if cw.acceptsGzip && len(body) >= compressionMinSize {
+			compressed, err := gzipBody(body)
+			if err != nil {
+				return err
+			}
+			cw.ratio.Observe(float64(len(compressed)) / float64(len(body)))
+			header.Set("Content-Encoding", "gzip")
+			body = compressed
+		}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	cw.ResponseWriter.WriteHeader(cw.status)
+	_, err := cw.ResponseWriter.Write(body)
+	return err
+}
+
+// bodyAllowed returns false for the statuses which RFC 7230 forbids from
+// having a body or a Content-Length describing one.
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(body); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,99 @@
+package wfe2
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	testCases := []struct {
+		acceptEncoding string
+		expected       bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"GZIP", true},
+		{"deflate, gzip;q=1.0, *;q=0.5", true},
+		{"br, *", true},
+		{"br", false},
+		{"gzip;q=0", false},
+		{"*;q=0", false},
+		{"gzip;q=0, *", false},
+		{"*, gzip;q=0", false},
+		{"gzip;q=bogus", false},
+	}
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/directory", nil)
+		if tc.acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		}
+		test.AssertEquals(t, acceptsGzip(req), tc.expected)
+	}
+}
+
+func TestCompressResponses(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	wfe.CompressResponses = true
+	mux := wfe.Handler(metrics.NoopRegisterer)
+	// Make the directory's random entry the same in every response.
+	core.RandReader = fakeRand{}
+	defer func() { core.RandReader = rand.Reader }()
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		responseWriter := httptest.NewRecorder()
+		mux.ServeHTTP(responseWriter, req)
+		return responseWriter
+	}
+
+	plain := get(directoryPath, "")
+	test.AssertEquals(t, plain.Code, http.StatusOK)
+	test.AssertEquals(t, plain.Header().Get("Content-Encoding"), "")
+	test.AssertEquals(t, plain.Header().Get("Vary"), "Accept-Encoding")
+	test.AssertEquals(t, plain.Header().Get("Content-Length"), strconv.Itoa(plain.Body.Len()))
+
+	compressed := get(directoryPath, "gzip")
+	test.AssertEquals(t, compressed.Code, http.StatusOK)
+	test.AssertEquals(t, compressed.Header().Get("Content-Encoding"), "gzip")
+	test.AssertEquals(t, compressed.Header().Get("Vary"), "Accept-Encoding")
+	test.AssertEquals(t, compressed.Header().Get("Content-Length"), strconv.Itoa(compressed.Body.Len()))
+	test.Assert(t, compressed.Body.Len() < plain.Body.Len(), "compressed directory isn't smaller")
+	gz, err := gzip.NewReader(bytes.NewReader(compressed.Body.Bytes()))
+	test.AssertNotError(t, err, "failed to read gzip header")
+	body, err := ioutil.ReadAll(gz)
+	test.AssertNotError(t, err, "failed to decompress directory")
+	test.AssertByteEquals(t, body, plain.Body.Bytes())
+	test.AssertEquals(t, test.CountHistogramSamples(wfe.stats.compressionRatio.WithLabelValues(directoryPath)), 1)
+
+	// DER encoded responses aren't compressed.
+	issuer := get(issuerPath, "gzip")
+	test.AssertEquals(t, issuer.Code, http.StatusOK)
+	test.AssertEquals(t, issuer.Header().Get("Content-Encoding"), "")
+	test.AssertByteEquals(t, issuer.Body.Bytes(), wfe.IssuerCert)
+
+	// Nor are bodies too small to benefit.
+	small := get(buildIDPath, "gzip")
+	test.AssertEquals(t, small.Code, http.StatusOK)
+	test.AssertEquals(t, small.Header().Get("Content-Encoding"), "")
+	test.AssertEquals(t, small.Header().Get("Vary"), "Accept-Encoding")
+
+	// Without CompressResponses, responses are left alone.
+	wfe.CompressResponses = false
+	mux = wfe.Handler(metrics.NoopRegisterer)
+	uncompressed := get(directoryPath, "gzip")
+	test.AssertEquals(t, uncompressed.Header().Get("Content-Encoding"), "")
+	test.AssertEquals(t, uncompressed.Header().Get("Vary"), "")
+}
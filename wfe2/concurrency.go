@@ -0,0 +1,111 @@
+package wfe2
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/probs"
+)
+
+const (
+	// concurrencyRetryAfter is the Retry-After sent with responses to requests
+	// rejected for exceeding MaxConcurrentRequestsPerAccount.
+	concurrencyRetryAfter = 5 * time.Second
+	// concurrencyBuckets is the number of buckets accounts are hashed into to
+	// label the rejection metric, bounding its cardinality.
+	concurrencyBuckets = 16
+)
+
+// accountLimiter counts the requests in flight for each account, keyed by
+// account ID, or for requests that aren't made by an account yet, by the
+// thumbprint of the key they're signed with.
+type accountLimiter struct {
+	sync.Mutex
+	inFlight   map[string]int
+	rejections *prometheus.CounterVec
+}
+
+func newAccountLimiter(stats prometheus.Registerer) *accountLimiter {
+	rejections := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "account_concurrency_rejections",
+		Help: "Number of requests rejected for exceeding the per-account limit on requests in flight, by hashed account bucket",
+	}, []string{"bucket"})
+	stats.MustRegister(rejections)
+	return &accountLimiter{
+		inFlight:   make(map[string]int),
+		rejections: rejections,
+	}
+}
+
+// acquire takes one of the limit slots for key, returning false if they're
+// all in use.
+func (al *accountLimiter) acquire(key string, limit int) bool {
+	al.Lock()
+	defer al.Unlock()
+	if al.inFlight[key] >= limit {
+		al.rejections.WithLabelValues(concurrencyBucket(key)).Inc()
+		return false
+	}
+	al.inFlight[key]++
+	return true
+}
+
+// release returns a slot taken by acquire.
+func (al *accountLimiter) release(key string) {
+	al.Lock()
+	defer al.Unlock()
+	al.inFlight[key]--
+	if al.inFlight[key] <= 0 {
+		delete(al.inFlight, key)
+	}
+}
+
+func concurrencyBucket(key string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return strconv.Itoa(int(h.Sum32() % concurrencyBuckets))
+}
+
+// requestSlotKey is the context.Context key under which HandleFunc stores the
+// requestSlot of each request.
+type requestSlotKey struct{}
+
+// requestSlot records the limiter slot, if any, taken by a request so it can
+// be released when the request is done.
+type requestSlot struct {
+	key string
+}
+
+// limitAccountConcurrency takes a slot for key from the limiter for the
+// request, once the request has been authenticated by it. If every slot for
+// key is in use a rate limited problem is returned, with a RetryAfter of
+// concurrencyRetryAfter. Requests only ever hold one slot: once one is
+// held, further calls (e.g. for a key rollover's inner JWS) do nothing.
+func (wfe *WebFrontEndImpl) limitAccountConcurrency(ctx context.Context, key string) *probs.ProblemDetails {
+	slot, ok := ctx.Value(requestSlotKey{}).(*requestSlot)
+	if !ok || wfe.MaxConcurrentRequestsPerAccount <= 0 || slot.key != "" {
+		return nil
+	}
+	if !wfe.accountLimiter.acquire(key, wfe.MaxConcurrentRequestsPerAccount) {
+		prob := probs.RateLimited(fmt.Sprintf(
+			"Too many concurrent requests for this account (limit %d), retry after %s",
+			wfe.MaxConcurrentRequestsPerAccount, concurrencyRetryAfter))
+		prob.RetryAfter = concurrencyRetryAfter
+		return prob
+	}
+	slot.key = key
+	return nil
+}
+
+// releaseAccountConcurrency releases the slot, if any, held by a request.
+func (wfe *WebFrontEndImpl) releaseAccountConcurrency(slot *requestSlot) {
+	if slot.key != "" {
+		wfe.accountLimiter.release(slot.key)
+	}
+}
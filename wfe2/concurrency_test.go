@@ -0,0 +1,72 @@
+package wfe2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/letsencrypt/boulder/web"
+)
+
+func TestLimitAccountConcurrency(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	request := func() (context.Context, *requestSlot) {
+		slot := &requestSlot{}
+		return context.WithValue(context.Background(), requestSlotKey{}, slot), slot
+	}
+
+	// Without a limit nothing is counted.
+	ctx, slot := request()
+	test.Assert(t, wfe.limitAccountConcurrency(ctx, "account:1") == nil, "request rejected without a limit")
+	test.AssertEquals(t, slot.key, "")
+
+	wfe.MaxConcurrentRequestsPerAccount = 2
+	ctx1, slot1 := request()
+	test.Assert(t, wfe.limitAccountConcurrency(ctx1, "account:1") == nil, "first request rejected")
+	// A request only ever holds one slot.
+	test.Assert(t, wfe.limitAccountConcurrency(ctx1, "account:1") == nil, "second check of a request rejected")
+	ctx2, slot2 := request()
+	test.Assert(t, wfe.limitAccountConcurrency(ctx2, "account:1") == nil, "second request rejected")
+
+	ctx3, slot3 := request()
+	prob := wfe.limitAccountConcurrency(ctx3, "account:1")
+	test.Assert(t, prob != nil, "request over the limit wasn't rejected")
+	test.AssertEquals(t, prob.Type, probs.RateLimitedProblem)
+	test.AssertEquals(t, prob.RetryAfter, concurrencyRetryAfter)
+	test.AssertEquals(t, test.CountCounterVec("bucket", concurrencyBucket("account:1"), wfe.accountLimiter.rejections), 1)
+
+	// Other accounts have their own limit.
+	ctx4, slot4 := request()
+	test.Assert(t, wfe.limitAccountConcurrency(ctx4, "account:2") == nil, "other account's request rejected")
+
+	// Once a request is done its slot is available again.
+	wfe.releaseAccountConcurrency(slot1)
+	ctx5, slot5 := request()
+	test.Assert(t, wfe.limitAccountConcurrency(ctx5, "account:1") == nil, "request rejected after a slot was released")
+
+	for _, slot := range []*requestSlot{slot2, slot3, slot4, slot5} {
+		wfe.releaseAccountConcurrency(slot)
+	}
+	test.AssertEquals(t, len(wfe.accountLimiter.inFlight), 0)
+}
+
+func TestHandleFuncReleasesAccountConcurrency(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	wfe.MaxConcurrentRequestsPerAccount = 1
+
+	mux := http.NewServeMux()
+	var prob *probs.ProblemDetails
+	wfe.HandleFunc(mux, "/test", func(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+		prob = wfe.limitAccountConcurrency(ctx, "account:1")
+	}, "POST")
+
+	for i := 0; i < 3; i++ {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/test", nil))
+		test.Assert(t, prob == nil, "sequential request rejected")
+	}
+	test.AssertEquals(t, len(wfe.accountLimiter.inFlight), 0)
+}
@@ -17,6 +17,12 @@ type wfe2Stats struct {
 	// improperECFieldLengths counts the number of ACME account EC JWKs we see
 	// with improper X and Y lengths for their curve
 	improperECFieldLengths prometheus.Counter
+	// oversizeRequests counts requests rejected because their body exceeded
+	// the maximum request size of the endpoint
+	oversizeRequests *prometheus.CounterVec
+	// compressionRatio observes the ratio of the compressed to uncompressed
+	// size of gzip compressed response bodies
+	compressionRatio *prometheus.HistogramVec
 }
 
 func initStats(stats prometheus.Registerer) wfe2Stats {
@@ -53,10 +59,31 @@ func initStats(stats prometheus.Registerer) wfe2Stats {
 	)
 	stats.MustRegister(improperECFieldLengths)
 
+	oversizeRequests := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oversize_requests",
+			Help: "Number of requests rejected for exceeding the endpoint's maximum request size",
+		},
+		[]string{"endpoint"},
+	)
+	stats.MustRegister(oversizeRequests)
+
+	compressionRatio := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "response_compression_ratio",
+			Help:    "Ratio of the compressed to uncompressed size of gzip compressed response bodies",
+			Buckets: prometheus.LinearBuckets(0.1, 0.1, 10),
+		},
+		[]string{"endpoint"},
+	)
+	stats.MustRegister(compressionRatio)
+
 	return wfe2Stats{
 		httpErrorCount:         httpErrorCount,
 		joseErrorCount:         joseErrorCount,
 		csrSignatureAlgs:       csrSignatureAlgs,
 		improperECFieldLengths: improperECFieldLengths,
+		oversizeRequests:       oversizeRequests,
+		compressionRatio:       compressionRatio,
 	}
 }
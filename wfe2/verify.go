@@ -2,8 +2,10 @@ package wfe2
 
 import (
 	"context"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,9 +29,34 @@ const (
 	// POST requests with a JWS body must have the following Content-Type header
 	expectedJWSContentType = "application/jose+json"
 
-	maxRequestSize = 50000
+	// defaultMaxRequestSize is the maximum size in bytes of a POST body for
+	// endpoints without an entry in defaultMaxRequestSizes or the WFE's
+	// MaxRequestSizes.
+	defaultMaxRequestSize = 50000
 )
 
+// defaultMaxRequestSizes are the maximum POST body sizes for endpoints whose
+// legitimate requests may be larger than defaultMaxRequestSize: new orders and
+// finalization CSRs with 100 long DNS names, and revocation requests carrying
+// such a certificate, are each doubly base64 encoded within a JWS.
+var defaultMaxRequestSizes = map[string]int64{
+	newOrderPath:      100000,
+	finalizeOrderPath: 100000,
+	revokeCertPath:    100000,
+}
+
+// maxRequestSize returns the maximum size in bytes of a POST body accepted by
+// the given endpoint.
+func (wfe *WebFrontEndImpl) maxRequestSize(endpoint string) int64 {
+	if size, ok := wfe.MaxRequestSizes[endpoint]; ok && size > 0 {
+		return size
+	}
+	if size, ok := defaultMaxRequestSizes[endpoint]; ok {
+		return size
+	}
+	return defaultMaxRequestSize
+}
+
 func sigAlgorithmForKey(key *jose.JSONWebKey) (jose.SignatureAlgorithm, error) {
 	switch k := key.Key.(type) {
 	case *rsa.PublicKey:
@@ -344,8 +371,10 @@ func (wfe *WebFrontEndImpl) parseJWS(body []byte) (*jose.JSONWebSignature, *prob
 	return parsedJWS, nil
 }
 
-// parseJWSRequest extracts a JSONWebSignature from an HTTP POST request's body using parseJWS.
-func (wfe *WebFrontEndImpl) parseJWSRequest(request *http.Request) (*jose.JSONWebSignature, *probs.ProblemDetails) {
+// parseJWSRequest extracts a JSONWebSignature from an HTTP POST request's body
+// using parseJWS. Bodies larger than the maximum request size of the
+// logEvent's endpoint are rejected before being parsed.
+func (wfe *WebFrontEndImpl) parseJWSRequest(request *http.Request, logEvent *web.RequestEvent) (*jose.JSONWebSignature, *probs.ProblemDetails) {
 	// Verify that the POST request has the expected headers
 	if prob := wfe.validPOSTRequest(request); prob != nil {
 		return nil, prob
@@ -353,10 +382,12 @@ func (wfe *WebFrontEndImpl) parseJWSRequest(request *http.Request) (*jose.JSONWe
 
 	// Read the POST request body's bytes. validPOSTRequest has already checked
 	// that the body is non-nil
-	bodyBytes, err := ioutil.ReadAll(http.MaxBytesReader(nil, request.Body, maxRequestSize))
+	maxSize := wfe.maxRequestSize(logEvent.Endpoint)
+	bodyBytes, err := ioutil.ReadAll(http.MaxBytesReader(nil, request.Body, maxSize))
 	if err != nil {
 		if err.Error() == "http: request body too large" {
-			return nil, probs.Unauthorized("request body too large")
+			wfe.stats.oversizeRequests.With(prometheus.Labels{"endpoint": logEvent.Endpoint}).Inc()
+			return nil, probs.RequestTooLarge(fmt.Sprintf("request body larger than %d bytes", maxSize))
 		}
 		wfe.stats.httpErrorCount.With(prometheus.Labels{"type": "UnableToReadReqBody"}).Inc()
 		return nil, probs.ServerInternal("unable to read request body")
@@ -569,6 +600,12 @@ func (wfe *WebFrontEndImpl) validJWSForAccount(
 		return nil, nil, nil, prob
 	}
 
+	// Only now that the account is known to have made the request does it
+	// count against the account's concurrency limit.
+	if prob := wfe.limitAccountConcurrency(ctx, fmt.Sprintf("account:%d", account.ID)); prob != nil {
+		return nil, nil, nil, prob
+	}
+
 	return payload, jws, account, nil
 }
 
@@ -582,7 +619,7 @@ func (wfe *WebFrontEndImpl) validPOSTForAccount(
 	ctx context.Context,
 	logEvent *web.RequestEvent) ([]byte, *jose.JSONWebSignature, *core.Registration, *probs.ProblemDetails) {
 	// Parse the JWS from the POST request
-	jws, prob := wfe.parseJWSRequest(request)
+	jws, prob := wfe.parseJWSRequest(request, logEvent)
 	if prob != nil {
 		return nil, nil, nil, prob
 	}
@@ -650,6 +687,18 @@ func (wfe *WebFrontEndImpl) validSelfAuthenticatedJWS(
 		return nil, nil, prob
 	}
 
+	// Requests without an account count against the concurrency limit of the
+	// key they're signed with.
+	if wfe.MaxConcurrentRequestsPerAccount > 0 {
+		thumbprint, err := pubKey.Thumbprint(crypto.SHA256)
+		if err != nil {
+			return nil, nil, probs.Malformed("Error computing JWK thumbprint")
+		}
+		if prob := wfe.limitAccountConcurrency(ctx, "key:"+base64.RawURLEncoding.EncodeToString(thumbprint)); prob != nil {
+			return nil, nil, prob
+		}
+	}
+
 	return payload, pubKey, nil
 }
 
@@ -660,7 +709,7 @@ func (wfe *WebFrontEndImpl) validSelfAuthenticatedPOST(
 	request *http.Request,
 	logEvent *web.RequestEvent) ([]byte, *jose.JSONWebKey, *probs.ProblemDetails) {
 	// Parse the JWS from the POST request
-	jws, prob := wfe.parseJWSRequest(request)
+	jws, prob := wfe.parseJWSRequest(request, logEvent)
 	if prob != nil {
 		return nil, nil, prob
 	}
@@ -777,6 +777,33 @@ func multiSigJWS(t *testing.T, nonceService jose.NonceSource) (*jose.JSONWebSign
 	return parsedJWS, body
 }
 
+func TestParseJWSRequestMaxSize(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	wfe.MaxRequestSizes = map[string]int64{acctPath: 1000}
+
+	body := fmt.Sprintf(`{"a":"%s"}`, strings.Repeat("a", 60000))
+	parse := func(endpoint string) *probs.ProblemDetails {
+		_, prob := wfe.parseJWSRequest(makePostRequestWithPath("test-path", body), &web.RequestEvent{Endpoint: endpoint})
+		return prob
+	}
+
+	// The body is too large for endpoints using the default limit, but not
+	// for new-order.
+	prob := parse(newAcctPath)
+	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
+	test.AssertEquals(t, prob.HTTPStatus, http.StatusRequestEntityTooLarge)
+	test.AssertEquals(t, test.CountCounterVec("endpoint", newAcctPath, wfe.stats.oversizeRequests), 1)
+	prob = parse(newOrderPath)
+	test.AssertEquals(t, prob.HTTPStatus, http.StatusBadRequest)
+	test.AssertEquals(t, test.CountCounterVec("endpoint", newOrderPath, wfe.stats.oversizeRequests), 0)
+
+	// A configured limit overrides the default.
+	body = fmt.Sprintf(`{"a":"%s"}`, strings.Repeat("a", 1000))
+	prob = parse(acctPath)
+	test.AssertEquals(t, prob.Detail, "request body larger than 1000 bytes")
+	test.AssertEquals(t, test.CountCounterVec("endpoint", acctPath, wfe.stats.oversizeRequests), 1)
+}
+
 func TestParseJWSRequest(t *testing.T) {
 	wfe, _ := setupWFE(t)
 
@@ -887,9 +914,9 @@ func TestParseJWSRequest(t *testing.T) {
 			Request: makePostRequestWithPath("test-path",
 				fmt.Sprintf(`{"a":"%s"}`, strings.Repeat("a", 50000))),
 			ExpectedProblem: &probs.ProblemDetails{
-				Type:       probs.UnauthorizedProblem,
-				Detail:     "request body too large",
-				HTTPStatus: http.StatusForbidden,
+				Type:       probs.MalformedProblem,
+				Detail:     "request body larger than 50000 bytes",
+				HTTPStatus: http.StatusRequestEntityTooLarge,
 			},
 		},
 	}
@@ -897,7 +924,7 @@ func TestParseJWSRequest(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
 			wfe.stats.joseErrorCount.Reset()
-			_, prob := wfe.parseJWSRequest(tc.Request)
+			_, prob := wfe.parseJWSRequest(tc.Request, &web.RequestEvent{})
 			if tc.ExpectedProblem == nil && prob != nil {
 				t.Fatalf("Expected nil problem, got %#v\n", prob)
 			} else {
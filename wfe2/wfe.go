@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"net"
 	"net/http"
 	"strconv"
@@ -57,6 +59,9 @@ const (
 	newOrderPath      = "/acme/new-order"
 	orderPath         = "/acme/order/"
 	finalizeOrderPath = "/acme/finalize/"
+	rateLimitsPath    = "/acme/rate-limits"
+	certificatesPath  = "/acme/certificates/"
+	renewalInfoPath   = "/acme/renewal-info/"
 
 	getAPIPrefix       = "/get/"
 	getOrderPath       = getAPIPrefix + "order/"
@@ -65,6 +70,19 @@ const (
 	getCertPath        = getAPIPrefix + "cert/"
 )
 
+// defaultCORSMaxAge is how long browsers may cache the result of a CORS
+// preflight request if the WFE's CORSMaxAge isn't set.
+const defaultCORSMaxAge = 24 * time.Hour
+
+// corsPaths are the endpoints which browser-based clients may request
+// cross-origin. They're unauthenticated and return nothing specific to an
+// account; every other endpoint is authenticated with JWS and never sends
+// CORS headers.
+var corsPaths = map[string]bool{
+	directoryPath: true,
+	newNoncePath:  true,
+}
+
 // WebFrontEndImpl provides all the logic for Boulder's web-facing interface,
 // i.e., ACME.  Its members configure the paths for various ACME functions,
 // plus a few other data items used in ACME.  Its methods are primarily handlers
@@ -102,6 +120,13 @@ type WebFrontEndImpl struct {
 	// "website" field.
 	DirectoryWebsite string
 
+	// CertificateProfiles maps the names of the issuance profiles clients may
+	// request in the "profile" field of a new order to a human readable
+	// description of each. They're advertised in the /directory response's
+	// "meta" element's "profiles" field. Orders that don't request a profile
+	// are issued under the CA's default profile.
+	CertificateProfiles map[string]string
+
 	// Allowed prefix for legacy accounts used by verify.go's `lookupJWK`.
 	// See `cmd/boulder-wfe2/main.go`'s comment on the configuration field
 	// `LegacyKeyIDPrefix` for more information.
@@ -115,12 +140,39 @@ type WebFrontEndImpl struct {
 	// Key policy.
 	keyPolicy goodkey.KeyPolicy
 
-	// CORS settings
+	// CORS settings. Cross-origin requests are only allowed to the endpoints in
+	// corsPaths, and only from the origins in AllowOrigins. If AllowOrigins is
+	// empty no CORS headers are sent.
 	AllowOrigins []string
+	// CORSAllowMethods restricts the methods cross-origin requests may use. If
+	// empty, every method handled by a CORS endpoint is allowed.
+	CORSAllowMethods []string
+	// CORSAllowHeaders lists request headers, in addition to Content-Type,
+	// which cross-origin requests may send.
+	CORSAllowHeaders []string
+	// CORSMaxAge is how long browsers may cache the result of a preflight
+	// request. If zero, defaultCORSMaxAge is used.
+	CORSMaxAge time.Duration
+
+	// MaxRequestSizes overrides the maximum size in bytes of POST bodies,
+	// keyed by endpoint path (e.g. "/acme/new-order"). Endpoints without an
+	// entry use defaultMaxRequestSizes, or else defaultMaxRequestSize.
+	MaxRequestSizes map[string]int64
 
 	// Maximum duration of a request
 	RequestTimeout time.Duration
 
+	// MaxConcurrentRequestsPerAccount limits the number of authenticated
+	// requests each account, or for new accounts each key, may have in flight
+	// at once. Requests beyond the limit are rejected with a rateLimited
+	// problem. Zero disables the limit.
+	MaxConcurrentRequestsPerAccount int
+	accountLimiter                  *accountLimiter
+
+	// CompressResponses enables gzip compression of JSON and PEM response
+	// bodies for clients whose Accept-Encoding allows it.
+	CompressResponses bool
+
 	// StaleTimeout determines the required staleness for resources allowed to be
 	// accessed via Boulder-specific GET-able APIs. Resources newer than
 	// staleTimeout must be accessed via POST-as-GET and the RFC 8555 ACME API. We
@@ -156,6 +208,7 @@ func NewWebFrontEndImpl(
 		certificateChains:            certificateChains,
 		issuerCertificates:           issuerCertificates,
 		stats:                        initStats(stats),
+		accountLimiter:               newAccountLimiter(stats),
 		remoteNonceService:           remoteNonceService,
 		noncePrefixMap:               noncePrefixMap,
 		staleTimeout:                 staleTimeout,
@@ -187,7 +240,8 @@ func NewWebFrontEndImpl(
 // * Respond http.StatusMethodNotAllowed for HTTP methods other than
 // those listed.
 //
-// * Set CORS headers when responding to CORS "actual" requests.
+// * Set CORS headers when responding to CORS "actual" requests to the
+// endpoints in corsPaths.
 //
 // * Never send a body in response to a HEAD request. Anything
 // written by the handler will be discarded if the method is HEAD.
@@ -205,6 +259,16 @@ func (wfe *WebFrontEndImpl) HandleFunc(mux *http.ServeMux, pattern string, h web
 	methodsStr := strings.Join(methods, ", ")
 	handler := http.StripPrefix(pattern, web.NewTopHandler(wfe.log,
 		web.WFEHandlerFunc(func(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+			if wfe.CompressResponses {
+				cw := newCompressingResponseWriter(response, request, wfe.stats.compressionRatio.WithLabelValues(pattern))
+				defer func() {
+					if err := cw.finish(); err != nil {
+						blog.ForContext(ctx, wfe.log).Warningf("Could not write response: %s", err)
+					}
+				}()
+				response = cw
+			}
+
 			if request.Method != "GET" || pattern == newNoncePath {
 				// Historically we did not return a error to the client
 				// if we failed to get a new nonce. We preserve that
@@ -248,7 +312,7 @@ func (wfe *WebFrontEndImpl) HandleFunc(mux *http.ServeMux, pattern string, h web
 				// of responses for us. This keeps the Content-Length for HEAD
 				// requests as the same as GET requests per the spec.
 			case "OPTIONS":
-				wfe.Options(response, request, methodsStr, methodsMap)
+				wfe.Options(response, request, methodsStr, wfe.corsMethods(pattern, methods))
 				return
 			}
 
@@ -261,14 +325,20 @@ func (wfe *WebFrontEndImpl) HandleFunc(mux *http.ServeMux, pattern string, h web
 				return
 			}
 
-			wfe.setCORSHeaders(response, request, "")
+			if containsMethod(wfe.corsMethods(pattern, methods), request.Method) {
+				wfe.setCORSHeaders(response, request, "")
+			}
 
 			timeout := wfe.RequestTimeout
 			if timeout == 0 {
 				timeout = 5 * time.Minute
 			}
 			ctx, cancel := context.WithTimeout(ctx, timeout)
-			// TODO(riking): add request context using WithValue
+			// Once the request is authenticated, the slot taken for its account
+			// by limitAccountConcurrency is recorded here.
+			slot := &requestSlot{}
+			defer wfe.releaseAccountConcurrency(slot)
+			ctx = context.WithValue(ctx, requestSlotKey{}, slot)
 
 			// Call the wrapped handler.
 			h(ctx, logEvent, response, request)
@@ -367,6 +437,8 @@ func (wfe *WebFrontEndImpl) Handler(stats prometheus.Registerer) http.Handler {
 	wfe.HandleFunc(m, rolloverPath, wfe.KeyRollover, "POST")
 	wfe.HandleFunc(m, newOrderPath, wfe.NewOrder, "POST")
 	wfe.HandleFunc(m, finalizeOrderPath, wfe.FinalizeOrder, "POST")
+	wfe.HandleFunc(m, rateLimitsPath, wfe.RateLimits, "POST")
+	wfe.HandleFunc(m, certificatesPath, wfe.Certificates, "POST")
 
 	// GETable and POST-as-GETable ACME endpoints
 	wfe.HandleFunc(m, directoryPath, wfe.Directory, "GET", "POST")
@@ -378,6 +450,8 @@ func (wfe *WebFrontEndImpl) Handler(stats prometheus.Registerer) http.Handler {
 	wfe.HandleFunc(m, authzv2Path, wfe.Authorization, "GET", "POST")
 	wfe.HandleFunc(m, challengev2Path, wfe.Challenge, "GET", "POST")
 	wfe.HandleFunc(m, certPath, wfe.Certificate, "GET", "POST")
+	// GETable ACME endpoints
+	wfe.HandleFunc(m, renewalInfoPath, wfe.RenewalInfo, "GET")
 	// Boulder-specific GET-able resource endpoints
 	wfe.HandleFunc(m, getOrderPath, wfe.GetOrder, "GET")
 	wfe.HandleFunc(m, getAuthzv2Path, wfe.Authorization, "GET")
@@ -448,6 +522,9 @@ func (wfe *WebFrontEndImpl) Directory(
 		"newOrder":   newOrderPath,
 		"keyChange":  rolloverPath,
 	}
+	if features.Enabled(features.ServeRenewalInfo) {
+		directoryEndpoints["renewalInfo"] = renewalInfoPath
+	}
 
 	if request.Method == http.MethodPost {
 		acct, prob := wfe.validPOSTAsGETForAccount(request, ctx, logEvent)
@@ -483,6 +560,11 @@ func (wfe *WebFrontEndImpl) Directory(
 	if wfe.DirectoryWebsite != "" {
 		metaMap["website"] = wfe.DirectoryWebsite
 	}
+	// The "meta" directory entry may also include an object describing the
+	// issuance profiles that may be requested in new orders
+	if len(wfe.CertificateProfiles) > 0 {
+		metaMap["profiles"] = wfe.CertificateProfiles
+	}
 	directoryEndpoints["meta"] = metaMap
 
 	response.Header().Set("Content-Type", "application/json")
@@ -841,7 +923,7 @@ func (wfe *WebFrontEndImpl) processRevocation(
 		return web.ProblemDetailsForError(err, "Failed to revoke certificate")
 	}
 
-	wfe.log.Debugf("Revoked %v", serial)
+	blog.ForContext(ctx, wfe.log).Debugf("Revoked %v", serial)
 	return nil
 }
 
@@ -967,7 +1049,7 @@ func (wfe *WebFrontEndImpl) RevokeCertificate(
 	// certificates are authorized to be revoked by the requester
 
 	// Parse the JWS from the HTTP Request
-	jws, prob := wfe.parseJWSRequest(request)
+	jws, prob := wfe.parseJWSRequest(request, logEvent)
 	if prob != nil {
 		wfe.sendError(response, logEvent, prob, nil)
 		return
@@ -997,7 +1079,7 @@ func (wfe *WebFrontEndImpl) RevokeCertificate(
 	response.WriteHeader(http.StatusOK)
 }
 
-func (wfe *WebFrontEndImpl) logCsr(request *http.Request, cr core.CertificateRequest, account core.Registration) {
+func (wfe *WebFrontEndImpl) logCsr(ctx context.Context, request *http.Request, cr core.CertificateRequest, account core.Registration) {
 	var csrLog = struct {
 		ClientAddr string
 		CSR        string
@@ -1007,7 +1089,7 @@ func (wfe *WebFrontEndImpl) logCsr(request *http.Request, cr core.CertificateReq
 		CSR:        hex.EncodeToString(cr.Bytes),
 		Requester:  account.ID,
 	}
-	wfe.log.AuditObject("Certificate request", csrLog)
+	blog.ForContext(ctx, wfe.log).AuditObject("Certificate request", csrLog)
 }
 
 // Challenge handles POST requests to challenge URLs belonging to
@@ -1681,7 +1763,90 @@ func (wfe *WebFrontEndImpl) Certificate(ctx context.Context, logEvent *web.Reque
 	response.Header().Set("Content-Type", "application/pem-certificate-chain")
 	response.WriteHeader(http.StatusOK)
 	if _, err = response.Write(responsePEM); err != nil {
-		wfe.log.Warningf("Could not write response: %s", err)
+		blog.ForContext(ctx, wfe.log).Warningf("Could not write response: %s", err)
+	}
+}
+
+// renewalInfoRetryAfter is how long clients are asked to wait before checking
+// a certificate's renewalInfo again.
+const renewalInfoRetryAfter = 6 * time.Hour
+
+// RenewalInfo implements the ACME renewalInfo (ARI) endpoint, suggesting when
+// the client should renew a certificate. The certificate is identified by the
+// request path {AKI}.{serial}, the base64url encodings of its Authority Key
+// Identifier and serial number. Revoked certificates should be renewed right
+// away; otherwise renewal is suggested two thirds of the way through the
+// certificate's validity period.
+func (wfe *WebFrontEndImpl) RenewalInfo(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+	if !features.Enabled(features.ServeRenewalInfo) {
+		wfe.sendError(response, logEvent, probs.NotFound("Feature not enabled"), nil)
+		return
+	}
+
+	akiAndSerial := strings.SplitN(request.URL.Path, ".", 2)
+	if len(akiAndSerial) != 2 {
+		wfe.sendError(response, logEvent, probs.Malformed("Path must be of the form {AKI}.{serial}"), nil)
+		return
+	}
+	aki, err := base64.RawURLEncoding.DecodeString(akiAndSerial[0])
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.Malformed("Authority Key Identifier was not base64url-encoded"), err)
+		return
+	}
+	serialBytes, err := base64.RawURLEncoding.DecodeString(akiAndSerial[1])
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.Malformed("Serial number was not base64url-encoded"), err)
+		return
+	}
+	serial := core.SerialToString(new(big.Int).SetBytes(serialBytes))
+	if !core.ValidSerial(serial) {
+		wfe.sendError(response, logEvent, probs.NotFound("Certificate not found"),
+			fmt.Errorf("certificate serial provided was not valid: %s", serial))
+		return
+	}
+	logEvent.Extra["RequestedSerial"] = serial
+
+	cert, err := wfe.SA.GetCertificate(ctx, serial)
+	if err != nil {
+		if berrors.Is(err, berrors.NotFound) {
+			wfe.sendError(response, logEvent, probs.NotFound("Certificate not found"), err)
+			return
+		}
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error retrieving certificate"), err)
+		return
+	}
+	parsedCert, err := x509.ParseCertificate(cert.DER)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal(
+			fmt.Sprintf("unable to parse Boulder issued certificate with serial %#v", serial)), err)
+		return
+	}
+	// Serials are only unique per issuer, so a certificate from some other
+	// issuer with the same serial is not the one being asked about.
+	if !bytes.Equal(parsedCert.AuthorityKeyId, aki) {
+		wfe.sendError(response, logEvent, probs.NotFound("Certificate not found"),
+			fmt.Errorf("certificate %s has a different Authority Key Identifier", serial))
+		return
+	}
+
+	status, err := wfe.SA.GetCertificateStatus(ctx, serial)
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error retrieving certificate status"), err)
+		return
+	}
+
+	var ri core.RenewalInfo
+	if status.Status == core.OCSPStatusRevoked {
+		ri = core.RenewalInfoImmediate(wfe.clk.Now())
+	} else {
+		ri = core.RenewalInfoSimple(parsedCert.NotBefore, parsedCert.NotAfter)
+	}
+
+	response.Header().Set("Retry-After", strconv.Itoa(int(renewalInfoRetryAfter/time.Second)))
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, ri)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshalling renewalInfo"), err)
+		return
 	}
 }
 
@@ -1691,7 +1856,7 @@ func (wfe *WebFrontEndImpl) Issuer(ctx context.Context, logEvent *web.RequestEve
 	response.Header().Set("Content-Type", "application/pkix-cert")
 	response.WriteHeader(http.StatusOK)
 	if _, err := response.Write(wfe.IssuerCert); err != nil {
-		wfe.log.Warningf("Could not write response: %s", err)
+		blog.ForContext(ctx, wfe.log).Warningf("Could not write response: %s", err)
 	}
 }
 
@@ -1701,12 +1866,13 @@ func (wfe *WebFrontEndImpl) BuildID(ctx context.Context, logEvent *web.RequestEv
 	response.WriteHeader(http.StatusOK)
 	detailsString := fmt.Sprintf("Boulder=(%s %s)", core.GetBuildID(), core.GetBuildTime())
 	if _, err := fmt.Fprintln(response, detailsString); err != nil {
-		wfe.log.Warningf("Could not write response: %s", err)
+		blog.ForContext(ctx, wfe.log).Warningf("Could not write response: %s", err)
 	}
 }
 
-// Options responds to an HTTP OPTIONS request.
-func (wfe *WebFrontEndImpl) Options(response http.ResponseWriter, request *http.Request, methodsStr string, methodsMap map[string]bool) {
+// Options responds to an HTTP OPTIONS request. corsMethods lists the methods
+// which cross-origin requests to the endpoint may use.
+func (wfe *WebFrontEndImpl) Options(response http.ResponseWriter, request *http.Request, methodsStr string, corsMethods []string) {
 	// Every OPTIONS request gets an Allow header with a list of supported methods.
 	response.Header().Set("Allow", methodsStr)
 
@@ -1716,9 +1882,37 @@ func (wfe *WebFrontEndImpl) Options(response http.ResponseWriter, request *http.
 	if reqMethod == "" {
 		reqMethod = "GET"
 	}
-	if methodsMap[reqMethod] {
-		wfe.setCORSHeaders(response, request, methodsStr)
+	if containsMethod(corsMethods, reqMethod) {
+		wfe.setCORSHeaders(response, request, strings.Join(corsMethods, ", "))
+	}
+}
+
+// corsMethods returns the methods, of those handled at pattern, which
+// cross-origin requests may use. It returns nil for endpoints which don't
+// allow cross-origin requests at all.
+func (wfe *WebFrontEndImpl) corsMethods(pattern string, methods []string) []string {
+	if !corsPaths[pattern] {
+		return nil
+	}
+	if len(wfe.CORSAllowMethods) == 0 {
+		return methods
+	}
+	var allowed []string
+	for _, m := range methods {
+		if containsMethod(wfe.CORSAllowMethods, m) {
+			allowed = append(allowed, m)
+		}
+	}
+	return allowed
+}
+
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
 	}
+	return false
 }
 
 // setCORSHeaders() tells the client that CORS is acceptable for this
@@ -1763,9 +1957,14 @@ func (wfe *WebFrontEndImpl) setCORSHeaders(response http.ResponseWriter, request
 	// not one of these values we must be explicit in saying that `Content-Type`
 	// is an allowed header. See MDN for more details:
 	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Access-Control-Allow-Headers
-	response.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	allowHeaders := append([]string{"Content-Type"}, wfe.CORSAllowHeaders...)
+	response.Header().Set("Access-Control-Allow-Headers", strings.Join(allowHeaders, ", "))
 	response.Header().Set("Access-Control-Expose-Headers", "Link, Replay-Nonce, Location")
-	response.Header().Set("Access-Control-Max-Age", "86400")
+	maxAge := wfe.CORSMaxAge
+	if maxAge == 0 {
+		maxAge = defaultCORSMaxAge
+	}
+	response.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(maxAge/time.Second)))
 }
 
 // KeyRollover allows a user to change their signing key
@@ -1880,6 +2079,7 @@ type orderJSON struct {
 	Finalize       string                      `json:"finalize"`
 	Certificate    string                      `json:"certificate,omitempty"`
 	Error          *probs.ProblemDetails       `json:"error,omitempty"`
+	Profile        string                      `json:"profile,omitempty"`
 }
 
 // orderToOrderJSON converts a *corepb.Order instance into an orderJSON struct
@@ -1898,6 +2098,7 @@ func (wfe *WebFrontEndImpl) orderToOrderJSON(request *http.Request, order *corep
 		Expires:     time.Unix(0, *order.Expires).UTC(),
 		Identifiers: idents,
 		Finalize:    finalizeURL,
+		Profile:     order.GetCertificateProfileName(),
 	}
 	// If there is an order error, prefix its type with the V2 namespace
 	if order.Error != nil {
@@ -1940,6 +2141,7 @@ func (wfe *WebFrontEndImpl) NewOrder(
 	var newOrderRequest struct {
 		Identifiers         []identifier.ACMEIdentifier `json:"identifiers"`
 		NotBefore, NotAfter string
+		Profile             string `json:"profile"`
 	}
 	err := json.Unmarshal(body, &newOrderRequest)
 	if err != nil {
@@ -1972,10 +2174,22 @@ func (wfe *WebFrontEndImpl) NewOrder(
 		names[i] = ident.Value
 	}
 
-	order, err := wfe.RA.NewOrder(ctx, &rapb.NewOrderRequest{
+	// An order may request one of the advertised issuance profiles; if it
+	// doesn't, the CA's default profile is used.
+	newOrderReq := &rapb.NewOrderRequest{
 		RegistrationID: &acct.ID,
 		Names:          names,
-	})
+	}
+	if newOrderRequest.Profile != "" {
+		if _, ok := wfe.CertificateProfiles[newOrderRequest.Profile]; !ok {
+			wfe.sendError(response, logEvent,
+				probs.Malformed("NewOrder request included unknown profile %q", newOrderRequest.Profile), nil)
+			return
+		}
+		newOrderReq.CertificateProfileName = &newOrderRequest.Profile
+	}
+
+	order, err := wfe.RA.NewOrder(ctx, newOrderReq)
 	if err != nil {
 		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error creating new order"), err)
 		return
@@ -2158,7 +2372,7 @@ func (wfe *WebFrontEndImpl) FinalizeOrder(ctx context.Context, logEvent *web.Req
 
 	certificateRequest := core.CertificateRequest{Bytes: rawCSR.CSR}
 	certificateRequest.CSR = csr
-	wfe.logCsr(request, certificateRequest, *acct)
+	wfe.logCsr(ctx, request, certificateRequest, *acct)
 
 	logEvent.Extra["CSRDNSNames"] = certificateRequest.CSR.DNSNames
 	logEvent.Extra["CSREmailAddresses"] = certificateRequest.CSR.EmailAddresses
@@ -2189,6 +2403,153 @@ func (wfe *WebFrontEndImpl) FinalizeOrder(ctx context.Context, logEvent *web.Req
 	}
 }
 
+// maxRateLimitNames is the most identifiers that a single rate limits request
+// may ask about.
+const maxRateLimitNames = 100
+
+// rateLimitJSON is the JSON representation of the account's usage of a single
+// rate limit.
+type rateLimitJSON struct {
+	Limit     string `json:"limit"`
+	Key       string `json:"key,omitempty"`
+	Threshold int64  `json:"threshold"`
+	Count     int64  `json:"count"`
+	Remaining int64  `json:"remaining"`
+	Window    string `json:"window"`
+	// ResetBy is the time by which everything currently counted against the
+	// limit will have left the window.
+	ResetBy time.Time `json:"resetBy"`
+}
+
+// rateLimitsJSON is the JSON representation of a rate limits response.
+type rateLimitsJSON struct {
+	Limits []rateLimitJSON `json:"limits"`
+}
+
+// RateLimits is a Boulder-specific endpoint that reports the requesting
+// account's current usage of the rate limits that apply to it, so that clients
+// can surface it to users before they run into a limit. The request is either
+// a POST-as-GET, which reports only per-account limits, or a POST with a body
+// like `{"identifiers":[{"type":"dns","value":"example.com"}]}`, which also
+// reports the per-name limits for each of the identifiers. Reading rate limits
+// doesn't count against any of them.
+func (wfe *WebFrontEndImpl) RateLimits(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+	body, _, acct, prob := wfe.validPOSTForAccount(request, ctx, logEvent)
+	if prob != nil {
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	var names []string
+	if len(body) > 0 {
+		var rateLimitsRequest struct {
+			Identifiers []identifier.ACMEIdentifier `json:"identifiers"`
+		}
+		err := json.Unmarshal(body, &rateLimitsRequest)
+		if err != nil {
+			wfe.sendError(response, logEvent, probs.Malformed("Unable to unmarshal rate limits request body"), err)
+			return
+		}
+		if len(rateLimitsRequest.Identifiers) > maxRateLimitNames {
+			wfe.sendError(response, logEvent,
+				probs.Malformed("Rate limits may be requested for at most %d identifiers", maxRateLimitNames), nil)
+			return
+		}
+		for _, ident := range rateLimitsRequest.Identifiers {
+			if ident.Type != identifier.DNS {
+				wfe.sendError(response, logEvent,
+					probs.Malformed("Rate limits request included invalid non-DNS type identifier: type %q, value %q",
+						ident.Type, ident.Value), nil)
+				return
+			}
+			names = append(names, ident.Value)
+		}
+	}
+
+	limits, err := wfe.RA.GetRateLimits(ctx, &rapb.GetRateLimitsRequest{
+		RegistrationID: &acct.ID,
+		Names:          names,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error retrieving rate limits"), err)
+		return
+	}
+
+	respObj := rateLimitsJSON{Limits: []rateLimitJSON{}}
+	for _, limit := range limits.Limits {
+		remaining := *limit.Threshold - *limit.Count
+		if remaining < 0 {
+			remaining = 0
+		}
+		var key string
+		if limit.Key != nil {
+			key = *limit.Key
+		}
+		respObj.Limits = append(respObj.Limits, rateLimitJSON{
+			Limit:     *limit.Limit,
+			Key:       key,
+			Threshold: *limit.Threshold,
+			Count:     *limit.Count,
+			Remaining: remaining,
+			Window:    time.Duration(*limit.Window).String(),
+			ResetBy:   time.Unix(0, *limit.ResetBy).UTC(),
+		})
+	}
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, respObj)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Unable to write rate limits response"), err)
+		return
+	}
+}
+
+// certificatesJSON is the JSON representation of a page of an account's
+// certificates.
+type certificatesJSON struct {
+	Certificates []string `json:"certificates"`
+}
+
+// Certificates is a Boulder-specific endpoint that lists the URLs of the
+// certificates issued to the requesting account, ordered by serial. It must be
+// requested with a POST-as-GET. The list is paginated in the same way as an
+// ACME orders list (RFC 8555 Section 7.1.2.1): when there are more
+// certificates a Link header with relation "next" gives the URL of the next
+// page. The first page is at certificatesPath and later pages append the
+// continuation cursor to it as a path component, rather than a query
+// parameter, so that the page URL can be signed in the JWS like any other.
+func (wfe *WebFrontEndImpl) Certificates(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+	acct, prob := wfe.validPOSTAsGETForAccount(request, ctx, logEvent)
+	if prob != nil {
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	cursor := request.URL.Path
+	page, err := wfe.SA.GetCertificatesByRegistration(ctx, &sapb.GetCertificatesByRegistrationRequest{
+		RegistrationID: &acct.ID,
+		Cursor:         &cursor,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error retrieving certificates"), err)
+		return
+	}
+
+	respObj := certificatesJSON{Certificates: []string{}}
+	for _, cert := range page.Certificates {
+		respObj.Certificates = append(respObj.Certificates,
+			web.RelativeEndpoint(request, certPath+*cert.Serial))
+	}
+	if page.NextCursor != nil && *page.NextCursor != "" {
+		nextURL := web.RelativeEndpoint(request, certificatesPath+*page.NextCursor)
+		response.Header().Add("Link", link(nextURL, "next"))
+	}
+
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, respObj)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Unable to write certificates response"), err)
+		return
+	}
+}
+
 func extractRequesterIP(req *http.Request) (net.IP, error) {
 	ip := net.ParseIP(req.Header.Get("X-Real-IP"))
 	if ip != nil {
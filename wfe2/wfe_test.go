@@ -9,12 +9,14 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -259,12 +261,13 @@ func (ra *MockRegistrationAuthority) NewOrder(ctx context.Context, req *rapb.New
 	zero := int64(0)
 	status := string(core.StatusPending)
 	return &corepb.Order{
-		Id:               &one,
-		RegistrationID:   req.RegistrationID,
-		Expires:          &zero,
-		Names:            req.Names,
-		Status:           &status,
-		V2Authorizations: []int64{1},
+		Id:                     &one,
+		RegistrationID:         req.RegistrationID,
+		Expires:                &zero,
+		Names:                  req.Names,
+		Status:                 &status,
+		V2Authorizations:       []int64{1},
+		CertificateProfileName: req.CertificateProfileName,
 	}, nil
 }
 
@@ -274,6 +277,40 @@ func (ra *MockRegistrationAuthority) FinalizeOrder(ctx context.Context, req *rap
 	return req.Order, nil
 }
 
+func (ra *MockRegistrationAuthority) GetRateLimits(ctx context.Context, req *rapb.GetRateLimitsRequest) (*rapb.RateLimits, error) {
+	newOrders := "newOrdersPerAccount"
+	certsPerName := "certificatesPerName"
+	window := int64(3 * time.Hour)
+	resetBy := time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC).UnixNano()
+	orderThreshold, orderCount := int64(300), int64(12)
+	limits := []*rapb.RateLimitStatus{
+		{
+			Limit:     &newOrders,
+			Threshold: &orderThreshold,
+			Count:     &orderCount,
+			Window:    &window,
+			ResetBy:   &resetBy,
+		},
+	}
+	for _, name := range req.Names {
+		name := name
+		threshold, count := int64(50), int64(60)
+		limits = append(limits, &rapb.RateLimitStatus{
+			Limit:     &certsPerName,
+			Key:       &name,
+			Threshold: &threshold,
+			Count:     &count,
+			Window:    &window,
+			ResetBy:   &resetBy,
+		})
+	}
+	return &rapb.RateLimits{Limits: limits}, nil
+}
+
+func (ra *MockRegistrationAuthority) DryRunIssuance(ctx context.Context, _ *rapb.DryRunIssuanceRequest) (*rapb.DryRunIssuanceResponse, error) {
+	return nil, nil
+}
+
 func makeBody(s string) io.ReadCloser {
 	return ioutil.NopCloser(strings.NewReader(s))
 }
@@ -505,7 +542,7 @@ func TestHandleFunc(t *testing.T) {
 		Header: map[string][]string{
 			"Origin": {testOrigin},
 		},
-	}, "/test", "GET")
+	}, directoryPath, "GET")
 	test.AssertEquals(t, stubCalled, false)
 	test.AssertEquals(t, rw.Code, http.StatusMethodNotAllowed)
 
@@ -515,7 +552,7 @@ func TestHandleFunc(t *testing.T) {
 		Header: map[string][]string{
 			"Origin": {testOrigin},
 		},
-	}, "/test", "GET", "POST")
+	}, directoryPath, "GET", "POST")
 	test.AssertEquals(t, stubCalled, true)
 	test.AssertEquals(t, rw.Code, http.StatusOK)
 	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Methods"), "")
@@ -530,7 +567,7 @@ func TestHandleFunc(t *testing.T) {
 			"Origin":                        {testOrigin},
 			"Access-Control-Request-Method": {"POST"},
 		},
-	}, "/test", "GET")
+	}, directoryPath, "GET")
 	test.AssertEquals(t, stubCalled, false)
 	test.AssertEquals(t, rw.Code, http.StatusOK)
 	test.AssertEquals(t, rw.Header().Get("Allow"), "GET, HEAD")
@@ -545,7 +582,7 @@ func TestHandleFunc(t *testing.T) {
 			"Access-Control-Request-Method":  {"POST"},
 			"Access-Control-Request-Headers": {"X-Accept-Header1, X-Accept-Header2", "X-Accept-Header3"},
 		},
-	}, "/test", "GET", "POST")
+	}, directoryPath, "GET", "POST")
 	test.AssertEquals(t, rw.Code, http.StatusOK)
 	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "*")
 	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Headers"), "Content-Type")
@@ -560,7 +597,7 @@ func TestHandleFunc(t *testing.T) {
 		Header: map[string][]string{
 			"Access-Control-Request-Method": {"POST"},
 		},
-	}, "/test", "GET", "POST")
+	}, directoryPath, "GET", "POST")
 	test.AssertEquals(t, rw.Code, http.StatusOK)
 	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "")
 	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Headers"), "")
@@ -574,7 +611,7 @@ func TestHandleFunc(t *testing.T) {
 			Header: map[string][]string{
 				"Origin": {testOrigin},
 			},
-		}, "/test", allowedMethod)
+		}, directoryPath, allowedMethod)
 		test.AssertEquals(t, rw.Code, http.StatusOK)
 		if allowedMethod == "GET" {
 			test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "*")
@@ -599,7 +636,7 @@ func TestHandleFunc(t *testing.T) {
 				"Origin":                        {testOrigin},
 				"Access-Control-Request-Method": {"POST"},
 			},
-		}, "/test", "POST")
+		}, directoryPath, "POST")
 		test.AssertEquals(t, rw.Code, http.StatusOK)
 		for _, h := range []string{
 			"Access-Control-Allow-Methods",
@@ -624,7 +661,7 @@ func TestHandleFunc(t *testing.T) {
 				"Origin":                        {testOrigin},
 				"Access-Control-Request-Method": {"POST"},
 			},
-		}, "/test", "POST")
+		}, directoryPath, "POST")
 		test.AssertEquals(t, rw.Code, http.StatusOK)
 		test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), testOrigin)
 		// http://www.w3.org/TR/cors/ section 6.4:
@@ -632,6 +669,78 @@ func TestHandleFunc(t *testing.T) {
 	}
 }
 
+func TestCORSEndpoints(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	wfe.AllowOrigins = []string{"*"}
+	testOrigin := "https://example.com"
+
+	var rw *httptest.ResponseRecorder
+	var stubCalled bool
+	runWrappedHandler := func(req *http.Request, pattern string, allowed ...string) {
+		mux := http.NewServeMux()
+		rw = httptest.NewRecorder()
+		stubCalled = false
+		wfe.HandleFunc(mux, pattern, func(context.Context, *web.RequestEvent, http.ResponseWriter, *http.Request) {
+			stubCalled = true
+		}, allowed...)
+		req.URL = mustParseURL(pattern)
+		mux.ServeHTTP(rw, req)
+	}
+	preflight := func(method string) *http.Request {
+		return &http.Request{
+			Method: "OPTIONS",
+			Header: map[string][]string{
+				"Origin":                        {testOrigin},
+				"Access-Control-Request-Method": {method},
+			},
+		}
+	}
+
+	// Authenticated endpoints never send CORS headers, for preflight or actual
+	// requests.
+	for _, pattern := range []string{newAcctPath, newOrderPath, orderPath, certPath} {
+		runWrappedHandler(preflight("POST"), pattern, "POST")
+		test.AssertEquals(t, rw.Code, http.StatusOK)
+		test.AssertEquals(t, rw.Header().Get("Allow"), "POST")
+		test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "")
+		test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Methods"), "")
+
+		runWrappedHandler(&http.Request{
+			Method: "POST",
+			Header: map[string][]string{"Origin": {testOrigin}},
+		}, pattern, "POST")
+		test.AssertEquals(t, stubCalled, true)
+		test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "")
+		test.AssertEquals(t, rw.Header().Get("Access-Control-Expose-Headers"), "")
+	}
+
+	// The new-nonce endpoint allows cross-origin requests.
+	runWrappedHandler(preflight("HEAD"), newNoncePath, "GET", "POST")
+	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "*")
+	test.AssertEquals(t, sortHeader(rw.Header().Get("Access-Control-Allow-Methods")), "GET, HEAD, POST")
+
+	// The allowed methods, headers and max age are configurable.
+	wfe.CORSAllowMethods = []string{"GET", "HEAD"}
+	wfe.CORSAllowHeaders = []string{"X-Custom-Header"}
+	wfe.CORSMaxAge = time.Hour
+	runWrappedHandler(preflight("HEAD"), newNoncePath, "GET", "POST")
+	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "*")
+	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Methods"), "GET, HEAD")
+	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Headers"), "Content-Type, X-Custom-Header")
+	test.AssertEquals(t, rw.Header().Get("Access-Control-Max-Age"), "3600")
+
+	runWrappedHandler(preflight("POST"), newNoncePath, "GET", "POST")
+	test.AssertEquals(t, sortHeader(rw.Header().Get("Allow")), "GET, HEAD, POST")
+	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "")
+
+	runWrappedHandler(&http.Request{
+		Method: "POST",
+		Header: map[string][]string{"Origin": {testOrigin}},
+	}, newNoncePath, "GET", "POST")
+	test.AssertEquals(t, stubCalled, true)
+	test.AssertEquals(t, rw.Header().Get("Access-Control-Allow-Origin"), "")
+}
+
 func TestPOST404(t *testing.T) {
 	wfe, _ := setupWFE(t)
 	responseWriter := httptest.NewRecorder()
@@ -713,6 +822,7 @@ func TestDirectory(t *testing.T) {
 		name         string
 		caaIdent     string
 		website      string
+		profiles     map[string]string
 		expectedJSON string
 		request      *http.Request
 	}{
@@ -771,6 +881,25 @@ func TestDirectory(t *testing.T) {
   "newNonce": "http://localhost/acme/new-nonce",
   "newOrder": "http://localhost/acme/new-order",
   "revokeCert": "http://localhost/acme/revoke-cert"
+}`,
+		},
+		{
+			name:     "standard GET, profiles meta",
+			profiles: map[string]string{"shortlived": "Short-lived certificates"},
+			request:  getReq,
+			expectedJSON: `{
+  "AAAAAAAAAAA": "https://community.letsencrypt.org/t/adding-random-entries-to-the-directory/33417",
+  "keyChange": "http://localhost:4300/acme/key-change",
+  "meta": {
+    "profiles": {
+      "shortlived": "Short-lived certificates"
+    },
+    "termsOfService": "http://example.invalid/terms"
+  },
+  "newAccount": "http://localhost:4300/acme/new-acct",
+  "newNonce": "http://localhost:4300/acme/new-nonce",
+  "newOrder": "http://localhost:4300/acme/new-order",
+  "revokeCert": "http://localhost:4300/acme/revoke-cert"
 }`,
 		},
 	}
@@ -780,6 +909,7 @@ func TestDirectory(t *testing.T) {
 			// Configure a caaIdentity and website for the /directory meta based on the tc
 			wfe.DirectoryCAAIdentity = tc.caaIdent // "Radiant Lock"
 			wfe.DirectoryWebsite = tc.website      //"zombo.com"
+			wfe.CertificateProfiles = tc.profiles
 			responseWriter := httptest.NewRecorder()
 			// Serve the /directory response for this request into a recorder
 			mux.ServeHTTP(responseWriter, tc.request)
@@ -2256,8 +2386,31 @@ func TestNewOrder(t *testing.T) {
 						"finalize": "http://localhost/acme/finalize/1/1"
 					}`,
 		},
+		{
+			Name:         "POST, unknown profile in payload",
+			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "profile":"unknown"}`, 1, wfe.nonceService),
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewOrder request included unknown profile \"unknown\"","status":400}`,
+		},
+		{
+			Name:    "POST, good payload with profile",
+			Request: signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "profile":"shortlived"}`, 1, wfe.nonceService),
+			ExpectedBody: `
+					{
+						"status": "pending",
+						"expires": "1970-01-01T00:00:00Z",
+						"identifiers": [
+							{ "type": "dns", "value": "not-example.com"}
+						],
+						"authorizations": [
+							"http://localhost/acme/authz-v3/1"
+						],
+						"finalize": "http://localhost/acme/finalize/1/1",
+						"profile": "shortlived"
+					}`,
+		},
 	}
 
+	wfe.CertificateProfiles = map[string]string{"shortlived": "Short-lived certificates"}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
 			responseWriter.Body.Reset()
@@ -2283,6 +2436,221 @@ func TestNewOrder(t *testing.T) {
 	}
 }
 
+func TestRateLimits(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	makePost := func(body string) *http.Request {
+		return signAndPost(t, rateLimitsPath, "http://localhost"+rateLimitsPath, body, 1, wfe.nonceService)
+	}
+
+	tooMany := make([]string, maxRateLimitNames+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf(`{"type":"dns","value":"%d.example.com"}`, i)
+	}
+
+	testCases := []struct {
+		Name     string
+		Request  *http.Request
+		Response string
+	}{
+		{
+			Name:    "POST-as-GET",
+			Request: makePost(""),
+			Response: `{"limits":[
+				{"limit":"newOrdersPerAccount","threshold":300,"count":12,"remaining":288,"window":"3h0m0s","resetBy":"2020-01-01T03:00:00Z"}
+			]}`,
+		},
+		{
+			Name:    "With identifiers",
+			Request: makePost(`{"identifiers":[{"type":"dns","value":"example.com"}]}`),
+			Response: `{"limits":[
+				{"limit":"newOrdersPerAccount","threshold":300,"count":12,"remaining":288,"window":"3h0m0s","resetBy":"2020-01-01T03:00:00Z"},
+				{"limit":"certificatesPerName","key":"example.com","threshold":50,"count":60,"remaining":0,"window":"3h0m0s","resetBy":"2020-01-01T03:00:00Z"}
+			]}`,
+		},
+		{
+			Name:     "Invalid body",
+			Request:  makePost(`{"identifiers":"example.com"}`),
+			Response: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Unable to unmarshal rate limits request body","status":400}`,
+		},
+		{
+			Name:     "Non-DNS identifier",
+			Request:  makePost(`{"identifiers":[{"type":"ip","value":"10.0.0.1"}]}`),
+			Response: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Rate limits request included invalid non-DNS type identifier: type \"ip\", value \"10.0.0.1\"","status":400}`,
+		},
+		{
+			Name:     "Too many identifiers",
+			Request:  makePost(`{"identifiers":[` + strings.Join(tooMany, ",") + `]}`),
+			Response: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Rate limits may be requested for at most 100 identifiers","status":400}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			responseWriter := httptest.NewRecorder()
+			wfe.RateLimits(ctx, newRequestEvent(), responseWriter, tc.Request)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.Response)
+		})
+	}
+}
+
+func TestCertificates(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	// The handler is called directly, so the request path is only what
+	// remains after certificatesPath has been stripped: the cursor.
+	makePost := func(cursor, body string) *http.Request {
+		signedURL := (&url.URL{Scheme: "http", Host: "localhost", Path: cursor}).String()
+		return signAndPost(t, cursor, signedURL, body, 1, wfe.nonceService)
+	}
+
+	testCases := []struct {
+		Name         string
+		Request      *http.Request
+		Response     string
+		ExpectedLink string
+	}{
+		{
+			Name:         "First page",
+			Request:      makePost("", ""),
+			Response:     `{"certificates":["http://localhost/acme/cert/0000000000000000000000000000000000b2"]}`,
+			ExpectedLink: `<http://localhost/acme/certificates/0000000000000000000000000000000000b2>;rel="next"`,
+		},
+		{
+			Name:     "Last page",
+			Request:  makePost("0000000000000000000000000000000000b2", ""),
+			Response: `{"certificates":["http://localhost/acme/cert/0000000000000000000000000000000000ee"]}`,
+		},
+		{
+			Name:     "Invalid cursor",
+			Request:  makePost("bogus", ""),
+			Response: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Error retrieving certificates :: invalid certificates cursor \"bogus\"","status":400}`,
+		},
+		{
+			Name:     "Not POST-as-GET",
+			Request:  makePost("", "{}"),
+			Response: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"POST-as-GET requests must have an empty payload","status":400}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			responseWriter := httptest.NewRecorder()
+			wfe.Certificates(ctx, newRequestEvent(), responseWriter, tc.Request)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.Response)
+			test.AssertEquals(t, responseWriter.Header().Get("Link"), tc.ExpectedLink)
+		})
+	}
+}
+
+func TestRenewalInfo(t *testing.T) {
+	wfe, fc := setupWFE(t)
+	mux := wfe.Handler(metrics.NoopRegisterer)
+
+	loadCert := func(path string) *x509.Certificate {
+		certPemBytes, err := ioutil.ReadFile(path)
+		test.AssertNotError(t, err, "failed to read "+path)
+		certBlock, _ := pem.Decode(certPemBytes)
+		cert, err := x509.ParseCertificate(certBlock.Bytes)
+		test.AssertNotError(t, err, "failed to parse "+path)
+		return cert
+	}
+	// 238.crt is good and 178.crt is revoked according to the mock SA.
+	good := loadCert("test/238.crt")
+	revoked := loadCert("test/178.crt")
+	certID := func(aki []byte, serial *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(aki) + "." +
+			base64.RawURLEncoding.EncodeToString(serial.Bytes())
+	}
+	get := func(path string) *httptest.ResponseRecorder {
+		responseWriter := httptest.NewRecorder()
+		mux.ServeHTTP(responseWriter, &http.Request{
+			Method: http.MethodGet,
+			URL:    mustParseURL(renewalInfoPath + path),
+		})
+		return responseWriter
+	}
+	goodPath := certID(good.AuthorityKeyId, good.SerialNumber)
+
+	// Without the feature flag there is no such endpoint.
+	responseWriter := get(goodPath)
+	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
+
+	_ = features.Set(map[string]bool{"ServeRenewalInfo": true})
+	defer features.Reset()
+
+	windowJSON := func(ri core.RenewalInfo) string {
+		encoded, err := json.Marshal(ri)
+		test.AssertNotError(t, err, "failed to marshal renewalInfo")
+		return string(encoded)
+	}
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "good certificate",
+			path:           goodPath,
+			expectedStatus: http.StatusOK,
+			expectedBody:   windowJSON(core.RenewalInfoSimple(good.NotBefore, good.NotAfter)),
+		},
+		{
+			name:           "revoked certificate",
+			path:           certID(revoked.AuthorityKeyId, revoked.SerialNumber),
+			expectedStatus: http.StatusOK,
+			expectedBody:   windowJSON(core.RenewalInfoImmediate(fc.Now())),
+		},
+		{
+			name:           "wrong AKI",
+			path:           certID([]byte("not the issuer"), good.SerialNumber),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Certificate not found","status":404}`,
+		},
+		{
+			name:           "unknown serial",
+			path:           certID(good.AuthorityKeyId, big.NewInt(0xff)),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Certificate not found","status":404}`,
+		},
+		{
+			name:           "missing serial",
+			path:           base64.RawURLEncoding.EncodeToString(good.AuthorityKeyId),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Path must be of the form {AKI}.{serial}","status":400}`,
+		},
+		{
+			name:           "bad encoding",
+			path:           "!!!." + base64.RawURLEncoding.EncodeToString(good.SerialNumber.Bytes()),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Authority Key Identifier was not base64url-encoded","status":400}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responseWriter := get(tc.path)
+			test.AssertEquals(t, responseWriter.Code, tc.expectedStatus)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.expectedBody)
+			if tc.expectedStatus == http.StatusOK {
+				test.AssertEquals(t, responseWriter.Header().Get("Retry-After"), "21600")
+			}
+		})
+	}
+
+	// The endpoint is advertised in the directory.
+	responseWriter = httptest.NewRecorder()
+	mux.ServeHTTP(responseWriter, &http.Request{
+		Method: http.MethodGet,
+		URL:    mustParseURL(directoryPath),
+		Host:   "localhost:4300",
+	})
+	var directory map[string]interface{}
+	test.AssertNotError(t, json.Unmarshal(responseWriter.Body.Bytes(), &directory), "failed to unmarshal directory")
+	test.AssertEquals(t, directory["renewalInfo"], "http://localhost:4300/acme/renewal-info/")
+}
+
 func TestFinalizeOrder(t *testing.T) {
 	wfe, _ := setupWFE(t)
 	responseWriter := httptest.NewRecorder()